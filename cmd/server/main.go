@@ -5,17 +5,26 @@ import (
 	"database/sql"
 	"fmt"
 	"log"
+	"net"
 	"net/http"
 	"os"
 	"os/signal"
 	"syscall"
 	"time"
+	// База часовых поясов встроена в бинарник: в образе alpine нет tzdata, а рабочие часы
+	// пользователей задаются в часовых поясах IANA
+	_ "time/tzdata"
 
 	"pr-review-assigner/internal/api"
 	"pr-review-assigner/internal/config"
+	"pr-review-assigner/internal/events"
 	"pr-review-assigner/internal/handler"
+	"pr-review-assigner/internal/migrate"
 	"pr-review-assigner/internal/service"
 	"pr-review-assigner/internal/storage"
+	"pr-review-assigner/internal/storage/memory"
+	"pr-review-assigner/internal/storage/sqlite"
+	"pr-review-assigner/migrations"
 
 	"github.com/go-chi/chi/v5"
 	"github.com/go-chi/cors"
@@ -29,32 +38,73 @@ func main() {
 		log.Fatalf("Failed to load config: %v", err)
 	}
 
-	// Подключение к PostgreSQL с retry логикой
-	db, err := connectDBWithRetry(cfg, 5, 5*time.Second)
+	// Инициализация репозиториев выбранного хранилища
+	backend, err := openStorage(cfg)
 	if err != nil {
-		log.Fatalf("Failed to connect to database: %v", err)
+		log.Fatalf("Failed to open storage: %v", err)
 	}
-	defer db.Close()
+	defer backend.close()
 
-	log.Println("Connected to database")
+	// Подкоманда "migrate up|down|status" управляет схемой и завершает процесс
+	if len(os.Args) > 1 && os.Args[1] == "migrate" {
+		if err := runMigrateCommand(context.Background(), backend.migrator, os.Args[2:], os.Stdout); err != nil {
+			backend.close()
+			log.Fatalf("Migration failed: %v", err)
+		}
+		return
+	}
 
-	// Инициализация репозиториев
-	repo := storage.NewRepository(db)
-	teamRepo := storage.NewTeamRepository(repo)
-	userRepo := storage.NewUserRepository(repo)
-	prRepo := storage.NewPRRepository(repo)
+	// Сервер не запускается на схеме старше, чем ожидает код
+	if err := ensureSchema(context.Background(), backend.migrator, cfg.MigrateOnStart); err != nil {
+		backend.close()
+		log.Fatalf("Database schema is not ready: %v", err)
+	}
+
+	teamRepo := backend.repos.Teams
+	userRepo := backend.repos.Users
+	prRepo := backend.repos.PRs
+	subscriptionRepo := backend.subscriptions
+	outboxRepo := backend.outbox
+
+	// Единый источник времени для сервисов, фоновых проверок и стратегии working_hours
+	clock := time.Now
+
+	// Стратегии выбора ревьюверов по командам
+	selectors, err := service.BuildReviewerSelectors(service.SelectorConfig{
+		DefaultStrategy:  cfg.ReviewerStrategy,
+		TeamStrategies:   cfg.TeamReviewerStrategies,
+		Weights:          cfg.ReviewerWeights,
+		WorkingHoursBase: cfg.WorkingHoursBaseStrategy,
+		Now:              clock,
+	}, service.NewReviewLoadSource(prRepo))
+	if err != nil {
+		log.Fatalf("Failed to configure reviewer selection: %v", err)
+	}
 
 	// Инициализация сервисов
-	teamService := service.NewTeamService(teamRepo, userRepo)
-	userService := service.NewUserService(userRepo, prRepo, teamRepo)
-	prService := service.NewPRService(prRepo, userRepo, teamRepo)
+	teamService := service.NewTeamService(teamRepo, userRepo, service.WithTxManager(backend.txManager))
+	userService := service.NewUserService(userRepo, prRepo, teamRepo,
+		service.WithReviewerSelectors(selectors), service.WithTxManager(backend.txManager), service.WithClock(clock))
+	prService := service.NewPRService(prRepo, userRepo, teamRepo,
+		service.WithReviewerSelectors(selectors), service.WithTxManager(backend.txManager), service.WithClock(clock))
+	webhookService := service.NewWebhookService(prService, userRepo)
+	subscriptionService := service.NewSubscriptionService(subscriptionRepo)
 
 	// Инициализация handlers
-	server := handler.NewServer(teamService, userService, prService)
+	server := handler.NewServer(teamService, userService, prService, subscriptionService,
+		handler.WithWebhooks(webhookService, handler.WebhookConfig{
+			GitHubSecret: cfg.GitHubWebhookSecret,
+			GitLabToken:  cfg.GitLabWebhookToken,
+		}),
+	)
 
 	// Настройка HTTP сервера
 	router := chi.NewRouter()
 
+	// Ограничение времени обработки запроса: медленный запрос к БД прерывается по контексту
+	// Потоковая выгрузка ограничивается отдельно, так как большая история передается дольше
+	router.Use(handler.RequestTimeout(cfg.RequestTimeout, handler.PathTimeout{Path: "/export/assignments", Timeout: cfg.ExportTimeout}))
+
 	// CORS middleware для Swagger UI
 	router.Use(cors.Handler(cors.Options{
 		AllowedOrigins:   []string{"http://localhost:8081"},
@@ -68,16 +118,68 @@ func main() {
 	apiHandler := api.Handler(server)
 	router.Mount("/", apiHandler)
 
+	// Вебхуки внешних систем (подключаются, только если задан секрет)
+	if cfg.GitHubWebhookSecret != "" {
+		router.Post("/webhooks/github", server.PostWebhookGitHub)
+		log.Println("GitHub webhook enabled at /webhooks/github")
+	}
+	if cfg.GitLabWebhookToken != "" {
+		router.Post("/webhooks/gitlab", server.PostWebhookGitLab)
+		log.Println("GitLab webhook enabled at /webhooks/gitlab")
+	}
+
 	// Статическая отдача OpenAPI спецификации для Swagger UI
 	router.Get("/openapi.yml", func(w http.ResponseWriter, r *http.Request) {
 		http.ServeFile(w, r, "./docs/openapi.yml")
 	})
 
+	// Базовый контекст запросов отменяется после Shutdown, чтобы прервать оставшиеся запросы к БД
+	baseCtx, cancelRequests := context.WithCancel(context.Background())
 	httpServer := &http.Server{
-		Addr:    fmt.Sprintf(":%d", cfg.ServerPort),
-		Handler: router,
+		Addr:        fmt.Sprintf(":%d", cfg.ServerPort),
+		Handler:     router,
+		BaseContext: func(net.Listener) context.Context { return baseCtx },
 	}
 
+	// Фоновая доставка событий из outbox
+	dispatcher := events.NewDispatcher(outboxRepo, buildEventSinks(cfg, subscriptionRepo), events.DispatcherConfig{
+		PollInterval:    cfg.OutboxPollInterval,
+		BatchSize:       cfg.OutboxBatchSize,
+		Lease:           events.DefaultDispatcherConfig.Lease,
+		RetryBackoff:    events.DefaultDispatcherConfig.RetryBackoff,
+		MaxRetryBackoff: events.DefaultDispatcherConfig.MaxRetryBackoff,
+	})
+	dispatcherCtx, stopDispatcher := context.WithCancel(context.Background())
+	dispatcherDone := make(chan struct{})
+	go func() {
+		defer close(dispatcherDone)
+		dispatcher.Run(dispatcherCtx)
+	}()
+
+	// Фоновая проверка SLA ревью: события review.sla_breached попадают в тот же outbox
+	slaMonitor := service.NewSLAMonitor(prRepo, cfg.SLACheckInterval, service.WithClock(clock))
+	slaMonitorDone := make(chan struct{})
+	go func() {
+		defer close(slaMonitorDone)
+		slaMonitor.Run(dispatcherCtx)
+	}()
+
+	// Фоновая замена ревьюверов без вердикта по политике команд (auto_reassign_after_seconds)
+	autoReassigner := service.NewAutoReassigner(prService, cfg.AutoReassignInterval)
+	autoReassignerDone := make(chan struct{})
+	go func() {
+		defer close(autoReassignerDone)
+		autoReassigner.Run(dispatcherCtx)
+	}()
+
+	// Фоновая передача ревью пользователей, у которых начался период отсутствия
+	reviewHandoff := service.NewReviewHandoff(userService, cfg.HandoffCheckInterval)
+	reviewHandoffDone := make(chan struct{})
+	go func() {
+		defer close(reviewHandoffDone)
+		reviewHandoff.Run(dispatcherCtx)
+	}()
+
 	// Graceful shutdown
 	go func() {
 		log.Printf("Server starting on port %d", cfg.ServerPort)
@@ -93,16 +195,118 @@ func main() {
 
 	log.Println("Shutting down server...")
 
-	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	ctx, cancel := context.WithTimeout(context.Background(), cfg.ShutdownTimeout)
 	defer cancel()
 
 	if err := httpServer.Shutdown(ctx); err != nil {
-		log.Fatalf("Server forced to shutdown: %v", err)
+		log.Printf("Server forced to shutdown: %v", err)
 	}
+	cancelRequests()
+
+	// Останавливаем фоновые проверки и доставку событий; недоставленные останутся в outbox до следующего запуска
+	stopDispatcher()
+	<-slaMonitorDone
+	<-autoReassignerDone
+	<-reviewHandoffDone
+	<-dispatcherDone
 
 	log.Println("Server exited")
 }
 
+// storageBackend репозитории хранилища, выбранного STORAGE_DRIVER
+type storageBackend struct {
+	repos         storage.Repositories
+	subscriptions storage.SubscriptionRepositoryInterface
+	outbox        events.OutboxStore
+	txManager     storage.TxManager
+	// migrator миграции схемы хранилища (nil для хранилища в памяти)
+	migrator *migrate.Migrator
+	close    func() error
+}
+
+// openStorage создает репозитории PostgreSQL, SQLite или хранилища в памяти
+func openStorage(cfg *config.Config) (*storageBackend, error) {
+	switch cfg.StorageDriver {
+	case "memory":
+		log.Println("Using in-memory storage: data is lost on restart")
+		store := memory.NewStore()
+		return &storageBackend{
+			repos: storage.Repositories{
+				Teams: memory.NewTeamRepository(store),
+				Users: memory.NewUserRepository(store),
+				PRs:   memory.NewPRRepository(store),
+			},
+			subscriptions: memory.NewSubscriptionRepository(store),
+			outbox:        memory.NewOutboxRepository(store),
+			txManager:     memory.NewTxManager(store),
+			close:         func() error { return nil },
+		}, nil
+	case "sqlite":
+		// Миграции SQLite встроены в бинарный файл; при открытии база не меняется,
+		// схему обновляет `migrate up` или MIGRATE_ON_START=true, как и для PostgreSQL
+		db, err := sqlite.Open(context.Background(), cfg.SQLitePath)
+		if err != nil {
+			return nil, err
+		}
+		log.Printf("Using SQLite database %s", cfg.SQLitePath)
+
+		repo := sqlite.NewRepository(db)
+		return &storageBackend{
+			repos: storage.Repositories{
+				Teams: sqlite.NewTeamRepository(repo),
+				Users: sqlite.NewUserRepository(repo),
+				PRs:   sqlite.NewPRRepository(repo),
+			},
+			subscriptions: sqlite.NewSubscriptionRepository(repo),
+			outbox:        sqlite.NewOutboxRepository(repo),
+			txManager:     sqlite.NewTxManager(db),
+			migrator:      migrate.New(db, sqlite.Migrations, migrate.SQLite),
+			close:         db.Close,
+		}, nil
+	}
+
+	// Подключение к PostgreSQL с retry логикой
+	db, err := connectDBWithRetry(cfg, 5, 5*time.Second)
+	if err != nil {
+		return nil, err
+	}
+
+	log.Println("Connected to database")
+
+	repo := storage.NewRepository(db)
+	return &storageBackend{
+		repos: storage.Repositories{
+			Teams: storage.NewTeamRepository(repo),
+			Users: storage.NewUserRepository(repo),
+			PRs:   storage.NewPRRepository(repo),
+		},
+		subscriptions: storage.NewSubscriptionRepository(repo),
+		outbox:        storage.NewOutboxRepository(repo),
+		txManager:     storage.NewTxManager(db),
+		migrator:      migrate.New(db, migrations.FS, migrate.Postgres),
+		close:         db.Close,
+	}, nil
+}
+
+// buildEventSinks создает получателей событий из outbox по конфигурации
+func buildEventSinks(cfg *config.Config, subscriptions events.SubscriptionStore) []events.Sink {
+	sinks := make([]events.Sink, 0, len(cfg.EventSinks))
+	for _, name := range cfg.EventSinks {
+		switch name {
+		case "webhook":
+			sinks = append(sinks, events.NewWebhookNotifier(subscriptions, events.WebhookConfig{
+				MaxAttempts: cfg.EventWebhookMaxAttempts,
+				Timeout:     cfg.EventWebhookTimeout,
+			}))
+		case "log":
+			sinks = append(sinks, events.NewLogSink(nil))
+		case "file":
+			sinks = append(sinks, events.NewFileSink(cfg.EventFilePath))
+		}
+	}
+	return sinks
+}
+
 // connectDBWithRetry подключается к БД с повторными попытками
 func connectDBWithRetry(cfg *config.Config, maxRetries int, retryInterval time.Duration) (*sql.DB, error) {
 	var db *sql.DB
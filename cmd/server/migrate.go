@@ -0,0 +1,107 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"strconv"
+
+	"pr-review-assigner/internal/migrate"
+)
+
+// errNoMigrations хранилище без схемы (в памяти)
+var errNoMigrations = errors.New("storage driver has no schema migrations")
+
+const migrateUsage = "usage: server migrate up | down [N] | status"
+
+// runMigrateCommand выполняет подкоманду migrate: up применяет все миграции,
+// down [N] откатывает последние N (по умолчанию одну), status выводит версию схемы
+func runMigrateCommand(ctx context.Context, migrator *migrate.Migrator, args []string, out io.Writer) error {
+	if migrator == nil {
+		return errNoMigrations
+	}
+	if len(args) == 0 {
+		return errors.New(migrateUsage)
+	}
+
+	switch args[0] {
+	case "up":
+		applied, err := migrator.Up(ctx)
+		for _, m := range applied {
+			fmt.Fprintf(out, "applied %d_%s\n", m.Version, m.Name)
+		}
+		if err != nil {
+			return err
+		}
+		if len(applied) == 0 {
+			fmt.Fprintln(out, "no change")
+		}
+	case "down":
+		steps := 1
+		if len(args) > 1 {
+			n, err := strconv.Atoi(args[1])
+			if err != nil || n < 1 {
+				return fmt.Errorf("invalid number of steps %q: %s", args[1], migrateUsage)
+			}
+			steps = n
+		}
+		reverted, err := migrator.Down(ctx, steps)
+		for _, m := range reverted {
+			fmt.Fprintf(out, "reverted %d_%s\n", m.Version, m.Name)
+		}
+		if err != nil {
+			return err
+		}
+		if len(reverted) == 0 {
+			fmt.Fprintln(out, "no change")
+		}
+	case "status":
+		status, err := migrator.Status(ctx)
+		if err != nil {
+			return err
+		}
+		fmt.Fprintf(out, "current version: %d\n", status.Current)
+		fmt.Fprintf(out, "latest version: %d\n", status.Latest)
+		fmt.Fprintf(out, "dirty: %t\n", status.Dirty)
+		for _, m := range status.Pending {
+			fmt.Fprintf(out, "pending %d_%s\n", m.Version, m.Name)
+		}
+	default:
+		return fmt.Errorf("unknown migrate command %q: %s", args[0], migrateUsage)
+	}
+	return nil
+}
+
+// ensureSchema проверяет, что схема не старше миграций, встроенных в бинарный файл
+// При apply недостающие миграции сначала применяются
+func ensureSchema(ctx context.Context, migrator *migrate.Migrator, apply bool) error {
+	if migrator == nil {
+		return nil
+	}
+
+	if apply {
+		applied, err := migrator.Up(ctx)
+		if err != nil {
+			return err
+		}
+		for _, m := range applied {
+			log.Printf("Applied migration %d_%s", m.Version, m.Name)
+		}
+	}
+
+	status, err := migrator.Status(ctx)
+	if err != nil {
+		return err
+	}
+	if status.Dirty {
+		return fmt.Errorf("%w at version %d", migrate.ErrDirty, status.Current)
+	}
+	if status.Behind() {
+		return fmt.Errorf("schema version %d is behind %d expected by this build: run \"server migrate up\" or set MIGRATE_ON_START=true",
+			status.Current, status.Latest)
+	}
+	log.Printf("Database schema version %d", status.Current)
+	return nil
+}
@@ -14,26 +14,143 @@ import (
 
 // Defines values for ErrorResponseErrorCode.
 const (
-	NOCANDIDATE ErrorResponseErrorCode = "NO_CANDIDATE"
-	NOTASSIGNED ErrorResponseErrorCode = "NOT_ASSIGNED"
-	NOTFOUND    ErrorResponseErrorCode = "NOT_FOUND"
-	PREXISTS    ErrorResponseErrorCode = "PR_EXISTS"
-	PRMERGED    ErrorResponseErrorCode = "PR_MERGED"
-	TEAMEXISTS  ErrorResponseErrorCode = "TEAM_EXISTS"
+	INVALIDREQUEST     ErrorResponseErrorCode = "INVALID_REQUEST"
+	NOCANDIDATE        ErrorResponseErrorCode = "NO_CANDIDATE"
+	NOTASSIGNED        ErrorResponseErrorCode = "NOT_ASSIGNED"
+	NOTENOUGHAPPROVALS ErrorResponseErrorCode = "NOT_ENOUGH_APPROVALS"
+	NOTFOUND           ErrorResponseErrorCode = "NOT_FOUND"
+	PRCLOSED           ErrorResponseErrorCode = "PR_CLOSED"
+	PRDRAFT            ErrorResponseErrorCode = "PR_DRAFT"
+	PREXISTS           ErrorResponseErrorCode = "PR_EXISTS"
+	PRMERGED           ErrorResponseErrorCode = "PR_MERGED"
+	REVIEWERSLIMIT     ErrorResponseErrorCode = "REVIEWERS_LIMIT"
+	TEAMEXISTS         ErrorResponseErrorCode = "TEAM_EXISTS"
+	TIMEOUT            ErrorResponseErrorCode = "TIMEOUT"
+	UNAUTHORIZED       ErrorResponseErrorCode = "UNAUTHORIZED"
+)
+
+// Defines values for EventType.
+const (
+	PrMerged           EventType = "pr.merged"
+	ReviewSlaBreached  EventType = "review.sla_breached"
+	ReviewerAssigned   EventType = "reviewer.assigned"
+	ReviewerReassigned EventType = "reviewer.reassigned"
+	ReviewerRemoved    EventType = "reviewer.removed"
+)
+
+// Defines values for IdentityProvider.
+const (
+	Github IdentityProvider = "github"
+	Gitlab IdentityProvider = "gitlab"
 )
 
 // Defines values for PullRequestStatus.
 const (
+	PullRequestStatusCLOSED PullRequestStatus = "CLOSED"
+	PullRequestStatusDRAFT  PullRequestStatus = "DRAFT"
 	PullRequestStatusMERGED PullRequestStatus = "MERGED"
 	PullRequestStatusOPEN   PullRequestStatus = "OPEN"
 )
 
+// Defines values for PullRequestHistoryEntryAction.
+const (
+	PRHistoryAutoReassigned PullRequestHistoryEntryAction = "AUTO_REASSIGNED"
+)
+
 // Defines values for PullRequestShortStatus.
 const (
+	PullRequestShortStatusCLOSED PullRequestShortStatus = "CLOSED"
+	PullRequestShortStatusDRAFT  PullRequestShortStatus = "DRAFT"
 	PullRequestShortStatusMERGED PullRequestShortStatus = "MERGED"
 	PullRequestShortStatusOPEN   PullRequestShortStatus = "OPEN"
 )
 
+// Defines values for ReviewVerdict.
+const (
+	APPROVED         ReviewVerdict = "APPROVED"
+	CHANGESREQUESTED ReviewVerdict = "CHANGES_REQUESTED"
+	COMMENTED        ReviewVerdict = "COMMENTED"
+)
+
+// Defines values for GetPullRequestListParamsSort.
+const (
+	PRListSortCreatedAt GetPullRequestListParamsSort = "created_at"
+	PRListSortName      GetPullRequestListParamsSort = "name"
+)
+
+// Defines values for GetPullRequestListParamsOrder.
+const (
+	PRListOrderAsc  GetPullRequestListParamsOrder = "asc"
+	PRListOrderDesc GetPullRequestListParamsOrder = "desc"
+)
+
+// AssignmentExportRow defines model for AssignmentExportRow.
+type AssignmentExportRow struct {
+	AssignedAt       *time.Time        `json:"assigned_at"`
+	AuthorId         string            `json:"author_id"`
+	CreatedAt        *time.Time        `json:"created_at"`
+	MergedAt         *time.Time        `json:"merged_at"`
+	PullRequestId    string            `json:"pull_request_id"`
+	PullRequestName  string            `json:"pull_request_name"`
+	ReviewerId       string            `json:"reviewer_id"`
+	ReviewerTeamName string            `json:"reviewer_team_name"`
+	ReviewerUsername string            `json:"reviewer_username"`
+	Status           PullRequestStatus `json:"status"`
+}
+
+// CodeownersRule defines model for CodeownersRule.
+type CodeownersRule struct {
+	// Line Номер строки правила в файле
+	Line int `json:"line"`
+
+	// Owners Владельцы как в файле - @user_id или @org/team_name
+	Owners  []string `json:"owners"`
+	Pattern string   `json:"pattern"`
+}
+
+// CreatePullRequestRequest defines model for CreatePullRequestRequest.
+type CreatePullRequestRequest struct {
+	AuthorId string `json:"author_id"`
+
+	// ChangedFiles Пути измененных файлов относительно корня репозитория. Владельцы этих файлов по правилам
+	// CODEOWNERS репозитория назначаются обязательными ревьюверами; требует repository
+	ChangedFiles *[]string `json:"changed_files,omitempty"`
+
+	// Draft Создать PR как черновик (DRAFT) - ревьюверы будут назначены после markReady
+	Draft           *bool  `json:"draft,omitempty"`
+	PullRequestId   string `json:"pull_request_id"`
+	PullRequestName string `json:"pull_request_name"`
+
+	// Repository Репозиторий PR (например, acme/api), по правилам CODEOWNERS которого назначаются владельцы
+	Repository *string `json:"repository,omitempty"`
+
+	// ReviewersCount Количество ревьюверов для этого PR. Должно быть в диапазоне
+	// [min_reviewers, max_reviewers] команды автора. По умолчанию max_reviewers
+	ReviewersCount *int `json:"reviewers_count,omitempty"`
+}
+
+// CreateUserUnavailabilityRequest defines model for CreateUserUnavailabilityRequest.
+type CreateUserUnavailabilityRequest struct {
+	EndsAt         time.Time `json:"ends_at"`
+	HandoffReviews *bool     `json:"handoff_reviews,omitempty"`
+	Reason         *string   `json:"reason,omitempty"`
+	StartsAt       time.Time `json:"starts_at"`
+	UserId         string    `json:"user_id"`
+}
+
+// CreateWebhookSubscriptionRequest defines model for CreateWebhookSubscriptionRequest.
+type CreateWebhookSubscriptionRequest struct {
+	// EventTypes Типы событий подписки; если не указаны - все события
+	EventTypes []EventType `json:"event_types,omitempty"`
+
+	// Secret Секрет для подписи тела запроса. Подпись передается в заголовке
+	// X-Signature-256 в виде sha256=<hex HMAC-SHA256(secret, body)>
+	Secret *string `json:"secret,omitempty"`
+
+	// Url http(s) URL получателя
+	Url string `json:"url"`
+}
+
 // ErrorResponse defines model for ErrorResponse.
 type ErrorResponse struct {
 	Error struct {
@@ -45,23 +162,95 @@ type ErrorResponse struct {
 // ErrorResponseErrorCode defines model for ErrorResponse.Error.Code.
 type ErrorResponseErrorCode string
 
+// EventType Тип события об изменении ревьюверов PR или о нарушении SLA ревью
+type EventType string
+
+// FairnessOutlier defines model for FairnessOutlier.
+type FairnessOutlier struct {
+	AssignmentsCount int `json:"assignments_count"`
+
+	// RatioToMean Отношение количества назначений к среднему по команде
+	RatioToMean float64 `json:"ratio_to_mean"`
+	UserId      string  `json:"user_id"`
+	Username    string  `json:"username"`
+}
+
+// FairnessReport defines model for FairnessReport.
+type FairnessReport struct {
+	// ActiveMembers Количество активных участников команды, между которыми считается распределение
+	ActiveMembers int        `json:"active_members"`
+	From          *time.Time `json:"from"`
+
+	// Gini Коэффициент Джини назначений (0 - поровну, ближе к 1 - назначения у одного участника)
+	Gini float64 `json:"gini"`
+
+	// MaxMinRatio Отношение максимума назначений к минимуму; null, если у кого-то нет назначений
+	MaxMinRatio      *float64 `json:"max_min_ratio"`
+	MeanAssignments  float64  `json:"mean_assignments"`
+	OutlierThreshold float64  `json:"outlier_threshold"`
+
+	// Outliers Участники, у которых назначений больше outlier_threshold средних
+	Outliers []FairnessOutlier `json:"outliers"`
+
+	// Stddev Стандартное отклонение назначений
+	Stddev           float64    `json:"stddev"`
+	TeamName         string     `json:"team_name"`
+	To               *time.Time `json:"to"`
+	TotalAssignments int        `json:"total_assignments"`
+}
+
+// IdentityProvider Внешняя система, из которой приходят события PR
+type IdentityProvider string
+
 // PullRequest defines model for PullRequest.
 type PullRequest struct {
-	// AssignedReviewers user_id назначенных ревьюверов (0..2)
-	AssignedReviewers []string          `json:"assigned_reviewers"`
-	AuthorId          string            `json:"author_id"`
-	CreatedAt         *time.Time        `json:"createdAt"`
-	MergedAt          *time.Time        `json:"mergedAt"`
-	PullRequestId     string            `json:"pull_request_id"`
-	PullRequestName   string            `json:"pull_request_name"`
-	Status            PullRequestStatus `json:"status"`
+	// AssignedReviewers user_id назначенных ревьюверов (0..max_reviewers команды автора)
+	AssignedReviewers []string   `json:"assigned_reviewers"`
+	AuthorId          string     `json:"author_id"`
+	ClosedAt          *time.Time `json:"closedAt"`
+	CreatedAt         *time.Time `json:"createdAt"`
+	MergedAt          *time.Time `json:"mergedAt"`
+	PullRequestId     string     `json:"pull_request_id"`
+	PullRequestName   string     `json:"pull_request_name"`
+
+	// ReviewersCount Количество ревьюверов, запрошенное для PR при создании (null - по настройке команды)
+	ReviewersCount *int `json:"reviewers_count"`
+
+	// Reviews Последний вердикт каждого назначенного ревьювера (только тех, кто уже отправил ревью)
+	Reviews []PullRequestReview `json:"reviews,omitempty"`
+
+	// Status DRAFT - черновик, ревьюверы назначаются после markReady;
+	// CLOSED - закрыт без слияния, может быть переоткрыт
+	Status PullRequestStatus `json:"status"`
 }
 
-// PullRequestStatus defines model for PullRequest.Status.
+// PullRequestStatus DRAFT - черновик, ревьюверы назначаются после markReady;
+// CLOSED - закрыт без слияния, может быть переоткрыт
 type PullRequestStatus string
 
+// PullRequestHistoryEntry defines model for PullRequestHistoryEntry.
+type PullRequestHistoryEntry struct {
+	// Action AUTO_REASSIGNED - ревьювер без вердикта заменен фоновым процессом
+	Action        PullRequestHistoryEntryAction `json:"action"`
+	CreatedAt     time.Time                     `json:"created_at"`
+	NewReviewerId string                        `json:"new_reviewer_id"`
+	OldReviewerId string                        `json:"old_reviewer_id"`
+}
+
+// PullRequestHistoryEntryAction AUTO_REASSIGNED - ревьювер без вердикта заменен фоновым процессом
+type PullRequestHistoryEntryAction string
+
+// PullRequestReview defines model for PullRequestReview.
+type PullRequestReview struct {
+	SubmittedAt time.Time     `json:"submitted_at"`
+	UserId      string        `json:"user_id"`
+	Verdict     ReviewVerdict `json:"verdict"`
+}
+
 // PullRequestShort defines model for PullRequestShort.
 type PullRequestShort struct {
+	// AssignedAt Время назначения пользователя ревьювером (в /users/getReview)
+	AssignedAt      *time.Time             `json:"assigned_at"`
 	AuthorId        string                 `json:"author_id"`
 	PullRequestId   string                 `json:"pull_request_id"`
 	PullRequestName string                 `json:"pull_request_name"`
@@ -71,11 +260,45 @@ type PullRequestShort struct {
 // PullRequestShortStatus defines model for PullRequestShort.Status.
 type PullRequestShortStatus string
 
+// RepositoryCodeowners defines model for RepositoryCodeowners.
+type RepositoryCodeowners struct {
+	// Content Правила в синтаксисе CODEOWNERS в исходном виде
+	Content    string           `json:"content"`
+	Repository string           `json:"repository"`
+	Rules      []CodeownersRule `json:"rules"`
+	UpdatedAt  time.Time        `json:"updated_at"`
+}
+
+// ReviewVerdict defines model for ReviewVerdict.
+type ReviewVerdict string
+
+// ReviewerPool defines model for ReviewerPool.
+type ReviewerPool struct {
+	// Members user_id участников пула; участники могут быть из разных команд
+	Members  []string `json:"members"`
+	PoolName string   `json:"pool_name"`
+}
+
 // ReviewerStatistics defines model for ReviewerStatistics.
 type ReviewerStatistics struct {
 	// AssignmentsCount Количество назначений на ревью
 	AssignmentsCount int `json:"assignments_count"`
 
+	// AuthoredPrsCount Количество PR, созданных пользователем
+	AuthoredPrsCount *int `json:"authored_prs_count,omitempty"`
+
+	// AvgTimeToMergeSeconds Среднее время от назначения до слияния PR в секундах; null, если слитых PR нет
+	AvgTimeToMergeSeconds *float64 `json:"avg_time_to_merge_seconds"`
+
+	// CompletedAssignmentsCount Назначения на слитые или закрытые PR
+	CompletedAssignmentsCount *int `json:"completed_assignments_count,omitempty"`
+
+	// OpenAssignmentsCount Назначения на открытые PR
+	OpenAssignmentsCount *int `json:"open_assignments_count,omitempty"`
+
+	// TeamName Команда пользователя
+	TeamName *string `json:"team_name,omitempty"`
+
 	// UserId Идентификатор пользователя
 	UserId string `json:"user_id"`
 
@@ -83,10 +306,63 @@ type ReviewerStatistics struct {
 	Username string `json:"username"`
 }
 
+// SetUserWorkingHoursRequest Без timezone, working_hours_start и working_hours_end рабочие часы пользователя сбрасываются
+type SetUserWorkingHoursRequest struct {
+	Timezone          *string `json:"timezone,omitempty"`
+	UserId            string  `json:"user_id"`
+	WorkingHoursEnd   *string `json:"working_hours_end,omitempty"`
+	WorkingHoursStart *string `json:"working_hours_start,omitempty"`
+}
+
+// StaleReview defines model for StaleReview.
+type StaleReview struct {
+	AssignedAt time.Time `json:"assigned_at"`
+	AuthorId   string    `json:"author_id"`
+
+	// DueAt Срок первого вердикта (assigned_at + review_sla_seconds команды)
+	DueAt time.Time `json:"due_at"`
+
+	// OverdueSeconds На сколько секунд срок превышен на момент запроса
+	OverdueSeconds  int64  `json:"overdue_seconds"`
+	PullRequestId   string `json:"pull_request_id"`
+	PullRequestName string `json:"pull_request_name"`
+	ReviewerId      string `json:"reviewer_id"`
+
+	// SlaBreachedAt Время записи события review.sla_breached; null, если фоновая проверка еще не дошла до назначения
+	SlaBreachedAt *time.Time `json:"sla_breached_at"`
+
+	// TeamName Команда автора PR, чей SLA нарушен
+	TeamName string `json:"team_name"`
+}
+
 // Team defines model for Team.
 type Team struct {
-	Members  []TeamMember `json:"members"`
-	TeamName string       `json:"team_name"`
+	// AutoReassignAfterSeconds Через сколько секунд без вердикта ревьювер PR команды автоматически заменяется
+	// другим активным участником. По умолчанию 0 - автоматическое переназначение отключено
+	AutoReassignAfterSeconds *int `json:"auto_reassign_after_seconds,omitempty"`
+
+	// FallbackPools Резервные пулы ревьюверов по порядку: из них добираются ревьюверы PR команды,
+	// когда активных участников команды не хватает. Пустой список снимает резервные пулы
+	FallbackPools *[]string `json:"fallback_pools,omitempty"`
+
+	// MaxAutoReassignments Максимальное количество автоматических переназначений одного PR (по умолчанию 1)
+	MaxAutoReassignments *int `json:"max_auto_reassignments,omitempty"`
+
+	// MaxReviewers Количество ревьюверов, назначаемых на PR команды по умолчанию, и верхняя граница для PR (по умолчанию 2)
+	MaxReviewers *int         `json:"max_reviewers,omitempty"`
+	Members      []TeamMember `json:"members"`
+
+	// MinReviewers Минимальное количество ревьюверов, которое можно запросить для PR команды (по умолчанию 1)
+	MinReviewers *int `json:"min_reviewers,omitempty"`
+
+	// RequiredApprovals Количество одобрений (APPROVED), необходимое для merge PR команды
+	// (по умолчанию 0 - не требуется, не больше max_reviewers)
+	RequiredApprovals *int `json:"required_approvals,omitempty"`
+
+	// ReviewSlaSeconds Время в секундах, за которое назначенный ревьювер должен дать первый вердикт по PR команды
+	// (например, 86400 - 24 часа). По умолчанию 0 - SLA не отслеживается
+	ReviewSlaSeconds *int   `json:"review_sla_seconds,omitempty"`
+	TeamName         string `json:"team_name"`
 }
 
 // TeamMember defines model for TeamMember.
@@ -96,12 +372,94 @@ type TeamMember struct {
 	Username string `json:"username"`
 }
 
+// UpdateUserUnavailabilityRequest defines model for UpdateUserUnavailabilityRequest.
+type UpdateUserUnavailabilityRequest struct {
+	EndsAt           time.Time `json:"ends_at"`
+	HandoffReviews   *bool     `json:"handoff_reviews,omitempty"`
+	Reason           *string   `json:"reason,omitempty"`
+	StartsAt         time.Time `json:"starts_at"`
+	UnavailabilityId int64     `json:"unavailability_id"`
+}
+
+// UploadCodeownersRequest defines model for UploadCodeownersRequest.
+type UploadCodeownersRequest struct {
+	// Content Содержимое файла CODEOWNERS
+	Content    string `json:"content"`
+	Repository string `json:"repository"`
+}
+
 // User defines model for User.
 type User struct {
 	IsActive bool   `json:"is_active"`
 	TeamName string `json:"team_name"`
-	UserId   string `json:"user_id"`
-	Username string `json:"username"`
+
+	// Timezone Часовой пояс IANA (например, Europe/Moscow); задается вместе с рабочими часами
+	Timezone *string `json:"timezone,omitempty"`
+	UserId   string  `json:"user_id"`
+	Username string  `json:"username"`
+
+	// WorkingHoursEnd Конец рабочего дня по местному времени в формате HH:MM; раньше начала - рабочий день переходит через полночь
+	WorkingHoursEnd *string `json:"working_hours_end,omitempty"`
+
+	// WorkingHoursStart Начало рабочего дня по местному времени в формате HH:MM
+	WorkingHoursStart *string `json:"working_hours_start,omitempty"`
+}
+
+// UserIdentity defines model for UserIdentity.
+type UserIdentity struct {
+	// Login Логин пользователя во внешней системе (без учета регистра)
+	Login string `json:"login"`
+
+	// Provider Внешняя система, из которой приходят события PR
+	Provider IdentityProvider `json:"provider"`
+	UserId   string           `json:"user_id"`
+}
+
+// UserUnavailability defines model for UserUnavailability.
+type UserUnavailability struct {
+	// EndsAt Конец периода (не включается)
+	EndsAt time.Time `json:"ends_at"`
+
+	// HandedOffAt Время передачи открытых ревью (если handoff_reviews)
+	HandedOffAt *time.Time `json:"handed_off_at"`
+
+	// HandoffReviews Передать открытые ревью пользователя другим участникам команды в начале периода
+	HandoffReviews   bool      `json:"handoff_reviews"`
+	Reason           string    `json:"reason"`
+	StartsAt         time.Time `json:"starts_at"`
+	UnavailabilityId int64     `json:"unavailability_id"`
+	UserId           string    `json:"user_id"`
+}
+
+// WebhookDelivery defines model for WebhookDelivery.
+type WebhookDelivery struct {
+	// Attempt Номер попытки доставки (с 1)
+	Attempt     int       `json:"attempt"`
+	DeliveredAt time.Time `json:"delivered_at"`
+	DeliveryId  int64     `json:"delivery_id"`
+	Error       *string   `json:"error,omitempty"`
+	EventId     string    `json:"event_id"`
+
+	// EventType Тип события об изменении ревьюверов PR или о нарушении SLA ревью
+	EventType EventType `json:"event_type"`
+
+	// StatusCode HTTP статус ответа получателя (0, если ответ не получен)
+	StatusCode     int   `json:"status_code"`
+	SubscriptionId int64 `json:"subscription_id"`
+	Success        bool  `json:"success"`
+}
+
+// WebhookSubscription defines model for WebhookSubscription.
+type WebhookSubscription struct {
+	CreatedAt time.Time `json:"created_at"`
+
+	// EventTypes Типы событий подписки; пустой список - все события
+	EventTypes     []EventType `json:"event_types"`
+	IsActive       bool        `json:"is_active"`
+	SubscriptionId int64       `json:"subscription_id"`
+
+	// Url URL, на который отправляются события (POST, application/json)
+	Url string `json:"url"`
 }
 
 // TeamNameQuery defines model for TeamNameQuery.
@@ -110,16 +468,83 @@ type TeamNameQuery = string
 // UserIdQuery defines model for UserIdQuery.
 type UserIdQuery = string
 
+// PostCodeownersDeleteJSONBody defines parameters for PostCodeownersDelete.
+type PostCodeownersDeleteJSONBody struct {
+	Repository string `json:"repository"`
+}
+
+// GetCodeownersGetParams defines parameters for GetCodeownersGet.
+type GetCodeownersGetParams struct {
+	Repository string `form:"repository" json:"repository"`
+}
+
+// PostPoolsDeleteJSONBody defines parameters for PostPoolsDelete.
+type PostPoolsDeleteJSONBody struct {
+	PoolName string `json:"pool_name"`
+}
+
+// GetPoolsGetParams defines parameters for GetPoolsGet.
+type GetPoolsGetParams struct {
+	PoolName string `form:"pool_name" json:"pool_name"`
+}
+
 // PostPullRequestAssignReviewersJSONBody defines parameters for PostPullRequestAssignReviewers.
 type PostPullRequestAssignReviewersJSONBody struct {
 	PullRequestId string `json:"pull_request_id"`
 }
 
-// PostPullRequestCreateJSONBody defines parameters for PostPullRequestCreate.
-type PostPullRequestCreateJSONBody struct {
-	AuthorId        string `json:"author_id"`
-	PullRequestId   string `json:"pull_request_id"`
-	PullRequestName string `json:"pull_request_name"`
+// PostPullRequestCloseJSONBody defines parameters for PostPullRequestClose.
+type PostPullRequestCloseJSONBody struct {
+	PullRequestId string `json:"pull_request_id"`
+}
+
+// GetPullRequestHistoryParams defines parameters for GetPullRequestHistory.
+type GetPullRequestHistoryParams struct {
+	PullRequestId string `form:"pull_request_id" json:"pull_request_id"`
+}
+
+// GetPullRequestListParams defines parameters for GetPullRequestList.
+type GetPullRequestListParams struct {
+	Status   *PullRequestStatus `form:"status,omitempty" json:"status,omitempty"`
+	AuthorId *string            `form:"author_id,omitempty" json:"author_id,omitempty"`
+
+	// ReviewerId PR, где пользователь сейчас назначен ревьювером
+	ReviewerId *string `form:"reviewer_id,omitempty" json:"reviewer_id,omitempty"`
+
+	// TeamName PR авторов из команды
+	TeamName *string `form:"team_name,omitempty" json:"team_name,omitempty"`
+
+	// CreatedFrom Создан не раньше (включительно)
+	CreatedFrom *time.Time `form:"created_from,omitempty" json:"created_from,omitempty"`
+
+	// CreatedTo Создан раньше (не включительно)
+	CreatedTo *time.Time `form:"created_to,omitempty" json:"created_to,omitempty"`
+
+	// MergedFrom Слит не раньше (включительно)
+	MergedFrom *time.Time `form:"merged_from,omitempty" json:"merged_from,omitempty"`
+
+	// MergedTo Слит раньше (не включительно)
+	MergedTo *time.Time `form:"merged_to,omitempty" json:"merged_to,omitempty"`
+
+	// Name Подстрока названия PR (без учета регистра)
+	Name  *string                        `form:"name,omitempty" json:"name,omitempty"`
+	Sort  *GetPullRequestListParamsSort  `form:"sort,omitempty" json:"sort,omitempty"`
+	Order *GetPullRequestListParamsOrder `form:"order,omitempty" json:"order,omitempty"`
+	Limit *int                           `form:"limit,omitempty" json:"limit,omitempty"`
+
+	// Cursor Курсор следующей страницы (next_cursor предыдущего ответа)
+	Cursor *string `form:"cursor,omitempty" json:"cursor,omitempty"`
+}
+
+// GetPullRequestListParamsSort defines parameters for GetPullRequestList.
+type GetPullRequestListParamsSort string
+
+// GetPullRequestListParamsOrder defines parameters for GetPullRequestList.
+type GetPullRequestListParamsOrder string
+
+// PostPullRequestMarkReadyJSONBody defines parameters for PostPullRequestMarkReady.
+type PostPullRequestMarkReadyJSONBody struct {
+	PullRequestId string `json:"pull_request_id"`
 }
 
 // PostPullRequestMergeJSONBody defines parameters for PostPullRequestMerge.
@@ -133,6 +558,66 @@ type PostPullRequestReassignJSONBody struct {
 	PullRequestId string `json:"pull_request_id"`
 }
 
+// PostPullRequestReopenJSONBody defines parameters for PostPullRequestReopen.
+type PostPullRequestReopenJSONBody struct {
+	PullRequestId string `json:"pull_request_id"`
+}
+
+// PostPullRequestReviewJSONBody defines parameters for PostPullRequestReview.
+type PostPullRequestReviewJSONBody struct {
+	PullRequestId string        `json:"pull_request_id"`
+	ReviewerId    string        `json:"reviewer_id"`
+	Verdict       ReviewVerdict `json:"verdict"`
+}
+
+// GetReviewsStaleParams defines parameters for GetReviewsStale.
+type GetReviewsStaleParams struct {
+	// TeamName Только PR авторов команды
+	TeamName *string `form:"team_name,omitempty" json:"team_name,omitempty"`
+
+	// ReviewerId Только назначения ревьювера
+	ReviewerId *string `form:"reviewer_id,omitempty" json:"reviewer_id,omitempty"`
+}
+
+// GetStatisticsParams defines parameters for GetStatistics.
+type GetStatisticsParams struct {
+	// TeamName Только пользователи команды
+	TeamName *string `form:"team_name,omitempty" json:"team_name,omitempty"`
+
+	// From Начало периода (включительно)
+	From *time.Time `form:"from,omitempty" json:"from,omitempty"`
+
+	// To Конец периода (не включительно)
+	To *time.Time `form:"to,omitempty" json:"to,omitempty"`
+
+	// Status Учитывать только PR в этом статусе
+	Status *PullRequestStatus `form:"status,omitempty" json:"status,omitempty"`
+}
+
+// GetStatisticsFairnessParams defines parameters for GetStatisticsFairness.
+type GetStatisticsFairnessParams struct {
+	// TeamName Уникальное имя команды
+	TeamName TeamNameQuery `form:"team_name" json:"team_name"`
+
+	// From Начало периода (включительно)
+	From *time.Time `form:"from,omitempty" json:"from,omitempty"`
+
+	// To Конец периода (не включительно)
+	To               *time.Time `form:"to,omitempty" json:"to,omitempty"`
+	OutlierThreshold *float64   `form:"outlier_threshold,omitempty" json:"outlier_threshold,omitempty"`
+}
+
+// PostSubscriptionsDeleteJSONBody defines parameters for PostSubscriptionsDelete.
+type PostSubscriptionsDeleteJSONBody struct {
+	SubscriptionId int64 `json:"subscription_id"`
+}
+
+// GetSubscriptionsDeliveriesParams defines parameters for GetSubscriptionsDeliveries.
+type GetSubscriptionsDeliveriesParams struct {
+	SubscriptionId int64 `form:"subscription_id" json:"subscription_id"`
+	Limit          *int  `form:"limit,omitempty" json:"limit,omitempty"`
+}
+
 // PostTeamDeactivateUsersJSONBody defines parameters for PostTeamDeactivateUsers.
 type PostTeamDeactivateUsersJSONBody struct {
 	// TeamName Имя команды
@@ -151,7 +636,12 @@ type GetTeamGetParams struct {
 // GetUsersGetReviewParams defines parameters for GetUsersGetReview.
 type GetUsersGetReviewParams struct {
 	// UserId Идентификатор пользователя
-	UserId UserIdQuery `form:"user_id" json:"user_id"`
+	UserId UserIdQuery        `form:"user_id" json:"user_id"`
+	Status *PullRequestStatus `form:"status,omitempty" json:"status,omitempty"`
+	Limit  *int               `form:"limit,omitempty" json:"limit,omitempty"`
+
+	// Cursor Курсор следующей страницы (next_cursor предыдущего ответа)
+	Cursor *string `form:"cursor,omitempty" json:"cursor,omitempty"`
 }
 
 // PostUsersSetIsActiveJSONBody defines parameters for PostUsersSetIsActive.
@@ -160,11 +650,40 @@ type PostUsersSetIsActiveJSONBody struct {
 	UserId   string `json:"user_id"`
 }
 
+// PostUsersUnavailabilityDeleteJSONBody defines parameters for PostUsersUnavailabilityDelete.
+type PostUsersUnavailabilityDeleteJSONBody struct {
+	UnavailabilityId int64 `json:"unavailability_id"`
+}
+
+// GetUsersUnavailabilityListParams defines parameters for GetUsersUnavailabilityList.
+type GetUsersUnavailabilityListParams struct {
+	UserId      *string `form:"user_id,omitempty" json:"user_id,omitempty"`
+	IncludePast *bool   `form:"include_past,omitempty" json:"include_past,omitempty"`
+}
+
+// PostCodeownersDeleteJSONRequestBody defines body for PostCodeownersDelete for application/json ContentType.
+type PostCodeownersDeleteJSONRequestBody PostCodeownersDeleteJSONBody
+
+// PostCodeownersUploadJSONRequestBody defines body for PostCodeownersUpload for application/json ContentType.
+type PostCodeownersUploadJSONRequestBody = UploadCodeownersRequest
+
+// PostPoolsDeleteJSONRequestBody defines body for PostPoolsDelete for application/json ContentType.
+type PostPoolsDeleteJSONRequestBody PostPoolsDeleteJSONBody
+
+// PostPoolsSetJSONRequestBody defines body for PostPoolsSet for application/json ContentType.
+type PostPoolsSetJSONRequestBody = ReviewerPool
+
 // PostPullRequestAssignReviewersJSONRequestBody defines body for PostPullRequestAssignReviewers for application/json ContentType.
 type PostPullRequestAssignReviewersJSONRequestBody PostPullRequestAssignReviewersJSONBody
 
+// PostPullRequestCloseJSONRequestBody defines body for PostPullRequestClose for application/json ContentType.
+type PostPullRequestCloseJSONRequestBody PostPullRequestCloseJSONBody
+
 // PostPullRequestCreateJSONRequestBody defines body for PostPullRequestCreate for application/json ContentType.
-type PostPullRequestCreateJSONRequestBody PostPullRequestCreateJSONBody
+type PostPullRequestCreateJSONRequestBody = CreatePullRequestRequest
+
+// PostPullRequestMarkReadyJSONRequestBody defines body for PostPullRequestMarkReady for application/json ContentType.
+type PostPullRequestMarkReadyJSONRequestBody PostPullRequestMarkReadyJSONBody
 
 // PostPullRequestMergeJSONRequestBody defines body for PostPullRequestMerge for application/json ContentType.
 type PostPullRequestMergeJSONRequestBody PostPullRequestMergeJSONBody
@@ -172,6 +691,18 @@ type PostPullRequestMergeJSONRequestBody PostPullRequestMergeJSONBody
 // PostPullRequestReassignJSONRequestBody defines body for PostPullRequestReassign for application/json ContentType.
 type PostPullRequestReassignJSONRequestBody PostPullRequestReassignJSONBody
 
+// PostPullRequestReopenJSONRequestBody defines body for PostPullRequestReopen for application/json ContentType.
+type PostPullRequestReopenJSONRequestBody PostPullRequestReopenJSONBody
+
+// PostPullRequestReviewJSONRequestBody defines body for PostPullRequestReview for application/json ContentType.
+type PostPullRequestReviewJSONRequestBody PostPullRequestReviewJSONBody
+
+// PostSubscriptionsAddJSONRequestBody defines body for PostSubscriptionsAdd for application/json ContentType.
+type PostSubscriptionsAddJSONRequestBody = CreateWebhookSubscriptionRequest
+
+// PostSubscriptionsDeleteJSONRequestBody defines body for PostSubscriptionsDelete for application/json ContentType.
+type PostSubscriptionsDeleteJSONRequestBody PostSubscriptionsDeleteJSONBody
+
 // PostTeamAddJSONRequestBody defines body for PostTeamAdd for application/json ContentType.
 type PostTeamAddJSONRequestBody = Team
 
@@ -181,26 +712,101 @@ type PostTeamDeactivateUsersJSONRequestBody PostTeamDeactivateUsersJSONBody
 // PostTeamUpdateJSONRequestBody defines body for PostTeamUpdate for application/json ContentType.
 type PostTeamUpdateJSONRequestBody = Team
 
+// PostUsersLinkIdentityJSONRequestBody defines body for PostUsersLinkIdentity for application/json ContentType.
+type PostUsersLinkIdentityJSONRequestBody = UserIdentity
+
 // PostUsersSetIsActiveJSONRequestBody defines body for PostUsersSetIsActive for application/json ContentType.
 type PostUsersSetIsActiveJSONRequestBody PostUsersSetIsActiveJSONBody
 
+// PostUsersSetWorkingHoursJSONRequestBody defines body for PostUsersSetWorkingHours for application/json ContentType.
+type PostUsersSetWorkingHoursJSONRequestBody = SetUserWorkingHoursRequest
+
+// PostUsersUnavailabilityAddJSONRequestBody defines body for PostUsersUnavailabilityAdd for application/json ContentType.
+type PostUsersUnavailabilityAddJSONRequestBody = CreateUserUnavailabilityRequest
+
+// PostUsersUnavailabilityDeleteJSONRequestBody defines body for PostUsersUnavailabilityDelete for application/json ContentType.
+type PostUsersUnavailabilityDeleteJSONRequestBody PostUsersUnavailabilityDeleteJSONBody
+
+// PostUsersUnavailabilityUpdateJSONRequestBody defines body for PostUsersUnavailabilityUpdate for application/json ContentType.
+type PostUsersUnavailabilityUpdateJSONRequestBody = UpdateUserUnavailabilityRequest
+
 // ServerInterface represents all server handlers.
 type ServerInterface interface {
+	// Удалить правила CODEOWNERS репозитория
+	// (POST /codeowners/delete)
+	PostCodeownersDelete(w http.ResponseWriter, r *http.Request)
+	// Получить правила CODEOWNERS репозитория
+	// (GET /codeowners/get)
+	GetCodeownersGet(w http.ResponseWriter, r *http.Request, params GetCodeownersGetParams)
+	// Загрузить правила CODEOWNERS репозитория
+	// (POST /codeowners/upload)
+	PostCodeownersUpload(w http.ResponseWriter, r *http.Request)
+	// Выгрузить все назначения ревьюверов
+	// (GET /export/assignments)
+	GetExportAssignments(w http.ResponseWriter, r *http.Request)
+	// Удалить пул ревьюверов
+	// (POST /pools/delete)
+	PostPoolsDelete(w http.ResponseWriter, r *http.Request)
+	// Получить пул ревьюверов
+	// (GET /pools/get)
+	GetPoolsGet(w http.ResponseWriter, r *http.Request, params GetPoolsGetParams)
+	// Список пулов ревьюверов
+	// (GET /pools/list)
+	GetPoolsList(w http.ResponseWriter, r *http.Request)
+	// Создать пул ревьюверов или заменить его участников
+	// (POST /pools/set)
+	PostPoolsSet(w http.ResponseWriter, r *http.Request)
 	// Автоматически назначить или дополнить ревьюверов для PR
 	// (POST /pullRequest/assignReviewers)
 	PostPullRequestAssignReviewers(w http.ResponseWriter, r *http.Request)
-	// Создать PR и автоматически назначить до 2 ревьюверов из команды автора
+	// Закрыть PR без слияния (идемпотентная операция)
+	// (POST /pullRequest/close)
+	PostPullRequestClose(w http.ResponseWriter, r *http.Request)
+	// Создать PR и автоматически назначить ревьюверов из команды автора
 	// (POST /pullRequest/create)
 	PostPullRequestCreate(w http.ResponseWriter, r *http.Request)
+	// Получить историю PR
+	// (GET /pullRequest/history)
+	GetPullRequestHistory(w http.ResponseWriter, r *http.Request, params GetPullRequestHistoryParams)
+	// Получить список PR с фильтрами и постраничной выдачей
+	// (GET /pullRequest/list)
+	GetPullRequestList(w http.ResponseWriter, r *http.Request, params GetPullRequestListParams)
+	// Перевести черновик в OPEN и назначить ревьюверов (идемпотентная операция)
+	// (POST /pullRequest/markReady)
+	PostPullRequestMarkReady(w http.ResponseWriter, r *http.Request)
 	// Пометить PR как MERGED (идемпотентная операция)
 	// (POST /pullRequest/merge)
 	PostPullRequestMerge(w http.ResponseWriter, r *http.Request)
 	// Переназначить конкретного ревьювера на другого из его команды
 	// (POST /pullRequest/reassign)
 	PostPullRequestReassign(w http.ResponseWriter, r *http.Request)
+	// Переоткрыть закрытый PR (идемпотентная операция)
+	// (POST /pullRequest/reopen)
+	PostPullRequestReopen(w http.ResponseWriter, r *http.Request)
+	// Отправить вердикт ревьювера по PR
+	// (POST /pullRequest/review)
+	PostPullRequestReview(w http.ResponseWriter, r *http.Request)
+	// Получить назначения с нарушенным SLA ревью
+	// (GET /reviews/stale)
+	GetReviewsStale(w http.ResponseWriter, r *http.Request, params GetReviewsStaleParams)
 	// Получить статистику назначений ревьюверов
 	// (GET /statistics)
-	GetStatistics(w http.ResponseWriter, r *http.Request)
+	GetStatistics(w http.ResponseWriter, r *http.Request, params GetStatisticsParams)
+	// Получить отчет о равномерности распределения ревью в команде
+	// (GET /statistics/fairness)
+	GetStatisticsFairness(w http.ResponseWriter, r *http.Request, params GetStatisticsFairnessParams)
+	// Подписаться на события об изменении ревьюверов
+	// (POST /subscriptions/add)
+	PostSubscriptionsAdd(w http.ResponseWriter, r *http.Request)
+	// Удалить подписку
+	// (POST /subscriptions/delete)
+	PostSubscriptionsDelete(w http.ResponseWriter, r *http.Request)
+	// Получить журнал доставок по подписке
+	// (GET /subscriptions/deliveries)
+	GetSubscriptionsDeliveries(w http.ResponseWriter, r *http.Request, params GetSubscriptionsDeliveriesParams)
+	// Получить список подписок
+	// (GET /subscriptions/list)
+	GetSubscriptionsList(w http.ResponseWriter, r *http.Request)
 	// Создать новую команду с участниками
 	// (POST /team/add)
 	PostTeamAdd(w http.ResponseWriter, r *http.Request)
@@ -216,95 +822,820 @@ type ServerInterface interface {
 	// Получить PR'ы, где пользователь назначен ревьювером
 	// (GET /users/getReview)
 	GetUsersGetReview(w http.ResponseWriter, r *http.Request, params GetUsersGetReviewParams)
+	// Связать логин во внешней системе (GitHub, GitLab) с пользователем
+	// (POST /users/linkIdentity)
+	PostUsersLinkIdentity(w http.ResponseWriter, r *http.Request)
 	// Установить флаг активности пользователя
 	// (POST /users/setIsActive)
 	PostUsersSetIsActive(w http.ResponseWriter, r *http.Request)
+	// Задать часовой пояс и рабочие часы пользователя
+	// (POST /users/setWorkingHours)
+	PostUsersSetWorkingHours(w http.ResponseWriter, r *http.Request)
+	// Добавить период отсутствия пользователя
+	// (POST /users/unavailability/add)
+	PostUsersUnavailabilityAdd(w http.ResponseWriter, r *http.Request)
+	// Удалить период отсутствия
+	// (POST /users/unavailability/delete)
+	PostUsersUnavailabilityDelete(w http.ResponseWriter, r *http.Request)
+	// Получить периоды отсутствия
+	// (GET /users/unavailability/list)
+	GetUsersUnavailabilityList(w http.ResponseWriter, r *http.Request, params GetUsersUnavailabilityListParams)
+	// Изменить период отсутствия
+	// (POST /users/unavailability/update)
+	PostUsersUnavailabilityUpdate(w http.ResponseWriter, r *http.Request)
 }
 
 // Unimplemented server implementation that returns http.StatusNotImplemented for each endpoint.
 
 type Unimplemented struct{}
 
+// Удалить правила CODEOWNERS репозитория
+// (POST /codeowners/delete)
+func (_ Unimplemented) PostCodeownersDelete(w http.ResponseWriter, r *http.Request) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Получить правила CODEOWNERS репозитория
+// (GET /codeowners/get)
+func (_ Unimplemented) GetCodeownersGet(w http.ResponseWriter, r *http.Request, params GetCodeownersGetParams) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Загрузить правила CODEOWNERS репозитория
+// (POST /codeowners/upload)
+func (_ Unimplemented) PostCodeownersUpload(w http.ResponseWriter, r *http.Request) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Выгрузить все назначения ревьюверов
+// (GET /export/assignments)
+func (_ Unimplemented) GetExportAssignments(w http.ResponseWriter, r *http.Request) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Удалить пул ревьюверов
+// (POST /pools/delete)
+func (_ Unimplemented) PostPoolsDelete(w http.ResponseWriter, r *http.Request) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Получить пул ревьюверов
+// (GET /pools/get)
+func (_ Unimplemented) GetPoolsGet(w http.ResponseWriter, r *http.Request, params GetPoolsGetParams) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Список пулов ревьюверов
+// (GET /pools/list)
+func (_ Unimplemented) GetPoolsList(w http.ResponseWriter, r *http.Request) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Создать пул ревьюверов или заменить его участников
+// (POST /pools/set)
+func (_ Unimplemented) PostPoolsSet(w http.ResponseWriter, r *http.Request) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
 // Автоматически назначить или дополнить ревьюверов для PR
 // (POST /pullRequest/assignReviewers)
 func (_ Unimplemented) PostPullRequestAssignReviewers(w http.ResponseWriter, r *http.Request) {
 	w.WriteHeader(http.StatusNotImplemented)
 }
 
-// Создать PR и автоматически назначить до 2 ревьюверов из команды автора
+// Закрыть PR без слияния (идемпотентная операция)
+// (POST /pullRequest/close)
+func (_ Unimplemented) PostPullRequestClose(w http.ResponseWriter, r *http.Request) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Создать PR и автоматически назначить ревьюверов из команды автора
 // (POST /pullRequest/create)
 func (_ Unimplemented) PostPullRequestCreate(w http.ResponseWriter, r *http.Request) {
 	w.WriteHeader(http.StatusNotImplemented)
 }
 
-// Пометить PR как MERGED (идемпотентная операция)
-// (POST /pullRequest/merge)
-func (_ Unimplemented) PostPullRequestMerge(w http.ResponseWriter, r *http.Request) {
-	w.WriteHeader(http.StatusNotImplemented)
+// Получить историю PR
+// (GET /pullRequest/history)
+func (_ Unimplemented) GetPullRequestHistory(w http.ResponseWriter, r *http.Request, params GetPullRequestHistoryParams) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Получить список PR с фильтрами и постраничной выдачей
+// (GET /pullRequest/list)
+func (_ Unimplemented) GetPullRequestList(w http.ResponseWriter, r *http.Request, params GetPullRequestListParams) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Перевести черновик в OPEN и назначить ревьюверов (идемпотентная операция)
+// (POST /pullRequest/markReady)
+func (_ Unimplemented) PostPullRequestMarkReady(w http.ResponseWriter, r *http.Request) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Пометить PR как MERGED (идемпотентная операция)
+// (POST /pullRequest/merge)
+func (_ Unimplemented) PostPullRequestMerge(w http.ResponseWriter, r *http.Request) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Переназначить конкретного ревьювера на другого из его команды
+// (POST /pullRequest/reassign)
+func (_ Unimplemented) PostPullRequestReassign(w http.ResponseWriter, r *http.Request) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Переоткрыть закрытый PR (идемпотентная операция)
+// (POST /pullRequest/reopen)
+func (_ Unimplemented) PostPullRequestReopen(w http.ResponseWriter, r *http.Request) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Отправить вердикт ревьювера по PR
+// (POST /pullRequest/review)
+func (_ Unimplemented) PostPullRequestReview(w http.ResponseWriter, r *http.Request) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Получить назначения с нарушенным SLA ревью
+// (GET /reviews/stale)
+func (_ Unimplemented) GetReviewsStale(w http.ResponseWriter, r *http.Request, params GetReviewsStaleParams) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Получить статистику назначений ревьюверов
+// (GET /statistics)
+func (_ Unimplemented) GetStatistics(w http.ResponseWriter, r *http.Request, params GetStatisticsParams) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Получить отчет о равномерности распределения ревью в команде
+// (GET /statistics/fairness)
+func (_ Unimplemented) GetStatisticsFairness(w http.ResponseWriter, r *http.Request, params GetStatisticsFairnessParams) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Подписаться на события об изменении ревьюверов
+// (POST /subscriptions/add)
+func (_ Unimplemented) PostSubscriptionsAdd(w http.ResponseWriter, r *http.Request) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Удалить подписку
+// (POST /subscriptions/delete)
+func (_ Unimplemented) PostSubscriptionsDelete(w http.ResponseWriter, r *http.Request) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Получить журнал доставок по подписке
+// (GET /subscriptions/deliveries)
+func (_ Unimplemented) GetSubscriptionsDeliveries(w http.ResponseWriter, r *http.Request, params GetSubscriptionsDeliveriesParams) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Получить список подписок
+// (GET /subscriptions/list)
+func (_ Unimplemented) GetSubscriptionsList(w http.ResponseWriter, r *http.Request) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Создать новую команду с участниками
+// (POST /team/add)
+func (_ Unimplemented) PostTeamAdd(w http.ResponseWriter, r *http.Request) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Массовая деактивация пользователей команды с автоматическим переназначением открытых PR
+// (POST /team/deactivateUsers)
+func (_ Unimplemented) PostTeamDeactivateUsers(w http.ResponseWriter, r *http.Request) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Получить команду с участниками
+// (GET /team/get)
+func (_ Unimplemented) GetTeamGet(w http.ResponseWriter, r *http.Request, params GetTeamGetParams) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Добавить или обновить участников существующей команды
+// (POST /team/update)
+func (_ Unimplemented) PostTeamUpdate(w http.ResponseWriter, r *http.Request) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Получить PR'ы, где пользователь назначен ревьювером
+// (GET /users/getReview)
+func (_ Unimplemented) GetUsersGetReview(w http.ResponseWriter, r *http.Request, params GetUsersGetReviewParams) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Связать логин во внешней системе (GitHub, GitLab) с пользователем
+// (POST /users/linkIdentity)
+func (_ Unimplemented) PostUsersLinkIdentity(w http.ResponseWriter, r *http.Request) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Установить флаг активности пользователя
+// (POST /users/setIsActive)
+func (_ Unimplemented) PostUsersSetIsActive(w http.ResponseWriter, r *http.Request) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Задать часовой пояс и рабочие часы пользователя
+// (POST /users/setWorkingHours)
+func (_ Unimplemented) PostUsersSetWorkingHours(w http.ResponseWriter, r *http.Request) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Добавить период отсутствия пользователя
+// (POST /users/unavailability/add)
+func (_ Unimplemented) PostUsersUnavailabilityAdd(w http.ResponseWriter, r *http.Request) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Удалить период отсутствия
+// (POST /users/unavailability/delete)
+func (_ Unimplemented) PostUsersUnavailabilityDelete(w http.ResponseWriter, r *http.Request) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Получить периоды отсутствия
+// (GET /users/unavailability/list)
+func (_ Unimplemented) GetUsersUnavailabilityList(w http.ResponseWriter, r *http.Request, params GetUsersUnavailabilityListParams) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Изменить период отсутствия
+// (POST /users/unavailability/update)
+func (_ Unimplemented) PostUsersUnavailabilityUpdate(w http.ResponseWriter, r *http.Request) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// ServerInterfaceWrapper converts contexts to parameters.
+type ServerInterfaceWrapper struct {
+	Handler            ServerInterface
+	HandlerMiddlewares []MiddlewareFunc
+	ErrorHandlerFunc   func(w http.ResponseWriter, r *http.Request, err error)
+}
+
+type MiddlewareFunc func(http.Handler) http.Handler
+
+// PostCodeownersDelete operation middleware
+func (siw *ServerInterfaceWrapper) PostCodeownersDelete(w http.ResponseWriter, r *http.Request) {
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.PostCodeownersDelete(w, r)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// GetCodeownersGet operation middleware
+func (siw *ServerInterfaceWrapper) GetCodeownersGet(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params GetCodeownersGetParams
+
+	// ------------- Required query parameter "repository" -------------
+
+	if paramValue := r.URL.Query().Get("repository"); paramValue != "" {
+
+	} else {
+		siw.ErrorHandlerFunc(w, r, &RequiredParamError{ParamName: "repository"})
+		return
+	}
+
+	err = runtime.BindQueryParameter("form", true, true, "repository", r.URL.Query(), &params.Repository)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "repository", Err: err})
+		return
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.GetCodeownersGet(w, r, params)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// PostCodeownersUpload operation middleware
+func (siw *ServerInterfaceWrapper) PostCodeownersUpload(w http.ResponseWriter, r *http.Request) {
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.PostCodeownersUpload(w, r)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// GetExportAssignments operation middleware
+func (siw *ServerInterfaceWrapper) GetExportAssignments(w http.ResponseWriter, r *http.Request) {
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.GetExportAssignments(w, r)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// PostPoolsDelete operation middleware
+func (siw *ServerInterfaceWrapper) PostPoolsDelete(w http.ResponseWriter, r *http.Request) {
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.PostPoolsDelete(w, r)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// GetPoolsGet operation middleware
+func (siw *ServerInterfaceWrapper) GetPoolsGet(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params GetPoolsGetParams
+
+	// ------------- Required query parameter "pool_name" -------------
+
+	if paramValue := r.URL.Query().Get("pool_name"); paramValue != "" {
+
+	} else {
+		siw.ErrorHandlerFunc(w, r, &RequiredParamError{ParamName: "pool_name"})
+		return
+	}
+
+	err = runtime.BindQueryParameter("form", true, true, "pool_name", r.URL.Query(), &params.PoolName)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "pool_name", Err: err})
+		return
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.GetPoolsGet(w, r, params)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// GetPoolsList operation middleware
+func (siw *ServerInterfaceWrapper) GetPoolsList(w http.ResponseWriter, r *http.Request) {
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.GetPoolsList(w, r)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// PostPoolsSet operation middleware
+func (siw *ServerInterfaceWrapper) PostPoolsSet(w http.ResponseWriter, r *http.Request) {
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.PostPoolsSet(w, r)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// PostPullRequestAssignReviewers operation middleware
+func (siw *ServerInterfaceWrapper) PostPullRequestAssignReviewers(w http.ResponseWriter, r *http.Request) {
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.PostPullRequestAssignReviewers(w, r)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// PostPullRequestClose operation middleware
+func (siw *ServerInterfaceWrapper) PostPullRequestClose(w http.ResponseWriter, r *http.Request) {
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.PostPullRequestClose(w, r)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// PostPullRequestCreate operation middleware
+func (siw *ServerInterfaceWrapper) PostPullRequestCreate(w http.ResponseWriter, r *http.Request) {
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.PostPullRequestCreate(w, r)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// GetPullRequestHistory operation middleware
+func (siw *ServerInterfaceWrapper) GetPullRequestHistory(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params GetPullRequestHistoryParams
+
+	// ------------- Required query parameter "pull_request_id" -------------
+
+	if paramValue := r.URL.Query().Get("pull_request_id"); paramValue != "" {
+
+	} else {
+		siw.ErrorHandlerFunc(w, r, &RequiredParamError{ParamName: "pull_request_id"})
+		return
+	}
+
+	err = runtime.BindQueryParameter("form", true, true, "pull_request_id", r.URL.Query(), &params.PullRequestId)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "pull_request_id", Err: err})
+		return
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.GetPullRequestHistory(w, r, params)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// GetPullRequestList operation middleware
+func (siw *ServerInterfaceWrapper) GetPullRequestList(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params GetPullRequestListParams
+
+	// ------------- Optional query parameter "status" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "status", r.URL.Query(), &params.Status)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "status", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "author_id" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "author_id", r.URL.Query(), &params.AuthorId)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "author_id", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "reviewer_id" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "reviewer_id", r.URL.Query(), &params.ReviewerId)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "reviewer_id", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "team_name" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "team_name", r.URL.Query(), &params.TeamName)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "team_name", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "created_from" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "created_from", r.URL.Query(), &params.CreatedFrom)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "created_from", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "created_to" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "created_to", r.URL.Query(), &params.CreatedTo)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "created_to", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "merged_from" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "merged_from", r.URL.Query(), &params.MergedFrom)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "merged_from", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "merged_to" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "merged_to", r.URL.Query(), &params.MergedTo)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "merged_to", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "name" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "name", r.URL.Query(), &params.Name)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "name", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "sort" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "sort", r.URL.Query(), &params.Sort)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "sort", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "order" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "order", r.URL.Query(), &params.Order)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "order", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "limit" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "limit", r.URL.Query(), &params.Limit)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "limit", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "cursor" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "cursor", r.URL.Query(), &params.Cursor)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "cursor", Err: err})
+		return
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.GetPullRequestList(w, r, params)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// PostPullRequestMarkReady operation middleware
+func (siw *ServerInterfaceWrapper) PostPullRequestMarkReady(w http.ResponseWriter, r *http.Request) {
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.PostPullRequestMarkReady(w, r)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// PostPullRequestMerge operation middleware
+func (siw *ServerInterfaceWrapper) PostPullRequestMerge(w http.ResponseWriter, r *http.Request) {
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.PostPullRequestMerge(w, r)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// PostPullRequestReassign operation middleware
+func (siw *ServerInterfaceWrapper) PostPullRequestReassign(w http.ResponseWriter, r *http.Request) {
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.PostPullRequestReassign(w, r)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// PostPullRequestReopen operation middleware
+func (siw *ServerInterfaceWrapper) PostPullRequestReopen(w http.ResponseWriter, r *http.Request) {
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.PostPullRequestReopen(w, r)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// PostPullRequestReview operation middleware
+func (siw *ServerInterfaceWrapper) PostPullRequestReview(w http.ResponseWriter, r *http.Request) {
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.PostPullRequestReview(w, r)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// GetReviewsStale operation middleware
+func (siw *ServerInterfaceWrapper) GetReviewsStale(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params GetReviewsStaleParams
+
+	// ------------- Optional query parameter "team_name" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "team_name", r.URL.Query(), &params.TeamName)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "team_name", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "reviewer_id" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "reviewer_id", r.URL.Query(), &params.ReviewerId)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "reviewer_id", Err: err})
+		return
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.GetReviewsStale(w, r, params)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// GetStatistics operation middleware
+func (siw *ServerInterfaceWrapper) GetStatistics(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params GetStatisticsParams
+
+	// ------------- Optional query parameter "team_name" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "team_name", r.URL.Query(), &params.TeamName)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "team_name", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "from" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "from", r.URL.Query(), &params.From)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "from", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "to" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "to", r.URL.Query(), &params.To)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "to", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "status" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "status", r.URL.Query(), &params.Status)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "status", Err: err})
+		return
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.GetStatistics(w, r, params)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
 }
 
-// Переназначить конкретного ревьювера на другого из его команды
-// (POST /pullRequest/reassign)
-func (_ Unimplemented) PostPullRequestReassign(w http.ResponseWriter, r *http.Request) {
-	w.WriteHeader(http.StatusNotImplemented)
-}
+// GetStatisticsFairness operation middleware
+func (siw *ServerInterfaceWrapper) GetStatisticsFairness(w http.ResponseWriter, r *http.Request) {
 
-// Получить статистику назначений ревьюверов
-// (GET /statistics)
-func (_ Unimplemented) GetStatistics(w http.ResponseWriter, r *http.Request) {
-	w.WriteHeader(http.StatusNotImplemented)
-}
+	var err error
 
-// Создать новую команду с участниками
-// (POST /team/add)
-func (_ Unimplemented) PostTeamAdd(w http.ResponseWriter, r *http.Request) {
-	w.WriteHeader(http.StatusNotImplemented)
-}
+	// Parameter object where we will unmarshal all parameters from the context
+	var params GetStatisticsFairnessParams
 
-// Массовая деактивация пользователей команды с автоматическим переназначением открытых PR
-// (POST /team/deactivateUsers)
-func (_ Unimplemented) PostTeamDeactivateUsers(w http.ResponseWriter, r *http.Request) {
-	w.WriteHeader(http.StatusNotImplemented)
-}
+	// ------------- Required query parameter "team_name" -------------
 
-// Получить команду с участниками
-// (GET /team/get)
-func (_ Unimplemented) GetTeamGet(w http.ResponseWriter, r *http.Request, params GetTeamGetParams) {
-	w.WriteHeader(http.StatusNotImplemented)
-}
+	if paramValue := r.URL.Query().Get("team_name"); paramValue != "" {
 
-// Добавить или обновить участников существующей команды
-// (POST /team/update)
-func (_ Unimplemented) PostTeamUpdate(w http.ResponseWriter, r *http.Request) {
-	w.WriteHeader(http.StatusNotImplemented)
-}
+	} else {
+		siw.ErrorHandlerFunc(w, r, &RequiredParamError{ParamName: "team_name"})
+		return
+	}
 
-// Получить PR'ы, где пользователь назначен ревьювером
-// (GET /users/getReview)
-func (_ Unimplemented) GetUsersGetReview(w http.ResponseWriter, r *http.Request, params GetUsersGetReviewParams) {
-	w.WriteHeader(http.StatusNotImplemented)
-}
+	err = runtime.BindQueryParameter("form", true, true, "team_name", r.URL.Query(), &params.TeamName)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "team_name", Err: err})
+		return
+	}
 
-// Установить флаг активности пользователя
-// (POST /users/setIsActive)
-func (_ Unimplemented) PostUsersSetIsActive(w http.ResponseWriter, r *http.Request) {
-	w.WriteHeader(http.StatusNotImplemented)
-}
+	// ------------- Optional query parameter "from" -------------
 
-// ServerInterfaceWrapper converts contexts to parameters.
-type ServerInterfaceWrapper struct {
-	Handler            ServerInterface
-	HandlerMiddlewares []MiddlewareFunc
-	ErrorHandlerFunc   func(w http.ResponseWriter, r *http.Request, err error)
-}
+	err = runtime.BindQueryParameter("form", true, false, "from", r.URL.Query(), &params.From)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "from", Err: err})
+		return
+	}
 
-type MiddlewareFunc func(http.Handler) http.Handler
+	// ------------- Optional query parameter "to" -------------
 
-// PostPullRequestAssignReviewers operation middleware
-func (siw *ServerInterfaceWrapper) PostPullRequestAssignReviewers(w http.ResponseWriter, r *http.Request) {
+	err = runtime.BindQueryParameter("form", true, false, "to", r.URL.Query(), &params.To)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "to", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "outlier_threshold" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "outlier_threshold", r.URL.Query(), &params.OutlierThreshold)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "outlier_threshold", Err: err})
+		return
+	}
 
 	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		siw.Handler.PostPullRequestAssignReviewers(w, r)
+		siw.Handler.GetStatisticsFairness(w, r, params)
 	}))
 
 	for _, middleware := range siw.HandlerMiddlewares {
@@ -314,11 +1645,11 @@ func (siw *ServerInterfaceWrapper) PostPullRequestAssignReviewers(w http.Respons
 	handler.ServeHTTP(w, r)
 }
 
-// PostPullRequestCreate operation middleware
-func (siw *ServerInterfaceWrapper) PostPullRequestCreate(w http.ResponseWriter, r *http.Request) {
+// PostSubscriptionsAdd operation middleware
+func (siw *ServerInterfaceWrapper) PostSubscriptionsAdd(w http.ResponseWriter, r *http.Request) {
 
 	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		siw.Handler.PostPullRequestCreate(w, r)
+		siw.Handler.PostSubscriptionsAdd(w, r)
 	}))
 
 	for _, middleware := range siw.HandlerMiddlewares {
@@ -328,11 +1659,11 @@ func (siw *ServerInterfaceWrapper) PostPullRequestCreate(w http.ResponseWriter,
 	handler.ServeHTTP(w, r)
 }
 
-// PostPullRequestMerge operation middleware
-func (siw *ServerInterfaceWrapper) PostPullRequestMerge(w http.ResponseWriter, r *http.Request) {
+// PostSubscriptionsDelete operation middleware
+func (siw *ServerInterfaceWrapper) PostSubscriptionsDelete(w http.ResponseWriter, r *http.Request) {
 
 	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		siw.Handler.PostPullRequestMerge(w, r)
+		siw.Handler.PostSubscriptionsDelete(w, r)
 	}))
 
 	for _, middleware := range siw.HandlerMiddlewares {
@@ -342,11 +1673,39 @@ func (siw *ServerInterfaceWrapper) PostPullRequestMerge(w http.ResponseWriter, r
 	handler.ServeHTTP(w, r)
 }
 
-// PostPullRequestReassign operation middleware
-func (siw *ServerInterfaceWrapper) PostPullRequestReassign(w http.ResponseWriter, r *http.Request) {
+// GetSubscriptionsDeliveries operation middleware
+func (siw *ServerInterfaceWrapper) GetSubscriptionsDeliveries(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params GetSubscriptionsDeliveriesParams
+
+	// ------------- Required query parameter "subscription_id" -------------
+
+	if paramValue := r.URL.Query().Get("subscription_id"); paramValue != "" {
+
+	} else {
+		siw.ErrorHandlerFunc(w, r, &RequiredParamError{ParamName: "subscription_id"})
+		return
+	}
+
+	err = runtime.BindQueryParameter("form", true, true, "subscription_id", r.URL.Query(), &params.SubscriptionId)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "subscription_id", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "limit" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "limit", r.URL.Query(), &params.Limit)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "limit", Err: err})
+		return
+	}
 
 	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		siw.Handler.PostPullRequestReassign(w, r)
+		siw.Handler.GetSubscriptionsDeliveries(w, r, params)
 	}))
 
 	for _, middleware := range siw.HandlerMiddlewares {
@@ -356,11 +1715,11 @@ func (siw *ServerInterfaceWrapper) PostPullRequestReassign(w http.ResponseWriter
 	handler.ServeHTTP(w, r)
 }
 
-// GetStatistics operation middleware
-func (siw *ServerInterfaceWrapper) GetStatistics(w http.ResponseWriter, r *http.Request) {
+// GetSubscriptionsList operation middleware
+func (siw *ServerInterfaceWrapper) GetSubscriptionsList(w http.ResponseWriter, r *http.Request) {
 
 	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		siw.Handler.GetStatistics(w, r)
+		siw.Handler.GetSubscriptionsList(w, r)
 	}))
 
 	for _, middleware := range siw.HandlerMiddlewares {
@@ -469,6 +1828,30 @@ func (siw *ServerInterfaceWrapper) GetUsersGetReview(w http.ResponseWriter, r *h
 		return
 	}
 
+	// ------------- Optional query parameter "status" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "status", r.URL.Query(), &params.Status)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "status", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "limit" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "limit", r.URL.Query(), &params.Limit)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "limit", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "cursor" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "cursor", r.URL.Query(), &params.Cursor)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "cursor", Err: err})
+		return
+	}
+
 	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		siw.Handler.GetUsersGetReview(w, r, params)
 	}))
@@ -480,6 +1863,20 @@ func (siw *ServerInterfaceWrapper) GetUsersGetReview(w http.ResponseWriter, r *h
 	handler.ServeHTTP(w, r)
 }
 
+// PostUsersLinkIdentity operation middleware
+func (siw *ServerInterfaceWrapper) PostUsersLinkIdentity(w http.ResponseWriter, r *http.Request) {
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.PostUsersLinkIdentity(w, r)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
 // PostUsersSetIsActive operation middleware
 func (siw *ServerInterfaceWrapper) PostUsersSetIsActive(w http.ResponseWriter, r *http.Request) {
 
@@ -494,6 +1891,97 @@ func (siw *ServerInterfaceWrapper) PostUsersSetIsActive(w http.ResponseWriter, r
 	handler.ServeHTTP(w, r)
 }
 
+// PostUsersSetWorkingHours operation middleware
+func (siw *ServerInterfaceWrapper) PostUsersSetWorkingHours(w http.ResponseWriter, r *http.Request) {
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.PostUsersSetWorkingHours(w, r)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// PostUsersUnavailabilityAdd operation middleware
+func (siw *ServerInterfaceWrapper) PostUsersUnavailabilityAdd(w http.ResponseWriter, r *http.Request) {
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.PostUsersUnavailabilityAdd(w, r)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// PostUsersUnavailabilityDelete operation middleware
+func (siw *ServerInterfaceWrapper) PostUsersUnavailabilityDelete(w http.ResponseWriter, r *http.Request) {
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.PostUsersUnavailabilityDelete(w, r)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// GetUsersUnavailabilityList operation middleware
+func (siw *ServerInterfaceWrapper) GetUsersUnavailabilityList(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params GetUsersUnavailabilityListParams
+
+	// ------------- Optional query parameter "user_id" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "user_id", r.URL.Query(), &params.UserId)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "user_id", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "include_past" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "include_past", r.URL.Query(), &params.IncludePast)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "include_past", Err: err})
+		return
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.GetUsersUnavailabilityList(w, r, params)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// PostUsersUnavailabilityUpdate operation middleware
+func (siw *ServerInterfaceWrapper) PostUsersUnavailabilityUpdate(w http.ResponseWriter, r *http.Request) {
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.PostUsersUnavailabilityUpdate(w, r)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
 type UnescapedCookieParamError struct {
 	ParamName string
 	Err       error
@@ -607,21 +2095,81 @@ func HandlerWithOptions(si ServerInterface, options ChiServerOptions) http.Handl
 		ErrorHandlerFunc:   options.ErrorHandlerFunc,
 	}
 
+	r.Group(func(r chi.Router) {
+		r.Post(options.BaseURL+"/codeowners/delete", wrapper.PostCodeownersDelete)
+	})
+	r.Group(func(r chi.Router) {
+		r.Get(options.BaseURL+"/codeowners/get", wrapper.GetCodeownersGet)
+	})
+	r.Group(func(r chi.Router) {
+		r.Post(options.BaseURL+"/codeowners/upload", wrapper.PostCodeownersUpload)
+	})
+	r.Group(func(r chi.Router) {
+		r.Get(options.BaseURL+"/export/assignments", wrapper.GetExportAssignments)
+	})
+	r.Group(func(r chi.Router) {
+		r.Post(options.BaseURL+"/pools/delete", wrapper.PostPoolsDelete)
+	})
+	r.Group(func(r chi.Router) {
+		r.Get(options.BaseURL+"/pools/get", wrapper.GetPoolsGet)
+	})
+	r.Group(func(r chi.Router) {
+		r.Get(options.BaseURL+"/pools/list", wrapper.GetPoolsList)
+	})
+	r.Group(func(r chi.Router) {
+		r.Post(options.BaseURL+"/pools/set", wrapper.PostPoolsSet)
+	})
 	r.Group(func(r chi.Router) {
 		r.Post(options.BaseURL+"/pullRequest/assignReviewers", wrapper.PostPullRequestAssignReviewers)
 	})
+	r.Group(func(r chi.Router) {
+		r.Post(options.BaseURL+"/pullRequest/close", wrapper.PostPullRequestClose)
+	})
 	r.Group(func(r chi.Router) {
 		r.Post(options.BaseURL+"/pullRequest/create", wrapper.PostPullRequestCreate)
 	})
+	r.Group(func(r chi.Router) {
+		r.Get(options.BaseURL+"/pullRequest/history", wrapper.GetPullRequestHistory)
+	})
+	r.Group(func(r chi.Router) {
+		r.Get(options.BaseURL+"/pullRequest/list", wrapper.GetPullRequestList)
+	})
+	r.Group(func(r chi.Router) {
+		r.Post(options.BaseURL+"/pullRequest/markReady", wrapper.PostPullRequestMarkReady)
+	})
 	r.Group(func(r chi.Router) {
 		r.Post(options.BaseURL+"/pullRequest/merge", wrapper.PostPullRequestMerge)
 	})
 	r.Group(func(r chi.Router) {
 		r.Post(options.BaseURL+"/pullRequest/reassign", wrapper.PostPullRequestReassign)
 	})
+	r.Group(func(r chi.Router) {
+		r.Post(options.BaseURL+"/pullRequest/reopen", wrapper.PostPullRequestReopen)
+	})
+	r.Group(func(r chi.Router) {
+		r.Post(options.BaseURL+"/pullRequest/review", wrapper.PostPullRequestReview)
+	})
+	r.Group(func(r chi.Router) {
+		r.Get(options.BaseURL+"/reviews/stale", wrapper.GetReviewsStale)
+	})
 	r.Group(func(r chi.Router) {
 		r.Get(options.BaseURL+"/statistics", wrapper.GetStatistics)
 	})
+	r.Group(func(r chi.Router) {
+		r.Get(options.BaseURL+"/statistics/fairness", wrapper.GetStatisticsFairness)
+	})
+	r.Group(func(r chi.Router) {
+		r.Post(options.BaseURL+"/subscriptions/add", wrapper.PostSubscriptionsAdd)
+	})
+	r.Group(func(r chi.Router) {
+		r.Post(options.BaseURL+"/subscriptions/delete", wrapper.PostSubscriptionsDelete)
+	})
+	r.Group(func(r chi.Router) {
+		r.Get(options.BaseURL+"/subscriptions/deliveries", wrapper.GetSubscriptionsDeliveries)
+	})
+	r.Group(func(r chi.Router) {
+		r.Get(options.BaseURL+"/subscriptions/list", wrapper.GetSubscriptionsList)
+	})
 	r.Group(func(r chi.Router) {
 		r.Post(options.BaseURL+"/team/add", wrapper.PostTeamAdd)
 	})
@@ -637,9 +2185,27 @@ func HandlerWithOptions(si ServerInterface, options ChiServerOptions) http.Handl
 	r.Group(func(r chi.Router) {
 		r.Get(options.BaseURL+"/users/getReview", wrapper.GetUsersGetReview)
 	})
+	r.Group(func(r chi.Router) {
+		r.Post(options.BaseURL+"/users/linkIdentity", wrapper.PostUsersLinkIdentity)
+	})
 	r.Group(func(r chi.Router) {
 		r.Post(options.BaseURL+"/users/setIsActive", wrapper.PostUsersSetIsActive)
 	})
+	r.Group(func(r chi.Router) {
+		r.Post(options.BaseURL+"/users/setWorkingHours", wrapper.PostUsersSetWorkingHours)
+	})
+	r.Group(func(r chi.Router) {
+		r.Post(options.BaseURL+"/users/unavailability/add", wrapper.PostUsersUnavailabilityAdd)
+	})
+	r.Group(func(r chi.Router) {
+		r.Post(options.BaseURL+"/users/unavailability/delete", wrapper.PostUsersUnavailabilityDelete)
+	})
+	r.Group(func(r chi.Router) {
+		r.Get(options.BaseURL+"/users/unavailability/list", wrapper.GetUsersUnavailabilityList)
+	})
+	r.Group(func(r chi.Router) {
+		r.Post(options.BaseURL+"/users/unavailability/update", wrapper.PostUsersUnavailabilityUpdate)
+	})
 
 	return r
 }
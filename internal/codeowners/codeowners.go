@@ -0,0 +1,273 @@
+// Package codeowners разбирает правила в синтаксисе CODEOWNERS и определяет владельцев измененных файлов
+//
+// Каждая непустая строка правил - шаблон пути и владельцы через пробел:
+//
+//	# комментарий
+//	*.go        @u1
+//	/docs/      @acme/docs
+//	api/**/*.sql @u2 @acme/backend
+//
+// Владелец @user_id - пользователь, @org/team_name - команда (часть до / не используется,
+// поэтому файлы CODEOWNERS из GitHub подходят без изменений). Для файла действует последнее
+// подходящее правило; правило без владельцев снимает владельцев, заданных выше.
+package codeowners
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+// Owner владелец файлов: пользователь или команда
+type Owner struct {
+	// Raw владелец в том виде, как он записан в правилах (@u1, @acme/backend)
+	Raw      string
+	UserID   string
+	TeamName string
+}
+
+// Rule правило CODEOWNERS
+type Rule struct {
+	// Line номер строки правила (с 1)
+	Line    int
+	Pattern string
+	Owners  []Owner
+
+	re *regexp.Regexp
+}
+
+// Match проверяет, что путь подходит под шаблон правила
+func (r *Rule) Match(path string) bool {
+	return r.re.MatchString(normalizePath(path))
+}
+
+// Ruleset разобранные правила в порядке следования в файле
+type Ruleset struct {
+	Rules []Rule
+}
+
+// Match возвращает последнее правило, под которое подходит путь, или nil
+func (s *Ruleset) Match(path string) *Rule {
+	path = normalizePath(path)
+	for i := len(s.Rules) - 1; i >= 0; i-- {
+		if s.Rules[i].re.MatchString(path) {
+			return &s.Rules[i]
+		}
+	}
+	return nil
+}
+
+// Owners возвращает владельцев всех путей без повторов в порядке первого упоминания
+func (s *Ruleset) Owners(paths []string) []Owner {
+	var owners []Owner
+	seen := make(map[string]bool)
+	for _, path := range paths {
+		rule := s.Match(path)
+		if rule == nil {
+			continue
+		}
+		for _, owner := range rule.Owners {
+			key := owner.UserID + "/" + owner.TeamName
+			if !seen[key] {
+				seen[key] = true
+				owners = append(owners, owner)
+			}
+		}
+	}
+	return owners
+}
+
+// LineError ошибка в строке правил
+type LineError struct {
+	Line    int
+	Message string
+}
+
+// ParseError содержит все ошибки, найденные при разборе правил
+type ParseError struct {
+	Errors []LineError
+}
+
+func (e *ParseError) Error() string {
+	messages := make([]string, len(e.Errors))
+	for i, lineErr := range e.Errors {
+		messages[i] = fmt.Sprintf("line %d: %s", lineErr.Line, lineErr.Message)
+	}
+	return strings.Join(messages, "; ")
+}
+
+// Parse разбирает правила CODEOWNERS; при ошибках возвращает *ParseError со всеми ошибочными строками
+func Parse(content string) (*Ruleset, error) {
+	ruleset := &Ruleset{}
+	var errs []LineError
+
+	for i, line := range strings.Split(content, "\n") {
+		lineNo := i + 1
+		fields, err := splitFields(strings.TrimSuffix(line, "\r"))
+		if err != nil {
+			errs = append(errs, LineError{Line: lineNo, Message: err.Error()})
+			continue
+		}
+		if len(fields) == 0 {
+			continue
+		}
+
+		rule, err := parseRule(fields)
+		if err != nil {
+			errs = append(errs, LineError{Line: lineNo, Message: err.Error()})
+			continue
+		}
+		rule.Line = lineNo
+		ruleset.Rules = append(ruleset.Rules, *rule)
+	}
+
+	if len(errs) > 0 {
+		return nil, &ParseError{Errors: errs}
+	}
+	return ruleset, nil
+}
+
+// splitFields делит строку на шаблон и владельцев по пробелам с учетом экранирования \
+// Комментарий начинается с # в начале поля и продолжается до конца строки
+func splitFields(line string) ([]string, error) {
+	var fields []string
+	var field strings.Builder
+	inField := false
+
+	for i := 0; i < len(line); i++ {
+		c := line[i]
+		switch {
+		case c == '\\':
+			if i+1 == len(line) {
+				return nil, fmt.Errorf("trailing backslash")
+			}
+			field.WriteByte(c)
+			field.WriteByte(line[i+1])
+			inField = true
+			i++
+		case c == ' ' || c == '\t':
+			if inField {
+				fields = append(fields, field.String())
+				field.Reset()
+				inField = false
+			}
+		case c == '#' && !inField:
+			return fields, nil
+		default:
+			field.WriteByte(c)
+			inField = true
+		}
+	}
+	if inField {
+		fields = append(fields, field.String())
+	}
+	return fields, nil
+}
+
+// parseRule разбирает шаблон и владельцев правила
+func parseRule(fields []string) (*Rule, error) {
+	pattern := fields[0]
+	re, err := compilePattern(pattern)
+	if err != nil {
+		return nil, err
+	}
+
+	rule := &Rule{Pattern: pattern, re: re}
+	for _, raw := range fields[1:] {
+		owner, err := parseOwner(raw)
+		if err != nil {
+			return nil, err
+		}
+		rule.Owners = append(rule.Owners, owner)
+	}
+	return rule, nil
+}
+
+// parseOwner разбирает владельца: @user_id или @org/team_name
+func parseOwner(raw string) (Owner, error) {
+	if !strings.HasPrefix(raw, "@") {
+		if strings.Contains(raw, "@") {
+			return Owner{}, fmt.Errorf("owner %q: email owners are not supported, use @user_id", raw)
+		}
+		return Owner{}, fmt.Errorf("owner %q must start with @", raw)
+	}
+
+	name := raw[1:]
+	parts := strings.Split(name, "/")
+	switch {
+	case len(parts) == 1 && name != "":
+		return Owner{Raw: raw, UserID: name}, nil
+	case len(parts) == 2 && parts[0] != "" && parts[1] != "":
+		return Owner{Raw: raw, TeamName: parts[1]}, nil
+	}
+	return Owner{}, fmt.Errorf("owner %q must be @user_id or @org/team_name", raw)
+}
+
+// compilePattern преобразует шаблон CODEOWNERS в регулярное выражение
+//   - шаблон с / в начале или в середине отсчитывается от корня репозитория, остальные - от любого каталога
+//   - шаблон с / в конце подходит только для содержимого каталога
+//   - * - любые символы, кроме /; ** - любые символы; ? - один символ, кроме /
+//   - шаблон подходит и для всего, что лежит внутри подходящего каталога, кроме шаблонов,
+//     оканчивающихся на /* - они подходят только для файлов непосредственно в каталоге
+func compilePattern(pattern string) (*regexp.Regexp, error) {
+	switch {
+	case strings.HasPrefix(pattern, "!"):
+		return nil, fmt.Errorf("pattern %q: negation is not supported", pattern)
+	case strings.ContainsAny(strings.ReplaceAll(pattern, `\[`, ""), "["):
+		return nil, fmt.Errorf("pattern %q: character ranges are not supported", pattern)
+	}
+
+	body := strings.TrimPrefix(pattern, "/")
+	anchored := body != pattern
+	dirOnly := strings.HasSuffix(body, "/")
+	body = strings.TrimSuffix(body, "/")
+	if body == "" {
+		return nil, fmt.Errorf("pattern %q matches nothing", pattern)
+	}
+	if strings.Contains(body, "/") {
+		anchored = true
+	}
+
+	var re strings.Builder
+	re.WriteString("^")
+	if !anchored {
+		re.WriteString("(?:.*/)?")
+	}
+	chars := []rune(body)
+	for i := 0; i < len(chars); i++ {
+		c := chars[i]
+		switch {
+		case c == '\\' && i+1 < len(chars):
+			re.WriteString(regexp.QuoteMeta(string(chars[i+1])))
+			i++
+		case strings.HasPrefix(string(chars[i:]), "**/"):
+			re.WriteString("(?:.*/)?")
+			i += 2
+		case strings.HasPrefix(string(chars[i:]), "**"):
+			re.WriteString(".*")
+			i++
+		case c == '*':
+			re.WriteString("[^/]*")
+		case c == '?':
+			re.WriteString("[^/]")
+		default:
+			re.WriteString(regexp.QuoteMeta(string(c)))
+		}
+	}
+	switch {
+	case dirOnly:
+		re.WriteString("/.*$")
+	case body == "*" || strings.HasSuffix(body, "/*"):
+		re.WriteString("$")
+	default:
+		re.WriteString("(?:/.*)?$")
+	}
+
+	return regexp.MustCompile(re.String()), nil
+}
+
+// normalizePath приводит путь измененного файла к виду относительно корня репозитория
+func normalizePath(path string) string {
+	path = strings.TrimPrefix(path, "./")
+	return strings.TrimPrefix(path, "/")
+}
@@ -0,0 +1,101 @@
+package codeowners
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRule_Match(t *testing.T) {
+	tests := []struct {
+		pattern string
+		path    string
+		match   bool
+	}{
+		// Шаблон без / подходит в любом каталоге
+		{"*.go", "main.go", true},
+		{"*.go", "internal/service/pr_service.go", true},
+		{"*.go", "main.gox", false},
+		{"Makefile", "tools/Makefile", true},
+		// / в начале или в середине - от корня репозитория
+		{"/build.sh", "build.sh", true},
+		{"/build.sh", "scripts/build.sh", false},
+		{"internal/api", "internal/api/api.gen.go", true},
+		{"internal/api", "pkg/internal/api/client.go", false},
+		// / в конце - содержимое каталога на любой глубине
+		{"apps/", "apps/web/index.js", true},
+		{"apps/", "src/apps/cli/main.go", true},
+		{"apps/", "apps", false},
+		{"/docs/", "docs/guide/intro.md", true},
+		{"/docs/", "src/docs/readme.md", false},
+		// /* - только файлы непосредственно в каталоге
+		{"docs/*", "docs/intro.md", true},
+		{"docs/*", "docs/guide/intro.md", false},
+		// ** - любое количество каталогов
+		{"**/logs", "build/logs/app.log", true},
+		{"**/logs", "logs/app.log", true},
+		{"api/**/*.sql", "api/migrations/v1/001.sql", true},
+		{"api/**/*.sql", "api/001.sql", true},
+		{"api/**/*.sql", "db/api/001.sql", false},
+		{"?.txt", "a.txt", true},
+		{"?.txt", "ab.txt", false},
+		{`path\ with\ spaces/`, "path with spaces/file.txt", true},
+		{"документация/", "документация/обзор.md", true},
+		{"*", "any/file", true},
+		// Путь может начинаться с / или ./
+		{"/cmd/", "./cmd/server/main.go", true},
+		{"/cmd/", "/cmd/server/main.go", true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.pattern+" "+tt.path, func(t *testing.T) {
+			ruleset, err := Parse(tt.pattern + " @u1")
+			require.NoError(t, err)
+			require.Len(t, ruleset.Rules, 1)
+			assert.Equal(t, tt.match, ruleset.Rules[0].Match(tt.path))
+		})
+	}
+}
+
+func TestRuleset_OwnersLastMatchWins(t *testing.T) {
+	ruleset, err := Parse(`
+# Владельцы по умолчанию
+*                 @u1
+*.sql             @acme/backend @u2   # миграции
+/docs/            @acme/docs
+/docs/generated/
+`)
+	require.NoError(t, err)
+	require.Len(t, ruleset.Rules, 4)
+	assert.Equal(t, 4, ruleset.Rules[1].Line)
+
+	assert.Equal(t, []Owner{{Raw: "@u1", UserID: "u1"}}, ruleset.Owners([]string{"main.go"}))
+	assert.Equal(t, []Owner{
+		{Raw: "@acme/backend", TeamName: "backend"},
+		{Raw: "@u2", UserID: "u2"},
+		{Raw: "@acme/docs", TeamName: "docs"},
+	}, ruleset.Owners([]string{"db/001.sql", "docs/intro.md", "db/002.sql"}))
+	// Правило без владельцев снимает владельцев, заданных выше
+	assert.Empty(t, ruleset.Owners([]string{"docs/generated/api.md"}))
+}
+
+func TestParse_Errors(t *testing.T) {
+	_, err := Parse(`*.go @u1
+!vendor/ @u2
+[ab].txt @u3
+*.md docs@example.com
+*.sh u4
+*.py @acme/
+/ @u5
+*.rb @u6 \`)
+
+	var parseErr *ParseError
+	require.True(t, errors.As(err, &parseErr))
+	lines := make([]int, len(parseErr.Errors))
+	for i, lineErr := range parseErr.Errors {
+		lines[i] = lineErr.Line
+	}
+	assert.Equal(t, []int{2, 3, 4, 5, 6, 7, 8}, lines)
+	assert.Contains(t, err.Error(), "line 4: owner \"docs@example.com\": email owners are not supported")
+}
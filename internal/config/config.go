@@ -4,31 +4,162 @@ import (
 	"fmt"
 	"os"
 	"strconv"
+	"strings"
+	"time"
 )
 
 // Config содержит конфигурацию приложения
 type Config struct {
+	// StorageDriver хранилище данных: postgres, sqlite (файл SQLitePath)
+	// или memory (данные в памяти процесса, теряются при перезапуске)
+	StorageDriver string
+	// SQLitePath путь к файлу базы SQLite
+	SQLitePath string
+	// MigrateOnStart применять встроенные миграции при запуске сервера
+	MigrateOnStart bool
+
 	DBHost     string
 	DBPort     int
 	DBUser     string
 	DBPassword string
 	DBName     string
 	ServerPort int
+
+	// RequestTimeout ограничение времени обработки одного HTTP запроса, включая запросы к БД
+	RequestTimeout time.Duration
+	// ExportTimeout ограничение времени потоковой выгрузки (/export/assignments) вместо RequestTimeout
+	ExportTimeout time.Duration
+	// ShutdownTimeout время на завершение текущих запросов при остановке сервера
+	ShutdownTimeout time.Duration
+
+	// ReviewerStrategy стратегия выбора ревьюверов по умолчанию
+	ReviewerStrategy string
+	// TeamReviewerStrategies стратегии выбора ревьюверов по командам: team_name -> стратегия
+	TeamReviewerStrategies map[string]string
+	// ReviewerWeights веса пользователей для стратегии weighted: user_id -> вес
+	ReviewerWeights map[string]int
+	// WorkingHoursBaseStrategy стратегия выбора внутри групп стратегии working_hours
+	WorkingHoursBaseStrategy string
+
+	// GitHubWebhookSecret секрет вебхука GitHub; если не задан, прием вебхуков GitHub отключен
+	GitHubWebhookSecret string
+	// GitLabWebhookToken секретный токен вебхука GitLab; если не задан, прием вебхуков GitLab отключен
+	GitLabWebhookToken string
+
+	// EventWebhookMaxAttempts максимальное количество попыток доставки события подписчику
+	EventWebhookMaxAttempts int
+	// EventWebhookTimeout таймаут одного запроса к подписчику
+	EventWebhookTimeout time.Duration
+
+	// EventSinks получатели событий из outbox: webhook, log, file
+	EventSinks []string
+	// EventFilePath файл для получателя file (NDJSON)
+	EventFilePath string
+	// OutboxPollInterval пауза между проверками outbox
+	OutboxPollInterval time.Duration
+	// OutboxBatchSize максимальное количество событий, обрабатываемых за одну проверку
+	OutboxBatchSize int
+
+	// SLACheckInterval пауза между фоновыми проверками SLA ревью
+	SLACheckInterval time.Duration
+	// AutoReassignInterval пауза между фоновыми проверками автоматического переназначения ревьюверов
+	AutoReassignInterval time.Duration
+	// HandoffCheckInterval пауза между фоновыми проверками начавшихся периодов отсутствия с передачей ревью
+	HandoffCheckInterval time.Duration
 }
 
 // Load загружает конфигурацию из переменных окружения
 func Load() (*Config, error) {
 	cfg := &Config{
+		StorageDriver:  getEnv("STORAGE_DRIVER", "postgres"),
+		SQLitePath:     getEnv("SQLITE_PATH", "pr-review-assigner.db"),
+		MigrateOnStart: getEnvAsBool("MIGRATE_ON_START", false),
+
 		DBHost:     getEnv("DB_HOST", "localhost"),
 		DBPort:     getEnvAsInt("DB_PORT", 5432),
 		DBUser:     getEnv("DB_USER", "postgres"),
 		DBPassword: getEnv("DB_PASSWORD", "postgres"),
 		DBName:     getEnv("DB_NAME", "pr_review_assigner"),
 		ServerPort: getEnvAsInt("SERVER_PORT", 8080),
+
+		RequestTimeout:  getEnvAsDuration("REQUEST_TIMEOUT", 5*time.Second),
+		ExportTimeout:   getEnvAsDuration("EXPORT_TIMEOUT", 5*time.Minute),
+		ShutdownTimeout: getEnvAsDuration("SHUTDOWN_TIMEOUT", 10*time.Second),
+
+		ReviewerStrategy:         getEnv("REVIEWER_STRATEGY", "random"),
+		TeamReviewerStrategies:   getEnvAsMap("TEAM_REVIEWER_STRATEGIES"),
+		WorkingHoursBaseStrategy: getEnv("WORKING_HOURS_BASE_STRATEGY", "random"),
+
+		GitHubWebhookSecret: os.Getenv("GITHUB_WEBHOOK_SECRET"),
+		GitLabWebhookToken:  os.Getenv("GITLAB_WEBHOOK_TOKEN"),
+
+		EventWebhookMaxAttempts: getEnvAsInt("EVENT_WEBHOOK_MAX_ATTEMPTS", 5),
+		EventWebhookTimeout:     getEnvAsDuration("EVENT_WEBHOOK_TIMEOUT", 10*time.Second),
+
+		EventSinks:         getEnvAsList("EVENT_SINKS", []string{"webhook"}),
+		EventFilePath:      getEnv("EVENT_FILE_PATH", "events.ndjson"),
+		OutboxPollInterval: getEnvAsDuration("OUTBOX_POLL_INTERVAL", time.Second),
+		OutboxBatchSize:    getEnvAsInt("OUTBOX_BATCH_SIZE", 100),
+
+		SLACheckInterval:     getEnvAsDuration("SLA_CHECK_INTERVAL", time.Minute),
+		AutoReassignInterval: getEnvAsDuration("AUTO_REASSIGN_INTERVAL", 5*time.Minute),
+		HandoffCheckInterval: getEnvAsDuration("HANDOFF_CHECK_INTERVAL", time.Minute),
 	}
 
-	if cfg.DBPassword == "" {
-		return nil, fmt.Errorf("DB_PASSWORD is required")
+	switch cfg.StorageDriver {
+	case "postgres":
+		if cfg.DBPassword == "" {
+			return nil, fmt.Errorf("DB_PASSWORD is required")
+		}
+	case "sqlite":
+		if cfg.SQLitePath == "" {
+			return nil, fmt.Errorf("SQLITE_PATH is required")
+		}
+	case "memory":
+	default:
+		return nil, fmt.Errorf("STORAGE_DRIVER: unknown driver %q", cfg.StorageDriver)
+	}
+
+	// Таймаут запроса меньше таймаута остановки: к концу Shutdown ни один запрос не удерживает соединение с БД
+	if cfg.RequestTimeout <= 0 || cfg.RequestTimeout >= cfg.ShutdownTimeout {
+		return nil, fmt.Errorf("REQUEST_TIMEOUT must be positive and less than SHUTDOWN_TIMEOUT")
+	}
+
+	if cfg.ExportTimeout < cfg.RequestTimeout {
+		return nil, fmt.Errorf("EXPORT_TIMEOUT must not be less than REQUEST_TIMEOUT")
+	}
+
+	if cfg.EventWebhookMaxAttempts < 1 {
+		return nil, fmt.Errorf("EVENT_WEBHOOK_MAX_ATTEMPTS must be at least 1")
+	}
+	if cfg.OutboxBatchSize < 1 {
+		return nil, fmt.Errorf("OUTBOX_BATCH_SIZE must be at least 1")
+	}
+	if cfg.SLACheckInterval <= 0 {
+		return nil, fmt.Errorf("SLA_CHECK_INTERVAL must be positive")
+	}
+	if cfg.AutoReassignInterval <= 0 {
+		return nil, fmt.Errorf("AUTO_REASSIGN_INTERVAL must be positive")
+	}
+	if cfg.HandoffCheckInterval <= 0 {
+		return nil, fmt.Errorf("HANDOFF_CHECK_INTERVAL must be positive")
+	}
+	for _, sink := range cfg.EventSinks {
+		switch sink {
+		case "webhook", "log", "file":
+		default:
+			return nil, fmt.Errorf("EVENT_SINKS: unknown sink %q", sink)
+		}
+	}
+
+	weights := getEnvAsMap("REVIEWER_WEIGHTS")
+	cfg.ReviewerWeights = make(map[string]int, len(weights))
+	for userID, weightStr := range weights {
+		weight, err := strconv.Atoi(weightStr)
+		if err != nil || weight < 0 {
+			return nil, fmt.Errorf("REVIEWER_WEIGHTS: invalid weight %q for user %s", weightStr, userID)
+		}
+		cfg.ReviewerWeights[userID] = weight
 	}
 
 	return cfg, nil
@@ -59,3 +190,56 @@ func getEnvAsInt(key string, defaultValue int) int {
 	return value
 }
 
+// getEnvAsBool разбирает переменную окружения в формате strconv.ParseBool (например, "true", "1")
+func getEnvAsBool(key string, defaultValue bool) bool {
+	valueStr := os.Getenv(key)
+	if valueStr == "" {
+		return defaultValue
+	}
+	value, err := strconv.ParseBool(valueStr)
+	if err != nil {
+		return defaultValue
+	}
+	return value
+}
+
+// getEnvAsDuration разбирает переменную окружения в формате time.ParseDuration (например, "500ms", "2s")
+func getEnvAsDuration(key string, defaultValue time.Duration) time.Duration {
+	valueStr := os.Getenv(key)
+	if valueStr == "" {
+		return defaultValue
+	}
+	value, err := time.ParseDuration(valueStr)
+	if err != nil {
+		return defaultValue
+	}
+	return value
+}
+
+// getEnvAsList разбирает переменную окружения вида "value1,value2"
+func getEnvAsList(key string, defaultValue []string) []string {
+	valueStr := os.Getenv(key)
+	if valueStr == "" {
+		return defaultValue
+	}
+	var result []string
+	for _, value := range strings.Split(valueStr, ",") {
+		if value = strings.TrimSpace(value); value != "" {
+			result = append(result, value)
+		}
+	}
+	return result
+}
+
+// getEnvAsMap разбирает переменную окружения вида "key1:value1,key2:value2"
+func getEnvAsMap(key string) map[string]string {
+	result := make(map[string]string)
+	for _, pair := range strings.Split(os.Getenv(key), ",") {
+		k, v, ok := strings.Cut(strings.TrimSpace(pair), ":")
+		if !ok || k == "" {
+			continue
+		}
+		result[strings.TrimSpace(k)] = strings.TrimSpace(v)
+	}
+	return result
+}
@@ -0,0 +1,101 @@
+package events
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Type тип события об изменении ревьюверов PR или о ходе ревью
+type Type string
+
+const (
+	ReviewerAssigned   Type = "reviewer.assigned"
+	ReviewerReassigned Type = "reviewer.reassigned"
+	ReviewerRemoved    Type = "reviewer.removed"
+	PRMerged           Type = "pr.merged"
+	ReviewSLABreached  Type = "review.sla_breached"
+)
+
+// Types список всех типов событий
+var Types = []Type{ReviewerAssigned, ReviewerReassigned, ReviewerRemoved, PRMerged, ReviewSLABreached}
+
+// IsKnownType проверяет, что тип события поддерживается
+func IsKnownType(t Type) bool {
+	for _, known := range Types {
+		if known == t {
+			return true
+		}
+	}
+	return false
+}
+
+// Event событие, отправляемое подписчикам
+type Event struct {
+	ID         string    `json:"id"`
+	Type       Type      `json:"type"`
+	OccurredAt time.Time `json:"occurred_at"`
+	Data       Data      `json:"data"`
+}
+
+// Data данные события; заполняются только поля, относящиеся к типу события
+type Data struct {
+	PullRequestID string `json:"pull_request_id"`
+	// ReviewerID ревьювер для reviewer.assigned, reviewer.removed и review.sla_breached
+	ReviewerID string `json:"reviewer_id,omitempty"`
+	// OldReviewerID и NewReviewerID для reviewer.reassigned
+	OldReviewerID string `json:"old_reviewer_id,omitempty"`
+	NewReviewerID string `json:"new_reviewer_id,omitempty"`
+	// AssignedAt и DueAt время назначения и срок первого вердикта для review.sla_breached
+	AssignedAt *time.Time `json:"assigned_at,omitempty"`
+	DueAt      *time.Time `json:"due_at,omitempty"`
+}
+
+// New создает событие с уникальным ID и текущим временем
+func New(t Type, data Data) Event {
+	return Event{
+		ID:         uuid.NewString(),
+		Type:       t,
+		OccurredAt: time.Now().UTC(),
+		Data:       data,
+	}
+}
+
+// NewReviewerAssigned создает событие назначения ревьювера
+func NewReviewerAssigned(prID, reviewerID string) Event {
+	return New(ReviewerAssigned, Data{PullRequestID: prID, ReviewerID: reviewerID})
+}
+
+// NewReviewerChanged создает событие замены ревьювера или, если замены нет, его удаления
+func NewReviewerChanged(prID, oldReviewerID, newReviewerID string) Event {
+	if newReviewerID == "" {
+		return New(ReviewerRemoved, Data{PullRequestID: prID, ReviewerID: oldReviewerID})
+	}
+	return New(ReviewerReassigned, Data{PullRequestID: prID, OldReviewerID: oldReviewerID, NewReviewerID: newReviewerID})
+}
+
+// NewReviewerChanges создает события по фактическому результату замены ревьювера:
+// removed - старый ревьювер был в PR и снят, added - новый ревьювер действительно добавлен
+// Если ничего не изменилось, событий нет
+func NewReviewerChanges(prID, oldReviewerID, newReviewerID string, removed, added bool) []Event {
+	switch {
+	case removed && added:
+		return []Event{NewReviewerChanged(prID, oldReviewerID, newReviewerID)}
+	case removed:
+		return []Event{NewReviewerChanged(prID, oldReviewerID, "")}
+	case added:
+		return []Event{NewReviewerAssigned(prID, newReviewerID)}
+	}
+	return nil
+}
+
+// NewPRMerged создает событие слияния PR
+func NewPRMerged(prID string) Event {
+	return New(PRMerged, Data{PullRequestID: prID})
+}
+
+// NewReviewSLABreached создает событие нарушения SLA: ревьювер не дал вердикт до срока dueAt
+func NewReviewSLABreached(prID, reviewerID string, assignedAt, dueAt time.Time) Event {
+	assignedAt, dueAt = assignedAt.UTC(), dueAt.UTC()
+	return New(ReviewSLABreached, Data{PullRequestID: prID, ReviewerID: reviewerID, AssignedAt: &assignedAt, DueAt: &dueAt})
+}
@@ -0,0 +1,162 @@
+package events
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"slices"
+	"time"
+)
+
+// OutboxRecord событие, сохраненное в outbox и ожидающее доставки
+type OutboxRecord struct {
+	ID    int64
+	Event Event
+	// Attempts количество неудачных попыток доставки
+	Attempts int
+	// DeliveredSinks получатели, принявшие событие при прошлых попытках
+	DeliveredSinks []string
+}
+
+// OutboxStore хранилище outbox
+// Записи добавляются репозиториями в той же транзакции, что и изменение ревьюверов
+type OutboxStore interface {
+	// ClaimPending резервирует до limit недоставленных записей на время lease,
+	// чтобы их не забрал другой экземпляр сервиса
+	ClaimPending(ctx context.Context, limit int, lease time.Duration) ([]OutboxRecord, error)
+	MarkDelivered(ctx context.Context, id int64) error
+	// MarkFailed сохраняет ошибку и получателей, принявших событие в этой попытке,
+	// и откладывает следующую попытку на retryAfter
+	MarkFailed(ctx context.Context, id int64, deliveredSinks []string, deliveryErr error, retryAfter time.Duration) error
+}
+
+// DispatcherConfig параметры разбора outbox
+type DispatcherConfig struct {
+	// PollInterval пауза между проверками outbox
+	PollInterval time.Duration
+	// BatchSize максимальное количество записей, обрабатываемых за одну проверку
+	BatchSize int
+	// Lease время, на которое запись резервируется за экземпляром сервиса
+	Lease time.Duration
+	// RetryBackoff пауза перед повторной доставкой записи (удваивается с каждой попыткой)
+	RetryBackoff time.Duration
+	// MaxRetryBackoff верхняя граница паузы перед повторной доставкой
+	MaxRetryBackoff time.Duration
+}
+
+// DefaultDispatcherConfig параметры разбора outbox по умолчанию
+var DefaultDispatcherConfig = DispatcherConfig{
+	PollInterval:    time.Second,
+	BatchSize:       100,
+	Lease:           time.Minute,
+	RetryBackoff:    5 * time.Second,
+	MaxRetryBackoff: 10 * time.Minute,
+}
+
+// Dispatcher в фоне доставляет события из outbox во все получатели
+// Запись помечается доставленной, только когда ее приняли все получатели;
+// при повторе событие отправляется только получателям, которые его еще не приняли
+type Dispatcher struct {
+	store OutboxStore
+	sinks []Sink
+	cfg   DispatcherConfig
+}
+
+// NewDispatcher создает новый экземпляр разбора outbox
+func NewDispatcher(store OutboxStore, sinks []Sink, cfg DispatcherConfig) *Dispatcher {
+	return &Dispatcher{
+		store: store,
+		sinks: sinks,
+		cfg:   cfg,
+	}
+}
+
+// Run разбирает outbox каждые PollInterval до отмены ctx
+func (d *Dispatcher) Run(ctx context.Context) {
+	ticker := time.NewTicker(d.cfg.PollInterval)
+	defer ticker.Stop()
+
+	for {
+		// Пока записи есть, разбираем их без паузы
+		for {
+			processed, err := d.DispatchPending(ctx)
+			if err != nil {
+				log.Printf("Warning: failed to dispatch outbox events: %v", err)
+				break
+			}
+			if processed < d.cfg.BatchSize || ctx.Err() != nil {
+				break
+			}
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// DispatchPending выполняет одну проверку outbox и возвращает количество обработанных записей
+// Доставка ограничена сроком резервирования: после его истечения записи может забрать
+// другой экземпляр, поэтому оставшиеся записи не отправляются и вернутся в outbox сами
+func (d *Dispatcher) DispatchPending(ctx context.Context) (int, error) {
+	records, err := d.store.ClaimPending(ctx, d.cfg.BatchSize, d.cfg.Lease)
+	if err != nil {
+		return 0, err
+	}
+
+	leaseCtx, cancel := context.WithTimeout(ctx, d.cfg.Lease)
+	defer cancel()
+
+	processed := 0
+	for _, record := range records {
+		if leaseCtx.Err() != nil {
+			log.Printf("Warning: outbox lease expired, %d events left for the next claim", len(records)-processed)
+			break
+		}
+		processed++
+
+		delivered, err := d.deliver(leaseCtx, record)
+		if err != nil {
+			log.Printf("Warning: failed to deliver outbox event %s (attempt %d): %v", record.Event.ID, record.Attempts+1, err)
+			if markErr := d.store.MarkFailed(ctx, record.ID, delivered, err, d.retryBackoff(record.Attempts)); markErr != nil {
+				return 0, markErr
+			}
+			continue
+		}
+		if err := d.store.MarkDelivered(ctx, record.ID); err != nil {
+			return 0, err
+		}
+	}
+
+	return processed, nil
+}
+
+// deliver отправляет событие получателям, еще не принявшим его, и объединяет их ошибки
+// Возвращает имена получателей, принявших событие в этой попытке
+func (d *Dispatcher) deliver(ctx context.Context, record OutboxRecord) ([]string, error) {
+	var delivered []string
+	var errs []error
+	for _, sink := range d.sinks {
+		if slices.Contains(record.DeliveredSinks, sink.Name()) {
+			continue
+		}
+		if err := sink.Send(ctx, record.Event); err != nil {
+			errs = append(errs, fmt.Errorf("%s: %w", sink.Name(), err))
+			continue
+		}
+		delivered = append(delivered, sink.Name())
+	}
+	return delivered, errors.Join(errs...)
+}
+
+// retryBackoff возвращает паузу перед следующей попыткой после attempts неудачных
+func (d *Dispatcher) retryBackoff(attempts int) time.Duration {
+	backoff := d.cfg.RetryBackoff
+	for i := 0; i < attempts && backoff < d.cfg.MaxRetryBackoff; i++ {
+		backoff *= 2
+	}
+	return min(backoff, d.cfg.MaxRetryBackoff)
+}
@@ -0,0 +1,198 @@
+package events
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// memoryOutbox хранит записи outbox в памяти
+type memoryOutbox struct {
+	records   []OutboxRecord
+	delivered map[int64]bool
+	failed    map[int64]time.Duration
+}
+
+func newMemoryOutbox(events ...Event) *memoryOutbox {
+	outbox := &memoryOutbox{delivered: map[int64]bool{}, failed: map[int64]time.Duration{}}
+	for i, event := range events {
+		outbox.records = append(outbox.records, OutboxRecord{ID: int64(i + 1), Event: event})
+	}
+	return outbox
+}
+
+func (o *memoryOutbox) ClaimPending(_ context.Context, limit int, _ time.Duration) ([]OutboxRecord, error) {
+	var pending []OutboxRecord
+	for _, record := range o.records {
+		if _, retryLater := o.failed[record.ID]; retryLater || o.delivered[record.ID] {
+			continue
+		}
+		if len(pending) == limit {
+			break
+		}
+		pending = append(pending, record)
+	}
+	return pending, nil
+}
+
+func (o *memoryOutbox) MarkDelivered(_ context.Context, id int64) error {
+	o.delivered[id] = true
+	return nil
+}
+
+func (o *memoryOutbox) MarkFailed(_ context.Context, id int64, deliveredSinks []string, _ error, retryAfter time.Duration) error {
+	o.failed[id] = retryAfter
+	for i := range o.records {
+		if o.records[i].ID == id {
+			o.records[i].Attempts++
+			o.records[i].DeliveredSinks = append(o.records[i].DeliveredSinks, deliveredSinks...)
+		}
+	}
+	return nil
+}
+
+// retryNow снимает паузу перед повторной доставкой со всех записей
+func (o *memoryOutbox) retryNow() {
+	clear(o.failed)
+}
+
+// recordingSink запоминает полученные события и может отказывать в приеме
+type recordingSink struct {
+	name     string
+	received []Event
+	err      error
+}
+
+func (s *recordingSink) Name() string { return s.name }
+
+func (s *recordingSink) Send(_ context.Context, event Event) error {
+	if s.err != nil {
+		return s.err
+	}
+	s.received = append(s.received, event)
+	return nil
+}
+
+func TestDispatcher_DeliversToAllSinksAndMarksDelivered(t *testing.T) {
+	assigned := NewReviewerAssigned("pr-1", "u2")
+	merged := NewPRMerged("pr-1")
+	outbox := newMemoryOutbox(assigned, merged)
+	first := &recordingSink{name: "first"}
+	second := &recordingSink{name: "second"}
+
+	dispatcher := NewDispatcher(outbox, []Sink{first, second}, DefaultDispatcherConfig)
+	processed, err := dispatcher.DispatchPending(context.Background())
+
+	require.NoError(t, err)
+	assert.Equal(t, 2, processed)
+	assert.Equal(t, []Event{assigned, merged}, first.received)
+	assert.Equal(t, []Event{assigned, merged}, second.received)
+	assert.True(t, outbox.delivered[1])
+	assert.True(t, outbox.delivered[2])
+
+	// Доставленные записи повторно не отправляются
+	processed, err = dispatcher.DispatchPending(context.Background())
+	require.NoError(t, err)
+	assert.Zero(t, processed)
+}
+
+func TestDispatcher_SinkFailureKeepsRecordPending(t *testing.T) {
+	outbox := newMemoryOutbox(NewPRMerged("pr-1"))
+	outbox.records[0].Attempts = 2
+	healthy := &recordingSink{name: "healthy"}
+	broken := &recordingSink{name: "broken", err: errors.New("disk full")}
+
+	cfg := DefaultDispatcherConfig
+	cfg.RetryBackoff = time.Second
+	cfg.MaxRetryBackoff = time.Minute
+	dispatcher := NewDispatcher(outbox, []Sink{healthy, broken}, cfg)
+	_, err := dispatcher.DispatchPending(context.Background())
+
+	require.NoError(t, err)
+	assert.False(t, outbox.delivered[1])
+	// После двух неудачных попыток пауза удваивается дважды
+	assert.Equal(t, 4*time.Second, outbox.failed[1])
+}
+
+// blockingSink ждет отмены контекста, как запрос к недоступному подписчику
+type blockingSink struct {
+	calls int
+}
+
+func (s *blockingSink) Name() string { return "blocking" }
+
+func (s *blockingSink) Send(ctx context.Context, _ Event) error {
+	s.calls++
+	<-ctx.Done()
+	return ctx.Err()
+}
+
+func TestDispatcher_StopsAtLeaseExpiry(t *testing.T) {
+	outbox := newMemoryOutbox(NewPRMerged("pr-1"), NewPRMerged("pr-2"), NewPRMerged("pr-3"))
+	sink := &blockingSink{}
+
+	cfg := DefaultDispatcherConfig
+	cfg.Lease = 20 * time.Millisecond
+	dispatcher := NewDispatcher(outbox, []Sink{sink}, cfg)
+	processed, err := dispatcher.DispatchPending(context.Background())
+
+	require.NoError(t, err)
+	assert.Equal(t, 1, processed)
+	assert.Equal(t, 1, sink.calls)
+	// Прерванная доставка откладывается, остальные записи дождутся следующей проверки
+	assert.Contains(t, outbox.failed, int64(1))
+	assert.NotContains(t, outbox.failed, int64(2))
+	assert.False(t, outbox.delivered[2])
+}
+
+func TestDispatcher_RetriesOnlyFailedSinks(t *testing.T) {
+	var calls atomic.Int32
+	receiver := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if calls.Add(1) < 3 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer receiver.Close()
+
+	event := NewPRMerged("pr-1")
+	outbox := newMemoryOutbox(event)
+	path := filepath.Join(t.TempDir(), "events.ndjson")
+	webhook := newTestNotifier(&memoryStore{subscriptions: []Subscription{{ID: 1, URL: receiver.URL}}})
+	dispatcher := NewDispatcher(outbox, []Sink{NewFileSink(path), webhook}, DefaultDispatcherConfig)
+
+	// Вебхук отказывает дважды, затем принимает событие
+	for range 3 {
+		_, err := dispatcher.DispatchPending(context.Background())
+		require.NoError(t, err)
+		outbox.retryNow()
+	}
+	assert.Equal(t, int32(3), calls.Load())
+	assert.True(t, outbox.delivered[1])
+
+	// Файл получил событие один раз, несмотря на повторы вебхука
+	content, err := os.ReadFile(path)
+	require.NoError(t, err)
+	lines := strings.Split(strings.TrimSpace(string(content)), "\n")
+	require.Len(t, lines, 1)
+	assert.Contains(t, lines[0], event.ID)
+}
+
+func TestDispatcher_RetryBackoffCapped(t *testing.T) {
+	dispatcher := NewDispatcher(nil, nil, DispatcherConfig{RetryBackoff: time.Second, MaxRetryBackoff: 10 * time.Second})
+
+	assert.Equal(t, time.Second, dispatcher.retryBackoff(0))
+	assert.Equal(t, 8*time.Second, dispatcher.retryBackoff(3))
+	assert.Equal(t, 10*time.Second, dispatcher.retryBackoff(50))
+}
@@ -0,0 +1,85 @@
+package events
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log"
+	"os"
+	"sync"
+)
+
+// Sink получатель событий из outbox
+// Send вызывается повторно, пока не вернет nil; принявшему событие получателю оно
+// больше не отправляется, но при сбое до сохранения результата попытки доставка
+// повторяется, поэтому получатель должен быть готов к дублям (дедупликация по Event.ID)
+// Name должен быть уникальным: по нему запоминаются получатели, принявшие событие
+type Sink interface {
+	Name() string
+	Send(ctx context.Context, event Event) error
+}
+
+// LogSink пишет события в лог приложения
+type LogSink struct {
+	logger *log.Logger
+}
+
+// NewLogSink создает получателя, пишущего события в logger (nil - стандартный лог)
+func NewLogSink(logger *log.Logger) *LogSink {
+	if logger == nil {
+		logger = log.Default()
+	}
+	return &LogSink{logger: logger}
+}
+
+// Name возвращает имя получателя событий
+func (s *LogSink) Name() string {
+	return "log"
+}
+
+// Send пишет событие в лог одной строкой JSON
+func (s *LogSink) Send(_ context.Context, event Event) error {
+	body, err := json.Marshal(event)
+	if err != nil {
+		return fmt.Errorf("failed to encode event: %w", err)
+	}
+	s.logger.Printf("Event: %s", body)
+	return nil
+}
+
+// FileSink дописывает события в файл в формате NDJSON (одно событие на строку)
+type FileSink struct {
+	path string
+	mu   sync.Mutex
+}
+
+// NewFileSink создает получателя, дописывающего события в файл path
+func NewFileSink(path string) *FileSink {
+	return &FileSink{path: path}
+}
+
+// Name возвращает имя получателя событий
+func (s *FileSink) Name() string {
+	return "file"
+}
+
+// Send дописывает событие в конец файла
+func (s *FileSink) Send(_ context.Context, event Event) error {
+	body, err := json.Marshal(event)
+	if err != nil {
+		return fmt.Errorf("failed to encode event: %w", err)
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	file, err := os.OpenFile(s.path, os.O_CREATE|os.O_APPEND|os.O_WRONLY, 0o644)
+	if err != nil {
+		return err
+	}
+	if _, err = file.Write(append(body, '\n')); err != nil {
+		file.Close()
+		return err
+	}
+	return file.Close()
+}
@@ -0,0 +1,33 @@
+package events
+
+import (
+	"context"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFileSink_AppendsNDJSON(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "events.ndjson")
+	sink := NewFileSink(path)
+
+	first := NewReviewerAssigned("pr-1", "u2")
+	second := NewReviewerChanged("pr-1", "u2", "u3")
+	require.NoError(t, sink.Send(context.Background(), first))
+	require.NoError(t, sink.Send(context.Background(), second))
+
+	content, err := os.ReadFile(path)
+	require.NoError(t, err)
+	lines := strings.Split(strings.TrimSpace(string(content)), "\n")
+	require.Len(t, lines, 2)
+
+	var decoded Event
+	require.NoError(t, json.Unmarshal([]byte(lines[1]), &decoded))
+	assert.Equal(t, second.ID, decoded.ID)
+	assert.Equal(t, ReviewerReassigned, decoded.Type)
+}
@@ -0,0 +1,229 @@
+package events
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"net/http"
+	"sync"
+	"time"
+)
+
+// SignatureHeader заголовок с подписью тела запроса: "sha256=<hex HMAC-SHA256(secret, body)>"
+const SignatureHeader = "X-Signature-256"
+
+// Subscription подписка на события по webhook
+type Subscription struct {
+	ID     int64
+	URL    string
+	Secret string
+	// EventTypes типы событий подписки; пустой список - все события
+	EventTypes []Type
+	IsActive   bool
+	CreatedAt  time.Time
+}
+
+// Delivery запись журнала доставки: одна попытка отправки события подписчику
+type Delivery struct {
+	ID             int64
+	SubscriptionID int64
+	EventID        string
+	EventType      Type
+	Attempt        int
+	// StatusCode HTTP статус ответа подписчика (0, если ответ не получен)
+	StatusCode  int
+	Error       string
+	Success     bool
+	DeliveredAt time.Time
+}
+
+// SubscriptionStore источник подписок и журнал доставок
+type SubscriptionStore interface {
+	GetSubscriptionsForEvent(ctx context.Context, eventType Type) ([]Subscription, error)
+	LogDelivery(ctx context.Context, delivery *Delivery) error
+	// ListEventDeliveries получает все попытки доставки события в порядке записи
+	ListEventDeliveries(ctx context.Context, eventID string) ([]Delivery, error)
+}
+
+// WebhookConfig параметры доставки событий
+type WebhookConfig struct {
+	// MaxAttempts максимальное количество попыток доставки одного события подписчику
+	MaxAttempts int
+	// Timeout таймаут одного HTTP запроса
+	Timeout time.Duration
+}
+
+// DefaultWebhookConfig параметры доставки по умолчанию
+var DefaultWebhookConfig = WebhookConfig{
+	MaxAttempts: 5,
+	Timeout:     10 * time.Second,
+}
+
+// WebhookNotifier доставляет события подписчикам по HTTP
+// Каждый вызов Send делает не больше одной попытки на подписчика; повторы выполняет
+// Dispatcher по своей паузе, а журнал доставок показывает, кому событие уже доставлено
+type WebhookNotifier struct {
+	store  SubscriptionStore
+	client *http.Client
+	cfg    WebhookConfig
+}
+
+// NewWebhookNotifier создает новый экземпляр доставки событий по webhook
+func NewWebhookNotifier(store SubscriptionStore, cfg WebhookConfig) *WebhookNotifier {
+	return &WebhookNotifier{
+		store:  store,
+		client: &http.Client{Timeout: cfg.Timeout},
+		cfg:    cfg,
+	}
+}
+
+// Name возвращает имя получателя событий
+func (n *WebhookNotifier) Name() string {
+	return "webhook"
+}
+
+// Send доставляет событие подпискам, которые его еще не получили
+// Ошибка возвращается, если доставку стоит повторить: не удалось получить подписки или журнал,
+// либо подписчик ответил повторяемой ошибкой и попытки еще не исчерпаны. Окончательные отказы
+// (4xx, кроме 408 и 429, или MaxAttempts попыток) только записываются в журнал
+func (n *WebhookNotifier) Send(ctx context.Context, event Event) error {
+	subscriptions, err := n.store.GetSubscriptionsForEvent(ctx, event.Type)
+	if err != nil {
+		return fmt.Errorf("failed to load webhook subscriptions: %w", err)
+	}
+	deliveries, err := n.store.ListEventDeliveries(ctx, event.ID)
+	if err != nil {
+		return fmt.Errorf("failed to load webhook deliveries: %w", err)
+	}
+	states := deliveryStates(deliveries)
+
+	var mu sync.Mutex
+	var errs []error
+	var wg sync.WaitGroup
+	for _, subscription := range subscriptions {
+		state := states[subscription.ID]
+		if state.done(n.cfg.MaxAttempts) {
+			continue
+		}
+
+		wg.Add(1)
+		go func(subscription Subscription, attempt int) {
+			defer wg.Done()
+			statusCode, err := n.Deliver(ctx, subscription, event, attempt)
+			if err == nil {
+				return
+			}
+			if !isRetryable(statusCode) || attempt >= n.cfg.MaxAttempts {
+				log.Printf("Warning: giving up delivery of event %s to subscription %d after attempt %d: %v", event.ID, subscription.ID, attempt, err)
+				return
+			}
+			mu.Lock()
+			errs = append(errs, fmt.Errorf("subscription %d: %w", subscription.ID, err))
+			mu.Unlock()
+		}(subscription, state.attempts+1)
+	}
+	wg.Wait()
+
+	return errors.Join(errs...)
+}
+
+// Deliver выполняет попытку attempt доставки события подписчику и записывает ее в журнал
+// Возвращает HTTP статус ответа (0, если ответ не получен) и ошибку доставки
+func (n *WebhookNotifier) Deliver(ctx context.Context, subscription Subscription, event Event, attempt int) (int, error) {
+	body, err := json.Marshal(event)
+	if err != nil {
+		return 0, fmt.Errorf("failed to encode event: %w", err)
+	}
+
+	statusCode, err := n.send(ctx, subscription, event, body)
+
+	delivery := &Delivery{
+		SubscriptionID: subscription.ID,
+		EventID:        event.ID,
+		EventType:      event.Type,
+		Attempt:        attempt,
+		StatusCode:     statusCode,
+		Success:        err == nil,
+		DeliveredAt:    time.Now().UTC(),
+	}
+	if err != nil {
+		delivery.Error = err.Error()
+	}
+	if logErr := n.store.LogDelivery(ctx, delivery); logErr != nil {
+		log.Printf("Warning: failed to log delivery of event %s: %v", event.ID, logErr)
+	}
+
+	return statusCode, err
+}
+
+// deliveryState итог предыдущих попыток доставки события одному подписчику
+type deliveryState struct {
+	attempts  int
+	delivered bool
+	// lastStatusCode статус ответа на последнюю попытку
+	lastStatusCode int
+}
+
+// done проверяет, что новых попыток доставки не нужно
+func (s deliveryState) done(maxAttempts int) bool {
+	return s.delivered || s.attempts >= maxAttempts || (s.attempts > 0 && !isRetryable(s.lastStatusCode))
+}
+
+// deliveryStates собирает итоги попыток доставки события по подпискам
+func deliveryStates(deliveries []Delivery) map[int64]deliveryState {
+	states := make(map[int64]deliveryState)
+	for _, delivery := range deliveries {
+		state := states[delivery.SubscriptionID]
+		state.attempts++
+		state.delivered = state.delivered || delivery.Success
+		state.lastStatusCode = delivery.StatusCode
+		states[delivery.SubscriptionID] = state
+	}
+	return states
+}
+
+// send выполняет одну попытку доставки и возвращает HTTP статус ответа
+func (n *WebhookNotifier) send(ctx context.Context, subscription Subscription, event Event, body []byte) (int, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, subscription.URL, bytes.NewReader(body))
+	if err != nil {
+		return 0, err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("User-Agent", "pr-review-assigner-webhook")
+	req.Header.Set("X-Event-Id", event.ID)
+	req.Header.Set("X-Event-Type", string(event.Type))
+	req.Header.Set(SignatureHeader, Sign(subscription.Secret, body))
+
+	resp, err := n.client.Do(req)
+	if err != nil {
+		return 0, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return resp.StatusCode, fmt.Errorf("unexpected status %d", resp.StatusCode)
+	}
+	return resp.StatusCode, nil
+}
+
+// isRetryable проверяет, имеет ли смысл повторять доставку после ответа с таким статусом
+// Повторяются сетевые ошибки (0), 408, 429 и ответы 5xx
+func isRetryable(statusCode int) bool {
+	return statusCode == 0 ||
+		statusCode == http.StatusRequestTimeout ||
+		statusCode == http.StatusTooManyRequests ||
+		statusCode >= 500
+}
+
+// Sign вычисляет подпись тела запроса для заголовка X-Signature-256
+func Sign(secret string, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write(body)
+	return "sha256=" + hex.EncodeToString(mac.Sum(nil))
+}
@@ -0,0 +1,240 @@
+package events
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// memoryStore хранит подписки и журнал доставок в памяти
+type memoryStore struct {
+	mu            sync.Mutex
+	subscriptions []Subscription
+	deliveries    []Delivery
+}
+
+func (s *memoryStore) GetSubscriptionsForEvent(_ context.Context, eventType Type) ([]Subscription, error) {
+	var result []Subscription
+	for _, subscription := range s.subscriptions {
+		if len(subscription.EventTypes) == 0 {
+			result = append(result, subscription)
+			continue
+		}
+		for _, t := range subscription.EventTypes {
+			if t == eventType {
+				result = append(result, subscription)
+				break
+			}
+		}
+	}
+	return result, nil
+}
+
+func (s *memoryStore) LogDelivery(_ context.Context, delivery *Delivery) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.deliveries = append(s.deliveries, *delivery)
+	return nil
+}
+
+func (s *memoryStore) ListEventDeliveries(_ context.Context, eventID string) ([]Delivery, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	var result []Delivery
+	for _, delivery := range s.deliveries {
+		if delivery.EventID == eventID {
+			result = append(result, delivery)
+		}
+	}
+	return result, nil
+}
+
+func newTestNotifier(store SubscriptionStore) *WebhookNotifier {
+	return NewWebhookNotifier(store, WebhookConfig{
+		MaxAttempts: 4,
+		Timeout:     time.Second,
+	})
+}
+
+func TestWebhookNotifier_DeliverSignedEvent(t *testing.T) {
+	var received []*http.Request
+	var bodies [][]byte
+	var mu sync.Mutex
+	receiver := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		mu.Lock()
+		received = append(received, r)
+		bodies = append(bodies, body)
+		mu.Unlock()
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer receiver.Close()
+
+	store := &memoryStore{subscriptions: []Subscription{
+		{ID: 1, URL: receiver.URL, Secret: "s3cret", EventTypes: []Type{ReviewerAssigned}},
+		{ID: 2, URL: receiver.URL, Secret: "other", EventTypes: []Type{PRMerged}},
+	}}
+	notifier := newTestNotifier(store)
+
+	event := NewReviewerAssigned("pr-1", "u2")
+	require.NoError(t, notifier.Send(context.Background(), event))
+
+	require.Len(t, received, 1)
+	assert.Equal(t, "application/json", received[0].Header.Get("Content-Type"))
+	assert.Equal(t, string(ReviewerAssigned), received[0].Header.Get("X-Event-Type"))
+	assert.Equal(t, event.ID, received[0].Header.Get("X-Event-Id"))
+	assert.Equal(t, Sign("s3cret", bodies[0]), received[0].Header.Get(SignatureHeader))
+
+	var decoded Event
+	require.NoError(t, json.Unmarshal(bodies[0], &decoded))
+	assert.Equal(t, event.ID, decoded.ID)
+	assert.Equal(t, "pr-1", decoded.Data.PullRequestID)
+	assert.Equal(t, "u2", decoded.Data.ReviewerID)
+
+	require.Len(t, store.deliveries, 1)
+	assert.True(t, store.deliveries[0].Success)
+	assert.Equal(t, 1, store.deliveries[0].Attempt)
+	assert.Equal(t, http.StatusNoContent, store.deliveries[0].StatusCode)
+}
+
+func TestWebhookNotifier_RetriesOnNextSend(t *testing.T) {
+	var calls atomic.Int32
+	receiver := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if calls.Add(1) < 3 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer receiver.Close()
+
+	store := &memoryStore{subscriptions: []Subscription{{ID: 1, URL: receiver.URL}}}
+	notifier := newTestNotifier(store)
+	event := NewPRMerged("pr-1")
+
+	// Каждый Send делает одну попытку, повторяет их outbox
+	assert.Error(t, notifier.Send(context.Background(), event))
+	assert.Error(t, notifier.Send(context.Background(), event))
+	require.NoError(t, notifier.Send(context.Background(), event))
+	assert.Equal(t, int32(3), calls.Load())
+
+	// Доставленное событие повторно не отправляется
+	require.NoError(t, notifier.Send(context.Background(), event))
+	assert.Equal(t, int32(3), calls.Load())
+
+	require.Len(t, store.deliveries, 3)
+	for i, delivery := range store.deliveries[:2] {
+		assert.Equal(t, i+1, delivery.Attempt)
+		assert.False(t, delivery.Success)
+		assert.Equal(t, http.StatusServiceUnavailable, delivery.StatusCode)
+	}
+	assert.Equal(t, 3, store.deliveries[2].Attempt)
+	assert.True(t, store.deliveries[2].Success)
+}
+
+func TestWebhookNotifier_RetriesOnlyFailedSubscriptions(t *testing.T) {
+	var healthyCalls, failingCalls atomic.Int32
+	healthy := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		healthyCalls.Add(1)
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer healthy.Close()
+	failing := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		failingCalls.Add(1)
+		w.WriteHeader(http.StatusBadGateway)
+	}))
+	defer failing.Close()
+
+	store := &memoryStore{subscriptions: []Subscription{
+		{ID: 1, URL: healthy.URL},
+		{ID: 2, URL: failing.URL},
+	}}
+	notifier := newTestNotifier(store)
+	event := NewPRMerged("pr-1")
+
+	assert.Error(t, notifier.Send(context.Background(), event))
+	assert.Error(t, notifier.Send(context.Background(), event))
+
+	assert.Equal(t, int32(1), healthyCalls.Load())
+	assert.Equal(t, int32(2), failingCalls.Load())
+}
+
+func TestWebhookNotifier_GivesUpAfterMaxAttempts(t *testing.T) {
+	var calls atomic.Int32
+	receiver := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls.Add(1)
+		w.WriteHeader(http.StatusInternalServerError)
+	}))
+	defer receiver.Close()
+
+	store := &memoryStore{subscriptions: []Subscription{{ID: 1, URL: receiver.URL}}}
+	notifier := newTestNotifier(store)
+	event := NewPRMerged("pr-1")
+
+	for range 3 {
+		assert.Error(t, notifier.Send(context.Background(), event))
+	}
+	// Последняя попытка: отказ окончательный, повторять запись outbox больше не нужно
+	require.NoError(t, notifier.Send(context.Background(), event))
+	require.NoError(t, notifier.Send(context.Background(), event))
+
+	assert.Equal(t, int32(4), calls.Load())
+	assert.Len(t, store.deliveries, 4)
+}
+
+func TestWebhookNotifier_DoesNotRetryClientErrors(t *testing.T) {
+	var calls atomic.Int32
+	receiver := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls.Add(1)
+		w.WriteHeader(http.StatusGone)
+	}))
+	defer receiver.Close()
+
+	store := &memoryStore{subscriptions: []Subscription{{ID: 1, URL: receiver.URL}}}
+	notifier := newTestNotifier(store)
+	event := NewPRMerged("pr-1")
+
+	require.NoError(t, notifier.Send(context.Background(), event))
+	require.NoError(t, notifier.Send(context.Background(), event))
+
+	assert.Equal(t, int32(1), calls.Load())
+	require.Len(t, store.deliveries, 1)
+	assert.Equal(t, http.StatusGone, store.deliveries[0].StatusCode)
+}
+
+func TestWebhookNotifier_DeliverReturnsStatusCode(t *testing.T) {
+	receiver := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusTooManyRequests)
+	}))
+	defer receiver.Close()
+
+	store := &memoryStore{}
+	notifier := newTestNotifier(store)
+
+	statusCode, err := notifier.Deliver(context.Background(), Subscription{ID: 1, URL: receiver.URL}, NewPRMerged("pr-1"), 2)
+
+	assert.Error(t, err)
+	assert.Equal(t, http.StatusTooManyRequests, statusCode)
+	require.Len(t, store.deliveries, 1)
+	assert.Equal(t, 2, store.deliveries[0].Attempt)
+}
+
+func TestNewReviewerChanged(t *testing.T) {
+	reassigned := NewReviewerChanged("pr-1", "u1", "u2")
+	assert.Equal(t, ReviewerReassigned, reassigned.Type)
+	assert.Equal(t, "u1", reassigned.Data.OldReviewerID)
+	assert.Equal(t, "u2", reassigned.Data.NewReviewerID)
+
+	removed := NewReviewerChanged("pr-1", "u1", "")
+	assert.Equal(t, ReviewerRemoved, removed.Type)
+	assert.Equal(t, "u1", removed.Data.ReviewerID)
+}
@@ -0,0 +1,56 @@
+package handler
+
+import (
+	"net/http"
+
+	"pr-review-assigner/internal/api"
+)
+
+type codeownersResponse struct {
+	Codeowners *api.RepositoryCodeowners `json:"codeowners"`
+}
+
+// PostCodeownersUpload проверяет и сохраняет правила CODEOWNERS репозитория
+// (POST /codeowners/upload)
+func (s *Server) PostCodeownersUpload(w http.ResponseWriter, r *http.Request) {
+	var req api.UploadCodeownersRequest
+	if !s.decodeJSON(w, r, &req) {
+		return
+	}
+
+	codeowners, err := s.prService.UploadCodeowners(r.Context(), &req)
+	if err != nil {
+		s.handleServiceError(w, err)
+		return
+	}
+
+	s.writeJSON(w, http.StatusOK, codeownersResponse{Codeowners: codeowners})
+}
+
+// GetCodeownersGet получает правила CODEOWNERS репозитория
+// (GET /codeowners/get)
+func (s *Server) GetCodeownersGet(w http.ResponseWriter, r *http.Request, params api.GetCodeownersGetParams) {
+	codeowners, err := s.prService.GetCodeowners(r.Context(), params.Repository)
+	if err != nil {
+		s.handleServiceError(w, err)
+		return
+	}
+
+	s.writeJSON(w, http.StatusOK, codeownersResponse{Codeowners: codeowners})
+}
+
+// PostCodeownersDelete удаляет правила CODEOWNERS репозитория
+// (POST /codeowners/delete)
+func (s *Server) PostCodeownersDelete(w http.ResponseWriter, r *http.Request) {
+	var req api.PostCodeownersDeleteJSONRequestBody
+	if !s.decodeJSON(w, r, &req) {
+		return
+	}
+
+	if err := s.prService.DeleteCodeowners(r.Context(), req.Repository); err != nil {
+		s.handleServiceError(w, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
@@ -0,0 +1,218 @@
+package handler
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"log"
+	"mime"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"pr-review-assigner/internal/api"
+)
+
+// exportFormat формат ответа, выбранный по заголовку Accept
+type exportFormat int
+
+const (
+	formatJSON exportFormat = iota
+	formatCSV
+	formatNDJSON
+)
+
+const (
+	contentTypeCSV    = "text/csv; charset=utf-8"
+	contentTypeNDJSON = "application/x-ndjson"
+)
+
+// flushEvery через сколько строк потоковый ответ отправляется клиенту
+const flushEvery = 100
+
+// negotiateFormat выбирает формат по первому поддерживаемому типу из Accept
+// Если подходящего типа нет (или Accept пустой), используется fallback
+func negotiateFormat(r *http.Request, fallback exportFormat) exportFormat {
+	for _, part := range strings.Split(r.Header.Get("Accept"), ",") {
+		mediaType, _, err := mime.ParseMediaType(strings.TrimSpace(part))
+		if err != nil {
+			continue
+		}
+		switch mediaType {
+		case "text/csv":
+			return formatCSV
+		case "application/x-ndjson":
+			return formatNDJSON
+		case "application/json":
+			return formatJSON
+		}
+	}
+	return fallback
+}
+
+// rowWriter пишет ответ построчно в CSV или NDJSON и периодически отправляет его клиенту,
+// не накапливая тело целиком, как writeJSON
+type rowWriter struct {
+	w       http.ResponseWriter
+	format  exportFormat
+	header  []string
+	csv     *csv.Writer
+	json    *json.Encoder
+	started bool
+	rows    int
+}
+
+// newRowWriter создает построчный writer; header - имена колонок CSV
+func newRowWriter(w http.ResponseWriter, format exportFormat, header []string) *rowWriter {
+	return &rowWriter{w: w, format: format, header: header}
+}
+
+// start отправляет заголовки ответа и строку заголовка CSV; повторные вызовы ничего не делают
+func (rw *rowWriter) start() error {
+	if rw.started {
+		return nil
+	}
+	rw.started = true
+
+	if rw.format == formatCSV {
+		rw.w.Header().Set("Content-Type", contentTypeCSV)
+		rw.w.WriteHeader(http.StatusOK)
+		rw.csv = csv.NewWriter(rw.w)
+		return rw.csv.Write(rw.header)
+	}
+	rw.w.Header().Set("Content-Type", contentTypeNDJSON)
+	rw.w.WriteHeader(http.StatusOK)
+	rw.json = json.NewEncoder(rw.w)
+	return nil
+}
+
+// write пишет одну строку: record для CSV или value как JSON объект для NDJSON
+func (rw *rowWriter) write(record []string, value any) error {
+	if err := rw.start(); err != nil {
+		return err
+	}
+
+	var err error
+	if rw.format == formatCSV {
+		err = rw.csv.Write(record)
+	} else {
+		err = rw.json.Encode(value)
+	}
+	if err != nil {
+		return err
+	}
+
+	rw.rows++
+	if rw.rows%flushEvery == 0 {
+		return rw.flush()
+	}
+	return nil
+}
+
+// finish отправляет остаток ответа; пустая выгрузка содержит только заголовок CSV
+func (rw *rowWriter) finish() error {
+	if err := rw.start(); err != nil {
+		return err
+	}
+	return rw.flush()
+}
+
+func (rw *rowWriter) flush() error {
+	if rw.csv != nil {
+		rw.csv.Flush()
+		if err := rw.csv.Error(); err != nil {
+			return err
+		}
+	}
+	err := http.NewResponseController(rw.w).Flush()
+	if err == http.ErrNotSupported {
+		return nil
+	}
+	return err
+}
+
+// assignmentCSVHeader колонки CSV выгрузки назначений
+var assignmentCSVHeader = []string{
+	"pull_request_id", "pull_request_name", "status", "author_id",
+	"reviewer_id", "reviewer_username", "reviewer_team_name",
+	"assigned_at", "created_at", "merged_at",
+}
+
+// GetExportAssignments выгружает все назначения ревьюверов построчно в NDJSON или CSV
+// (GET /export/assignments)
+func (s *Server) GetExportAssignments(w http.ResponseWriter, r *http.Request) {
+	rw := newRowWriter(w, negotiateFormat(r, formatNDJSON), assignmentCSVHeader)
+	if rw.format == formatJSON {
+		rw.format = formatNDJSON
+	}
+
+	err := s.prService.ExportAssignments(r.Context(), func(row *api.AssignmentExportRow) error {
+		return rw.write([]string{
+			row.PullRequestId,
+			row.PullRequestName,
+			string(row.Status),
+			row.AuthorId,
+			row.ReviewerId,
+			row.ReviewerUsername,
+			row.ReviewerTeamName,
+			formatCSVTime(row.AssignedAt),
+			formatCSVTime(row.CreatedAt),
+			formatCSVTime(row.MergedAt),
+		}, row)
+	})
+	if err == nil {
+		err = rw.finish()
+	}
+	if err != nil {
+		if !rw.started {
+			s.handleServiceError(w, err)
+			return
+		}
+		// Статус уже отправлен: выгрузка обрывается, и клиент получает неполный ответ
+		log.Printf("Export of assignments aborted after %d rows: %v", rw.rows, err)
+	}
+}
+
+// statisticsCSVHeader колонки CSV статистики ревьюверов
+var statisticsCSVHeader = []string{
+	"user_id", "username", "team_name", "assignments_count",
+	"open_assignments_count", "completed_assignments_count", "authored_prs_count",
+	"avg_time_to_merge_seconds",
+}
+
+// writeStatisticsRows пишет статистику ревьюверов в CSV или NDJSON
+func (s *Server) writeStatisticsRows(w http.ResponseWriter, format exportFormat, stats []reviewerStat) {
+	rw := newRowWriter(w, format, statisticsCSVHeader)
+	for i := range stats {
+		stat := &stats[i]
+		avg := ""
+		if stat.AvgTimeToMergeSeconds != nil {
+			avg = strconv.FormatFloat(*stat.AvgTimeToMergeSeconds, 'f', -1, 64)
+		}
+		err := rw.write([]string{
+			stat.UserId,
+			stat.Username,
+			stat.TeamName,
+			strconv.Itoa(stat.AssignmentsCount),
+			strconv.Itoa(stat.OpenAssignmentsCount),
+			strconv.Itoa(stat.CompletedAssignmentsCount),
+			strconv.Itoa(stat.AuthoredPRsCount),
+			avg,
+		}, stat)
+		if err != nil {
+			log.Printf("Failed to write statistics: %v", err)
+			return
+		}
+	}
+	if err := rw.finish(); err != nil {
+		log.Printf("Failed to write statistics: %v", err)
+	}
+}
+
+// formatCSVTime форматирует время для CSV; пустая строка, если времени нет
+func formatCSVTime(t *time.Time) string {
+	if t == nil {
+		return ""
+	}
+	return t.UTC().Format(time.RFC3339Nano)
+}
@@ -0,0 +1,140 @@
+package handler
+
+import (
+	"bufio"
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"pr-review-assigner/internal/api"
+	"pr-review-assigner/internal/service"
+	"pr-review-assigner/internal/storage/memory"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// newExportTestServer создает сервер над хранилищем в памяти с двумя назначениями
+func newExportTestServer(t *testing.T) *Server {
+	t.Helper()
+	store := memory.NewStore()
+	teamRepo, userRepo, prRepo := memory.NewTeamRepository(store), memory.NewUserRepository(store), memory.NewPRRepository(store)
+	ctx := context.Background()
+
+	require.NoError(t, teamRepo.CreateTeam(ctx, "backend"))
+	for _, id := range []string{"u1", "u2", "u3"} {
+		require.NoError(t, userRepo.CreateOrUpdateUser(ctx, &api.User{UserId: id, Username: "name-" + id, TeamName: "backend", IsActive: true}))
+	}
+	_, err := prRepo.CreatePR(ctx, &api.PullRequest{
+		PullRequestId:     "pr-1",
+		PullRequestName:   "Add search, filters",
+		AuthorId:          "u1",
+		Status:            api.PullRequestStatusOPEN,
+		AssignedReviewers: []string{"u2", "u3"},
+	})
+	require.NoError(t, err)
+
+	prService := service.NewPRService(prRepo, userRepo, teamRepo)
+	return NewServer(nil, nil, prService, nil)
+}
+
+func TestNegotiateFormat(t *testing.T) {
+	tests := []struct {
+		accept string
+		want   exportFormat
+	}{
+		{"", formatJSON},
+		{"*/*", formatJSON},
+		{"text/csv", formatCSV},
+		{"application/x-ndjson", formatNDJSON},
+		{"text/html, text/csv;q=0.9, application/json", formatCSV},
+		{"application/json, text/csv", formatJSON},
+	}
+	for _, tt := range tests {
+		req := httptest.NewRequest(http.MethodGet, "/statistics", nil)
+		req.Header.Set("Accept", tt.accept)
+		assert.Equal(t, tt.want, negotiateFormat(req, formatJSON), "Accept: %q", tt.accept)
+	}
+}
+
+func TestGetExportAssignments_NDJSON(t *testing.T) {
+	server := newExportTestServer(t)
+
+	rec := httptest.NewRecorder()
+	server.GetExportAssignments(rec, httptest.NewRequest(http.MethodGet, "/export/assignments", nil))
+
+	require.Equal(t, http.StatusOK, rec.Code)
+	assert.Equal(t, contentTypeNDJSON, rec.Header().Get("Content-Type"))
+	var rows []api.AssignmentExportRow
+	scanner := bufio.NewScanner(rec.Body)
+	for scanner.Scan() {
+		var row api.AssignmentExportRow
+		require.NoError(t, json.Unmarshal(scanner.Bytes(), &row))
+		rows = append(rows, row)
+	}
+	require.Len(t, rows, 2)
+	assert.Equal(t, "pr-1", rows[0].PullRequestId)
+	assert.Equal(t, "name-u2", rows[0].ReviewerUsername)
+	assert.Equal(t, "backend", rows[0].ReviewerTeamName)
+	assert.NotNil(t, rows[0].AssignedAt)
+	assert.Nil(t, rows[0].MergedAt)
+}
+
+func TestGetExportAssignments_CSV(t *testing.T) {
+	server := newExportTestServer(t)
+
+	req := httptest.NewRequest(http.MethodGet, "/export/assignments", nil)
+	req.Header.Set("Accept", "text/csv")
+	rec := httptest.NewRecorder()
+	server.GetExportAssignments(rec, req)
+
+	require.Equal(t, http.StatusOK, rec.Code)
+	assert.Equal(t, contentTypeCSV, rec.Header().Get("Content-Type"))
+	records, err := csv.NewReader(rec.Body).ReadAll()
+	require.NoError(t, err)
+	require.Len(t, records, 3)
+	assert.Equal(t, assignmentCSVHeader, records[0])
+	// Значения с запятыми экранируются
+	assert.Equal(t, "Add search, filters", records[1][1])
+	assert.Equal(t, "u2", records[1][4])
+	assert.Equal(t, "u3", records[2][4])
+	assert.Empty(t, records[1][9])
+}
+
+func TestGetStatistics_ContentNegotiation(t *testing.T) {
+	server := newExportTestServer(t)
+
+	req := httptest.NewRequest(http.MethodGet, "/statistics", nil)
+	req.Header.Set("Accept", "text/csv")
+	rec := httptest.NewRecorder()
+	server.GetStatistics(rec, req, api.GetStatisticsParams{})
+
+	require.Equal(t, http.StatusOK, rec.Code)
+	records, err := csv.NewReader(rec.Body).ReadAll()
+	require.NoError(t, err)
+	require.Len(t, records, 4)
+	assert.Equal(t, statisticsCSVHeader, records[0])
+	assert.Equal(t, []string{"u2", "name-u2", "backend", "1", "1", "0", "0", ""}, records[1])
+
+	req = httptest.NewRequest(http.MethodGet, "/statistics", nil)
+	req.Header.Set("Accept", "application/x-ndjson")
+	rec = httptest.NewRecorder()
+	server.GetStatistics(rec, req, api.GetStatisticsParams{})
+
+	require.Equal(t, http.StatusOK, rec.Code)
+	lines := strings.Split(strings.TrimSpace(rec.Body.String()), "\n")
+	require.Len(t, lines, 3)
+	var stat reviewerStat
+	require.NoError(t, json.Unmarshal([]byte(lines[0]), &stat))
+	assert.Equal(t, "u2", stat.UserId)
+
+	// Без Accept ответ остается в JSON
+	rec = httptest.NewRecorder()
+	server.GetStatistics(rec, httptest.NewRequest(http.MethodGet, "/statistics", nil), api.GetStatisticsParams{})
+	assert.Contains(t, rec.Header().Get("Content-Type"), "application/json")
+	assert.Contains(t, rec.Body.String(), `"statistics"`)
+}
@@ -0,0 +1,32 @@
+package handler
+
+import (
+	"context"
+	"net/http"
+	"time"
+)
+
+// PathTimeout ограничение времени обработки запросов к пути, отличное от общего
+// (например, для потоковой выгрузки)
+type PathTimeout struct {
+	Path    string
+	Timeout time.Duration
+}
+
+// RequestTimeout ограничивает время обработки запроса: контекст запроса отменяется
+// по истечении timeout, и вместе с ним прерываются запросы к БД
+func RequestTimeout(timeout time.Duration, overrides ...PathTimeout) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			limit := timeout
+			for _, override := range overrides {
+				if r.URL.Path == override.Path {
+					limit = override.Timeout
+				}
+			}
+			ctx, cancel := context.WithTimeout(r.Context(), limit)
+			defer cancel()
+			next.ServeHTTP(w, r.WithContext(ctx))
+		})
+	}
+}
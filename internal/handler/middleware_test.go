@@ -0,0 +1,57 @@
+package handler
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"pr-review-assigner/internal/api"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRequestTimeout_SetsDeadline(t *testing.T) {
+	var deadline time.Time
+	var hasDeadline bool
+	h := RequestTimeout(time.Second)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		deadline, hasDeadline = r.Context().Deadline()
+	}))
+
+	h.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/", nil))
+
+	require.True(t, hasDeadline)
+	assert.WithinDuration(t, time.Now().Add(time.Second), deadline, 500*time.Millisecond)
+}
+
+func TestRequestTimeout_PathOverride(t *testing.T) {
+	var deadlines []time.Duration
+	h := RequestTimeout(time.Second, PathTimeout{Path: "/export/assignments", Timeout: time.Hour})(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		deadline, _ := r.Context().Deadline()
+		deadlines = append(deadlines, time.Until(deadline).Round(time.Second))
+	}))
+
+	h.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/export/assignments", nil))
+	h.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/statistics", nil))
+
+	assert.Equal(t, []time.Duration{time.Hour, time.Second}, deadlines)
+}
+
+func TestRequestTimeout_DeadlineExceededReturnsGatewayTimeout(t *testing.T) {
+	server := NewServer(nil, nil, nil, nil)
+	h := RequestTimeout(time.Millisecond)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		// Имитируем медленный запрос к БД, прерванный по контексту
+		<-r.Context().Done()
+		server.handleServiceError(w, r.Context().Err())
+	}))
+
+	rec := httptest.NewRecorder()
+	h.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/", nil))
+
+	assert.Equal(t, http.StatusGatewayTimeout, rec.Code)
+	var resp api.ErrorResponse
+	require.NoError(t, json.NewDecoder(rec.Body).Decode(&resp))
+	assert.Equal(t, api.TIMEOUT, resp.Error.Code)
+}
@@ -0,0 +1,72 @@
+package handler
+
+import (
+	"net/http"
+
+	"pr-review-assigner/internal/api"
+)
+
+type reviewerPoolResponse struct {
+	Pool *api.ReviewerPool `json:"pool"`
+}
+
+type reviewerPoolsResponse struct {
+	Pools []api.ReviewerPool `json:"pools"`
+}
+
+// PostPoolsSet создает пул ревьюверов или заменяет его участников
+// (POST /pools/set)
+func (s *Server) PostPoolsSet(w http.ResponseWriter, r *http.Request) {
+	var req api.ReviewerPool
+	if !s.decodeJSON(w, r, &req) {
+		return
+	}
+
+	pool, err := s.teamService.SetReviewerPool(r.Context(), &req)
+	if err != nil {
+		s.handleServiceError(w, err)
+		return
+	}
+
+	s.writeJSON(w, http.StatusOK, reviewerPoolResponse{Pool: pool})
+}
+
+// GetPoolsGet получает пул ревьюверов
+// (GET /pools/get)
+func (s *Server) GetPoolsGet(w http.ResponseWriter, r *http.Request, params api.GetPoolsGetParams) {
+	pool, err := s.teamService.GetReviewerPool(r.Context(), params.PoolName)
+	if err != nil {
+		s.handleServiceError(w, err)
+		return
+	}
+
+	s.writeJSON(w, http.StatusOK, reviewerPoolResponse{Pool: pool})
+}
+
+// GetPoolsList получает все пулы ревьюверов
+// (GET /pools/list)
+func (s *Server) GetPoolsList(w http.ResponseWriter, r *http.Request) {
+	pools, err := s.teamService.ListReviewerPools(r.Context())
+	if err != nil {
+		s.handleServiceError(w, err)
+		return
+	}
+
+	s.writeJSON(w, http.StatusOK, reviewerPoolsResponse{Pools: pools})
+}
+
+// PostPoolsDelete удаляет пул ревьюверов
+// (POST /pools/delete)
+func (s *Server) PostPoolsDelete(w http.ResponseWriter, r *http.Request) {
+	var req api.PostPoolsDeleteJSONRequestBody
+	if !s.decodeJSON(w, r, &req) {
+		return
+	}
+
+	if err := s.teamService.DeleteReviewerPool(r.Context(), req.PoolName); err != nil {
+		s.handleServiceError(w, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
@@ -10,16 +10,28 @@ import (
 
 // Server реализует ServerInterface для обработки HTTP запросов
 type Server struct {
-	teamService *service.TeamService
-	userService *service.UserService
-	prService   *service.PRService
+	teamService         *service.TeamService
+	userService         *service.UserService
+	prService           *service.PRService
+	subscriptionService *service.SubscriptionService
+
+	// Обработка вебхуков внешних систем (необязательно)
+	webhooks      WebhookProcessor
+	webhookConfig WebhookConfig
 }
 
+// ServerOption настраивает необязательные возможности сервера
+type ServerOption func(*Server)
+
 // Типизированные структуры ответов для устранения дублирования
 type teamResponse struct {
 	Team *api.Team `json:"team"`
 }
 
+type identityResponse struct {
+	Identity *api.UserIdentity `json:"identity"`
+}
+
 type userResponse struct {
 	User *api.User `json:"user"`
 }
@@ -36,6 +48,12 @@ type reassignResponse struct {
 type userReviewResponse struct {
 	UserId       string                 `json:"user_id"`
 	PullRequests []api.PullRequestShort `json:"pull_requests"`
+	NextCursor   string                 `json:"next_cursor,omitempty"`
+}
+
+type prListResponse struct {
+	PullRequests []api.PullRequest `json:"pull_requests"`
+	NextCursor   string            `json:"next_cursor,omitempty"`
 }
 
 type deactivateUsersResponse struct {
@@ -44,32 +62,65 @@ type deactivateUsersResponse struct {
 }
 
 type reviewerStat struct {
-	UserId           string `json:"user_id"`
-	Username         string `json:"username"`
-	AssignmentsCount int    `json:"assignments_count"`
+	UserId                    string   `json:"user_id"`
+	Username                  string   `json:"username"`
+	TeamName                  string   `json:"team_name"`
+	AssignmentsCount          int      `json:"assignments_count"`
+	OpenAssignmentsCount      int      `json:"open_assignments_count"`
+	CompletedAssignmentsCount int      `json:"completed_assignments_count"`
+	AuthoredPRsCount          int      `json:"authored_prs_count"`
+	AvgTimeToMergeSeconds     *float64 `json:"avg_time_to_merge_seconds"`
 }
 
 type statisticsResponse struct {
 	Statistics []reviewerStat `json:"statistics"`
 }
 
+type fairnessResponse struct {
+	Report *api.FairnessReport `json:"report"`
+}
+
+type staleReviewsResponse struct {
+	StaleReviews []api.StaleReview `json:"stale_reviews"`
+}
+
+type prHistoryResponse struct {
+	PullRequestId string                        `json:"pull_request_id"`
+	History       []api.PullRequestHistoryEntry `json:"history"`
+}
+
 // errorCodeToHTTPStatus маппинг кодов ошибок на HTTP статусы
 var errorCodeToHTTPStatus = map[api.ErrorResponseErrorCode]int{
 	api.TEAMEXISTS:  http.StatusBadRequest,
 	api.PRMERGED:    http.StatusConflict,
+	api.PRCLOSED:    http.StatusConflict,
+	api.PRDRAFT:     http.StatusConflict,
 	api.NOTASSIGNED: http.StatusConflict,
 	api.NOCANDIDATE: http.StatusConflict,
 	api.PREXISTS:    http.StatusConflict,
 	api.NOTFOUND:    http.StatusNotFound,
+
+	api.INVALIDREQUEST: http.StatusBadRequest,
+	api.REVIEWERSLIMIT: http.StatusConflict,
+
+	api.NOTENOUGHAPPROVALS: http.StatusConflict,
+	api.UNAUTHORIZED:       http.StatusUnauthorized,
+
+	api.TIMEOUT: http.StatusGatewayTimeout,
 }
 
 // NewServer создает новый экземпляр сервера
-func NewServer(teamService *service.TeamService, userService *service.UserService, prService *service.PRService) *Server {
-	return &Server{
-		teamService: teamService,
-		userService: userService,
-		prService:   prService,
+func NewServer(teamService *service.TeamService, userService *service.UserService, prService *service.PRService, subscriptionService *service.SubscriptionService, opts ...ServerOption) *Server {
+	s := &Server{
+		teamService:         teamService,
+		userService:         userService,
+		prService:           prService,
+		subscriptionService: subscriptionService,
 	}
+	for _, opt := range opts {
+		opt(s)
+	}
+	return s
 }
 
 // decodeJSON декодирует JSON из тела запроса
@@ -104,6 +155,8 @@ func (s *Server) writeError(w http.ResponseWriter, statusCode int, code api.Erro
 
 // handleServiceError обрабатывает ошибку сервиса и возвращает соответствующий HTTP ответ
 func (s *Server) handleServiceError(w http.ResponseWriter, err error) {
+	// Ошибки хранилища (в т.ч. истекший таймаут запроса) могли вернуться из сервиса без маппинга
+	err = service.MapStorageError(err)
 	if service.IsServiceError(err) {
 		se := service.GetServiceError(err)
 		statusCode, ok := errorCodeToHTTPStatus[se.Code]
@@ -124,7 +177,7 @@ func (s *Server) PostTeamAdd(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	result, err := s.teamService.CreateOrUpdateTeam(&team)
+	result, err := s.teamService.CreateOrUpdateTeam(r.Context(), &team)
 	if err != nil {
 		s.handleServiceError(w, err)
 		return
@@ -141,7 +194,7 @@ func (s *Server) PostTeamUpdate(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	result, err := s.teamService.UpdateTeam(&team)
+	result, err := s.teamService.UpdateTeam(r.Context(), &team)
 	if err != nil {
 		s.handleServiceError(w, err)
 		return
@@ -153,7 +206,7 @@ func (s *Server) PostTeamUpdate(w http.ResponseWriter, r *http.Request) {
 // GetTeamGet получает команду с участниками
 // (GET /team/get)
 func (s *Server) GetTeamGet(w http.ResponseWriter, r *http.Request, params api.GetTeamGetParams) {
-	team, err := s.teamService.GetTeam(params.TeamName)
+	team, err := s.teamService.GetTeam(r.Context(), params.TeamName)
 	if err != nil {
 		s.handleServiceError(w, err)
 		return
@@ -170,7 +223,7 @@ func (s *Server) PostTeamDeactivateUsers(w http.ResponseWriter, r *http.Request)
 		return
 	}
 
-	deactivatedUsers, reassignedCount, err := s.userService.DeactivateTeamUsers(req.TeamName, req.UserIds)
+	deactivatedUsers, reassignedCount, err := s.userService.DeactivateTeamUsers(r.Context(), req.TeamName, req.UserIds)
 	if err != nil {
 		s.handleServiceError(w, err)
 		return
@@ -190,7 +243,7 @@ func (s *Server) PostUsersSetIsActive(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	user, err := s.userService.SetUserIsActive(req.UserId, req.IsActive)
+	user, err := s.userService.SetUserIsActive(r.Context(), req.UserId, req.IsActive)
 	if err != nil {
 		s.handleServiceError(w, err)
 		return
@@ -199,15 +252,49 @@ func (s *Server) PostUsersSetIsActive(w http.ResponseWriter, r *http.Request) {
 	s.writeJSON(w, http.StatusOK, userResponse{User: user})
 }
 
-// PostPullRequestCreate создает PR и автоматически назначает до MaxReviewers ревьюверов из команды автора
+// PostUsersSetWorkingHours задает часовой пояс и рабочие часы пользователя
+// (POST /users/setWorkingHours)
+func (s *Server) PostUsersSetWorkingHours(w http.ResponseWriter, r *http.Request) {
+	var req api.SetUserWorkingHoursRequest
+	if !s.decodeJSON(w, r, &req) {
+		return
+	}
+
+	user, err := s.userService.SetUserWorkingHours(r.Context(), &req)
+	if err != nil {
+		s.handleServiceError(w, err)
+		return
+	}
+
+	s.writeJSON(w, http.StatusOK, userResponse{User: user})
+}
+
+// PostUsersLinkIdentity связывает логин во внешней системе с пользователем
+// (POST /users/linkIdentity)
+func (s *Server) PostUsersLinkIdentity(w http.ResponseWriter, r *http.Request) {
+	var req api.UserIdentity
+	if !s.decodeJSON(w, r, &req) {
+		return
+	}
+
+	identity, err := s.userService.LinkIdentity(r.Context(), &req)
+	if err != nil {
+		s.handleServiceError(w, err)
+		return
+	}
+
+	s.writeJSON(w, http.StatusOK, identityResponse{Identity: identity})
+}
+
+// PostPullRequestCreate создает PR и автоматически назначает ревьюверов из команды автора
 // (POST /pullRequest/create)
 func (s *Server) PostPullRequestCreate(w http.ResponseWriter, r *http.Request) {
-	var req api.PostPullRequestCreateJSONBody
+	var req api.CreatePullRequestRequest
 	if !s.decodeJSON(w, r, &req) {
 		return
 	}
 
-	pr, err := s.prService.CreatePR(req.PullRequestId, req.PullRequestName, req.AuthorId)
+	pr, err := s.prService.CreatePR(r.Context(), &req)
 	if err != nil {
 		s.handleServiceError(w, err)
 		return
@@ -224,7 +311,7 @@ func (s *Server) PostPullRequestAssignReviewers(w http.ResponseWriter, r *http.R
 		return
 	}
 
-	pr, err := s.prService.AutoAssignReviewers(req.PullRequestId)
+	pr, err := s.prService.AutoAssignReviewers(r.Context(), req.PullRequestId)
 	if err != nil {
 		s.handleServiceError(w, err)
 		return
@@ -241,7 +328,75 @@ func (s *Server) PostPullRequestMerge(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	pr, err := s.prService.MergePR(req.PullRequestId)
+	pr, err := s.prService.MergePR(r.Context(), req.PullRequestId)
+	if err != nil {
+		s.handleServiceError(w, err)
+		return
+	}
+
+	s.writeJSON(w, http.StatusOK, prResponse{PR: pr})
+}
+
+// PostPullRequestClose закрывает PR без слияния (идемпотентная операция)
+// (POST /pullRequest/close)
+func (s *Server) PostPullRequestClose(w http.ResponseWriter, r *http.Request) {
+	var req api.PostPullRequestCloseJSONBody
+	if !s.decodeJSON(w, r, &req) {
+		return
+	}
+
+	pr, err := s.prService.ClosePR(r.Context(), req.PullRequestId)
+	if err != nil {
+		s.handleServiceError(w, err)
+		return
+	}
+
+	s.writeJSON(w, http.StatusOK, prResponse{PR: pr})
+}
+
+// PostPullRequestReopen переоткрывает закрытый PR (идемпотентная операция)
+// (POST /pullRequest/reopen)
+func (s *Server) PostPullRequestReopen(w http.ResponseWriter, r *http.Request) {
+	var req api.PostPullRequestReopenJSONBody
+	if !s.decodeJSON(w, r, &req) {
+		return
+	}
+
+	pr, err := s.prService.ReopenPR(r.Context(), req.PullRequestId)
+	if err != nil {
+		s.handleServiceError(w, err)
+		return
+	}
+
+	s.writeJSON(w, http.StatusOK, prResponse{PR: pr})
+}
+
+// PostPullRequestMarkReady переводит черновик в OPEN и назначает ревьюверов (идемпотентная операция)
+// (POST /pullRequest/markReady)
+func (s *Server) PostPullRequestMarkReady(w http.ResponseWriter, r *http.Request) {
+	var req api.PostPullRequestMarkReadyJSONBody
+	if !s.decodeJSON(w, r, &req) {
+		return
+	}
+
+	pr, err := s.prService.MarkReady(r.Context(), req.PullRequestId)
+	if err != nil {
+		s.handleServiceError(w, err)
+		return
+	}
+
+	s.writeJSON(w, http.StatusOK, prResponse{PR: pr})
+}
+
+// PostPullRequestReview сохраняет вердикт ревьювера по PR
+// (POST /pullRequest/review)
+func (s *Server) PostPullRequestReview(w http.ResponseWriter, r *http.Request) {
+	var req api.PostPullRequestReviewJSONBody
+	if !s.decodeJSON(w, r, &req) {
+		return
+	}
+
+	pr, err := s.prService.SubmitReview(r.Context(), req.PullRequestId, req.ReviewerId, req.Verdict)
 	if err != nil {
 		s.handleServiceError(w, err)
 		return
@@ -258,7 +413,7 @@ func (s *Server) PostPullRequestReassign(w http.ResponseWriter, r *http.Request)
 		return
 	}
 
-	pr, newUserID, err := s.prService.ReassignReviewer(req.PullRequestId, req.OldReviewerId)
+	pr, newUserID, err := s.prService.ReassignReviewer(r.Context(), req.PullRequestId, req.OldReviewerId)
 	if err != nil {
 		s.handleServiceError(w, err)
 		return
@@ -270,10 +425,40 @@ func (s *Server) PostPullRequestReassign(w http.ResponseWriter, r *http.Request)
 	})
 }
 
+// GetPullRequestHistory получает историю PR
+// (GET /pullRequest/history)
+func (s *Server) GetPullRequestHistory(w http.ResponseWriter, r *http.Request, params api.GetPullRequestHistoryParams) {
+	history, err := s.prService.GetPRHistory(r.Context(), params.PullRequestId)
+	if err != nil {
+		s.handleServiceError(w, err)
+		return
+	}
+
+	s.writeJSON(w, http.StatusOK, prHistoryResponse{
+		PullRequestId: params.PullRequestId,
+		History:       history,
+	})
+}
+
+// GetPullRequestList получает страницу списка PR с фильтрами
+// (GET /pullRequest/list)
+func (s *Server) GetPullRequestList(w http.ResponseWriter, r *http.Request, params api.GetPullRequestListParams) {
+	prs, nextCursor, err := s.prService.ListPRs(r.Context(), &params)
+	if err != nil {
+		s.handleServiceError(w, err)
+		return
+	}
+
+	s.writeJSON(w, http.StatusOK, prListResponse{
+		PullRequests: prs,
+		NextCursor:   nextCursor,
+	})
+}
+
 // GetUsersGetReview получает PR'ы, где пользователь назначен ревьювером
 // (GET /users/getReview)
 func (s *Server) GetUsersGetReview(w http.ResponseWriter, r *http.Request, params api.GetUsersGetReviewParams) {
-	prs, err := s.prService.GetPRsByReviewer(params.UserId)
+	prs, nextCursor, err := s.prService.GetPRsByReviewer(r.Context(), &params)
 	if err != nil {
 		s.handleServiceError(w, err)
 		return
@@ -282,13 +467,14 @@ func (s *Server) GetUsersGetReview(w http.ResponseWriter, r *http.Request, param
 	s.writeJSON(w, http.StatusOK, userReviewResponse{
 		UserId:       params.UserId,
 		PullRequests: prs,
+		NextCursor:   nextCursor,
 	})
 }
 
 // GetStatistics получает статистику назначений ревьюверов
 // (GET /statistics)
-func (s *Server) GetStatistics(w http.ResponseWriter, r *http.Request) {
-	statistics, err := s.prService.GetReviewerStatistics()
+func (s *Server) GetStatistics(w http.ResponseWriter, r *http.Request, params api.GetStatisticsParams) {
+	statistics, err := s.prService.GetReviewerStatistics(r.Context(), &params)
 	if err != nil {
 		s.handleServiceError(w, err)
 		return
@@ -298,11 +484,49 @@ func (s *Server) GetStatistics(w http.ResponseWriter, r *http.Request) {
 	stats := make([]reviewerStat, len(statistics))
 	for i, stat := range statistics {
 		stats[i] = reviewerStat{
-			UserId:           stat.UserID,
-			Username:         stat.Username,
-			AssignmentsCount: stat.AssignmentsCount,
+			UserId:                    stat.UserID,
+			Username:                  stat.Username,
+			TeamName:                  stat.TeamName,
+			AssignmentsCount:          stat.AssignmentsCount,
+			OpenAssignmentsCount:      stat.OpenAssignmentsCount,
+			CompletedAssignmentsCount: stat.CompletedAssignmentsCount,
+			AuthoredPRsCount:          stat.AuthoredPRsCount,
 		}
+		if stat.AvgTimeToMerge != nil {
+			seconds := stat.AvgTimeToMerge.Seconds()
+			stats[i].AvgTimeToMergeSeconds = &seconds
+		}
+	}
+
+	// Для таблиц статистика отдается в CSV или NDJSON по заголовку Accept
+	if format := negotiateFormat(r, formatJSON); format != formatJSON {
+		s.writeStatisticsRows(w, format, stats)
+		return
 	}
 
 	s.writeJSON(w, http.StatusOK, statisticsResponse{Statistics: stats})
 }
+
+// GetStatisticsFairness получает отчет о равномерности распределения ревью в команде
+// (GET /statistics/fairness)
+func (s *Server) GetStatisticsFairness(w http.ResponseWriter, r *http.Request, params api.GetStatisticsFairnessParams) {
+	report, err := s.prService.GetFairnessReport(r.Context(), &params)
+	if err != nil {
+		s.handleServiceError(w, err)
+		return
+	}
+
+	s.writeJSON(w, http.StatusOK, fairnessResponse{Report: report})
+}
+
+// GetReviewsStale получает назначения с нарушенным SLA ревью
+// (GET /reviews/stale)
+func (s *Server) GetReviewsStale(w http.ResponseWriter, r *http.Request, params api.GetReviewsStaleParams) {
+	stale, err := s.prService.GetStaleReviews(r.Context(), &params)
+	if err != nil {
+		s.handleServiceError(w, err)
+		return
+	}
+
+	s.writeJSON(w, http.StatusOK, staleReviewsResponse{StaleReviews: stale})
+}
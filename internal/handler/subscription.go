@@ -0,0 +1,80 @@
+package handler
+
+import (
+	"net/http"
+
+	"pr-review-assigner/internal/api"
+)
+
+type subscriptionResponse struct {
+	Subscription *api.WebhookSubscription `json:"subscription"`
+}
+
+type subscriptionsResponse struct {
+	Subscriptions []api.WebhookSubscription `json:"subscriptions"`
+}
+
+type deliveriesResponse struct {
+	SubscriptionId int64                 `json:"subscription_id"`
+	Deliveries     []api.WebhookDelivery `json:"deliveries"`
+}
+
+// PostSubscriptionsAdd создает подписку на события об изменении ревьюверов
+// (POST /subscriptions/add)
+func (s *Server) PostSubscriptionsAdd(w http.ResponseWriter, r *http.Request) {
+	var req api.CreateWebhookSubscriptionRequest
+	if !s.decodeJSON(w, r, &req) {
+		return
+	}
+
+	subscription, err := s.subscriptionService.CreateSubscription(r.Context(), &req)
+	if err != nil {
+		s.handleServiceError(w, err)
+		return
+	}
+
+	s.writeJSON(w, http.StatusCreated, subscriptionResponse{Subscription: subscription})
+}
+
+// GetSubscriptionsList получает список подписок
+// (GET /subscriptions/list)
+func (s *Server) GetSubscriptionsList(w http.ResponseWriter, r *http.Request) {
+	subscriptions, err := s.subscriptionService.ListSubscriptions(r.Context())
+	if err != nil {
+		s.handleServiceError(w, err)
+		return
+	}
+
+	s.writeJSON(w, http.StatusOK, subscriptionsResponse{Subscriptions: subscriptions})
+}
+
+// PostSubscriptionsDelete удаляет подписку
+// (POST /subscriptions/delete)
+func (s *Server) PostSubscriptionsDelete(w http.ResponseWriter, r *http.Request) {
+	var req api.PostSubscriptionsDeleteJSONRequestBody
+	if !s.decodeJSON(w, r, &req) {
+		return
+	}
+
+	if err := s.subscriptionService.DeleteSubscription(r.Context(), req.SubscriptionId); err != nil {
+		s.handleServiceError(w, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// GetSubscriptionsDeliveries получает журнал доставок по подписке
+// (GET /subscriptions/deliveries)
+func (s *Server) GetSubscriptionsDeliveries(w http.ResponseWriter, r *http.Request, params api.GetSubscriptionsDeliveriesParams) {
+	deliveries, err := s.subscriptionService.ListDeliveries(r.Context(), params.SubscriptionId, params.Limit)
+	if err != nil {
+		s.handleServiceError(w, err)
+		return
+	}
+
+	s.writeJSON(w, http.StatusOK, deliveriesResponse{
+		SubscriptionId: params.SubscriptionId,
+		Deliveries:     deliveries,
+	})
+}
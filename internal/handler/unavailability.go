@@ -0,0 +1,77 @@
+package handler
+
+import (
+	"net/http"
+
+	"pr-review-assigner/internal/api"
+)
+
+type unavailabilityResponse struct {
+	Unavailability *api.UserUnavailability `json:"unavailability"`
+}
+
+type unavailabilityListResponse struct {
+	Unavailability []api.UserUnavailability `json:"unavailability"`
+}
+
+// PostUsersUnavailabilityAdd добавляет период отсутствия пользователя
+// (POST /users/unavailability/add)
+func (s *Server) PostUsersUnavailabilityAdd(w http.ResponseWriter, r *http.Request) {
+	var req api.CreateUserUnavailabilityRequest
+	if !s.decodeJSON(w, r, &req) {
+		return
+	}
+
+	period, err := s.userService.CreateUnavailability(r.Context(), &req)
+	if err != nil {
+		s.handleServiceError(w, err)
+		return
+	}
+
+	s.writeJSON(w, http.StatusCreated, unavailabilityResponse{Unavailability: period})
+}
+
+// GetUsersUnavailabilityList получает периоды отсутствия
+// (GET /users/unavailability/list)
+func (s *Server) GetUsersUnavailabilityList(w http.ResponseWriter, r *http.Request, params api.GetUsersUnavailabilityListParams) {
+	periods, err := s.userService.ListUnavailability(r.Context(), &params)
+	if err != nil {
+		s.handleServiceError(w, err)
+		return
+	}
+
+	s.writeJSON(w, http.StatusOK, unavailabilityListResponse{Unavailability: periods})
+}
+
+// PostUsersUnavailabilityUpdate изменяет период отсутствия
+// (POST /users/unavailability/update)
+func (s *Server) PostUsersUnavailabilityUpdate(w http.ResponseWriter, r *http.Request) {
+	var req api.UpdateUserUnavailabilityRequest
+	if !s.decodeJSON(w, r, &req) {
+		return
+	}
+
+	period, err := s.userService.UpdateUnavailability(r.Context(), &req)
+	if err != nil {
+		s.handleServiceError(w, err)
+		return
+	}
+
+	s.writeJSON(w, http.StatusOK, unavailabilityResponse{Unavailability: period})
+}
+
+// PostUsersUnavailabilityDelete удаляет период отсутствия
+// (POST /users/unavailability/delete)
+func (s *Server) PostUsersUnavailabilityDelete(w http.ResponseWriter, r *http.Request) {
+	var req api.PostUsersUnavailabilityDeleteJSONRequestBody
+	if !s.decodeJSON(w, r, &req) {
+		return
+	}
+
+	if err := s.userService.DeleteUnavailability(r.Context(), req.UnavailabilityId); err != nil {
+		s.handleServiceError(w, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
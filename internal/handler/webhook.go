@@ -0,0 +1,164 @@
+package handler
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+
+	"pr-review-assigner/internal/api"
+	"pr-review-assigner/internal/service"
+)
+
+// maxWebhookBodySize максимальный размер тела вебхука (GitHub ограничивает payload 25 МБ, GitLab - 25 МБ по умолчанию)
+const maxWebhookBodySize = 25 << 20
+
+// WebhookProcessor применяет события PR из внешних систем
+type WebhookProcessor interface {
+	ProcessPullRequestEvent(ctx context.Context, event *service.PullRequestEvent) (*api.PullRequest, error)
+}
+
+// WebhookConfig секреты для проверки подлинности вебхуков
+type WebhookConfig struct {
+	// GitHubSecret секрет для проверки подписи X-Hub-Signature-256
+	GitHubSecret string
+	// GitLabToken секретный токен, который GitLab передает в заголовке X-Gitlab-Token
+	GitLabToken string
+}
+
+// WithWebhooks включает обработку вебхуков внешних систем
+func WithWebhooks(processor WebhookProcessor, cfg WebhookConfig) ServerOption {
+	return func(s *Server) {
+		s.webhooks = processor
+		s.webhookConfig = cfg
+	}
+}
+
+type webhookStatusResponse struct {
+	Status string `json:"status"`
+}
+
+// githubPullRequestPayload поля события pull_request GitHub, используемые сервисом
+type githubPullRequestPayload struct {
+	Action      string `json:"action"`
+	PullRequest struct {
+		Number int    `json:"number"`
+		Title  string `json:"title"`
+		Draft  bool   `json:"draft"`
+		Merged bool   `json:"merged"`
+		User   struct {
+			Login string `json:"login"`
+		} `json:"user"`
+	} `json:"pull_request"`
+	Repository struct {
+		FullName string `json:"full_name"`
+	} `json:"repository"`
+}
+
+// PostWebhookGitHub принимает события pull_request из GitHub
+// (POST /webhooks/github)
+func (s *Server) PostWebhookGitHub(w http.ResponseWriter, r *http.Request) {
+	if s.webhooks == nil || s.webhookConfig.GitHubSecret == "" {
+		s.writeError(w, http.StatusNotFound, api.NOTFOUND, "github webhook is not configured")
+		return
+	}
+
+	body, err := io.ReadAll(http.MaxBytesReader(w, r.Body, maxWebhookBodySize))
+	if err != nil {
+		s.writeError(w, http.StatusBadRequest, api.INVALIDREQUEST, "failed to read request body")
+		return
+	}
+
+	if !verifyGitHubSignature(s.webhookConfig.GitHubSecret, body, r.Header.Get("X-Hub-Signature-256")) {
+		s.writeError(w, http.StatusUnauthorized, api.UNAUTHORIZED, "invalid webhook signature")
+		return
+	}
+
+	switch r.Header.Get("X-GitHub-Event") {
+	case "pull_request":
+	case "ping":
+		s.writeJSON(w, http.StatusOK, webhookStatusResponse{Status: "pong"})
+		return
+	default:
+		s.writeJSON(w, http.StatusAccepted, webhookStatusResponse{Status: "ignored"})
+		return
+	}
+
+	event, err := parseGitHubPullRequestEvent(body)
+	if err != nil {
+		s.writeError(w, http.StatusBadRequest, api.INVALIDREQUEST, err.Error())
+		return
+	}
+	if event == nil {
+		s.writeJSON(w, http.StatusAccepted, webhookStatusResponse{Status: "ignored"})
+		return
+	}
+
+	pr, err := s.webhooks.ProcessPullRequestEvent(r.Context(), event)
+	if err != nil {
+		s.handleServiceError(w, err)
+		return
+	}
+
+	s.writeJSON(w, http.StatusOK, prResponse{PR: pr})
+}
+
+// verifyGitHubSignature проверяет подпись HMAC-SHA256 тела запроса (заголовок "sha256=<hex>")
+func verifyGitHubSignature(secret string, body []byte, header string) bool {
+	signature, ok := strings.CutPrefix(header, "sha256=")
+	if !ok {
+		return false
+	}
+	expected, err := hex.DecodeString(signature)
+	if err != nil {
+		return false
+	}
+
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write(body)
+	return hmac.Equal(mac.Sum(nil), expected)
+}
+
+// parseGitHubPullRequestEvent преобразует событие pull_request GitHub в событие сервиса
+// Для действий, которые сервис не обрабатывает, возвращает nil
+// Идентификатор PR - "<owner>/<repo>#<number>"
+func parseGitHubPullRequestEvent(body []byte) (*service.PullRequestEvent, error) {
+	var payload githubPullRequestPayload
+	if err := json.Unmarshal(body, &payload); err != nil {
+		return nil, fmt.Errorf("invalid pull_request payload: %w", err)
+	}
+	if payload.Repository.FullName == "" || payload.PullRequest.Number == 0 {
+		return nil, fmt.Errorf("invalid pull_request payload: repository and pull request number are required")
+	}
+
+	event := &service.PullRequestEvent{
+		Provider:        api.Github,
+		PullRequestID:   fmt.Sprintf("%s#%d", payload.Repository.FullName, payload.PullRequest.Number),
+		PullRequestName: payload.PullRequest.Title,
+		AuthorLogin:     payload.PullRequest.User.Login,
+		Draft:           payload.PullRequest.Draft,
+	}
+
+	switch payload.Action {
+	case "opened":
+		event.Action = service.PullRequestOpened
+	case "reopened":
+		event.Action = service.PullRequestReopened
+	case "closed":
+		event.Action = service.PullRequestClosed
+		if payload.PullRequest.Merged {
+			event.Action = service.PullRequestMerged
+		}
+	case "ready_for_review":
+		event.Action = service.PullRequestReadyForReview
+	default:
+		return nil, nil
+	}
+
+	return event, nil
+}
@@ -0,0 +1,126 @@
+package handler
+
+import (
+	"crypto/subtle"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+
+	"pr-review-assigner/internal/api"
+	"pr-review-assigner/internal/service"
+)
+
+// gitlabMergeRequestPayload поля события Merge Request Hook GitLab, используемые сервисом
+type gitlabMergeRequestPayload struct {
+	ObjectKind string `json:"object_kind"`
+	// User пользователь, выполнивший действие (для action=open - автор MR)
+	User struct {
+		Username string `json:"username"`
+	} `json:"user"`
+	Project struct {
+		PathWithNamespace string `json:"path_with_namespace"`
+	} `json:"project"`
+	ObjectAttributes struct {
+		IID    int    `json:"iid"`
+		Title  string `json:"title"`
+		Action string `json:"action"`
+		Draft  bool   `json:"draft"`
+	} `json:"object_attributes"`
+	Changes struct {
+		Draft *struct {
+			Previous bool `json:"previous"`
+			Current  bool `json:"current"`
+		} `json:"draft"`
+	} `json:"changes"`
+}
+
+// PostWebhookGitLab принимает события Merge Request Hook из GitLab
+// (POST /webhooks/gitlab)
+func (s *Server) PostWebhookGitLab(w http.ResponseWriter, r *http.Request) {
+	if s.webhooks == nil || s.webhookConfig.GitLabToken == "" {
+		s.writeError(w, http.StatusNotFound, api.NOTFOUND, "gitlab webhook is not configured")
+		return
+	}
+
+	token := r.Header.Get("X-Gitlab-Token")
+	if subtle.ConstantTimeCompare([]byte(token), []byte(s.webhookConfig.GitLabToken)) != 1 {
+		s.writeError(w, http.StatusUnauthorized, api.UNAUTHORIZED, "invalid webhook token")
+		return
+	}
+
+	if r.Header.Get("X-Gitlab-Event") != "Merge Request Hook" {
+		s.writeJSON(w, http.StatusAccepted, webhookStatusResponse{Status: "ignored"})
+		return
+	}
+
+	body, err := io.ReadAll(http.MaxBytesReader(w, r.Body, maxWebhookBodySize))
+	if err != nil {
+		s.writeError(w, http.StatusBadRequest, api.INVALIDREQUEST, "failed to read request body")
+		return
+	}
+
+	event, err := parseGitLabMergeRequestEvent(body)
+	if err != nil {
+		s.writeError(w, http.StatusBadRequest, api.INVALIDREQUEST, err.Error())
+		return
+	}
+	if event == nil {
+		s.writeJSON(w, http.StatusAccepted, webhookStatusResponse{Status: "ignored"})
+		return
+	}
+
+	pr, err := s.webhooks.ProcessPullRequestEvent(r.Context(), event)
+	if err != nil {
+		s.handleServiceError(w, err)
+		return
+	}
+
+	s.writeJSON(w, http.StatusOK, prResponse{PR: pr})
+}
+
+// parseGitLabMergeRequestEvent преобразует событие merge_request GitLab в событие сервиса
+// Для действий, которые сервис не обрабатывает, возвращает nil
+// Идентификатор PR - "<group>/<project>!<iid>"
+func parseGitLabMergeRequestEvent(body []byte) (*service.PullRequestEvent, error) {
+	var payload gitlabMergeRequestPayload
+	if err := json.Unmarshal(body, &payload); err != nil {
+		return nil, fmt.Errorf("invalid merge_request payload: %w", err)
+	}
+	if payload.ObjectKind != "merge_request" {
+		return nil, nil
+	}
+	if payload.Project.PathWithNamespace == "" || payload.ObjectAttributes.IID == 0 {
+		return nil, fmt.Errorf("invalid merge_request payload: project and merge request iid are required")
+	}
+
+	event := &service.PullRequestEvent{
+		Provider:        api.Gitlab,
+		PullRequestID:   fmt.Sprintf("%s!%d", payload.Project.PathWithNamespace, payload.ObjectAttributes.IID),
+		PullRequestName: payload.ObjectAttributes.Title,
+		AuthorLogin:     payload.User.Username,
+		Draft:           payload.ObjectAttributes.Draft,
+	}
+
+	switch payload.ObjectAttributes.Action {
+	case "open":
+		event.Action = service.PullRequestOpened
+	case "reopen":
+		event.Action = service.PullRequestReopened
+	case "close":
+		event.Action = service.PullRequestClosed
+	case "merge":
+		event.Action = service.PullRequestMerged
+	case "update":
+		// Снятие отметки Draft приходит как update с изменением поля draft
+		draft := payload.Changes.Draft
+		if draft == nil || !draft.Previous || draft.Current {
+			return nil, nil
+		}
+		event.Action = service.PullRequestReadyForReview
+	default:
+		return nil, nil
+	}
+
+	return event, nil
+}
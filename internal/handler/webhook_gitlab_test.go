@@ -0,0 +1,151 @@
+package handler
+
+import (
+	"bytes"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"pr-review-assigner/internal/api"
+	"pr-review-assigner/internal/service"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const testGitLabToken = "gitlab-hook-token"
+
+func newGitLabRequest(body []byte, event, token string) *http.Request {
+	req := httptest.NewRequest(http.MethodPost, "/webhooks/gitlab", bytes.NewReader(body))
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("X-Gitlab-Event", event)
+	req.Header.Set("X-Gitlab-Token", token)
+	return req
+}
+
+func TestPostWebhookGitLab_Actions(t *testing.T) {
+	tests := []struct {
+		fixture string
+		want    service.PullRequestEvent
+	}{
+		{
+			fixture: "gitlab/merge_request_open.json",
+			want: service.PullRequestEvent{
+				Provider:        api.Gitlab,
+				Action:          service.PullRequestOpened,
+				PullRequestID:   "platform/backend!17",
+				PullRequestName: "Add search endpoint",
+				AuthorLogin:     "Alice.Lab",
+			},
+		},
+		{
+			fixture: "gitlab/merge_request_open_draft.json",
+			want: service.PullRequestEvent{
+				Provider:        api.Gitlab,
+				Action:          service.PullRequestOpened,
+				PullRequestID:   "platform/backend!18",
+				PullRequestName: "Draft: search ranking",
+				AuthorLogin:     "Alice.Lab",
+				Draft:           true,
+			},
+		},
+		{
+			fixture: "gitlab/merge_request_reopen.json",
+			want: service.PullRequestEvent{
+				Provider:        api.Gitlab,
+				Action:          service.PullRequestReopened,
+				PullRequestID:   "platform/backend!17",
+				PullRequestName: "Add search endpoint",
+				AuthorLogin:     "Alice.Lab",
+			},
+		},
+		{
+			fixture: "gitlab/merge_request_close.json",
+			want: service.PullRequestEvent{
+				Provider:        api.Gitlab,
+				Action:          service.PullRequestClosed,
+				PullRequestID:   "platform/backend!17",
+				PullRequestName: "Add search endpoint",
+				AuthorLogin:     "Alice.Lab",
+			},
+		},
+		{
+			fixture: "gitlab/merge_request_merge.json",
+			want: service.PullRequestEvent{
+				Provider:        api.Gitlab,
+				Action:          service.PullRequestMerged,
+				PullRequestID:   "platform/backend!17",
+				PullRequestName: "Add search endpoint",
+				AuthorLogin:     "Alice.Lab",
+			},
+		},
+		{
+			fixture: "gitlab/merge_request_update_ready.json",
+			want: service.PullRequestEvent{
+				Provider:        api.Gitlab,
+				Action:          service.PullRequestReadyForReview,
+				PullRequestID:   "platform/backend!18",
+				PullRequestName: "Search ranking",
+				AuthorLogin:     "Alice.Lab",
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.fixture, func(t *testing.T) {
+			processor := &fakeWebhookProcessor{}
+			server := NewServer(nil, nil, nil, nil, WithWebhooks(processor, WebhookConfig{GitLabToken: testGitLabToken}))
+
+			rec := httptest.NewRecorder()
+			server.PostWebhookGitLab(rec, newGitLabRequest(loadFixture(t, tt.fixture), "Merge Request Hook", testGitLabToken))
+
+			assert.Equal(t, http.StatusOK, rec.Code)
+			require.Len(t, processor.events, 1)
+			assert.Equal(t, tt.want, *processor.events[0])
+		})
+	}
+}
+
+func TestPostWebhookGitLab_InvalidToken(t *testing.T) {
+	processor := &fakeWebhookProcessor{}
+	server := NewServer(nil, nil, nil, nil, WithWebhooks(processor, WebhookConfig{GitLabToken: testGitLabToken}))
+	body := loadFixture(t, "gitlab/merge_request_open.json")
+
+	rec := httptest.NewRecorder()
+	server.PostWebhookGitLab(rec, newGitLabRequest(body, "Merge Request Hook", "wrong-token"))
+	assert.Equal(t, http.StatusUnauthorized, rec.Code)
+
+	rec = httptest.NewRecorder()
+	server.PostWebhookGitLab(rec, newGitLabRequest(body, "Merge Request Hook", ""))
+	assert.Equal(t, http.StatusUnauthorized, rec.Code)
+
+	assert.Empty(t, processor.events)
+}
+
+func TestPostWebhookGitLab_IgnoredEvents(t *testing.T) {
+	processor := &fakeWebhookProcessor{}
+	server := NewServer(nil, nil, nil, nil, WithWebhooks(processor, WebhookConfig{GitLabToken: testGitLabToken}))
+
+	for _, fixture := range []string{"gitlab/merge_request_update_title.json", "gitlab/merge_request_approved.json"} {
+		rec := httptest.NewRecorder()
+		server.PostWebhookGitLab(rec, newGitLabRequest(loadFixture(t, fixture), "Merge Request Hook", testGitLabToken))
+		assert.Equal(t, http.StatusAccepted, rec.Code, fixture)
+	}
+
+	// Событие другого типа
+	rec := httptest.NewRecorder()
+	server.PostWebhookGitLab(rec, newGitLabRequest([]byte(`{"object_kind":"push"}`), "Push Hook", testGitLabToken))
+	assert.Equal(t, http.StatusAccepted, rec.Code)
+
+	assert.Empty(t, processor.events)
+}
+
+func TestPostWebhookGitLab_NotConfigured(t *testing.T) {
+	// Настроен только GitHub - пустой токен GitLab не должен приниматься
+	server := NewServer(nil, nil, nil, nil, WithWebhooks(&fakeWebhookProcessor{}, WebhookConfig{GitHubSecret: testGitHubSecret}))
+
+	rec := httptest.NewRecorder()
+	server.PostWebhookGitLab(rec, newGitLabRequest(loadFixture(t, "gitlab/merge_request_open.json"), "Merge Request Hook", ""))
+
+	assert.Equal(t, http.StatusNotFound, rec.Code)
+}
@@ -0,0 +1,210 @@
+package handler
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"pr-review-assigner/internal/api"
+	"pr-review-assigner/internal/service"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const testGitHubSecret = "It's a Secret to Everybody"
+
+// fakeWebhookProcessor запоминает полученные события
+type fakeWebhookProcessor struct {
+	events []*service.PullRequestEvent
+	err    error
+}
+
+func (p *fakeWebhookProcessor) ProcessPullRequestEvent(_ context.Context, event *service.PullRequestEvent) (*api.PullRequest, error) {
+	p.events = append(p.events, event)
+	if p.err != nil {
+		return nil, p.err
+	}
+	return &api.PullRequest{PullRequestId: event.PullRequestID, Status: api.PullRequestStatusOPEN}, nil
+}
+
+func loadFixture(t *testing.T, name string) []byte {
+	t.Helper()
+	body, err := os.ReadFile(filepath.Join("testdata", name))
+	require.NoError(t, err)
+	return body
+}
+
+func signGitHub(secret string, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write(body)
+	return "sha256=" + hex.EncodeToString(mac.Sum(nil))
+}
+
+func newGitHubRequest(body []byte, event, signature string) *http.Request {
+	req := httptest.NewRequest(http.MethodPost, "/webhooks/github", bytes.NewReader(body))
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("X-GitHub-Event", event)
+	req.Header.Set("X-Hub-Signature-256", signature)
+	return req
+}
+
+func TestVerifyGitHubSignature_KnownVector(t *testing.T) {
+	// Пример из документации GitHub по проверке вебхуков
+	signature := "sha256=757107ea0eb2509fc211221cce984b8a37570b6d7586c22c46f4379c8b043e17"
+
+	assert.True(t, verifyGitHubSignature(testGitHubSecret, []byte("Hello, World!"), signature))
+	assert.False(t, verifyGitHubSignature(testGitHubSecret, []byte("Hello, World?"), signature))
+	assert.False(t, verifyGitHubSignature(testGitHubSecret, []byte("Hello, World!"), "sha1=757107ea"))
+}
+
+func TestPostWebhookGitHub_Actions(t *testing.T) {
+	tests := []struct {
+		fixture string
+		want    service.PullRequestEvent
+	}{
+		{
+			fixture: "github/pull_request_opened.json",
+			want: service.PullRequestEvent{
+				Provider:        api.Github,
+				Action:          service.PullRequestOpened,
+				PullRequestID:   "octo-org/backend#42",
+				PullRequestName: "Add search endpoint",
+				AuthorLogin:     "Alice-GH",
+			},
+		},
+		{
+			fixture: "github/pull_request_opened_draft.json",
+			want: service.PullRequestEvent{
+				Provider:        api.Github,
+				Action:          service.PullRequestOpened,
+				PullRequestID:   "octo-org/backend#43",
+				PullRequestName: "WIP: search ranking",
+				AuthorLogin:     "Alice-GH",
+				Draft:           true,
+			},
+		},
+		{
+			fixture: "github/pull_request_reopened.json",
+			want: service.PullRequestEvent{
+				Provider:        api.Github,
+				Action:          service.PullRequestReopened,
+				PullRequestID:   "octo-org/backend#42",
+				PullRequestName: "Add search endpoint",
+				AuthorLogin:     "Alice-GH",
+			},
+		},
+		{
+			fixture: "github/pull_request_closed.json",
+			want: service.PullRequestEvent{
+				Provider:        api.Github,
+				Action:          service.PullRequestClosed,
+				PullRequestID:   "octo-org/backend#42",
+				PullRequestName: "Add search endpoint",
+				AuthorLogin:     "Alice-GH",
+			},
+		},
+		{
+			fixture: "github/pull_request_closed_merged.json",
+			want: service.PullRequestEvent{
+				Provider:        api.Github,
+				Action:          service.PullRequestMerged,
+				PullRequestID:   "octo-org/backend#42",
+				PullRequestName: "Add search endpoint",
+				AuthorLogin:     "Alice-GH",
+			},
+		},
+		{
+			fixture: "github/pull_request_ready_for_review.json",
+			want: service.PullRequestEvent{
+				Provider:        api.Github,
+				Action:          service.PullRequestReadyForReview,
+				PullRequestID:   "octo-org/backend#43",
+				PullRequestName: "Search ranking",
+				AuthorLogin:     "Alice-GH",
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.fixture, func(t *testing.T) {
+			processor := &fakeWebhookProcessor{}
+			server := NewServer(nil, nil, nil, nil, WithWebhooks(processor, WebhookConfig{GitHubSecret: testGitHubSecret}))
+			body := loadFixture(t, tt.fixture)
+
+			rec := httptest.NewRecorder()
+			server.PostWebhookGitHub(rec, newGitHubRequest(body, "pull_request", signGitHub(testGitHubSecret, body)))
+
+			assert.Equal(t, http.StatusOK, rec.Code)
+			require.Len(t, processor.events, 1)
+			assert.Equal(t, tt.want, *processor.events[0])
+		})
+	}
+}
+
+func TestPostWebhookGitHub_InvalidSignature(t *testing.T) {
+	processor := &fakeWebhookProcessor{}
+	server := NewServer(nil, nil, nil, nil, WithWebhooks(processor, WebhookConfig{GitHubSecret: testGitHubSecret}))
+	body := loadFixture(t, "github/pull_request_opened.json")
+
+	rec := httptest.NewRecorder()
+	server.PostWebhookGitHub(rec, newGitHubRequest(body, "pull_request", signGitHub("wrong secret", body)))
+
+	assert.Equal(t, http.StatusUnauthorized, rec.Code)
+	assert.Contains(t, rec.Body.String(), string(api.UNAUTHORIZED))
+	assert.Empty(t, processor.events)
+}
+
+func TestPostWebhookGitHub_IgnoredEvents(t *testing.T) {
+	processor := &fakeWebhookProcessor{}
+	server := NewServer(nil, nil, nil, nil, WithWebhooks(processor, WebhookConfig{GitHubSecret: testGitHubSecret}))
+
+	// Неподдерживаемое действие pull_request
+	labeled := loadFixture(t, "github/pull_request_labeled.json")
+	rec := httptest.NewRecorder()
+	server.PostWebhookGitHub(rec, newGitHubRequest(labeled, "pull_request", signGitHub(testGitHubSecret, labeled)))
+	assert.Equal(t, http.StatusAccepted, rec.Code)
+
+	// Событие другого типа
+	push := []byte(`{"ref":"refs/heads/main"}`)
+	rec = httptest.NewRecorder()
+	server.PostWebhookGitHub(rec, newGitHubRequest(push, "push", signGitHub(testGitHubSecret, push)))
+	assert.Equal(t, http.StatusAccepted, rec.Code)
+
+	// ping при создании вебхука
+	ping := []byte(`{"zen":"Keep it logically awesome.","hook_id":1}`)
+	rec = httptest.NewRecorder()
+	server.PostWebhookGitHub(rec, newGitHubRequest(ping, "ping", signGitHub(testGitHubSecret, ping)))
+	assert.Equal(t, http.StatusOK, rec.Code)
+
+	assert.Empty(t, processor.events)
+}
+
+func TestPostWebhookGitHub_ServiceError(t *testing.T) {
+	// Логин автора не связан с пользователем
+	processor := &fakeWebhookProcessor{err: service.ErrNotFound}
+	server := NewServer(nil, nil, nil, nil, WithWebhooks(processor, WebhookConfig{GitHubSecret: testGitHubSecret}))
+	body := loadFixture(t, "github/pull_request_opened.json")
+
+	rec := httptest.NewRecorder()
+	server.PostWebhookGitHub(rec, newGitHubRequest(body, "pull_request", signGitHub(testGitHubSecret, body)))
+
+	assert.Equal(t, http.StatusNotFound, rec.Code)
+}
+
+func TestPostWebhookGitHub_NotConfigured(t *testing.T) {
+	server := NewServer(nil, nil, nil, nil)
+	body := loadFixture(t, "github/pull_request_opened.json")
+
+	rec := httptest.NewRecorder()
+	server.PostWebhookGitHub(rec, newGitHubRequest(body, "pull_request", signGitHub("", body)))
+
+	assert.Equal(t, http.StatusNotFound, rec.Code)
+}
@@ -0,0 +1,311 @@
+// Package migrate применяет встроенные в бинарный файл миграции схемы
+// Миграции записываются в формате golang-migrate (NNNNNN_name.up.sql и NNNNNN_name.down.sql),
+// а версия хранится в таблице schema_migrations в том же виде, поэтому база,
+// размеченная контейнером migrate, продолжает обслуживаться этим пакетом и наоборот
+package migrate
+
+import (
+	"cmp"
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"io/fs"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+// ErrDirty возвращается, если предыдущая миграция (например, запущенная golang-migrate) не завершилась
+var ErrDirty = errors.New("schema is dirty")
+
+// Dialect запросы к schema_migrations, зависящие от СУБД
+type Dialect struct {
+	name string
+	// tableExists проверяет наличие schema_migrations без ее создания
+	tableExists string
+	createTable string
+	setVersion  string
+	// lock и unlock сериализуют миграции нескольких экземпляров сервиса (пустые, если не нужны)
+	lock   string
+	unlock string
+}
+
+// Postgres диалект PostgreSQL; на время миграции берется advisory lock
+var Postgres = Dialect{
+	name:        "postgres",
+	tableExists: `SELECT to_regclass('schema_migrations') IS NOT NULL`,
+	createTable: `CREATE TABLE IF NOT EXISTS schema_migrations (version bigint NOT NULL PRIMARY KEY, dirty boolean NOT NULL)`,
+	setVersion:  `INSERT INTO schema_migrations (version, dirty) VALUES ($1, false)`,
+	lock:        `SELECT pg_advisory_lock(7345218093)`,
+	unlock:      `SELECT pg_advisory_unlock(7345218093)`,
+}
+
+// SQLite диалект SQLite; запись и так сериализуется блокировкой базы
+var SQLite = Dialect{
+	name:        "sqlite",
+	tableExists: `SELECT EXISTS(SELECT 1 FROM sqlite_master WHERE type = 'table' AND name = 'schema_migrations')`,
+	createTable: `CREATE TABLE IF NOT EXISTS schema_migrations (version INTEGER NOT NULL PRIMARY KEY, dirty BOOLEAN NOT NULL)`,
+	setVersion:  `INSERT INTO schema_migrations (version, dirty) VALUES (?, 0)`,
+}
+
+// Migration одна версия схемы
+type Migration struct {
+	Version int64
+	Name    string
+	up      string
+	down    string
+}
+
+// Status состояние схемы относительно миграций, встроенных в бинарный файл
+type Status struct {
+	// Current примененная версия (0, если миграции не применялись)
+	Current int64
+	Dirty   bool
+	// Latest последняя известная коду версия
+	Latest int64
+	// Pending миграции, которые еще не применены
+	Pending []Migration
+}
+
+// Behind сообщает, что схема старше, чем ожидает код
+func (s *Status) Behind() bool {
+	return s.Current < s.Latest
+}
+
+// Migrator применяет и откатывает миграции из files
+type Migrator struct {
+	db      *sql.DB
+	files   fs.FS
+	dialect Dialect
+}
+
+// New создает новый экземпляр мигратора
+// files содержит файлы миграций в корне (см. fs.Sub для встроенных подкаталогов)
+func New(db *sql.DB, files fs.FS, dialect Dialect) *Migrator {
+	return &Migrator{db: db, files: files, dialect: dialect}
+}
+
+// Migrations возвращает миграции, упорядоченные по версии
+func (m *Migrator) Migrations() ([]Migration, error) {
+	entries, err := fs.ReadDir(m.files, ".")
+	if err != nil {
+		return nil, fmt.Errorf("failed to read migrations: %w", err)
+	}
+
+	byVersion := make(map[int64]*Migration)
+	for _, entry := range entries {
+		base, direction, ok := strings.Cut(strings.TrimSuffix(entry.Name(), ".sql"), ".")
+		if entry.IsDir() || !strings.HasSuffix(entry.Name(), ".sql") || !ok {
+			continue
+		}
+		prefix, name, _ := strings.Cut(base, "_")
+		version, err := strconv.ParseInt(prefix, 10, 64)
+		if err != nil || version <= 0 {
+			return nil, fmt.Errorf("invalid migration file name %q", entry.Name())
+		}
+
+		migration, exists := byVersion[version]
+		if !exists {
+			migration = &Migration{Version: version, Name: name}
+			byVersion[version] = migration
+		}
+		switch direction {
+		case "up":
+			migration.up = entry.Name()
+		case "down":
+			migration.down = entry.Name()
+		default:
+			return nil, fmt.Errorf("invalid migration file name %q", entry.Name())
+		}
+	}
+
+	migrations := make([]Migration, 0, len(byVersion))
+	for _, migration := range byVersion {
+		if migration.up == "" {
+			return nil, fmt.Errorf("migration %d has no up file", migration.Version)
+		}
+		migrations = append(migrations, *migration)
+	}
+	slices.SortFunc(migrations, func(a, b Migration) int {
+		return cmp.Compare(a.Version, b.Version)
+	})
+	return migrations, nil
+}
+
+// Status возвращает текущую версию схемы и список непримененных миграций
+// Таблица schema_migrations при этом не создается
+func (m *Migrator) Status(ctx context.Context) (*Status, error) {
+	migrations, err := m.Migrations()
+	if err != nil {
+		return nil, err
+	}
+
+	status := &Status{}
+	if len(migrations) > 0 {
+		status.Latest = migrations[len(migrations)-1].Version
+	}
+
+	var exists bool
+	if err = m.db.QueryRowContext(ctx, m.dialect.tableExists).Scan(&exists); err != nil {
+		return nil, fmt.Errorf("failed to check schema_migrations: %w", err)
+	}
+	if exists {
+		if status.Current, status.Dirty, err = readVersion(ctx, m.db); err != nil {
+			return nil, err
+		}
+	}
+
+	for _, migration := range migrations {
+		if migration.Version > status.Current {
+			status.Pending = append(status.Pending, migration)
+		}
+	}
+	return status, nil
+}
+
+// Up применяет все непримененные миграции и возвращает их
+// Каждая миграция выполняется в своей транзакции вместе с обновлением версии
+func (m *Migrator) Up(ctx context.Context) ([]Migration, error) {
+	migrations, err := m.Migrations()
+	if err != nil {
+		return nil, err
+	}
+
+	var applied []Migration
+	err = m.withLock(ctx, func() error {
+		for _, migration := range migrations {
+			done, err := m.step(ctx, migration.up, func(current int64) (int64, bool) {
+				return migration.Version, current < migration.Version
+			})
+			if err != nil {
+				return fmt.Errorf("migration %d_%s: %w", migration.Version, migration.Name, err)
+			}
+			if done {
+				applied = append(applied, migration)
+			}
+		}
+		return nil
+	})
+	return applied, err
+}
+
+// Down откатывает последние steps миграций и возвращает откаченные
+func (m *Migrator) Down(ctx context.Context, steps int) ([]Migration, error) {
+	migrations, err := m.Migrations()
+	if err != nil {
+		return nil, err
+	}
+
+	var reverted []Migration
+	err = m.withLock(ctx, func() error {
+		for i := len(migrations) - 1; i >= 0 && len(reverted) < steps; i-- {
+			migration := migrations[i]
+			var previous int64
+			if i > 0 {
+				previous = migrations[i-1].Version
+			}
+			if migration.down == "" {
+				return fmt.Errorf("migration %d_%s has no down file", migration.Version, migration.Name)
+			}
+
+			done, err := m.step(ctx, migration.down, func(current int64) (int64, bool) {
+				return previous, current == migration.Version
+			})
+			if err != nil {
+				return fmt.Errorf("migration %d_%s: %w", migration.Version, migration.Name, err)
+			}
+			if done {
+				reverted = append(reverted, migration)
+			}
+		}
+		return nil
+	})
+	return reverted, err
+}
+
+// step выполняет файл миграции, если needed разрешает это для текущей версии, и записывает новую версию
+// Версия читается в той же транзакции, поэтому параллельный запуск не применит миграцию дважды
+func (m *Migrator) step(ctx context.Context, file string, needed func(current int64) (next int64, ok bool)) (bool, error) {
+	tx, err := m.db.BeginTx(ctx, nil)
+	if err != nil {
+		return false, err
+	}
+	defer tx.Rollback()
+
+	current, dirty, err := readVersion(ctx, tx)
+	if err != nil {
+		return false, err
+	}
+	if dirty {
+		return false, fmt.Errorf("%w at version %d, fix the database and reset schema_migrations manually", ErrDirty, current)
+	}
+	next, ok := needed(current)
+	if !ok {
+		return false, nil
+	}
+
+	script, err := fs.ReadFile(m.files, file)
+	if err != nil {
+		return false, fmt.Errorf("failed to read %s: %w", file, err)
+	}
+	if _, err = tx.ExecContext(ctx, string(script)); err != nil {
+		return false, err
+	}
+	if _, err = tx.ExecContext(ctx, `DELETE FROM schema_migrations`); err != nil {
+		return false, err
+	}
+	// Как и golang-migrate, после отката первой миграции таблица версий остается пустой
+	if next > 0 {
+		if _, err = tx.ExecContext(ctx, m.dialect.setVersion, next); err != nil {
+			return false, err
+		}
+	}
+
+	if err = tx.Commit(); err != nil {
+		return false, err
+	}
+	return true, nil
+}
+
+// withLock создает schema_migrations и выполняет fn под блокировкой диалекта
+func (m *Migrator) withLock(ctx context.Context, fn func() error) error {
+	if _, err := m.db.ExecContext(ctx, m.dialect.createTable); err != nil {
+		return fmt.Errorf("failed to create schema_migrations: %w", err)
+	}
+	if m.dialect.lock == "" {
+		return fn()
+	}
+
+	// Advisory lock принадлежит сессии, поэтому берется и снимается на одном соединении
+	conn, err := m.db.Conn(ctx)
+	if err != nil {
+		return err
+	}
+	defer conn.Close()
+	if _, err = conn.ExecContext(ctx, m.dialect.lock); err != nil {
+		return fmt.Errorf("failed to lock %s migrations: %w", m.dialect.name, err)
+	}
+	defer conn.ExecContext(context.WithoutCancel(ctx), m.dialect.unlock)
+
+	return fn()
+}
+
+// queryRower общий интерфейс для *sql.DB и *sql.Tx
+type queryRower interface {
+	QueryRowContext(ctx context.Context, query string, args ...any) *sql.Row
+}
+
+// readVersion читает версию схемы; пустая таблица означает версию 0
+func readVersion(ctx context.Context, db queryRower) (int64, bool, error) {
+	var version int64
+	var dirty bool
+	err := db.QueryRowContext(ctx, `SELECT version, dirty FROM schema_migrations LIMIT 1`).Scan(&version, &dirty)
+	if errors.Is(err, sql.ErrNoRows) {
+		return 0, false, nil
+	}
+	if err != nil {
+		return 0, false, fmt.Errorf("failed to read schema version: %w", err)
+	}
+	return version, dirty, nil
+}
@@ -0,0 +1,170 @@
+package migrate
+
+import (
+	"context"
+	"database/sql"
+	"path/filepath"
+	"testing"
+	"testing/fstest"
+
+	"pr-review-assigner/migrations"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	_ "modernc.org/sqlite"
+)
+
+func testFiles() fstest.MapFS {
+	return fstest.MapFS{
+		"000001_users.up.sql":     {Data: []byte(`CREATE TABLE users (id TEXT PRIMARY KEY);`)},
+		"000001_users.down.sql":   {Data: []byte(`DROP TABLE users;`)},
+		"000002_teams.up.sql":     {Data: []byte(`CREATE TABLE teams (name TEXT PRIMARY KEY); CREATE INDEX idx_teams ON teams(name);`)},
+		"000002_teams.down.sql":   {Data: []byte(`DROP TABLE teams;`)},
+		"000003_invalid.up.sql":   {Data: []byte(`CREATE TABLE broken (`)},
+		"000003_invalid.down.sql": {Data: []byte(``)},
+		"README.md":               {Data: []byte(`not a migration`)},
+	}
+}
+
+func newTestDB(t *testing.T) *sql.DB {
+	t.Helper()
+	db, err := sql.Open("sqlite", filepath.Join(t.TempDir(), "test.db"))
+	require.NoError(t, err)
+	t.Cleanup(func() { db.Close() })
+	return db
+}
+
+func tableExists(t *testing.T, db *sql.DB, name string) bool {
+	t.Helper()
+	var exists bool
+	require.NoError(t, db.QueryRow(`SELECT EXISTS(SELECT 1 FROM sqlite_master WHERE type = 'table' AND name = ?)`, name).Scan(&exists))
+	return exists
+}
+
+func TestMigrator_Migrations(t *testing.T) {
+	migrator := New(nil, testFiles(), SQLite)
+
+	migrations, err := migrator.Migrations()
+	require.NoError(t, err)
+	require.Len(t, migrations, 3)
+	assert.Equal(t, int64(1), migrations[0].Version)
+	assert.Equal(t, "users", migrations[0].Name)
+	assert.Equal(t, int64(3), migrations[2].Version)
+
+	files := testFiles()
+	files["000004_orphan.down.sql"] = &fstest.MapFile{}
+	_, err = New(nil, files, SQLite).Migrations()
+	assert.Error(t, err)
+}
+
+func TestMigrator_StatusOnEmptyDatabase(t *testing.T) {
+	db := newTestDB(t)
+	files := testFiles()
+	delete(files, "000003_invalid.up.sql")
+	delete(files, "000003_invalid.down.sql")
+
+	status, err := New(db, files, SQLite).Status(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, int64(0), status.Current)
+	assert.Equal(t, int64(2), status.Latest)
+	assert.Len(t, status.Pending, 2)
+	assert.True(t, status.Behind())
+	// Status не создает таблицу версий
+	assert.False(t, tableExists(t, db, "schema_migrations"))
+}
+
+func TestMigrator_UpStopsAtFailedMigration(t *testing.T) {
+	db := newTestDB(t)
+	migrator := New(db, testFiles(), SQLite)
+	ctx := context.Background()
+
+	applied, err := migrator.Up(ctx)
+	assert.ErrorContains(t, err, "migration 3_invalid")
+	require.Len(t, applied, 2)
+
+	// Неудачная миграция откатывается целиком и не помечает схему dirty
+	status, err := migrator.Status(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, int64(2), status.Current)
+	assert.False(t, status.Dirty)
+	assert.True(t, status.Behind())
+	assert.True(t, tableExists(t, db, "teams"))
+}
+
+func TestMigrator_UpIsIdempotent(t *testing.T) {
+	db := newTestDB(t)
+	files := testFiles()
+	delete(files, "000003_invalid.up.sql")
+	delete(files, "000003_invalid.down.sql")
+	migrator := New(db, files, SQLite)
+	ctx := context.Background()
+
+	applied, err := migrator.Up(ctx)
+	require.NoError(t, err)
+	assert.Len(t, applied, 2)
+	applied, err = migrator.Up(ctx)
+	require.NoError(t, err)
+	assert.Empty(t, applied)
+
+	status, err := migrator.Status(ctx)
+	require.NoError(t, err)
+	assert.False(t, status.Behind())
+	assert.Empty(t, status.Pending)
+}
+
+func TestMigrator_Down(t *testing.T) {
+	db := newTestDB(t)
+	files := testFiles()
+	delete(files, "000003_invalid.up.sql")
+	delete(files, "000003_invalid.down.sql")
+	migrator := New(db, files, SQLite)
+	ctx := context.Background()
+	_, err := migrator.Up(ctx)
+	require.NoError(t, err)
+
+	reverted, err := migrator.Down(ctx, 1)
+	require.NoError(t, err)
+	require.Len(t, reverted, 1)
+	assert.Equal(t, int64(2), reverted[0].Version)
+	assert.False(t, tableExists(t, db, "teams"))
+	status, err := migrator.Status(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, int64(1), status.Current)
+
+	// Откат больше, чем применено, останавливается на пустой схеме
+	reverted, err = migrator.Down(ctx, 5)
+	require.NoError(t, err)
+	assert.Len(t, reverted, 1)
+	assert.False(t, tableExists(t, db, "users"))
+	var rows int
+	require.NoError(t, db.QueryRow(`SELECT COUNT(*) FROM schema_migrations`).Scan(&rows))
+	assert.Zero(t, rows)
+}
+
+func TestMigrator_DirtySchema(t *testing.T) {
+	db := newTestDB(t)
+	migrator := New(db, testFiles(), SQLite)
+	ctx := context.Background()
+
+	// Так оставляет таблицу версий golang-migrate после сбоя миграции
+	_, err := db.Exec(SQLite.createTable)
+	require.NoError(t, err)
+	_, err = db.Exec(`INSERT INTO schema_migrations (version, dirty) VALUES (1, 1)`)
+	require.NoError(t, err)
+
+	status, err := migrator.Status(ctx)
+	require.NoError(t, err)
+	assert.True(t, status.Dirty)
+	_, err = migrator.Up(ctx)
+	assert.ErrorIs(t, err, ErrDirty)
+}
+
+func TestPostgresMigrationsAreWellFormed(t *testing.T) {
+	list, err := New(nil, migrations.FS, Postgres).Migrations()
+	require.NoError(t, err)
+	require.NotEmpty(t, list)
+	for i, migration := range list {
+		assert.Equal(t, int64(i+1), migration.Version, "migration versions must be sequential")
+		assert.NotEmpty(t, migration.down, "migration %d has no down file", migration.Version)
+	}
+}
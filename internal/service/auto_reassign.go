@@ -0,0 +1,164 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"time"
+
+	"pr-review-assigner/internal/api"
+	"pr-review-assigner/internal/storage"
+)
+
+// AutoReassignTimedOut заменяет ревьюверов, не давших вердикт за auto_reassign_after команды автора PR,
+// другими активными участниками их команды (или резервных пулов команды) и возвращает количество замен
+// Снятый ревьювер записывается в историю PR и больше не назначается на этот PR автоматически;
+// если замены нет, ревьювер остается назначенным
+func (s *PRService) AutoReassignTimedOut(ctx context.Context) (int, error) {
+	now := s.deps.now().UTC()
+	timeouts, err := s.prRepo.GetReviewTimeouts(ctx, now)
+	if err != nil {
+		return 0, err
+	}
+
+	// Лимит проверяется и по заменам, сделанным в этом проходе
+	reassignments := make(map[string]int)
+	reassigned := 0
+	for _, timeout := range timeouts {
+		if _, ok := reassignments[timeout.PullRequestID]; !ok {
+			reassignments[timeout.PullRequestID] = timeout.AutoReassignments
+		}
+		if reassignments[timeout.PullRequestID] >= timeout.MaxAutoReassignments {
+			continue
+		}
+
+		newUserID, err := s.autoReassignReviewer(ctx, &timeout, now)
+		if err != nil {
+			return reassigned, err
+		}
+		if newUserID != "" {
+			reassignments[timeout.PullRequestID]++
+			reassigned++
+		}
+	}
+	return reassigned, nil
+}
+
+// autoReassignReviewer заменяет ревьювера по истекшему назначению и возвращает нового ревьювера
+// Пустой результат без ошибки: PR изменился с момента выборки или подходящих кандидатов нет
+func (s *PRService) autoReassignReviewer(ctx context.Context, timeout *storage.ReviewTimeout, now time.Time) (string, error) {
+	pr, err := s.prRepo.GetPR(ctx, timeout.PullRequestID)
+	if errors.Is(err, storage.ErrNotFound) {
+		return "", nil
+	}
+	if err != nil {
+		return "", err
+	}
+	if pr.Status != api.PullRequestStatusOPEN || !slices.Contains(pr.AssignedReviewers, timeout.ReviewerID) {
+		return "", nil
+	}
+
+	// Ревьюверы, уже снятые с PR автоматически, повторно не назначаются
+	autoReassigned, err := autoReassignedReviewers(ctx, s.prRepo, pr.PullRequestId)
+	if err != nil {
+		return "", err
+	}
+	excludeUserIDs := append([]string{pr.AuthorId}, pr.AssignedReviewers...)
+	excludeUserIDs = append(excludeUserIDs, autoReassigned...)
+
+	oldReviewer, err := s.userRepo.GetUser(ctx, timeout.ReviewerID)
+	if err != nil {
+		return "", err
+	}
+	candidates, err := s.userRepo.GetActiveUsersByTeam(ctx, oldReviewer.TeamName, timeout.ReviewerID, now)
+	if err != nil {
+		return "", err
+	}
+	availableCandidates := filterCandidates(candidates, excludeUserIDs...)
+
+	selector := s.deps.selectors.ForTeam(oldReviewer.TeamName)
+	newReviewerIDs, err := selector.Select(ctx, availableCandidates, 1)
+	if err != nil {
+		return "", err
+	}
+	var newUserID string
+	if len(newReviewerIDs) > 0 {
+		newUserID = newReviewerIDs[0]
+	} else {
+		// В команде замены нет - ищем ее в резервных пулах команды
+		newUserID, err = pickFromFallbackPools(ctx, s.teamRepo, s.userRepo, selector, oldReviewer.TeamName, now, excludeUserIDs...)
+		if err != nil || newUserID == "" {
+			return "", err
+		}
+	}
+
+	// Замена и запись в историю фиксируются вместе
+	err = s.deps.txManager.WithTx(ctx, func(repos storage.Repositories) error {
+		if _, err := repos.PRs.ReassignReviewer(ctx, pr.PullRequestId, timeout.ReviewerID, newUserID); err != nil {
+			return err
+		}
+		return repos.PRs.AddPRHistory(ctx, pr.PullRequestId, &api.PullRequestHistoryEntry{
+			Action:        api.PRHistoryAutoReassigned,
+			OldReviewerId: timeout.ReviewerID,
+			NewReviewerId: newUserID,
+			CreatedAt:     now,
+		})
+	})
+	if errors.Is(err, storage.ErrNotFound) {
+		// Ревьювера уже сняли с PR параллельно
+		return "", nil
+	}
+	if err != nil {
+		return "", err
+	}
+	return newUserID, nil
+}
+
+// autoReassignedReviewers возвращает ревьюверов, снятых с PR автоматически по истории PR
+// Они не назначаются на этот PR повторно ни при автоматическом, ни при ручном назначении,
+// ни при деактивации и отсутствии ревьювера
+func autoReassignedReviewers(ctx context.Context, prRepo storage.PRRepositoryInterface, prID string) ([]string, error) {
+	history, err := prRepo.GetPRHistory(ctx, prID)
+	if err != nil {
+		return nil, err
+	}
+	var userIDs []string
+	for _, entry := range history {
+		if entry.Action == api.PRHistoryAutoReassigned {
+			userIDs = append(userIDs, entry.OldReviewerId)
+		}
+	}
+	return userIDs, nil
+}
+
+// GetPRHistory получает историю PR
+func (s *PRService) GetPRHistory(ctx context.Context, prID string) ([]api.PullRequestHistoryEntry, error) {
+	if _, err := s.prRepo.GetPR(ctx, prID); err != nil {
+		return nil, MapStorageError(err)
+	}
+
+	history, err := s.prRepo.GetPRHistory(ctx, prID)
+	if err != nil {
+		return nil, MapStorageError(err)
+	}
+	return history, nil
+}
+
+// AutoReassigner в фоне заменяет ревьюверов, не давших вердикт вовремя, по политике команд
+type AutoReassigner struct {
+	prService *PRService
+	interval  time.Duration
+}
+
+// NewAutoReassigner создает фоновое переназначение с проверкой каждые interval
+func NewAutoReassigner(prService *PRService, interval time.Duration) *AutoReassigner {
+	return &AutoReassigner{
+		prService: prService,
+		interval:  interval,
+	}
+}
+
+// Run проверяет назначения каждые interval до отмены ctx
+func (a *AutoReassigner) Run(ctx context.Context) {
+	runPeriodically(ctx, a.interval, "auto-reassign reviewers", a.prService.AutoReassignTimedOut)
+}
@@ -0,0 +1,137 @@
+package service
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"pr-review-assigner/internal/api"
+	"pr-review-assigner/internal/storage"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPRService_AutoReassignTimedOut(t *testing.T) {
+	mockPRRepo := new(MockPRRepository)
+	mockUserRepo := new(MockUserRepository)
+	clock := &fixedClock{now: time.Date(2025, 10, 25, 13, 0, 0, 0, time.UTC)}
+	service := NewPRService(mockPRRepo, mockUserRepo, new(MockTeamRepository), WithClock(clock.Now))
+
+	assignedAt := clock.now.Add(-5 * time.Hour)
+	mockPRRepo.On("GetReviewTimeouts", clock.now).Return([]storage.ReviewTimeout{
+		{PullRequestID: "pr-1", TeamName: "backend", ReviewerID: "u2", AssignedAt: assignedAt, MaxAutoReassignments: 1},
+		// Лимит PR исчерпан заменой u2 в этом же проходе
+		{PullRequestID: "pr-1", TeamName: "backend", ReviewerID: "u3", AssignedAt: assignedAt, MaxAutoReassignments: 1},
+	}, nil)
+	mockPRRepo.On("GetPR", "pr-1").Return(&api.PullRequest{
+		PullRequestId:     "pr-1",
+		AuthorId:          "u1",
+		Status:            api.PullRequestStatusOPEN,
+		AssignedReviewers: []string{"u2", "u3"},
+	}, nil).Once()
+	// u5 уже снимали с этого PR автоматически
+	mockPRRepo.On("GetPRHistory", "pr-1").Return([]api.PullRequestHistoryEntry{
+		{Action: api.PRHistoryAutoReassigned, OldReviewerId: "u5", NewReviewerId: "u2"},
+	}, nil)
+	mockUserRepo.On("GetUser", "u2").Return(&api.User{UserId: "u2", TeamName: "backend", IsActive: true}, nil)
+	mockUserRepo.On("GetActiveUsersByTeam", "backend", "u2", clock.now).Return([]api.User{
+		{UserId: "u1", TeamName: "backend", IsActive: true},
+		{UserId: "u3", TeamName: "backend", IsActive: true},
+		{UserId: "u4", TeamName: "backend", IsActive: true},
+		{UserId: "u5", TeamName: "backend", IsActive: true},
+	}, nil)
+	mockPRRepo.On("ReassignReviewer", "pr-1", "u2", "u4").Return(&api.PullRequest{PullRequestId: "pr-1"}, nil)
+	mockPRRepo.On("AddPRHistory", "pr-1", &api.PullRequestHistoryEntry{
+		Action:        api.PRHistoryAutoReassigned,
+		OldReviewerId: "u2",
+		NewReviewerId: "u4",
+		CreatedAt:     clock.now,
+	}).Return(nil)
+
+	reassigned, err := service.AutoReassignTimedOut(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, 1, reassigned)
+	mockPRRepo.AssertExpectations(t)
+	mockUserRepo.AssertExpectations(t)
+}
+
+func TestPRService_AutoReassignTimedOutUsesUTC(t *testing.T) {
+	mockPRRepo := new(MockPRRepository)
+	// Время назначения хранится без часового пояса в UTC, поэтому и текущее время передается в UTC
+	clock := &fixedClock{now: time.Date(2025, 10, 25, 16, 0, 0, 0, time.FixedZone("MSK", 3*60*60))}
+	service := NewPRService(mockPRRepo, new(MockUserRepository), new(MockTeamRepository), WithClock(clock.Now))
+
+	mockPRRepo.On("GetReviewTimeouts", clock.now.UTC()).Return([]storage.ReviewTimeout{}, nil)
+
+	_, err := service.AutoReassignTimedOut(context.Background())
+	require.NoError(t, err)
+	mockPRRepo.AssertExpectations(t)
+}
+
+func TestPRService_AutoReassignTimedOutNoCandidates(t *testing.T) {
+	mockPRRepo := new(MockPRRepository)
+	mockUserRepo := new(MockUserRepository)
+	mockTeamRepo := new(MockTeamRepository)
+	service := NewPRService(mockPRRepo, mockUserRepo, mockTeamRepo)
+
+	mockPRRepo.On("GetReviewTimeouts", mock.Anything).Return([]storage.ReviewTimeout{
+		{PullRequestID: "pr-1", TeamName: "backend", ReviewerID: "u2", MaxAutoReassignments: 2},
+	}, nil)
+	mockPRRepo.On("GetPR", "pr-1").Return(&api.PullRequest{
+		PullRequestId:     "pr-1",
+		AuthorId:          "u1",
+		Status:            api.PullRequestStatusOPEN,
+		AssignedReviewers: []string{"u2"},
+	}, nil)
+	mockPRRepo.On("GetPRHistory", "pr-1").Return([]api.PullRequestHistoryEntry{}, nil)
+	mockUserRepo.On("GetUser", "u2").Return(&api.User{UserId: "u2", TeamName: "backend", IsActive: true}, nil)
+	mockUserRepo.On("GetActiveUsersByTeam", "backend", "u2", mock.Anything).Return([]api.User{{UserId: "u1", TeamName: "backend", IsActive: true}}, nil)
+	// Резервных пулов у команды нет
+	mockTeamRepo.On("GetTeamSettings", "backend").Return(defaultTeamSettings("backend"), nil)
+
+	// Ревьювер без замены остается назначенным
+	reassigned, err := service.AutoReassignTimedOut(context.Background())
+	require.NoError(t, err)
+	assert.Zero(t, reassigned)
+	mockPRRepo.AssertNotCalled(t, "ReassignReviewer", mock.Anything, mock.Anything, mock.Anything)
+	mockPRRepo.AssertNotCalled(t, "AddPRHistory", mock.Anything, mock.Anything)
+}
+
+func TestPRService_ReassignReviewerSkipsAutoReassigned(t *testing.T) {
+	mockPRRepo := new(MockPRRepository)
+	mockUserRepo := new(MockUserRepository)
+	service := NewPRService(mockPRRepo, mockUserRepo, new(MockTeamRepository))
+
+	mockPRRepo.On("GetPR", "pr-1").Return(&api.PullRequest{
+		PullRequestId:     "pr-1",
+		AuthorId:          "u1",
+		Status:            api.PullRequestStatusOPEN,
+		AssignedReviewers: []string{"u2"},
+	}, nil)
+	// u3 сняли с PR автоматически, вернуть его ручной заменой нельзя
+	mockPRRepo.On("GetPRHistory", "pr-1").Return([]api.PullRequestHistoryEntry{
+		{Action: api.PRHistoryAutoReassigned, OldReviewerId: "u3", NewReviewerId: "u2"},
+	}, nil)
+	mockUserRepo.On("GetUser", "u2").Return(&api.User{UserId: "u2", TeamName: "backend", IsActive: true}, nil)
+	mockUserRepo.On("GetActiveUsersByTeam", "backend", "u2", mock.Anything).Return([]api.User{
+		{UserId: "u3", TeamName: "backend", IsActive: true},
+		{UserId: "u4", TeamName: "backend", IsActive: true},
+	}, nil)
+	mockPRRepo.On("ReassignReviewer", "pr-1", "u2", "u4").Return(&api.PullRequest{PullRequestId: "pr-1", AssignedReviewers: []string{"u4"}}, nil)
+
+	_, newUserID, err := service.ReassignReviewer(context.Background(), "pr-1", "u2")
+	require.NoError(t, err)
+	assert.Equal(t, "u4", newUserID)
+	mockPRRepo.AssertExpectations(t)
+}
+
+func TestPRService_GetPRHistoryNotFound(t *testing.T) {
+	mockPRRepo := new(MockPRRepository)
+	service := NewPRService(mockPRRepo, new(MockUserRepository), new(MockTeamRepository))
+
+	mockPRRepo.On("GetPR", "missing").Return(nil, storage.ErrNotFound)
+	_, err := service.GetPRHistory(context.Background(), "missing")
+	assert.Equal(t, ErrNotFound, err)
+}
@@ -0,0 +1,198 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"time"
+
+	"pr-review-assigner/internal/api"
+	"pr-review-assigner/internal/codeowners"
+	"pr-review-assigner/internal/storage"
+)
+
+// UploadCodeowners проверяет и сохраняет правила CODEOWNERS репозитория, заменяя прежние
+// Кроме синтаксиса проверяется, что все владельцы существуют: @user_id - пользователь, @org/team_name - команда
+func (s *PRService) UploadCodeowners(ctx context.Context, req *api.UploadCodeownersRequest) (*api.RepositoryCodeowners, error) {
+	if req.Repository == "" {
+		return nil, ErrRepositoryRequired
+	}
+
+	ruleset, err := codeowners.Parse(req.Content)
+	if err != nil {
+		return nil, invalidCodeowners(err)
+	}
+	if err = s.checkCodeowners(ctx, ruleset); err != nil {
+		return nil, err
+	}
+
+	file := &storage.CodeownersFile{
+		Repository: req.Repository,
+		Content:    req.Content,
+		UpdatedAt:  s.deps.now(),
+	}
+	if err = s.prRepo.SaveCodeowners(ctx, file); err != nil {
+		return nil, MapStorageError(err)
+	}
+	return codeownersResponse(file, ruleset), nil
+}
+
+// GetCodeowners получает правила CODEOWNERS репозитория
+func (s *PRService) GetCodeowners(ctx context.Context, repository string) (*api.RepositoryCodeowners, error) {
+	file, err := s.prRepo.GetCodeowners(ctx, repository)
+	if err != nil {
+		return nil, MapStorageError(err)
+	}
+	ruleset, err := codeowners.Parse(file.Content)
+	if err != nil {
+		return nil, err
+	}
+	return codeownersResponse(file, ruleset), nil
+}
+
+// DeleteCodeowners удаляет правила CODEOWNERS репозитория
+func (s *PRService) DeleteCodeowners(ctx context.Context, repository string) error {
+	return MapStorageError(s.prRepo.DeleteCodeowners(ctx, repository))
+}
+
+// checkCodeowners проверяет, что владельцы из правил существуют
+func (s *PRService) checkCodeowners(ctx context.Context, ruleset *codeowners.Ruleset) error {
+	var errs []codeowners.LineError
+	known := make(map[codeowners.Owner]bool)
+	for _, rule := range ruleset.Rules {
+		for _, owner := range rule.Owners {
+			owner.Raw = ""
+			exists, checked := known[owner]
+			if !checked {
+				var err error
+				if owner.UserID != "" {
+					_, err = s.userRepo.GetUser(ctx, owner.UserID)
+				} else {
+					_, err = s.teamRepo.GetTeamSettings(ctx, owner.TeamName)
+				}
+				if err != nil && !errors.Is(err, storage.ErrNotFound) {
+					return MapStorageError(err)
+				}
+				exists = err == nil
+				known[owner] = exists
+			}
+
+			if !exists && owner.UserID != "" {
+				errs = append(errs, codeowners.LineError{Line: rule.Line, Message: fmt.Sprintf("unknown user %q", owner.UserID)})
+			} else if !exists {
+				errs = append(errs, codeowners.LineError{Line: rule.Line, Message: fmt.Sprintf("unknown team %q", owner.TeamName)})
+			}
+		}
+	}
+
+	if len(errs) > 0 {
+		return invalidCodeowners(&codeowners.ParseError{Errors: errs})
+	}
+	return nil
+}
+
+// codeOwnerReviewers возвращает владельцев измененных файлов по правилам CODEOWNERS репозитория,
+// которые могут ревьюить PR: активных пользователей без текущего периода отсутствия, кроме автора
+// Команду-владельца представляет один участник, выбранный по стратегии команды, если среди
+// владельцев-пользователей нет ее участника
+func (s *PRService) codeOwnerReviewers(ctx context.Context, repository string, changedFiles []string, authorID string, now time.Time) ([]string, error) {
+	if len(changedFiles) == 0 {
+		return nil, nil
+	}
+
+	file, err := s.prRepo.GetCodeowners(ctx, repository)
+	if errors.Is(err, storage.ErrNotFound) {
+		// Для репозитория без правил ревьюверы выбираются только из команды автора
+		return nil, nil
+	}
+	if err != nil {
+		return nil, MapStorageError(err)
+	}
+	ruleset, err := codeowners.Parse(file.Content)
+	if err != nil {
+		return nil, err
+	}
+	owners := ruleset.Owners(changedFiles)
+
+	// Активные участники команд (без автора) запрашиваются один раз на команду
+	activeByTeam := make(map[string][]api.User)
+	activeMembers := func(teamName string) ([]api.User, error) {
+		if members, ok := activeByTeam[teamName]; ok {
+			return members, nil
+		}
+		members, err := s.userRepo.GetActiveUsersByTeam(ctx, teamName, authorID, now)
+		if err != nil {
+			return nil, err
+		}
+		activeByTeam[teamName] = members
+		return members, nil
+	}
+
+	var reviewerIDs []string
+	coveredTeams := make(map[string]bool)
+	for _, owner := range owners {
+		if owner.UserID == "" || owner.UserID == authorID || slices.Contains(reviewerIDs, owner.UserID) {
+			continue
+		}
+		user, err := s.userRepo.GetUser(ctx, owner.UserID)
+		if errors.Is(err, storage.ErrNotFound) {
+			// Пользователь удален после загрузки правил
+			continue
+		}
+		if err != nil {
+			return nil, MapStorageError(err)
+		}
+
+		members, err := activeMembers(user.TeamName)
+		if err != nil {
+			return nil, err
+		}
+		if !slices.ContainsFunc(members, func(member api.User) bool { return member.UserId == owner.UserID }) {
+			continue
+		}
+		reviewerIDs = append(reviewerIDs, owner.UserID)
+		coveredTeams[user.TeamName] = true
+	}
+
+	for _, owner := range owners {
+		if owner.TeamName == "" || coveredTeams[owner.TeamName] {
+			continue
+		}
+		members, err := activeMembers(owner.TeamName)
+		if err != nil {
+			return nil, err
+		}
+		picked, err := s.deps.selectors.ForTeam(owner.TeamName).Select(ctx, filterCandidates(members, reviewerIDs...), 1)
+		if err != nil {
+			return nil, err
+		}
+		reviewerIDs = append(reviewerIDs, picked...)
+		coveredTeams[owner.TeamName] = true
+	}
+
+	return reviewerIDs, nil
+}
+
+// invalidCodeowners возвращает ошибку проверки правил с номерами ошибочных строк
+func invalidCodeowners(err error) *ServiceError {
+	return &ServiceError{Code: ErrInvalidCodeowners.Code, Message: ErrInvalidCodeowners.Message + ": " + err.Error()}
+}
+
+// codeownersResponse преобразует сохраненные правила в ответ API
+func codeownersResponse(file *storage.CodeownersFile, ruleset *codeowners.Ruleset) *api.RepositoryCodeowners {
+	rules := make([]api.CodeownersRule, 0, len(ruleset.Rules))
+	for _, rule := range ruleset.Rules {
+		owners := make([]string, 0, len(rule.Owners))
+		for _, owner := range rule.Owners {
+			owners = append(owners, owner.Raw)
+		}
+		rules = append(rules, api.CodeownersRule{Line: rule.Line, Pattern: rule.Pattern, Owners: owners})
+	}
+	return &api.RepositoryCodeowners{
+		Repository: file.Repository,
+		Content:    file.Content,
+		Rules:      rules,
+		UpdatedAt:  file.UpdatedAt,
+	}
+}
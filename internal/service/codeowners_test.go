@@ -0,0 +1,70 @@
+package service
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"pr-review-assigner/internal/api"
+	"pr-review-assigner/internal/storage"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPRService_UploadCodeowners(t *testing.T) {
+	mockPRRepo := new(MockPRRepository)
+	mockUserRepo := new(MockUserRepository)
+	mockTeamRepo := new(MockTeamRepository)
+	clock := &fixedClock{now: time.Date(2025, 10, 25, 13, 0, 0, 0, time.UTC)}
+	service := NewPRService(mockPRRepo, mockUserRepo, mockTeamRepo, WithClock(clock.Now))
+
+	content := "*.sql @acme/backend @u2\n/docs/ @u2\n"
+	// Каждый владелец проверяется один раз
+	mockUserRepo.On("GetUser", "u2").Return(&api.User{UserId: "u2", TeamName: "backend", IsActive: true}, nil).Once()
+	mockTeamRepo.On("GetTeamSettings", "backend").Return(defaultTeamSettings("backend"), nil).Once()
+	mockPRRepo.On("SaveCodeowners", &storage.CodeownersFile{Repository: "acme/api", Content: content, UpdatedAt: clock.now}).Return(nil)
+
+	codeowners, err := service.UploadCodeowners(context.Background(), &api.UploadCodeownersRequest{Repository: "acme/api", Content: content})
+	require.NoError(t, err)
+	assert.Equal(t, []api.CodeownersRule{
+		{Line: 1, Pattern: "*.sql", Owners: []string{"@acme/backend", "@u2"}},
+		{Line: 2, Pattern: "/docs/", Owners: []string{"@u2"}},
+	}, codeowners.Rules)
+	assert.Equal(t, clock.now, codeowners.UpdatedAt)
+	mockPRRepo.AssertExpectations(t)
+	mockUserRepo.AssertExpectations(t)
+	mockTeamRepo.AssertExpectations(t)
+}
+
+func TestPRService_UploadCodeownersInvalid(t *testing.T) {
+	mockPRRepo := new(MockPRRepository)
+	mockUserRepo := new(MockUserRepository)
+	mockTeamRepo := new(MockTeamRepository)
+	service := NewPRService(mockPRRepo, mockUserRepo, mockTeamRepo)
+
+	_, err := service.UploadCodeowners(context.Background(), &api.UploadCodeownersRequest{Repository: "acme/api", Content: "*.go @u1\n!vendor/ @u2\n"})
+	require.NotNil(t, GetServiceError(err))
+	assert.Equal(t, api.INVALIDREQUEST, GetServiceError(err).Code)
+	assert.Contains(t, err.Error(), "line 2: pattern \"!vendor/\": negation is not supported")
+
+	mockUserRepo.On("GetUser", "u9").Return(nil, storage.ErrNotFound)
+	mockTeamRepo.On("GetTeamSettings", "missing").Return(nil, storage.ErrNotFound)
+	_, err = service.UploadCodeowners(context.Background(), &api.UploadCodeownersRequest{Repository: "acme/api", Content: "*.go @u9\n*.sql @acme/missing\n"})
+	assert.EqualError(t, err, `invalid CODEOWNERS rules: line 1: unknown user "u9"; line 2: unknown team "missing"`)
+
+	_, err = service.UploadCodeowners(context.Background(), &api.UploadCodeownersRequest{Content: "*.go @u1"})
+	assert.Equal(t, ErrRepositoryRequired, err)
+	mockPRRepo.AssertNotCalled(t, "SaveCodeowners", mock.Anything)
+}
+
+func TestPRService_CreatePRChangedFilesRequireRepository(t *testing.T) {
+	mockUserRepo := new(MockUserRepository)
+	service := NewPRService(new(MockPRRepository), mockUserRepo, new(MockTeamRepository))
+
+	changedFiles := []string{"main.go"}
+	_, err := service.CreatePR(context.Background(), &api.CreatePullRequestRequest{PullRequestId: "pr-1", AuthorId: "u1", ChangedFiles: &changedFiles})
+	assert.Equal(t, ErrRepositoryRequired, err)
+	mockUserRepo.AssertNotCalled(t, "GetUser", mock.Anything)
+}
@@ -1,6 +1,7 @@
 package service
 
 import (
+	"context"
 	"errors"
 
 	"pr-review-assigner/internal/api"
@@ -15,6 +16,43 @@ var (
 	ErrNotAssigned = &ServiceError{Code: api.NOTASSIGNED, Message: "reviewer is not assigned to this PR"}
 	ErrNoCandidate = &ServiceError{Code: api.NOCANDIDATE, Message: "no active replacement candidate in team"}
 	ErrNotFound    = &ServiceError{Code: api.NOTFOUND, Message: "resource not found"}
+	ErrPRClosed    = &ServiceError{Code: api.PRCLOSED, Message: "pull request is closed"}
+	ErrPRDraft     = &ServiceError{Code: api.PRDRAFT, Message: "pull request is a draft"}
+
+	ErrInvalidReviewerLimits = &ServiceError{Code: api.INVALIDREQUEST, Message: "invalid reviewer limits: require 0 <= min_reviewers <= max_reviewers and max_reviewers >= 1"}
+	ErrInvalidReviewersCount = &ServiceError{Code: api.INVALIDREQUEST, Message: "reviewers_count is outside of the team's [min_reviewers, max_reviewers] range"}
+	ErrReviewersLimit        = &ServiceError{Code: api.REVIEWERSLIMIT, Message: "pull request already has the maximum number of reviewers"}
+
+	ErrInvalidRequiredApprovals = &ServiceError{Code: api.INVALIDREQUEST, Message: "invalid required_approvals: require 0 <= required_approvals <= max_reviewers"}
+	ErrInvalidReviewSLA         = &ServiceError{Code: api.INVALIDREQUEST, Message: "review_sla_seconds must be >= 0"}
+	ErrInvalidAutoReassign      = &ServiceError{Code: api.INVALIDREQUEST, Message: "auto_reassign_after_seconds and max_auto_reassignments must be >= 0"}
+	ErrInvalidVerdict           = &ServiceError{Code: api.INVALIDREQUEST, Message: "verdict must be one of APPROVED, CHANGES_REQUESTED, COMMENTED"}
+	ErrNotEnoughApprovals       = &ServiceError{Code: api.NOTENOUGHAPPROVALS, Message: "not enough approvals to merge PR"}
+
+	ErrInvalidUnavailabilityPeriod = &ServiceError{Code: api.INVALIDREQUEST, Message: "ends_at must be after starts_at"}
+	ErrInvalidWorkingHours         = &ServiceError{Code: api.INVALIDREQUEST, Message: "working hours require an IANA timezone and distinct working_hours_start and working_hours_end in HH:MM format"}
+
+	ErrInvalidCodeowners  = &ServiceError{Code: api.INVALIDREQUEST, Message: "invalid CODEOWNERS rules"}
+	ErrRepositoryRequired = &ServiceError{Code: api.INVALIDREQUEST, Message: "repository is required for CODEOWNERS rules and changed_files"}
+
+	ErrInvalidReviewerPool  = &ServiceError{Code: api.INVALIDREQUEST, Message: "pool_name is required and members must be existing users"}
+	ErrInvalidFallbackPools = &ServiceError{Code: api.INVALIDREQUEST, Message: "fallback_pools must list existing reviewer pools without duplicates"}
+
+	ErrUnknownProvider   = &ServiceError{Code: api.INVALIDREQUEST, Message: "unknown identity provider"}
+	ErrEmptyLogin        = &ServiceError{Code: api.INVALIDREQUEST, Message: "login is required"}
+	ErrUnsupportedAction = &ServiceError{Code: api.INVALIDREQUEST, Message: "unsupported pull request action"}
+
+	ErrInvalidWebhookURL = &ServiceError{Code: api.INVALIDREQUEST, Message: "url must be an absolute http or https URL"}
+	ErrUnknownEventType  = &ServiceError{Code: api.INVALIDREQUEST, Message: "unknown event type"}
+
+	ErrInvalidStatus    = &ServiceError{Code: api.INVALIDREQUEST, Message: "status must be one of OPEN, MERGED, CLOSED, DRAFT"}
+	ErrInvalidSort      = &ServiceError{Code: api.INVALIDREQUEST, Message: "sort must be one of created_at, name and order one of asc, desc"}
+	ErrInvalidTimeRange = &ServiceError{Code: api.INVALIDREQUEST, Message: "time range start must be before its end"}
+	ErrInvalidCursor    = &ServiceError{Code: api.INVALIDREQUEST, Message: "invalid cursor: pass next_cursor of the previous page with the same sort and order"}
+
+	ErrInvalidOutlierThreshold = &ServiceError{Code: api.INVALIDREQUEST, Message: "outlier_threshold must be a number >= 1"}
+
+	ErrTimeout = &ServiceError{Code: api.TIMEOUT, Message: "request timed out"}
 )
 
 // ServiceError представляет ошибку сервисного слоя с кодом API
@@ -50,6 +88,14 @@ func MapStorageError(err error) error {
 	if errors.Is(err, storage.ErrNotFound) {
 		return ErrNotFound
 	}
+
+	if errors.Is(err, storage.ErrCheckViolation) {
+		return ErrReviewersLimit
+	}
+
+	if errors.Is(err, context.DeadlineExceeded) {
+		return ErrTimeout
+	}
 	
 	return err
 }
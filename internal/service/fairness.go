@@ -0,0 +1,129 @@
+package service
+
+import (
+	"cmp"
+	"context"
+	"math"
+	"slices"
+	"strings"
+
+	"pr-review-assigner/internal/api"
+	"pr-review-assigner/internal/storage"
+)
+
+// DefaultOutlierThreshold во сколько раз назначений должно быть больше среднего, чтобы участник считался выбросом
+const DefaultOutlierThreshold = 1.5
+
+// GetFairnessReport считает, насколько равномерно назначения за период распределены между активными участниками команды
+// Назначения берутся из статистики ревьюверов, состав команды - из GetUsersByTeam
+func (s *PRService) GetFairnessReport(ctx context.Context, params *api.GetStatisticsFairnessParams) (*api.FairnessReport, error) {
+	threshold := DefaultOutlierThreshold
+	if params.OutlierThreshold != nil {
+		threshold = *params.OutlierThreshold
+	}
+	if threshold < 1 || math.IsNaN(threshold) || math.IsInf(threshold, 0) {
+		return nil, ErrInvalidOutlierThreshold
+	}
+	if !validRange(params.From, params.To) {
+		return nil, ErrInvalidTimeRange
+	}
+
+	if _, err := s.teamRepo.GetTeamSettings(ctx, params.TeamName); err != nil {
+		return nil, MapStorageError(err)
+	}
+	members, err := s.userRepo.GetUsersByTeam(ctx, params.TeamName)
+	if err != nil {
+		return nil, err
+	}
+	statistics, err := s.prRepo.GetReviewerStatistics(ctx, &storage.StatisticsFilter{
+		TeamName: params.TeamName,
+		From:     params.From,
+		To:       params.To,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	assignments := make(map[string]int, len(statistics))
+	for _, stat := range statistics {
+		assignments[stat.UserID] = stat.AssignmentsCount
+	}
+
+	// Распределение считается только между активными участниками: неактивные не получают назначений
+	var active []api.User
+	var counts []float64
+	for _, member := range members {
+		if member.IsActive {
+			active = append(active, member)
+			counts = append(counts, float64(assignments[member.UserId]))
+		}
+	}
+
+	report := &api.FairnessReport{
+		TeamName:         params.TeamName,
+		From:             params.From,
+		To:               params.To,
+		ActiveMembers:    len(active),
+		OutlierThreshold: threshold,
+		Outliers:         []api.FairnessOutlier{},
+	}
+	if len(active) == 0 {
+		return report, nil
+	}
+
+	var total float64
+	for _, count := range counts {
+		total += count
+	}
+	mean := total / float64(len(counts))
+	report.TotalAssignments = int(total)
+	report.MeanAssignments = mean
+	report.Gini = gini(counts)
+	report.Stddev = stddev(counts, mean)
+	if low, high := slices.Min(counts), slices.Max(counts); low > 0 {
+		ratio := high / low
+		report.MaxMinRatio = &ratio
+	}
+
+	if mean > 0 {
+		for i, member := range active {
+			if ratio := counts[i] / mean; ratio > threshold {
+				report.Outliers = append(report.Outliers, api.FairnessOutlier{
+					UserId:           member.UserId,
+					Username:         member.Username,
+					AssignmentsCount: int(counts[i]),
+					RatioToMean:      ratio,
+				})
+			}
+		}
+		slices.SortFunc(report.Outliers, func(a, b api.FairnessOutlier) int {
+			return cmp.Or(cmp.Compare(b.AssignmentsCount, a.AssignmentsCount), strings.Compare(a.UserId, b.UserId))
+		})
+	}
+
+	return report, nil
+}
+
+// gini вычисляет коэффициент Джини неотрицательных значений: 0 при равном распределении
+func gini(values []float64) float64 {
+	sorted := slices.Sorted(slices.Values(values))
+	var sum, weighted float64
+	for i, value := range sorted {
+		sum += value
+		weighted += float64(i+1) * value
+	}
+	if sum == 0 {
+		return 0
+	}
+	n := float64(len(sorted))
+	return 2*weighted/(n*sum) - (n+1)/n
+}
+
+// stddev вычисляет стандартное отклонение генеральной совокупности
+func stddev(values []float64, mean float64) float64 {
+	var squares float64
+	for _, value := range values {
+		squares += (value - mean) * (value - mean)
+	}
+	return math.Sqrt(squares / float64(len(values)))
+}
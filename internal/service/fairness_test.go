@@ -0,0 +1,101 @@
+package service
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"pr-review-assigner/internal/api"
+	"pr-review-assigner/internal/storage"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGini(t *testing.T) {
+	assert.Zero(t, gini([]float64{3, 3, 3}))
+	assert.Zero(t, gini([]float64{0, 0}))
+	assert.InDelta(t, 0.75, gini([]float64{0, 0, 0, 8}), 1e-9)
+	assert.InDelta(t, 1.0/3, gini([]float64{2, 2, 8}), 1e-9)
+}
+
+func TestPRService_GetFairnessReport(t *testing.T) {
+	mockPRRepo := new(MockPRRepository)
+	mockUserRepo := new(MockUserRepository)
+	mockTeamRepo := new(MockTeamRepository)
+	service := NewPRService(mockPRRepo, mockUserRepo, mockTeamRepo)
+
+	from := time.Date(2025, 10, 13, 0, 0, 0, 0, time.UTC)
+	to := from.AddDate(0, 0, 14)
+	members := []api.User{
+		{UserId: "u1", Username: "Alice", TeamName: "backend", IsActive: true},
+		{UserId: "u2", Username: "Bob", TeamName: "backend", IsActive: true},
+		{UserId: "u3", Username: "Charlie", TeamName: "backend", IsActive: true},
+		{UserId: "u4", Username: "David", TeamName: "backend", IsActive: false},
+	}
+	statistics := []storage.ReviewerStatistic{
+		{UserID: "u2", AssignmentsCount: 8},
+		{UserID: "u1", AssignmentsCount: 2},
+		{UserID: "u3", AssignmentsCount: 2},
+		{UserID: "u4", AssignmentsCount: 5},
+	}
+	mockTeamRepo.On("GetTeamSettings", "backend").Return(defaultTeamSettings("backend"), nil)
+	mockUserRepo.On("GetUsersByTeam", "backend").Return(members, nil)
+	mockPRRepo.On("GetReviewerStatistics", &storage.StatisticsFilter{TeamName: "backend", From: &from, To: &to}).Return(statistics, nil)
+
+	report, err := service.GetFairnessReport(context.Background(), &api.GetStatisticsFairnessParams{TeamName: "backend", From: &from, To: &to})
+	require.NoError(t, err)
+
+	// Неактивный участник не входит в распределение
+	assert.Equal(t, 3, report.ActiveMembers)
+	assert.Equal(t, 12, report.TotalAssignments)
+	assert.InDelta(t, 4, report.MeanAssignments, 1e-9)
+	assert.InDelta(t, 1.0/3, report.Gini, 1e-9)
+	require.NotNil(t, report.MaxMinRatio)
+	assert.InDelta(t, 4, *report.MaxMinRatio, 1e-9)
+	assert.InDelta(t, 2.828427, report.Stddev, 1e-6)
+	assert.Equal(t, DefaultOutlierThreshold, report.OutlierThreshold)
+	assert.Equal(t, []api.FairnessOutlier{{UserId: "u2", Username: "Bob", AssignmentsCount: 8, RatioToMean: 2}}, report.Outliers)
+	mockPRRepo.AssertExpectations(t)
+}
+
+func TestPRService_GetFairnessReport_NoAssignments(t *testing.T) {
+	mockPRRepo := new(MockPRRepository)
+	mockUserRepo := new(MockUserRepository)
+	mockTeamRepo := new(MockTeamRepository)
+	service := NewPRService(mockPRRepo, mockUserRepo, mockTeamRepo)
+
+	mockTeamRepo.On("GetTeamSettings", "backend").Return(defaultTeamSettings("backend"), nil)
+	mockUserRepo.On("GetUsersByTeam", "backend").Return([]api.User{
+		{UserId: "u1", IsActive: true},
+		{UserId: "u2", IsActive: true},
+	}, nil)
+	mockPRRepo.On("GetReviewerStatistics", mock.Anything).Return([]storage.ReviewerStatistic{}, nil)
+
+	report, err := service.GetFairnessReport(context.Background(), &api.GetStatisticsFairnessParams{TeamName: "backend"})
+	require.NoError(t, err)
+	assert.Zero(t, report.Gini)
+	assert.Zero(t, report.Stddev)
+	// Отношение максимума к минимуму не определено, если у кого-то нет назначений
+	assert.Nil(t, report.MaxMinRatio)
+	assert.Empty(t, report.Outliers)
+}
+
+func TestPRService_GetFairnessReport_InvalidParams(t *testing.T) {
+	mockTeamRepo := new(MockTeamRepository)
+	service := NewPRService(new(MockPRRepository), new(MockUserRepository), mockTeamRepo)
+	ctx := context.Background()
+
+	threshold := 0.5
+	_, err := service.GetFairnessReport(ctx, &api.GetStatisticsFairnessParams{TeamName: "backend", OutlierThreshold: &threshold})
+	assert.Equal(t, ErrInvalidOutlierThreshold, err)
+
+	from := time.Now()
+	_, err = service.GetFairnessReport(ctx, &api.GetStatisticsFairnessParams{TeamName: "backend", From: &from, To: &from})
+	assert.Equal(t, ErrInvalidTimeRange, err)
+
+	mockTeamRepo.On("GetTeamSettings", "missing").Return(nil, storage.ErrNotFound)
+	_, err = service.GetFairnessReport(ctx, &api.GetStatisticsFairnessParams{TeamName: "missing"})
+	assert.Equal(t, ErrNotFound, err)
+}
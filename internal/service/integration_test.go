@@ -0,0 +1,501 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"pr-review-assigner/internal/api"
+	"pr-review-assigner/internal/events"
+	"pr-review-assigner/internal/storage/memory"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// integrationServices сервисы поверх хранилища в памяти
+type integrationServices struct {
+	teams *TeamService
+	users *UserService
+	prs   *PRService
+}
+
+func newIntegrationServices() *integrationServices {
+	store := memory.NewStore()
+	teamRepo := memory.NewTeamRepository(store)
+	userRepo := memory.NewUserRepository(store)
+	prRepo := memory.NewPRRepository(store)
+
+	// Round-robin делает выбор кандидатов детерминированным
+	selectors := NewReviewerSelectors(func() ReviewerSelector { return NewRoundRobinSelector() })
+	return &integrationServices{
+		teams: NewTeamService(teamRepo, userRepo, WithTxManager(memory.NewTxManager(store))),
+		users: NewUserService(userRepo, prRepo, teamRepo, WithReviewerSelectors(selectors), WithTxManager(memory.NewTxManager(store))),
+		prs:   NewPRService(prRepo, userRepo, teamRepo, WithReviewerSelectors(selectors)),
+	}
+}
+
+func (s *integrationServices) createTeam(t *testing.T, teamName string, userIDs ...string) {
+	t.Helper()
+	members := make([]api.TeamMember, 0, len(userIDs))
+	for _, userID := range userIDs {
+		members = append(members, api.TeamMember{UserId: userID, Username: "name-" + userID, IsActive: true})
+	}
+	_, err := s.teams.CreateOrUpdateTeam(context.Background(), &api.Team{TeamName: teamName, Members: members})
+	require.NoError(t, err)
+}
+
+func TestIntegration_PRLifecycle(t *testing.T) {
+	s := newIntegrationServices()
+	s.createTeam(t, "backend", "u1", "u2", "u3")
+	ctx := context.Background()
+
+	pr, err := s.prs.CreatePR(ctx, &api.CreatePullRequestRequest{PullRequestId: "pr-1", PullRequestName: "Feature", AuthorId: "u1"})
+	require.NoError(t, err)
+	assert.ElementsMatch(t, []string{"u2", "u3"}, pr.AssignedReviewers)
+
+	_, err = s.prs.CreatePR(ctx, &api.CreatePullRequestRequest{PullRequestId: "pr-1", PullRequestName: "Feature", AuthorId: "u1"})
+	assert.Equal(t, ErrPRExists, err)
+
+	// Замены нет: все остальные участники команды уже назначены, ревьювер снимается
+	pr, newUserID, err := s.prs.ReassignReviewer(ctx, "pr-1", "u2")
+	require.NoError(t, err)
+	assert.Empty(t, newUserID)
+	assert.Equal(t, []string{"u3"}, pr.AssignedReviewers)
+
+	_, err = s.prs.SubmitReview(ctx, "pr-1", "u2", api.APPROVED)
+	assert.Equal(t, ErrNotAssigned, err)
+	pr, err = s.prs.SubmitReview(ctx, "pr-1", "u3", api.APPROVED)
+	require.NoError(t, err)
+	require.Len(t, pr.Reviews, 1)
+
+	pr, err = s.prs.MergePR(ctx, "pr-1")
+	require.NoError(t, err)
+	assert.Equal(t, api.PullRequestStatusMERGED, pr.Status)
+
+	_, _, err = s.prs.ReassignReviewer(ctx, "pr-1", "u3")
+	assert.Equal(t, ErrPRMerged, err)
+}
+
+func TestIntegration_DeactivateTeamUsersReassignsOpenPRs(t *testing.T) {
+	s := newIntegrationServices()
+	s.createTeam(t, "backend", "u1", "u2", "u3", "u4")
+	ctx := context.Background()
+
+	one := 1
+	_, err := s.prs.CreatePR(ctx, &api.CreatePullRequestRequest{PullRequestId: "pr-1", PullRequestName: "Feature", AuthorId: "u1", ReviewersCount: &one})
+	require.NoError(t, err)
+	pr, err := s.prs.MergePR(ctx, "pr-1")
+	require.NoError(t, err)
+	assert.Equal(t, []string{"u2"}, pr.AssignedReviewers)
+
+	pr, err = s.prs.CreatePR(ctx, &api.CreatePullRequestRequest{PullRequestId: "pr-2", PullRequestName: "Fix", AuthorId: "u1"})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"u3", "u4"}, pr.AssignedReviewers)
+
+	deactivated, reassigned, err := s.users.DeactivateTeamUsers(ctx, "backend", []string{"u2", "u3"})
+	require.NoError(t, err)
+	assert.Len(t, deactivated, 2)
+	assert.Equal(t, 1, reassigned)
+
+	// В открытом PR u3 снимается без замены (u4 уже назначен), слитый PR не меняется
+	pr, err = s.prs.prRepo.GetPR(ctx, "pr-2")
+	require.NoError(t, err)
+	assert.Equal(t, []string{"u4"}, pr.AssignedReviewers)
+
+	merged, err := s.prs.prRepo.GetPR(ctx, "pr-1")
+	require.NoError(t, err)
+	assert.Equal(t, []string{"u2"}, merged.AssignedReviewers)
+
+	team, err := s.teams.GetTeam(ctx, "backend")
+	require.NoError(t, err)
+	for _, member := range team.Members {
+		assert.Equal(t, member.UserId == "u1" || member.UserId == "u4", member.IsActive, member.UserId)
+	}
+}
+
+func TestIntegration_DeactivateUnknownUserChangesNothing(t *testing.T) {
+	s := newIntegrationServices()
+	s.createTeam(t, "backend", "u1", "u2")
+	s.createTeam(t, "frontend", "f1")
+	ctx := context.Background()
+
+	_, _, err := s.users.DeactivateTeamUsers(ctx, "backend", []string{"u2", "f1"})
+	assert.Equal(t, ErrNotFound, err)
+
+	team, err := s.teams.GetTeam(ctx, "backend")
+	require.NoError(t, err)
+	for _, member := range team.Members {
+		assert.True(t, member.IsActive, member.UserId)
+	}
+}
+
+func TestIntegration_ReviewSLABreach(t *testing.T) {
+	store := memory.NewStore()
+	teamRepo := memory.NewTeamRepository(store)
+	userRepo := memory.NewUserRepository(store)
+	prRepo := memory.NewPRRepository(store)
+	clock := &fixedClock{now: time.Now()}
+	teams := NewTeamService(teamRepo, userRepo)
+	prs := NewPRService(prRepo, userRepo, teamRepo, WithClock(clock.Now))
+	monitor := NewSLAMonitor(prRepo, time.Minute, WithClock(clock.Now))
+	ctx := context.Background()
+
+	slaSeconds := 24 * 60 * 60
+	_, err := teams.CreateOrUpdateTeam(ctx, &api.Team{
+		TeamName:         "backend",
+		Members:          []api.TeamMember{{UserId: "u1", Username: "Alice", IsActive: true}, {UserId: "u2", Username: "Bob", IsActive: true}},
+		ReviewSlaSeconds: &slaSeconds,
+	})
+	require.NoError(t, err)
+	_, err = prs.CreatePR(ctx, &api.CreatePullRequestRequest{PullRequestId: "pr-1", PullRequestName: "Feature", AuthorId: "u1"})
+	require.NoError(t, err)
+
+	breached, err := monitor.CheckBreaches(ctx)
+	require.NoError(t, err)
+	assert.Zero(t, breached)
+
+	// Через сутки без вердикта SLA нарушен; повторная проверка событие не дублирует
+	clock.now = clock.now.Add(25 * time.Hour)
+	breached, err = monitor.CheckBreaches(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, 1, breached)
+	breached, err = monitor.CheckBreaches(ctx)
+	require.NoError(t, err)
+	assert.Zero(t, breached)
+
+	stale, err := prs.GetStaleReviews(ctx, &api.GetReviewsStaleParams{})
+	require.NoError(t, err)
+	require.Len(t, stale, 1)
+	assert.Equal(t, "u2", stale[0].ReviewerId)
+	assert.NotNil(t, stale[0].SlaBreachedAt)
+
+	records, err := memory.NewOutboxRepository(store).ClaimPending(ctx, 100, time.Minute)
+	require.NoError(t, err)
+	require.NotEmpty(t, records)
+	last := records[len(records)-1].Event
+	assert.Equal(t, events.ReviewSLABreached, last.Type)
+	assert.Equal(t, "u2", last.Data.ReviewerID)
+}
+
+func TestIntegration_AutoReassign(t *testing.T) {
+	store := memory.NewStore()
+	teamRepo := memory.NewTeamRepository(store)
+	userRepo := memory.NewUserRepository(store)
+	prRepo := memory.NewPRRepository(store)
+	clock := &fixedClock{now: time.Now()}
+	teams := NewTeamService(teamRepo, userRepo)
+	prs := NewPRService(prRepo, userRepo, teamRepo, WithClock(clock.Now), WithTxManager(memory.NewTxManager(store)))
+	ctx := context.Background()
+
+	maxReviewers, afterSeconds, maxAutoReassignments := 1, 60*60, 2
+	_, err := teams.CreateOrUpdateTeam(ctx, &api.Team{
+		TeamName: "backend",
+		Members: []api.TeamMember{
+			{UserId: "u1", Username: "Alice", IsActive: true},
+			{UserId: "u2", Username: "Bob", IsActive: true},
+			{UserId: "u3", Username: "Carol", IsActive: true},
+			{UserId: "u4", Username: "Dave", IsActive: true},
+		},
+		MaxReviewers:             &maxReviewers,
+		AutoReassignAfterSeconds: &afterSeconds,
+		MaxAutoReassignments:     &maxAutoReassignments,
+	})
+	require.NoError(t, err)
+	pr, err := prs.CreatePR(ctx, &api.CreatePullRequestRequest{PullRequestId: "pr-1", PullRequestName: "Feature", AuthorId: "u1"})
+	require.NoError(t, err)
+	require.Len(t, pr.AssignedReviewers, 1)
+	seen := []string{pr.AssignedReviewers[0]}
+
+	reassigned, err := prs.AutoReassignTimedOut(ctx)
+	require.NoError(t, err)
+	assert.Zero(t, reassigned)
+
+	// Каждый снятый ревьювер больше не назначается на PR; после двух замен лимит исчерпан
+	for i := 0; i < 3; i++ {
+		clock.now = clock.now.Add(2 * time.Hour)
+		reassigned, err = prs.AutoReassignTimedOut(ctx)
+		require.NoError(t, err)
+
+		pr, err = prRepo.GetPR(ctx, "pr-1")
+		require.NoError(t, err)
+		require.Len(t, pr.AssignedReviewers, 1)
+		if i < 2 {
+			assert.Equal(t, 1, reassigned)
+			assert.NotContains(t, seen, pr.AssignedReviewers[0])
+			seen = append(seen, pr.AssignedReviewers[0])
+		} else {
+			assert.Zero(t, reassigned)
+			assert.Equal(t, seen[2], pr.AssignedReviewers[0])
+		}
+	}
+
+	history, err := prs.GetPRHistory(ctx, "pr-1")
+	require.NoError(t, err)
+	require.Len(t, history, 2)
+	assert.Equal(t, seen[0], history[0].OldReviewerId)
+	assert.Equal(t, seen[1], history[0].NewReviewerId)
+	assert.Equal(t, seen[1], history[1].OldReviewerId)
+	assert.Equal(t, seen[2], history[1].NewReviewerId)
+}
+
+func TestIntegration_UnavailabilityHandoff(t *testing.T) {
+	s := newIntegrationServices()
+	ctx := context.Background()
+
+	maxReviewers := 1
+	_, err := s.teams.CreateOrUpdateTeam(ctx, &api.Team{
+		TeamName: "backend",
+		Members: []api.TeamMember{
+			{UserId: "u1", Username: "Alice", IsActive: true},
+			{UserId: "u2", Username: "Bob", IsActive: true},
+			{UserId: "u3", Username: "Carol", IsActive: true},
+			{UserId: "u4", Username: "Dave", IsActive: true},
+		},
+		MaxReviewers: &maxReviewers,
+	})
+	require.NoError(t, err)
+
+	now := time.Now()
+	_, err = s.users.CreateUnavailability(ctx, &api.CreateUserUnavailabilityRequest{UserId: "u4", StartsAt: now.Add(-time.Hour), EndsAt: now.Add(24 * time.Hour)})
+	require.NoError(t, err)
+
+	// Отсутствующий u4 не назначается ревьювером
+	for i := 0; i < 10; i++ {
+		pr, err := s.prs.CreatePR(ctx, &api.CreatePullRequestRequest{PullRequestId: fmt.Sprintf("pr-%d", i), PullRequestName: "Feature", AuthorId: "u1"})
+		require.NoError(t, err)
+		require.Len(t, pr.AssignedReviewers, 1)
+		assert.NotEqual(t, "u4", pr.AssignedReviewers[0])
+	}
+
+	reviewer := "u2"
+	handoff := true
+	_, err = s.users.CreateUnavailability(ctx, &api.CreateUserUnavailabilityRequest{UserId: reviewer, StartsAt: now.Add(-time.Minute), EndsAt: now.Add(time.Hour), HandoffReviews: &handoff})
+	require.NoError(t, err)
+
+	handedOff, err := s.users.HandOffReviews(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, 1, handedOff)
+	handedOff, err = s.users.HandOffReviews(ctx)
+	require.NoError(t, err)
+	assert.Zero(t, handedOff)
+
+	// Ревью u2 переданы единственному доступному участнику команды
+	prs, _, err := s.prs.GetPRsByReviewer(ctx, &api.GetUsersGetReviewParams{UserId: reviewer})
+	require.NoError(t, err)
+	assert.Empty(t, prs)
+	prs, _, err = s.prs.GetPRsByReviewer(ctx, &api.GetUsersGetReviewParams{UserId: "u3"})
+	require.NoError(t, err)
+	assert.Len(t, prs, 10)
+
+	periods, err := s.users.ListUnavailability(ctx, &api.GetUsersUnavailabilityListParams{UserId: &reviewer})
+	require.NoError(t, err)
+	require.Len(t, periods, 1)
+	assert.NotNil(t, periods[0].HandedOffAt)
+}
+
+func TestIntegration_WorkingHoursSelection(t *testing.T) {
+	store := memory.NewStore()
+	teamRepo := memory.NewTeamRepository(store)
+	userRepo := memory.NewUserRepository(store)
+	prRepo := memory.NewPRRepository(store)
+	// 17:00 UTC: 10:00 в Сан-Франциско, 20:00 в Москве
+	clock := &fixedClock{now: time.Date(2025, 10, 25, 17, 0, 0, 0, time.UTC)}
+	selectors, err := BuildReviewerSelectors(SelectorConfig{DefaultStrategy: StrategyWorkingHours, Now: clock.Now}, NewReviewLoadSource(prRepo))
+	require.NoError(t, err)
+	teams := NewTeamService(teamRepo, userRepo)
+	users := NewUserService(userRepo, prRepo, teamRepo, WithReviewerSelectors(selectors), WithClock(clock.Now))
+	prs := NewPRService(prRepo, userRepo, teamRepo, WithReviewerSelectors(selectors), WithClock(clock.Now))
+	ctx := context.Background()
+
+	maxReviewers := 1
+	_, err = teams.CreateOrUpdateTeam(ctx, &api.Team{
+		TeamName: "backend",
+		Members: []api.TeamMember{
+			{UserId: "u1", Username: "Alice", IsActive: true},
+			{UserId: "u2", Username: "Bob", IsActive: true},
+			{UserId: "u3", Username: "Carol", IsActive: true},
+		},
+		MaxReviewers: &maxReviewers,
+	})
+	require.NoError(t, err)
+	setHours := func(userID, timezone string) {
+		start, end := "09:00", "18:00"
+		_, err := users.SetUserWorkingHours(ctx, &api.SetUserWorkingHoursRequest{UserId: userID, Timezone: &timezone, WorkingHoursStart: &start, WorkingHoursEnd: &end})
+		require.NoError(t, err)
+	}
+	setHours("u2", "America/Los_Angeles")
+	setHours("u3", "Europe/Moscow")
+
+	for i := 0; i < 5; i++ {
+		pr, err := prs.CreatePR(ctx, &api.CreatePullRequestRequest{PullRequestId: fmt.Sprintf("pr-%d", i), PullRequestName: "Feature", AuthorId: "u1"})
+		require.NoError(t, err)
+		assert.Equal(t, []string{"u2"}, pr.AssignedReviewers)
+		require.NotNil(t, pr.CreatedAt)
+		assert.True(t, clock.now.Equal(*pr.CreatedAt))
+	}
+
+	// Утром по Москве в рабочем времени только u3
+	clock.now = time.Date(2025, 10, 26, 7, 0, 0, 0, time.UTC)
+	pr, err := prs.CreatePR(ctx, &api.CreatePullRequestRequest{PullRequestId: "pr-morning", PullRequestName: "Feature", AuthorId: "u1"})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"u3"}, pr.AssignedReviewers)
+}
+
+func TestIntegration_CodeownersRouting(t *testing.T) {
+	s := newIntegrationServices()
+	ctx := context.Background()
+	s.createTeam(t, "backend", "u1", "u2", "u3")
+	s.createTeam(t, "dba", "d1", "d2")
+	s.createTeam(t, "docs", "w1")
+
+	_, err := s.prs.UploadCodeowners(ctx, &api.UploadCodeownersRequest{
+		Repository: "acme/api",
+		Content:    "*.sql @acme/dba\n/docs/ @w1\n/cmd/ @u1\n",
+	})
+	require.NoError(t, err)
+
+	repository := "acme/api"
+	createPR := func(prID string, reviewersCount *int, changedFiles ...string) *api.PullRequest {
+		pr, err := s.prs.CreatePR(ctx, &api.CreatePullRequestRequest{
+			PullRequestId:   prID,
+			PullRequestName: "Feature",
+			AuthorId:        "u1",
+			Repository:      &repository,
+			ChangedFiles:    &changedFiles,
+			ReviewersCount:  reviewersCount,
+		})
+		require.NoError(t, err)
+		return pr
+	}
+
+	// Владелец-команда получает одно место, оставшееся заполняет команда автора
+	pr := createPR("pr-1", nil, "db/001.sql", "main.go")
+	require.Len(t, pr.AssignedReviewers, 2)
+	assert.Contains(t, []string{"d1", "d2"}, pr.AssignedReviewers[0])
+	assert.Contains(t, []string{"u2", "u3"}, pr.AssignedReviewers[1])
+
+	// Владельцев больше, чем мест - назначаются все, количество ревьюверов PR увеличивается
+	one := 1
+	pr = createPR("pr-2", &one, "db/002.sql", "docs/intro.md")
+	require.Len(t, pr.AssignedReviewers, 2)
+	assert.Equal(t, "w1", pr.AssignedReviewers[0])
+	assert.Contains(t, []string{"d1", "d2"}, pr.AssignedReviewers[1])
+	require.NotNil(t, pr.ReviewersCount)
+	assert.Equal(t, 2, *pr.ReviewersCount)
+
+	// Автор не назначается ревьювером своего PR, даже если он владелец
+	pr = createPR("pr-3", nil, "cmd/server/main.go")
+	require.Len(t, pr.AssignedReviewers, 2)
+	assert.ElementsMatch(t, []string{"u2", "u3"}, pr.AssignedReviewers)
+
+	// Для репозитория без правил работает обычный выбор
+	repository = "acme/web"
+	pr = createPR("pr-4", nil, "db/001.sql")
+	assert.ElementsMatch(t, []string{"u2", "u3"}, pr.AssignedReviewers)
+}
+
+func TestIntegration_CodeownersDraftMarkReady(t *testing.T) {
+	s := newIntegrationServices()
+	ctx := context.Background()
+	s.createTeam(t, "backend", "u1", "u2", "u3")
+	s.createTeam(t, "dba", "d1")
+	s.createTeam(t, "docs", "w1")
+
+	// Файлы черновика сохраняются, владельцы назначаются при переводе в OPEN
+	repository := "acme/api"
+	changedFiles := []string{"db/001.sql", "docs/intro.md"}
+	one, draft := 1, true
+	pr, err := s.prs.CreatePR(ctx, &api.CreatePullRequestRequest{
+		PullRequestId:   "pr-1",
+		PullRequestName: "Feature",
+		AuthorId:        "u1",
+		Repository:      &repository,
+		ChangedFiles:    &changedFiles,
+		ReviewersCount:  &one,
+		Draft:           &draft,
+	})
+	require.NoError(t, err)
+	assert.Empty(t, pr.AssignedReviewers)
+
+	// Правила, загруженные после создания черновика, тоже учитываются
+	_, err = s.prs.UploadCodeowners(ctx, &api.UploadCodeownersRequest{
+		Repository: "acme/api",
+		Content:    "*.sql @acme/dba\n/docs/ @w1\n",
+	})
+	require.NoError(t, err)
+
+	pr, err = s.prs.MarkReady(ctx, "pr-1")
+	require.NoError(t, err)
+	assert.Equal(t, api.PullRequestStatusOPEN, pr.Status)
+	assert.Equal(t, []string{"w1", "d1"}, pr.AssignedReviewers)
+	require.NotNil(t, pr.ReviewersCount)
+	assert.Equal(t, 2, *pr.ReviewersCount)
+}
+
+func TestIntegration_FallbackReviewerPools(t *testing.T) {
+	s := newIntegrationServices()
+	ctx := context.Background()
+	s.createTeam(t, "mobile", "m1", "m2")
+	s.createTeam(t, "platform", "p1", "p2", "p3")
+
+	// Без резервных пулов команда из двух человек получает одного ревьювера
+	pr, err := s.prs.CreatePR(ctx, &api.CreatePullRequestRequest{PullRequestId: "pr-0", PullRequestName: "Feature", AuthorId: "m1"})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"m2"}, pr.AssignedReviewers)
+
+	_, err = s.teams.SetReviewerPool(ctx, &api.ReviewerPool{PoolName: "seniors", Members: []string{"m1", "p1", "p2"}})
+	require.NoError(t, err)
+	pools := []string{"seniors"}
+	team, err := s.teams.UpdateTeam(ctx, &api.Team{TeamName: "mobile", Members: []api.TeamMember{}, FallbackPools: &pools})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"seniors"}, *team.FallbackPools)
+
+	pr, err = s.prs.CreatePR(ctx, &api.CreatePullRequestRequest{PullRequestId: "pr-1", PullRequestName: "Feature", AuthorId: "m1"})
+	require.NoError(t, err)
+	require.Len(t, pr.AssignedReviewers, 2)
+	assert.Equal(t, "m2", pr.AssignedReviewers[0])
+	assert.Contains(t, []string{"p1", "p2"}, pr.AssignedReviewers[1])
+	poolReviewer := pr.AssignedReviewers[1]
+
+	// Замена участника команды, когда в команде больше нет кандидатов, берется из пула
+	pr, newUserID, err := s.prs.ReassignReviewer(ctx, "pr-1", "m2")
+	require.NoError(t, err)
+	assert.Contains(t, []string{"p1", "p2"}, newUserID)
+	assert.NotEqual(t, poolReviewer, newUserID)
+	assert.ElementsMatch(t, []string{"p1", "p2"}, pr.AssignedReviewers)
+
+	// Удаленный пул больше не используется командой
+	require.NoError(t, s.teams.DeleteReviewerPool(ctx, "seniors"))
+	team, err = s.teams.GetTeam(ctx, "mobile")
+	require.NoError(t, err)
+	assert.Empty(t, *team.FallbackPools)
+}
+
+func TestIntegration_DeactivateTeamUsersUsesFallbackPools(t *testing.T) {
+	s := newIntegrationServices()
+	ctx := context.Background()
+	s.createTeam(t, "mobile", "m1", "m2", "m3")
+	s.createTeam(t, "platform", "p1")
+
+	_, err := s.teams.SetReviewerPool(ctx, &api.ReviewerPool{PoolName: "seniors", Members: []string{"p1"}})
+	require.NoError(t, err)
+	pools := []string{"seniors"}
+	_, err = s.teams.UpdateTeam(ctx, &api.Team{TeamName: "mobile", Members: []api.TeamMember{}, FallbackPools: &pools})
+	require.NoError(t, err)
+
+	one := 1
+	pr, err := s.prs.CreatePR(ctx, &api.CreatePullRequestRequest{PullRequestId: "pr-1", PullRequestName: "Feature", AuthorId: "m1", ReviewersCount: &one})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"m2"}, pr.AssignedReviewers)
+
+	// После деактивации в команде не остается кандидатов, замена берется из пула
+	_, reassigned, err := s.users.DeactivateTeamUsers(ctx, "mobile", []string{"m2", "m3"})
+	require.NoError(t, err)
+	assert.Equal(t, 1, reassigned)
+
+	pr, err = s.prs.prRepo.GetPR(ctx, "pr-1")
+	require.NoError(t, err)
+	assert.Equal(t, []string{"p1"}, pr.AssignedReviewers)
+}
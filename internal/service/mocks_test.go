@@ -1,9 +1,11 @@
 package service
 
 import (
+	"context"
 	"time"
 
 	"pr-review-assigner/internal/api"
+	"pr-review-assigner/internal/events"
 	"pr-review-assigner/internal/storage"
 
 	"github.com/stretchr/testify/mock"
@@ -14,12 +16,12 @@ type MockTeamRepository struct {
 	mock.Mock
 }
 
-func (m *MockTeamRepository) CreateTeam(teamName string) error {
+func (m *MockTeamRepository) CreateTeam(_ context.Context, teamName string) error {
 	args := m.Called(teamName)
 	return args.Error(0)
 }
 
-func (m *MockTeamRepository) GetTeam(teamName string) (*api.Team, error) {
+func (m *MockTeamRepository) GetTeam(_ context.Context, teamName string) (*api.Team, error) {
 	args := m.Called(teamName)
 	if args.Get(0) == nil {
 		return nil, args.Error(1)
@@ -27,22 +29,61 @@ func (m *MockTeamRepository) GetTeam(teamName string) (*api.Team, error) {
 	return args.Get(0).(*api.Team), args.Error(1)
 }
 
-func (m *MockTeamRepository) TeamExists(teamName string) (bool, error) {
+func (m *MockTeamRepository) TeamExists(_ context.Context, teamName string) (bool, error) {
 	args := m.Called(teamName)
 	return args.Bool(0), args.Error(1)
 }
 
+func (m *MockTeamRepository) GetTeamSettings(_ context.Context, teamName string) (*storage.TeamSettings, error) {
+	args := m.Called(teamName)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*storage.TeamSettings), args.Error(1)
+}
+
+func (m *MockTeamRepository) UpdateTeamSettings(_ context.Context, settings *storage.TeamSettings) error {
+	args := m.Called(settings)
+	return args.Error(0)
+}
+
+func (m *MockTeamRepository) SaveReviewerPool(_ context.Context, pool *api.ReviewerPool) error {
+	args := m.Called(pool)
+	return args.Error(0)
+}
+
+func (m *MockTeamRepository) GetReviewerPool(_ context.Context, poolName string) (*api.ReviewerPool, error) {
+	args := m.Called(poolName)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*api.ReviewerPool), args.Error(1)
+}
+
+func (m *MockTeamRepository) ListReviewerPools(_ context.Context) ([]api.ReviewerPool, error) {
+	args := m.Called()
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]api.ReviewerPool), args.Error(1)
+}
+
+func (m *MockTeamRepository) DeleteReviewerPool(_ context.Context, poolName string) error {
+	args := m.Called(poolName)
+	return args.Error(0)
+}
+
 // MockUserRepository - мок для UserRepository
 type MockUserRepository struct {
 	mock.Mock
 }
 
-func (m *MockUserRepository) CreateOrUpdateUser(user *api.User) error {
+func (m *MockUserRepository) CreateOrUpdateUser(_ context.Context, user *api.User) error {
 	args := m.Called(user)
 	return args.Error(0)
 }
 
-func (m *MockUserRepository) GetUser(userID string) (*api.User, error) {
+func (m *MockUserRepository) GetUser(_ context.Context, userID string) (*api.User, error) {
 	args := m.Called(userID)
 	if args.Get(0) == nil {
 		return nil, args.Error(1)
@@ -50,7 +91,7 @@ func (m *MockUserRepository) GetUser(userID string) (*api.User, error) {
 	return args.Get(0).(*api.User), args.Error(1)
 }
 
-func (m *MockUserRepository) UpdateUserIsActive(userID string, isActive bool) (*api.User, error) {
+func (m *MockUserRepository) UpdateUserIsActive(_ context.Context, userID string, isActive bool) (*api.User, error) {
 	args := m.Called(userID, isActive)
 	if args.Get(0) == nil {
 		return nil, args.Error(1)
@@ -58,15 +99,31 @@ func (m *MockUserRepository) UpdateUserIsActive(userID string, isActive bool) (*
 	return args.Get(0).(*api.User), args.Error(1)
 }
 
-func (m *MockUserRepository) GetActiveUsersByTeam(teamName string, excludeUserID string) ([]api.User, error) {
-	args := m.Called(teamName, excludeUserID)
+func (m *MockUserRepository) UpdateUserWorkingHours(_ context.Context, userID string, hours *storage.WorkingHours) (*api.User, error) {
+	args := m.Called(userID, hours)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*api.User), args.Error(1)
+}
+
+func (m *MockUserRepository) GetActiveUsersByTeam(_ context.Context, teamName string, excludeUserID string, now time.Time) ([]api.User, error) {
+	args := m.Called(teamName, excludeUserID, now)
 	if args.Get(0) == nil {
 		return nil, args.Error(1)
 	}
 	return args.Get(0).([]api.User), args.Error(1)
 }
 
-func (m *MockUserRepository) BatchDeactivateUsers(userIDs []string) ([]api.User, error) {
+func (m *MockUserRepository) GetActiveUsersByPool(_ context.Context, poolName string, now time.Time) ([]api.User, error) {
+	args := m.Called(poolName, now)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]api.User), args.Error(1)
+}
+
+func (m *MockUserRepository) BatchDeactivateUsers(_ context.Context, userIDs []string) ([]api.User, error) {
 	args := m.Called(userIDs)
 	if args.Get(0) == nil {
 		return nil, args.Error(1)
@@ -74,7 +131,7 @@ func (m *MockUserRepository) BatchDeactivateUsers(userIDs []string) ([]api.User,
 	return args.Get(0).([]api.User), args.Error(1)
 }
 
-func (m *MockUserRepository) GetUsersByTeam(teamName string) ([]api.User, error) {
+func (m *MockUserRepository) GetUsersByTeam(_ context.Context, teamName string) ([]api.User, error) {
 	args := m.Called(teamName)
 	if args.Get(0) == nil {
 		return nil, args.Error(1)
@@ -82,12 +139,72 @@ func (m *MockUserRepository) GetUsersByTeam(teamName string) ([]api.User, error)
 	return args.Get(0).([]api.User), args.Error(1)
 }
 
+func (m *MockUserRepository) GetUserIDByLogin(_ context.Context, provider string, login string) (string, error) {
+	args := m.Called(provider, login)
+	return args.String(0), args.Error(1)
+}
+
+func (m *MockUserRepository) LinkLogin(_ context.Context, provider string, login string, userID string) error {
+	args := m.Called(provider, login, userID)
+	return args.Error(0)
+}
+
+func (m *MockUserRepository) CreateUnavailability(_ context.Context, period *api.UserUnavailability) (*api.UserUnavailability, error) {
+	args := m.Called(period)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*api.UserUnavailability), args.Error(1)
+}
+
+func (m *MockUserRepository) GetUnavailability(_ context.Context, unavailabilityID int64) (*api.UserUnavailability, error) {
+	args := m.Called(unavailabilityID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*api.UserUnavailability), args.Error(1)
+}
+
+func (m *MockUserRepository) ListUnavailability(_ context.Context, filter *storage.UnavailabilityFilter) ([]api.UserUnavailability, error) {
+	args := m.Called(filter)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]api.UserUnavailability), args.Error(1)
+}
+
+func (m *MockUserRepository) UpdateUnavailability(_ context.Context, period *api.UserUnavailability) (*api.UserUnavailability, error) {
+	args := m.Called(period)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*api.UserUnavailability), args.Error(1)
+}
+
+func (m *MockUserRepository) DeleteUnavailability(_ context.Context, unavailabilityID int64) error {
+	args := m.Called(unavailabilityID)
+	return args.Error(0)
+}
+
+func (m *MockUserRepository) GetPendingHandoffs(_ context.Context, now time.Time) ([]api.UserUnavailability, error) {
+	args := m.Called(now)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]api.UserUnavailability), args.Error(1)
+}
+
+func (m *MockUserRepository) MarkHandedOff(_ context.Context, unavailabilityID int64, at time.Time) (bool, error) {
+	args := m.Called(unavailabilityID, at)
+	return args.Bool(0), args.Error(1)
+}
+
 // MockPRRepository - мок для PRRepository
 type MockPRRepository struct {
 	mock.Mock
 }
 
-func (m *MockPRRepository) CreatePR(pr *api.PullRequest) (*api.PullRequest, error) {
+func (m *MockPRRepository) CreatePR(_ context.Context, pr *api.PullRequest) (*api.PullRequest, error) {
 	args := m.Called(pr)
 	if args.Get(0) == nil {
 		return nil, args.Error(1)
@@ -95,7 +212,7 @@ func (m *MockPRRepository) CreatePR(pr *api.PullRequest) (*api.PullRequest, erro
 	return args.Get(0).(*api.PullRequest), args.Error(1)
 }
 
-func (m *MockPRRepository) GetPR(prID string) (*api.PullRequest, error) {
+func (m *MockPRRepository) GetPR(_ context.Context, prID string) (*api.PullRequest, error) {
 	args := m.Called(prID)
 	if args.Get(0) == nil {
 		return nil, args.Error(1)
@@ -103,23 +220,23 @@ func (m *MockPRRepository) GetPR(prID string) (*api.PullRequest, error) {
 	return args.Get(0).(*api.PullRequest), args.Error(1)
 }
 
-func (m *MockPRRepository) UpdatePRStatus(prID string, status api.PullRequestStatus, mergedAt *time.Time) (*api.PullRequest, error) {
-	args := m.Called(prID, status, mergedAt)
+func (m *MockPRRepository) UpdatePRStatus(_ context.Context, prID string, status api.PullRequestStatus, changedAt *time.Time) (*api.PullRequest, error) {
+	args := m.Called(prID, status, changedAt)
 	if args.Get(0) == nil {
 		return nil, args.Error(1)
 	}
 	return args.Get(0).(*api.PullRequest), args.Error(1)
 }
 
-func (m *MockPRRepository) GetPRsByReviewer(userID string) ([]api.PullRequestShort, error) {
-	args := m.Called(userID)
+func (m *MockPRRepository) GetPRsByReviewer(_ context.Context, userID string, filter *storage.ReviewerPRsFilter) ([]api.PullRequestShort, error) {
+	args := m.Called(userID, filter)
 	if args.Get(0) == nil {
 		return nil, args.Error(1)
 	}
 	return args.Get(0).([]api.PullRequestShort), args.Error(1)
 }
 
-func (m *MockPRRepository) ReassignReviewer(prID string, oldUserID, newUserID string) (*api.PullRequest, error) {
+func (m *MockPRRepository) ReassignReviewer(_ context.Context, prID string, oldUserID, newUserID string) (*api.PullRequest, error) {
 	args := m.Called(prID, oldUserID, newUserID)
 	if args.Get(0) == nil {
 		return nil, args.Error(1)
@@ -127,20 +244,20 @@ func (m *MockPRRepository) ReassignReviewer(prID string, oldUserID, newUserID st
 	return args.Get(0).(*api.PullRequest), args.Error(1)
 }
 
-func (m *MockPRRepository) AddReviewer(prID string, userID string) error {
+func (m *MockPRRepository) AddReviewer(_ context.Context, prID string, userID string) error {
 	args := m.Called(prID, userID)
 	return args.Error(0)
 }
 
-func (m *MockPRRepository) GetReviewerStatistics() ([]storage.ReviewerStatistic, error) {
-	args := m.Called()
+func (m *MockPRRepository) GetReviewerStatistics(_ context.Context, filter *storage.StatisticsFilter) ([]storage.ReviewerStatistic, error) {
+	args := m.Called(filter)
 	if args.Get(0) == nil {
 		return nil, args.Error(1)
 	}
 	return args.Get(0).([]storage.ReviewerStatistic), args.Error(1)
 }
 
-func (m *MockPRRepository) GetOpenPRsByReviewers(userIDs []string) ([]api.PullRequest, error) {
+func (m *MockPRRepository) GetOpenPRsByReviewers(_ context.Context, userIDs []string) ([]api.PullRequest, error) {
 	args := m.Called(userIDs)
 	if args.Get(0) == nil {
 		return nil, args.Error(1)
@@ -148,7 +265,197 @@ func (m *MockPRRepository) GetOpenPRsByReviewers(userIDs []string) ([]api.PullRe
 	return args.Get(0).([]api.PullRequest), args.Error(1)
 }
 
-func (m *MockPRRepository) BatchReassignReviewers(reassignments map[string]map[string]string) error {
+func (m *MockPRRepository) GetOpenReviewCounts(_ context.Context, userIDs []string) (map[string]int, error) {
+	args := m.Called(userIDs)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(map[string]int), args.Error(1)
+}
+
+func (m *MockPRRepository) BatchReassignReviewers(_ context.Context, reassignments map[string]map[string]string) error {
 	args := m.Called(reassignments)
 	return args.Error(0)
 }
+
+func (m *MockPRRepository) AddReview(_ context.Context, prID string, userID string, verdict api.ReviewVerdict) error {
+	args := m.Called(prID, userID, verdict)
+	return args.Error(0)
+}
+
+func (m *MockPRRepository) ExportAssignments(_ context.Context, fn func(row *api.AssignmentExportRow) error) error {
+	args := m.Called()
+	if rows, ok := args.Get(0).([]api.AssignmentExportRow); ok {
+		for i := range rows {
+			if err := fn(&rows[i]); err != nil {
+				return err
+			}
+		}
+	}
+	return args.Error(1)
+}
+
+func (m *MockPRRepository) ListPRs(_ context.Context, filter *storage.PRListFilter) ([]api.PullRequest, error) {
+	args := m.Called(filter)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]api.PullRequest), args.Error(1)
+}
+
+func (m *MockPRRepository) GetStaleReviews(_ context.Context, filter *storage.StaleReviewsFilter) ([]storage.StaleReview, error) {
+	args := m.Called(filter)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]storage.StaleReview), args.Error(1)
+}
+
+func (m *MockPRRepository) MarkSLABreached(_ context.Context, review *storage.StaleReview, at time.Time) (bool, error) {
+	args := m.Called(review, at)
+	return args.Bool(0), args.Error(1)
+}
+
+func (m *MockPRRepository) GetReviewTimeouts(_ context.Context, now time.Time) ([]storage.ReviewTimeout, error) {
+	args := m.Called(now)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]storage.ReviewTimeout), args.Error(1)
+}
+
+func (m *MockPRRepository) AddPRHistory(_ context.Context, prID string, entry *api.PullRequestHistoryEntry) error {
+	args := m.Called(prID, entry)
+	return args.Error(0)
+}
+
+func (m *MockPRRepository) GetPRHistory(_ context.Context, prID string) ([]api.PullRequestHistoryEntry, error) {
+	args := m.Called(prID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]api.PullRequestHistoryEntry), args.Error(1)
+}
+
+func (m *MockPRRepository) SaveCodeowners(_ context.Context, file *storage.CodeownersFile) error {
+	args := m.Called(file)
+	return args.Error(0)
+}
+
+func (m *MockPRRepository) GetCodeowners(_ context.Context, repository string) (*storage.CodeownersFile, error) {
+	args := m.Called(repository)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*storage.CodeownersFile), args.Error(1)
+}
+
+func (m *MockPRRepository) DeleteCodeowners(_ context.Context, repository string) error {
+	args := m.Called(repository)
+	return args.Error(0)
+}
+
+func (m *MockPRRepository) SavePRChangedFiles(_ context.Context, prID string, files *storage.PRChangedFiles) error {
+	args := m.Called(prID, files)
+	return args.Error(0)
+}
+
+func (m *MockPRRepository) GetPRChangedFiles(_ context.Context, prID string) (*storage.PRChangedFiles, error) {
+	args := m.Called(prID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*storage.PRChangedFiles), args.Error(1)
+}
+
+func (m *MockPRRepository) SetReviewersCount(_ context.Context, prID string, count int) error {
+	args := m.Called(prID, count)
+	return args.Error(0)
+}
+
+// MockSubscriptionRepository - мок для SubscriptionRepository
+type MockSubscriptionRepository struct {
+	mock.Mock
+}
+
+func (m *MockSubscriptionRepository) GetSubscriptionsForEvent(_ context.Context, eventType events.Type) ([]events.Subscription, error) {
+	args := m.Called(eventType)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]events.Subscription), args.Error(1)
+}
+
+func (m *MockSubscriptionRepository) LogDelivery(_ context.Context, delivery *events.Delivery) error {
+	args := m.Called(delivery)
+	return args.Error(0)
+}
+
+func (m *MockSubscriptionRepository) CreateSubscription(_ context.Context, subscription *events.Subscription) (*events.Subscription, error) {
+	args := m.Called(subscription)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*events.Subscription), args.Error(1)
+}
+
+func (m *MockSubscriptionRepository) ListSubscriptions(_ context.Context) ([]events.Subscription, error) {
+	args := m.Called()
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]events.Subscription), args.Error(1)
+}
+
+func (m *MockSubscriptionRepository) DeleteSubscription(_ context.Context, subscriptionID int64) error {
+	args := m.Called(subscriptionID)
+	return args.Error(0)
+}
+
+func (m *MockSubscriptionRepository) SubscriptionExists(_ context.Context, subscriptionID int64) (bool, error) {
+	args := m.Called(subscriptionID)
+	return args.Bool(0), args.Error(1)
+}
+
+func (m *MockSubscriptionRepository) ListDeliveries(_ context.Context, subscriptionID int64, limit int) ([]events.Delivery, error) {
+	args := m.Called(subscriptionID, limit)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]events.Delivery), args.Error(1)
+}
+
+func (m *MockSubscriptionRepository) ListEventDeliveries(_ context.Context, eventID string) ([]events.Delivery, error) {
+	args := m.Called(eventID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]events.Delivery), args.Error(1)
+}
+
+// fakeTxManager передает в транзакцию заданные репозитории и запоминает ее исход
+type fakeTxManager struct {
+	repos      storage.Repositories
+	committed  int
+	rolledBack int
+}
+
+func (m *fakeTxManager) WithTx(_ context.Context, fn func(repos storage.Repositories) error) error {
+	if err := fn(m.repos); err != nil {
+		m.rolledBack++
+		return err
+	}
+	m.committed++
+	return nil
+}
+
+// defaultTeamSettings возвращает настройки команды по умолчанию
+func defaultTeamSettings(teamName string) *storage.TeamSettings {
+	return &storage.TeamSettings{
+		TeamName:     teamName,
+		MinReviewers: storage.DefaultMinReviewers,
+		MaxReviewers: storage.DefaultMaxReviewers,
+
+		MaxAutoReassignments: storage.DefaultMaxAutoReassignments,
+	}
+}
@@ -0,0 +1,70 @@
+package service
+
+import (
+	"context"
+	"time"
+
+	"pr-review-assigner/internal/storage"
+)
+
+// dependencies содержит необязательные зависимости сервисов
+type dependencies struct {
+	selectors *ReviewerSelectors
+	txManager storage.TxManager
+	// now источник текущего времени сервиса: отметки времени PR, проверки SLA ревью
+	// и периодов отсутствия
+	now func() time.Time
+}
+
+// Option настраивает необязательные зависимости сервиса
+type Option func(*dependencies)
+
+// WithReviewerSelectors задает стратегии выбора ревьюверов по командам
+func WithReviewerSelectors(selectors *ReviewerSelectors) Option {
+	return func(d *dependencies) {
+		d.selectors = selectors
+	}
+}
+
+// WithTxManager задает менеджер транзакций для операций над несколькими репозиториями
+func WithTxManager(txManager storage.TxManager) Option {
+	return func(d *dependencies) {
+		d.txManager = txManager
+	}
+}
+
+// WithClock задает источник текущего времени; в тестах позволяет проверять сроки без ожидания
+func WithClock(now func() time.Time) Option {
+	return func(d *dependencies) {
+		d.now = now
+	}
+}
+
+// newDependencies применяет опции и заполняет значения по умолчанию
+func newDependencies(opts []Option) dependencies {
+	var d dependencies
+	for _, opt := range opts {
+		opt(&d)
+	}
+
+	if d.selectors == nil {
+		d.selectors = NewReviewerSelectors(func() ReviewerSelector {
+			return NewRandomSelector(time.Now().UnixNano())
+		})
+	}
+	if d.now == nil {
+		d.now = time.Now
+	}
+
+	return d
+}
+
+// directTxManager выполняет операции напрямую через репозитории сервиса без общей транзакции
+// Используется, если TxManager не задан
+type directTxManager struct {
+	repos storage.Repositories
+}
+
+func (m directTxManager) WithTx(_ context.Context, fn func(repos storage.Repositories) error) error {
+	return fn(m.repos)
+}
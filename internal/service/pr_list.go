@@ -0,0 +1,180 @@
+package service
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"time"
+
+	"pr-review-assigner/internal/api"
+	"pr-review-assigner/internal/storage"
+)
+
+const (
+	// DefaultPRListLimit размер страницы списка PR по умолчанию
+	DefaultPRListLimit = 50
+	// MaxPRListLimit максимальный размер страницы списка PR
+	MaxPRListLimit = 500
+)
+
+// prListCursor содержимое курсора списка PR
+// Сортировка сохраняется в курсоре, чтобы курсор не применялся к выдаче в другом порядке
+type prListCursor struct {
+	Sort          api.GetPullRequestListParamsSort  `json:"s"`
+	Order         api.GetPullRequestListParamsOrder `json:"o"`
+	CreatedAt     *time.Time                        `json:"c,omitempty"`
+	Name          string                            `json:"n,omitempty"`
+	PullRequestID string                            `json:"id"`
+}
+
+// reviewerPRsCursor содержимое курсора списка PR ревьювера
+type reviewerPRsCursor struct {
+	AssignedAt    time.Time `json:"a"`
+	PullRequestID string    `json:"id"`
+}
+
+// ListPRs получает страницу списка PR и курсор следующей страницы (пустой на последней странице)
+// limit приводится к диапазону [1, MaxPRListLimit], по умолчанию DefaultPRListLimit
+func (s *PRService) ListPRs(ctx context.Context, params *api.GetPullRequestListParams) ([]api.PullRequest, string, error) {
+	sortBy, order := api.PRListSortCreatedAt, api.PRListOrderDesc
+	if params.Sort != nil {
+		sortBy = *params.Sort
+	}
+	if params.Order != nil {
+		order = *params.Order
+	}
+	if (sortBy != api.PRListSortCreatedAt && sortBy != api.PRListSortName) ||
+		(order != api.PRListOrderAsc && order != api.PRListOrderDesc) {
+		return nil, "", ErrInvalidSort
+	}
+
+	filter := &storage.PRListFilter{
+		AuthorID:     deref(params.AuthorId),
+		ReviewerID:   deref(params.ReviewerId),
+		TeamName:     deref(params.TeamName),
+		CreatedFrom:  params.CreatedFrom,
+		CreatedTo:    params.CreatedTo,
+		MergedFrom:   params.MergedFrom,
+		MergedTo:     params.MergedTo,
+		NameContains: deref(params.Name),
+		SortBy:       storage.PRSortField(sortBy),
+		Descending:   order == api.PRListOrderDesc,
+		Limit:        DefaultPRListLimit,
+	}
+	if params.Status != nil {
+		if !isValidStatus(*params.Status) {
+			return nil, "", ErrInvalidStatus
+		}
+		filter.Status = *params.Status
+	}
+	if !validRange(params.CreatedFrom, params.CreatedTo) || !validRange(params.MergedFrom, params.MergedTo) {
+		return nil, "", ErrInvalidTimeRange
+	}
+	if params.Limit != nil && *params.Limit > 0 {
+		filter.Limit = min(*params.Limit, MaxPRListLimit)
+	}
+	if params.Cursor != nil && *params.Cursor != "" {
+		after, err := decodePRListCursor(*params.Cursor, sortBy, order)
+		if err != nil {
+			return nil, "", err
+		}
+		filter.After = after
+	}
+
+	// Лишний PR показывает, что есть следующая страница
+	pageSize := filter.Limit
+	filter.Limit++
+	prs, err := s.prRepo.ListPRs(ctx, filter)
+	if err != nil {
+		return nil, "", MapStorageError(err)
+	}
+	if len(prs) <= pageSize {
+		return prs, "", nil
+	}
+
+	prs = prs[:pageSize]
+	return prs, encodePRListCursor(&prs[pageSize-1], sortBy, order), nil
+}
+
+// isValidStatus проверяет, что статус PR входит в список допустимых
+func isValidStatus(status api.PullRequestStatus) bool {
+	switch status {
+	case api.PullRequestStatusOPEN, api.PullRequestStatusMERGED, api.PullRequestStatusCLOSED, api.PullRequestStatusDRAFT:
+		return true
+	default:
+		return false
+	}
+}
+
+// validRange проверяет, что начало интервала раньше конца (если заданы оба)
+func validRange(from, to *time.Time) bool {
+	return from == nil || to == nil || from.Before(*to)
+}
+
+// encodePRListCursor кодирует позицию последнего PR страницы в непрозрачную строку
+func encodePRListCursor(pr *api.PullRequest, sortBy api.GetPullRequestListParamsSort, order api.GetPullRequestListParamsOrder) string {
+	cursor := prListCursor{Sort: sortBy, Order: order, PullRequestID: pr.PullRequestId}
+	if sortBy == api.PRListSortName {
+		cursor.Name = pr.PullRequestName
+	} else {
+		cursor.CreatedAt = pr.CreatedAt
+	}
+
+	data, _ := json.Marshal(cursor)
+	return base64.RawURLEncoding.EncodeToString(data)
+}
+
+// decodePRListCursor разбирает курсор и проверяет, что он выдан для той же сортировки
+func decodePRListCursor(value string, sortBy api.GetPullRequestListParamsSort, order api.GetPullRequestListParamsOrder) (*storage.PRListCursor, error) {
+	data, err := base64.RawURLEncoding.DecodeString(value)
+	if err != nil {
+		return nil, ErrInvalidCursor
+	}
+	var cursor prListCursor
+	if err = json.Unmarshal(data, &cursor); err != nil {
+		return nil, ErrInvalidCursor
+	}
+	if cursor.Sort != sortBy || cursor.Order != order || cursor.PullRequestID == "" ||
+		(sortBy == api.PRListSortCreatedAt && cursor.CreatedAt == nil) {
+		return nil, ErrInvalidCursor
+	}
+
+	after := &storage.PRListCursor{Name: cursor.Name, PullRequestID: cursor.PullRequestID}
+	if cursor.CreatedAt != nil {
+		after.CreatedAt = *cursor.CreatedAt
+	}
+	return after, nil
+}
+
+// encodeReviewerPRsCursor кодирует позицию последнего PR страницы ревьювера в непрозрачную строку
+func encodeReviewerPRsCursor(pr *api.PullRequestShort) string {
+	cursor := reviewerPRsCursor{PullRequestID: pr.PullRequestId}
+	if pr.AssignedAt != nil {
+		cursor.AssignedAt = *pr.AssignedAt
+	}
+
+	data, _ := json.Marshal(cursor)
+	return base64.RawURLEncoding.EncodeToString(data)
+}
+
+// decodeReviewerPRsCursor разбирает курсор списка PR ревьювера
+func decodeReviewerPRsCursor(value string) (*storage.ReviewerPRsCursor, error) {
+	data, err := base64.RawURLEncoding.DecodeString(value)
+	if err != nil {
+		return nil, ErrInvalidCursor
+	}
+	var cursor reviewerPRsCursor
+	if err = json.Unmarshal(data, &cursor); err != nil || cursor.PullRequestID == "" {
+		return nil, ErrInvalidCursor
+	}
+	return &storage.ReviewerPRsCursor{AssignedAt: cursor.AssignedAt, PullRequestID: cursor.PullRequestID}, nil
+}
+
+// deref возвращает значение необязательного параметра или нулевое значение типа
+func deref[T any](value *T) T {
+	if value == nil {
+		var zero T
+		return zero
+	}
+	return *value
+}
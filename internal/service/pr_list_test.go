@@ -0,0 +1,151 @@
+package service
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"pr-review-assigner/internal/api"
+	"pr-review-assigner/internal/storage"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func listedPRs(ids ...string) []api.PullRequest {
+	base := time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC)
+	prs := make([]api.PullRequest, len(ids))
+	for i, id := range ids {
+		createdAt := base.Add(time.Duration(i) * time.Hour)
+		prs[i] = api.PullRequest{PullRequestId: id, PullRequestName: "PR " + id, CreatedAt: &createdAt, AssignedReviewers: []string{}}
+	}
+	return prs
+}
+
+func TestPRService_ListPRs_Pagination(t *testing.T) {
+	mockPRRepo := new(MockPRRepository)
+	service := NewPRService(mockPRRepo, new(MockUserRepository), new(MockTeamRepository))
+	ctx := context.Background()
+
+	// Запрашивается на один PR больше страницы, чтобы узнать о следующей
+	prs := listedPRs("pr-1", "pr-2", "pr-3")
+	mockPRRepo.On("ListPRs", mock.MatchedBy(func(filter *storage.PRListFilter) bool {
+		return filter.After == nil && filter.Limit == 3 && filter.SortBy == storage.PRSortCreatedAt &&
+			filter.Descending && filter.TeamName == "backend"
+	})).Return(prs, nil).Once()
+
+	limit, team := 2, "backend"
+	page, cursor, err := service.ListPRs(ctx, &api.GetPullRequestListParams{Limit: &limit, TeamName: &team})
+	require.NoError(t, err)
+	assert.Equal(t, prs[:2], page)
+	require.NotEmpty(t, cursor)
+
+	// Курсор указывает на последний PR страницы
+	mockPRRepo.On("ListPRs", mock.MatchedBy(func(filter *storage.PRListFilter) bool {
+		return filter.After != nil && filter.After.PullRequestID == "pr-2" && filter.After.CreatedAt.Equal(*prs[1].CreatedAt)
+	})).Return(prs[2:], nil).Once()
+
+	page, next, err := service.ListPRs(ctx, &api.GetPullRequestListParams{Limit: &limit, TeamName: &team, Cursor: &cursor})
+	require.NoError(t, err)
+	assert.Equal(t, prs[2:], page)
+	assert.Empty(t, next)
+	mockPRRepo.AssertExpectations(t)
+}
+
+func TestPRService_ListPRs_LimitIsClamped(t *testing.T) {
+	mockPRRepo := new(MockPRRepository)
+	service := NewPRService(mockPRRepo, new(MockUserRepository), new(MockTeamRepository))
+
+	mockPRRepo.On("ListPRs", mock.MatchedBy(func(filter *storage.PRListFilter) bool {
+		return filter.Limit == MaxPRListLimit+1
+	})).Return([]api.PullRequest{}, nil)
+
+	limit := 10000
+	page, cursor, err := service.ListPRs(context.Background(), &api.GetPullRequestListParams{Limit: &limit})
+	require.NoError(t, err)
+	assert.Empty(t, page)
+	assert.Empty(t, cursor)
+	mockPRRepo.AssertExpectations(t)
+}
+
+func TestPRService_ListPRs_InvalidParams(t *testing.T) {
+	mockPRRepo := new(MockPRRepository)
+	service := NewPRService(mockPRRepo, new(MockUserRepository), new(MockTeamRepository))
+	ctx := context.Background()
+
+	byName := api.PRListSortName
+	cursor := encodePRListCursor(&listedPRs("pr-1")[0], api.PRListSortCreatedAt, api.PRListOrderDesc)
+	badSort := api.GetPullRequestListParamsSort("author")
+	badStatus := api.PullRequestStatus("UNKNOWN")
+	from := time.Now()
+	to := from.Add(-time.Hour)
+	garbage := "not-a-cursor"
+
+	tests := []struct {
+		name   string
+		params api.GetPullRequestListParams
+		want   error
+	}{
+		{"unknown sort", api.GetPullRequestListParams{Sort: &badSort}, ErrInvalidSort},
+		{"unknown status", api.GetPullRequestListParams{Status: &badStatus}, ErrInvalidStatus},
+		{"empty created range", api.GetPullRequestListParams{CreatedFrom: &from, CreatedTo: &to}, ErrInvalidTimeRange},
+		{"empty merged range", api.GetPullRequestListParams{MergedFrom: &from, MergedTo: &from}, ErrInvalidTimeRange},
+		{"malformed cursor", api.GetPullRequestListParams{Cursor: &garbage}, ErrInvalidCursor},
+		{"cursor of another sort", api.GetPullRequestListParams{Sort: &byName, Cursor: &cursor}, ErrInvalidCursor},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, _, err := service.ListPRs(ctx, &tt.params)
+			assert.ErrorIs(t, err, tt.want)
+		})
+	}
+	mockPRRepo.AssertNotCalled(t, "ListPRs", mock.Anything)
+}
+
+func TestPRService_GetPRsByReviewer_Pagination(t *testing.T) {
+	mockPRRepo := new(MockPRRepository)
+	mockUserRepo := new(MockUserRepository)
+	service := NewPRService(mockPRRepo, mockUserRepo, new(MockTeamRepository))
+	ctx := context.Background()
+
+	assignedAt := time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC)
+	prs := []api.PullRequestShort{
+		{PullRequestId: "pr-3", AssignedAt: &assignedAt},
+		{PullRequestId: "pr-2", AssignedAt: &assignedAt},
+		{PullRequestId: "pr-1", AssignedAt: &assignedAt},
+	}
+	mockUserRepo.On("GetUser", "u2").Return(&api.User{UserId: "u2"}, nil)
+	mockPRRepo.On("GetPRsByReviewer", "u2", &storage.ReviewerPRsFilter{Status: api.PullRequestStatusOPEN, Limit: 3}).Return(prs, nil).Once()
+
+	limit, status := 2, api.PullRequestStatusOPEN
+	page, cursor, err := service.GetPRsByReviewer(ctx, &api.GetUsersGetReviewParams{UserId: "u2", Status: &status, Limit: &limit})
+	require.NoError(t, err)
+	assert.Equal(t, prs[:2], page)
+	require.NotEmpty(t, cursor)
+
+	mockPRRepo.On("GetPRsByReviewer", "u2", mock.MatchedBy(func(filter *storage.ReviewerPRsFilter) bool {
+		return filter.After != nil && filter.After.PullRequestID == "pr-2" && filter.After.AssignedAt.Equal(assignedAt)
+	})).Return(prs[2:], nil).Once()
+
+	page, next, err := service.GetPRsByReviewer(ctx, &api.GetUsersGetReviewParams{UserId: "u2", Status: &status, Limit: &limit, Cursor: &cursor})
+	require.NoError(t, err)
+	assert.Equal(t, prs[2:], page)
+	assert.Empty(t, next)
+	mockPRRepo.AssertExpectations(t)
+}
+
+func TestPRService_GetPRsByReviewer_InvalidParams(t *testing.T) {
+	mockPRRepo := new(MockPRRepository)
+	service := NewPRService(mockPRRepo, new(MockUserRepository), new(MockTeamRepository))
+	ctx := context.Background()
+
+	badStatus := api.PullRequestStatus("UNKNOWN")
+	_, _, err := service.GetPRsByReviewer(ctx, &api.GetUsersGetReviewParams{UserId: "u2", Status: &badStatus})
+	assert.ErrorIs(t, err, ErrInvalidStatus)
+
+	garbage := "not-a-cursor"
+	_, _, err = service.GetPRsByReviewer(ctx, &api.GetUsersGetReviewParams{UserId: "u2", Cursor: &garbage})
+	assert.ErrorIs(t, err, ErrInvalidCursor)
+	mockPRRepo.AssertNotCalled(t, "GetPRsByReviewer", mock.Anything, mock.Anything)
+}
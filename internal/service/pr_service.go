@@ -1,45 +1,57 @@
 package service
 
 import (
+	"context"
 	"errors"
-	"math/rand"
-	"time"
+	"slices"
 
 	"pr-review-assigner/internal/api"
 	"pr-review-assigner/internal/storage"
 )
 
-const (
-	// MaxReviewers максимальное количество ревьюверов на PR
-	MaxReviewers = 2
-)
-
 // PRService предоставляет бизнес-логику для работы с Pull Request'ами
 type PRService struct {
 	prRepo   storage.PRRepositoryInterface
 	userRepo storage.UserRepositoryInterface
 	teamRepo storage.TeamRepositoryInterface
+	deps     dependencies
 }
 
 // NewPRService создает новый экземпляр сервиса PR
-func NewPRService(prRepo storage.PRRepositoryInterface, userRepo storage.UserRepositoryInterface, teamRepo storage.TeamRepositoryInterface) *PRService {
-	return &PRService{
+func NewPRService(prRepo storage.PRRepositoryInterface, userRepo storage.UserRepositoryInterface, teamRepo storage.TeamRepositoryInterface, opts ...Option) *PRService {
+	s := &PRService{
 		prRepo:   prRepo,
 		userRepo: userRepo,
 		teamRepo: teamRepo,
+		deps:     newDependencies(opts),
+	}
+	if s.deps.txManager == nil {
+		s.deps.txManager = directTxManager{repos: storage.Repositories{Teams: teamRepo, Users: userRepo, PRs: prRepo}}
 	}
+	return s
 }
 
-// CreatePR создает новый PR и автоматически назначает до MaxReviewers активных ревьюверов из команды автора
-func (s *PRService) CreatePR(prID, prName, authorID string) (*api.PullRequest, error) {
+// CreatePR создает новый PR и автоматически назначает активных ревьюверов из команды автора,
+// при их нехватке - из резервных пулов команды
+// Количество ревьюверов - req.ReviewersCount, если указан, иначе max_reviewers команды автора
+// Черновик (req.Draft) создается в статусе DRAFT без ревьюверов - они назначаются в MarkReady,
+// а req.Repository и req.ChangedFiles сохраняются, чтобы тогда же назначить владельцев кода
+// Владельцы req.ChangedFiles по правилам CODEOWNERS репозитория назначаются обязательно,
+// оставшиеся места заполняются из команды автора; если владельцев больше, назначаются все
+func (s *PRService) CreatePR(ctx context.Context, req *api.CreatePullRequestRequest) (*api.PullRequest, error) {
+	changedFiles := deref(req.ChangedFiles)
+	if len(changedFiles) > 0 && deref(req.Repository) == "" {
+		return nil, ErrRepositoryRequired
+	}
+
 	// Проверяем существование автора
-	author, err := s.userRepo.GetUser(authorID)
+	author, err := s.userRepo.GetUser(ctx, req.AuthorId)
 	if err != nil {
 		return nil, MapStorageError(err)
 	}
 
 	// Проверяем, что PR еще не существует (попытка создать существующий PR)
-	existingPR, err := s.prRepo.GetPR(prID)
+	existingPR, err := s.prRepo.GetPR(ctx, req.PullRequestId)
 	if err == nil && existingPR != nil {
 		return nil, ErrPRExists
 	}
@@ -47,27 +59,81 @@ func (s *PRService) CreatePR(prID, prName, authorID string) (*api.PullRequest, e
 		return nil, MapStorageError(err)
 	}
 
-	// Получаем активных пользователей команды автора (исключая самого автора)
-	candidates, err := s.userRepo.GetActiveUsersByTeam(author.TeamName, authorID)
+	// Определяем количество ревьюверов по настройкам команды автора
+	settings, err := s.teamRepo.GetTeamSettings(ctx, author.TeamName)
+	if err != nil {
+		return nil, MapStorageError(err)
+	}
+	reviewersCount, err := effectiveReviewersCount(settings, req.ReviewersCount)
 	if err != nil {
 		return nil, err
 	}
 
-	// Выбираем случайных ревьюверов (до MaxReviewers)
-	reviewerIDs := s.selectRandomReviewers(candidates, MaxReviewers)
+	now := s.deps.now()
+	status := api.PullRequestStatusOPEN
+	reviewerIDs := []string{}
+	prReviewersCount := req.ReviewersCount
+	if req.Draft != nil && *req.Draft {
+		// Назначение ревьюверов откладывается до MarkReady
+		status = api.PullRequestStatusDRAFT
+	} else {
+		// Владельцы измененных файлов - обязательные ревьюверы
+		ownerIDs, err := s.codeOwnerReviewers(ctx, deref(req.Repository), changedFiles, req.AuthorId, now)
+		if err != nil {
+			return nil, err
+		}
+		if len(ownerIDs) > reviewersCount {
+			reviewersCount = len(ownerIDs)
+			prReviewersCount = &reviewersCount
+		}
+
+		// Получаем активных пользователей команды автора (исключая самого автора)
+		candidates, err := s.userRepo.GetActiveUsersByTeam(ctx, author.TeamName, req.AuthorId, now)
+		if err != nil {
+			return nil, err
+		}
+
+		// Оставшиеся места заполняем по стратегии команды
+		selectedIDs, err := s.deps.selectors.ForTeam(author.TeamName).Select(ctx, filterCandidates(candidates, ownerIDs...), reviewersCount-len(ownerIDs))
+		if err != nil {
+			return nil, err
+		}
+		reviewerIDs = append(reviewerIDs, ownerIDs...)
+		reviewerIDs = append(reviewerIDs, selectedIDs...)
+
+		// Если активных участников команды не хватило, добираем из резервных пулов
+		reviewerIDs, err = fillFromFallbackPools(ctx, s.userRepo, s.deps.selectors.ForTeam(author.TeamName), settings, reviewerIDs, reviewersCount, now, req.AuthorId)
+		if err != nil {
+			return nil, err
+		}
+	}
 
 	// Создаем PR
-	now := time.Now()
 	pr := &api.PullRequest{
-		PullRequestId:     prID,
-		PullRequestName:   prName,
-		AuthorId:          authorID,
-		Status:            api.PullRequestStatusOPEN,
+		PullRequestId:     req.PullRequestId,
+		PullRequestName:   req.PullRequestName,
+		AuthorId:          req.AuthorId,
+		Status:            status,
 		AssignedReviewers: reviewerIDs,
+		ReviewersCount:    prReviewersCount,
 		CreatedAt:         &now,
 	}
 
-	createdPR, err := s.prRepo.CreatePR(pr)
+	// PR и файлы черновика сохраняются вместе
+	var createdPR *api.PullRequest
+	err = s.deps.txManager.WithTx(ctx, func(repos storage.Repositories) error {
+		var err error
+		if createdPR, err = repos.PRs.CreatePR(ctx, pr); err != nil {
+			return err
+		}
+		if status != api.PullRequestStatusDRAFT || len(changedFiles) == 0 {
+			return nil
+		}
+		return repos.PRs.SavePRChangedFiles(ctx, pr.PullRequestId, &storage.PRChangedFiles{
+			Repository: deref(req.Repository),
+			Paths:      changedFiles,
+		})
+	})
 	if err != nil {
 		if errors.Is(err, storage.ErrDuplicateKey) {
 			return nil, ErrPRExists
@@ -79,21 +145,147 @@ func (s *PRService) CreatePR(prID, prName, authorID string) (*api.PullRequest, e
 }
 
 // MergePR помечает PR как MERGED (идемпотентная операция)
-func (s *PRService) MergePR(prID string) (*api.PullRequest, error) {
+// Закрытый PR и черновик слить нельзя; если команда автора требует одобрений,
+// PR сливается только при их наличии
+func (s *PRService) MergePR(ctx context.Context, prID string) (*api.PullRequest, error) {
+	return s.mergePR(ctx, prID, true)
+}
+
+// mergePR помечает PR как MERGED; requireApprovals отключается для слияний,
+// которые уже произошли во внешней системе (вебхуки)
+func (s *PRService) mergePR(ctx context.Context, prID string, requireApprovals bool) (*api.PullRequest, error) {
 	// Получаем PR
-	pr, err := s.prRepo.GetPR(prID)
+	pr, err := s.prRepo.GetPR(ctx, prID)
 	if err != nil {
 		return nil, MapStorageError(err)
 	}
 
-	// Если PR уже MERGED, возвращаем его
-	if pr.Status == api.PullRequestStatusMERGED {
+	switch pr.Status {
+	case api.PullRequestStatusMERGED:
+		// Если PR уже MERGED, возвращаем его
 		return pr, nil
+	case api.PullRequestStatusCLOSED:
+		return nil, ErrPRClosed
+	case api.PullRequestStatusDRAFT:
+		return nil, ErrPRDraft
+	}
+
+	// Проверяем количество одобрений
+	if requireApprovals {
+		if err = s.checkApprovals(ctx, pr); err != nil {
+			return nil, err
+		}
 	}
 
 	// Обновляем статус на MERGED
-	now := time.Now()
-	updatedPR, err := s.prRepo.UpdatePRStatus(prID, api.PullRequestStatusMERGED, &now)
+	now := s.deps.now()
+	updatedPR, err := s.prRepo.UpdatePRStatus(ctx, prID, api.PullRequestStatusMERGED, &now)
+	if err != nil {
+		return nil, MapStorageError(err)
+	}
+
+	return updatedPR, nil
+}
+
+// checkApprovals проверяет, что PR набрал необходимое командой автора количество одобрений
+// Если для PR запрошено меньше ревьюверов, чем required_approvals, достаточно одобрения каждого из них
+func (s *PRService) checkApprovals(ctx context.Context, pr *api.PullRequest) error {
+	author, err := s.userRepo.GetUser(ctx, pr.AuthorId)
+	if err != nil {
+		return MapStorageError(err)
+	}
+
+	settings, err := s.teamRepo.GetTeamSettings(ctx, author.TeamName)
+	if err != nil {
+		return MapStorageError(err)
+	}
+
+	required := settings.RequiredApprovals
+	if pr.ReviewersCount != nil && *pr.ReviewersCount < required {
+		required = *pr.ReviewersCount
+	}
+	if required == 0 {
+		return nil
+	}
+
+	approvals := 0
+	for _, review := range pr.Reviews {
+		if review.Verdict == api.APPROVED {
+			approvals++
+		}
+	}
+	if approvals < required {
+		return ErrNotEnoughApprovals
+	}
+
+	return nil
+}
+
+// SubmitReview сохраняет вердикт назначенного ревьювера по OPEN PR и возвращает обновленный PR
+func (s *PRService) SubmitReview(ctx context.Context, prID, reviewerID string, verdict api.ReviewVerdict) (*api.PullRequest, error) {
+	if !isValidVerdict(verdict) {
+		return nil, ErrInvalidVerdict
+	}
+
+	pr, err := s.prRepo.GetPR(ctx, prID)
+	if err != nil {
+		return nil, MapStorageError(err)
+	}
+
+	switch pr.Status {
+	case api.PullRequestStatusMERGED:
+		return nil, ErrPRMerged
+	case api.PullRequestStatusCLOSED:
+		return nil, ErrPRClosed
+	case api.PullRequestStatusDRAFT:
+		return nil, ErrPRDraft
+	}
+
+	// Вердикт может отправить только назначенный ревьювер
+	isAssigned := false
+	for _, assignedID := range pr.AssignedReviewers {
+		if assignedID == reviewerID {
+			isAssigned = true
+			break
+		}
+	}
+	if !isAssigned {
+		return nil, ErrNotAssigned
+	}
+
+	if err = s.prRepo.AddReview(ctx, prID, reviewerID, verdict); err != nil {
+		return nil, MapStorageError(err)
+	}
+
+	return s.prRepo.GetPR(ctx, prID)
+}
+
+// isValidVerdict проверяет, что вердикт входит в список допустимых
+func isValidVerdict(verdict api.ReviewVerdict) bool {
+	switch verdict {
+	case api.APPROVED, api.CHANGESREQUESTED, api.COMMENTED:
+		return true
+	}
+	return false
+}
+
+// ClosePR закрывает OPEN или DRAFT PR без слияния (идемпотентная операция)
+// Назначенные ревьюверы сохраняются
+func (s *PRService) ClosePR(ctx context.Context, prID string) (*api.PullRequest, error) {
+	pr, err := s.prRepo.GetPR(ctx, prID)
+	if err != nil {
+		return nil, MapStorageError(err)
+	}
+
+	switch pr.Status {
+	case api.PullRequestStatusCLOSED:
+		return pr, nil
+	case api.PullRequestStatusMERGED:
+		return nil, ErrPRMerged
+	}
+
+	now := s.deps.now()
+	updatedPR, err := s.prRepo.UpdatePRStatus(ctx, prID, api.PullRequestStatusCLOSED, &now)
 	if err != nil {
 		return nil, MapStorageError(err)
 	}
@@ -101,18 +293,80 @@ func (s *PRService) MergePR(prID string) (*api.PullRequest, error) {
 	return updatedPR, nil
 }
 
-// ReassignReviewer переназначает одного ревьювера на другого из команды заменяемого ревьювера
-// Работает только для OPEN PR
-func (s *PRService) ReassignReviewer(prID, oldUserID string) (*api.PullRequest, string, error) {
+// ReopenPR переоткрывает закрытый PR и дополняет ревьюверов (идемпотентная операция)
+// OPEN и DRAFT PR возвращаются без изменений
+func (s *PRService) ReopenPR(ctx context.Context, prID string) (*api.PullRequest, error) {
+	pr, err := s.prRepo.GetPR(ctx, prID)
+	if err != nil {
+		return nil, MapStorageError(err)
+	}
+
+	switch pr.Status {
+	case api.PullRequestStatusOPEN, api.PullRequestStatusDRAFT:
+		return pr, nil
+	case api.PullRequestStatusMERGED:
+		return nil, ErrPRMerged
+	}
+
+	reopenedPR, err := s.prRepo.UpdatePRStatus(ctx, prID, api.PullRequestStatusOPEN, nil)
+	if err != nil {
+		return nil, MapStorageError(err)
+	}
+
+	// Пока PR был закрыт, ревьюверы могли быть удалены - дополняем их
+	return s.assignMissingReviewers(ctx, reopenedPR, nil)
+}
+
+// MarkReady переводит черновик в OPEN и назначает ревьюверов (идемпотентная операция)
+// Владельцы файлов, сохраненных при создании черновика, назначаются обязательно, как в CreatePR
+func (s *PRService) MarkReady(ctx context.Context, prID string) (*api.PullRequest, error) {
+	pr, err := s.prRepo.GetPR(ctx, prID)
+	if err != nil {
+		return nil, MapStorageError(err)
+	}
+
+	switch pr.Status {
+	case api.PullRequestStatusOPEN:
+		return pr, nil
+	case api.PullRequestStatusMERGED:
+		return nil, ErrPRMerged
+	case api.PullRequestStatusCLOSED:
+		return nil, ErrPRClosed
+	}
+
+	readyPR, err := s.prRepo.UpdatePRStatus(ctx, prID, api.PullRequestStatusOPEN, nil)
+	if err != nil {
+		return nil, MapStorageError(err)
+	}
+
+	files, err := s.prRepo.GetPRChangedFiles(ctx, prID)
+	if err != nil {
+		return nil, MapStorageError(err)
+	}
+	ownerIDs, err := s.codeOwnerReviewers(ctx, files.Repository, files.Paths, readyPR.AuthorId, s.deps.now())
+	if err != nil {
+		return nil, err
+	}
+
+	return s.assignMissingReviewers(ctx, readyPR, ownerIDs)
+}
+
+// ReassignReviewer переназначает одного ревьювера на другого из команды заменяемого ревьювера,
+// а если в ней нет кандидатов - из резервных пулов этой команды
+// Не работает для MERGED и CLOSED PR
+func (s *PRService) ReassignReviewer(ctx context.Context, prID, oldUserID string) (*api.PullRequest, string, error) {
 	// Получаем PR
-	pr, err := s.prRepo.GetPR(prID)
+	pr, err := s.prRepo.GetPR(ctx, prID)
 	if err != nil {
 		return nil, "", MapStorageError(err)
 	}
 
-	// Проверяем, что PR не MERGED
-	if pr.Status == api.PullRequestStatusMERGED {
+	// Проверяем, что PR не MERGED и не CLOSED
+	switch pr.Status {
+	case api.PullRequestStatusMERGED:
 		return nil, "", ErrPRMerged
+	case api.PullRequestStatusCLOSED:
+		return nil, "", ErrPRClosed
 	}
 
 	// Проверяем, что старый ревьювер назначен на этот PR
@@ -128,34 +382,50 @@ func (s *PRService) ReassignReviewer(prID, oldUserID string) (*api.PullRequest,
 	}
 
 	// Получаем информацию о заменяемом ревьювере
-	oldReviewer, err := s.userRepo.GetUser(oldUserID)
+	oldReviewer, err := s.userRepo.GetUser(ctx, oldUserID)
 	if err != nil {
 		return nil, "", MapStorageError(err)
 	}
 
 	// Получаем активных пользователей команды заменяемого ревьювера (исключая его самого)
-	candidates, err := s.userRepo.GetActiveUsersByTeam(oldReviewer.TeamName, oldUserID)
+	now := s.deps.now()
+	candidates, err := s.userRepo.GetActiveUsersByTeam(ctx, oldReviewer.TeamName, oldUserID, now)
 	if err != nil {
 		return nil, "", err
 	}
 
-	// Исключаем автора PR и уже назначенных ревьюверов из кандидатов
+	// Исключаем автора PR, уже назначенных ревьюверов и снятых с PR автоматически
+	autoReassigned, err := autoReassignedReviewers(ctx, s.prRepo, prID)
+	if err != nil {
+		return nil, "", err
+	}
 	excludeUserIDs := append([]string{pr.AuthorId}, pr.AssignedReviewers...)
+	excludeUserIDs = append(excludeUserIDs, autoReassigned...)
 	availableCandidates := filterCandidates(candidates, excludeUserIDs...)
 
 	// Определяем нового ревьювера
 	var newUserID string
 	if len(availableCandidates) > 0 {
-		// Есть доступные кандидаты - выбираем случайного
-		newReviewerIDs := s.selectRandomReviewers(availableCandidates, 1)
+		// Есть доступные кандидаты - выбираем по стратегии команды
+		newReviewerIDs, err := s.deps.selectors.ForTeam(oldReviewer.TeamName).Select(ctx, availableCandidates, 1)
+		if err != nil {
+			return nil, "", err
+		}
 		if len(newReviewerIDs) > 0 {
 			newUserID = newReviewerIDs[0]
 		}
 	}
+	// Активных участников команды не осталось - ищем замену в резервных пулах команды
+	if newUserID == "" {
+		newUserID, err = pickFromFallbackPools(ctx, s.teamRepo, s.userRepo, s.deps.selectors.ForTeam(oldReviewer.TeamName), oldReviewer.TeamName, now, excludeUserIDs...)
+		if err != nil {
+			return nil, "", MapStorageError(err)
+		}
+	}
 	// Если newUserID пустой, просто удалим старого ревьювера без замены
 
 	// Переназначаем ревьювера (или удаляем, если newUserID пустой)
-	updatedPR, err := s.prRepo.ReassignReviewer(prID, oldUserID, newUserID)
+	updatedPR, err := s.prRepo.ReassignReviewer(ctx, prID, oldUserID, newUserID)
 	if err != nil {
 		if errors.Is(err, storage.ErrNotFound) {
 			return nil, "", ErrNotAssigned
@@ -166,67 +436,133 @@ func (s *PRService) ReassignReviewer(prID, oldUserID string) (*api.PullRequest,
 	return updatedPR, newUserID, nil
 }
 
-// GetPRsByReviewer получает список PR, где пользователь назначен ревьювером
-func (s *PRService) GetPRsByReviewer(userID string) ([]api.PullRequestShort, error) {
+// GetPRsByReviewer получает страницу PR, где пользователь назначен ревьювером, и курсор следующей страницы
+// limit приводится к диапазону [1, MaxPRListLimit], по умолчанию DefaultPRListLimit
+func (s *PRService) GetPRsByReviewer(ctx context.Context, params *api.GetUsersGetReviewParams) ([]api.PullRequestShort, string, error) {
+	filter := &storage.ReviewerPRsFilter{Limit: DefaultPRListLimit}
+	if params.Status != nil {
+		if !isValidStatus(*params.Status) {
+			return nil, "", ErrInvalidStatus
+		}
+		filter.Status = *params.Status
+	}
+	if params.Limit != nil && *params.Limit > 0 {
+		filter.Limit = min(*params.Limit, MaxPRListLimit)
+	}
+	if params.Cursor != nil && *params.Cursor != "" {
+		after, err := decodeReviewerPRsCursor(*params.Cursor)
+		if err != nil {
+			return nil, "", err
+		}
+		filter.After = after
+	}
+
 	// Проверяем существование пользователя
-	_, err := s.userRepo.GetUser(userID)
+	_, err := s.userRepo.GetUser(ctx, params.UserId)
 	if err != nil {
-		return nil, MapStorageError(err)
+		return nil, "", MapStorageError(err)
 	}
 
-	// Получаем список PR
-	prs, err := s.prRepo.GetPRsByReviewer(userID)
+	// Лишний PR показывает, что есть следующая страница
+	pageSize := filter.Limit
+	filter.Limit++
+	prs, err := s.prRepo.GetPRsByReviewer(ctx, params.UserId, filter)
 	if err != nil {
-		return nil, err
+		return nil, "", err
+	}
+	if len(prs) <= pageSize {
+		return prs, "", nil
 	}
 
-	return prs, nil
+	prs = prs[:pageSize]
+	return prs, encodeReviewerPRsCursor(&prs[pageSize-1]), nil
 }
 
 // AutoAssignReviewers автоматически назначает или дополняет ревьюверов для PR
-// Если уже 2 ревьювера - ничего не делает
-// Если 1 ревьювер - добавляет второго
-// Если 0 ревьюверов - назначает до 2
-func (s *PRService) AutoAssignReviewers(prID string) (*api.PullRequest, error) {
+// до эффективного количества: reviewers_count PR, если он задан, иначе max_reviewers команды автора
+// Для черновика назначение откладывается до MarkReady
+func (s *PRService) AutoAssignReviewers(ctx context.Context, prID string) (*api.PullRequest, error) {
 	// Получаем PR
-	pr, err := s.prRepo.GetPR(prID)
+	pr, err := s.prRepo.GetPR(ctx, prID)
 	if err != nil {
 		return nil, MapStorageError(err)
 	}
 
-	// Проверяем, что PR не MERGED
-	if pr.Status == api.PullRequestStatusMERGED {
+	switch pr.Status {
+	case api.PullRequestStatusMERGED:
 		return nil, ErrPRMerged
-	}
-
-	// Если уже назначено максимальное количество ревьюверов, возвращаем PR без изменений
-	if len(pr.AssignedReviewers) >= MaxReviewers {
+	case api.PullRequestStatusCLOSED:
+		return nil, ErrPRClosed
+	case api.PullRequestStatusDRAFT:
 		return pr, nil
 	}
 
+	return s.assignMissingReviewers(ctx, pr, nil)
+}
+
+// assignMissingReviewers дополняет ревьюверов OPEN PR до эффективного количества
+// Обязательные ревьюверы requiredIDs (владельцы кода) назначаются первыми; если вместе с уже
+// назначенными их больше эффективного количества, reviewers_count PR увеличивается
+func (s *PRService) assignMissingReviewers(ctx context.Context, pr *api.PullRequest, requiredIDs []string) (*api.PullRequest, error) {
+	prID := pr.PullRequestId
+
 	// Получаем автора PR
-	author, err := s.userRepo.GetUser(pr.AuthorId)
+	author, err := s.userRepo.GetUser(ctx, pr.AuthorId)
 	if err != nil {
 		return nil, MapStorageError(err)
 	}
 
-	// Получаем активных пользователей команды автора (исключая самого автора)
-	candidates, err := s.userRepo.GetActiveUsersByTeam(author.TeamName, pr.AuthorId)
+	// Определяем сколько ревьюверов нужно добавить
+	settings, err := s.teamRepo.GetTeamSettings(ctx, author.TeamName)
 	if err != nil {
-		return nil, err
+		return nil, MapStorageError(err)
 	}
+	reviewersCount := settings.MaxReviewers
+	if pr.ReviewersCount != nil {
+		reviewersCount = *pr.ReviewersCount
+	}
+	ownerIDs := slices.DeleteFunc(slices.Clone(requiredIDs), func(userID string) bool {
+		return slices.Contains(pr.AssignedReviewers, userID)
+	})
+	if required := len(pr.AssignedReviewers) + len(ownerIDs); required > reviewersCount {
+		if err := s.prRepo.SetReviewersCount(ctx, prID, required); err != nil {
+			return nil, MapStorageError(err)
+		}
+		reviewersCount = required
+	}
+	needReviewers := reviewersCount - len(pr.AssignedReviewers)
 
-	// Исключаем уже назначенных ревьюверов
-	availableCandidates := filterCandidates(candidates, pr.AssignedReviewers...)
-
-	// Определяем сколько ревьюверов нужно добавить
-	needReviewers := MaxReviewers - len(pr.AssignedReviewers)
+	// Если уже назначено нужное количество ревьюверов, возвращаем PR без изменений
 	if needReviewers <= 0 {
 		return pr, nil
 	}
 
-	// Выбираем случайных ревьюверов
-	newReviewerIDs := s.selectRandomReviewers(availableCandidates, needReviewers)
+	// Получаем активных пользователей команды автора (исключая самого автора)
+	now := s.deps.now()
+	candidates, err := s.userRepo.GetActiveUsersByTeam(ctx, author.TeamName, pr.AuthorId, now)
+	if err != nil {
+		return nil, err
+	}
+
+	// Исключаем уже назначенных ревьюверов и снятых с PR автоматически
+	autoReassigned, err := autoReassignedReviewers(ctx, s.prRepo, prID)
+	if err != nil {
+		return nil, err
+	}
+	excludeUserIDs := append([]string{pr.AuthorId}, pr.AssignedReviewers...)
+	excludeUserIDs = append(excludeUserIDs, autoReassigned...)
+	availableCandidates := filterCandidates(candidates, append(excludeUserIDs, ownerIDs...)...)
+
+	// Оставшиеся после владельцев кода места заполняем по стратегии команды
+	selectedIDs, err := s.deps.selectors.ForTeam(author.TeamName).Select(ctx, availableCandidates, needReviewers-len(ownerIDs))
+	if err != nil {
+		return nil, err
+	}
+	newReviewerIDs := append(ownerIDs, selectedIDs...)
+	newReviewerIDs, err = fillFromFallbackPools(ctx, s.userRepo, s.deps.selectors.ForTeam(author.TeamName), settings, newReviewerIDs, needReviewers, now, excludeUserIDs...)
+	if err != nil {
+		return nil, err
+	}
 
 	// Если нет доступных кандидатов, возвращаем PR без изменений
 	if len(newReviewerIDs) == 0 {
@@ -235,14 +571,26 @@ func (s *PRService) AutoAssignReviewers(prID string) (*api.PullRequest, error) {
 
 	// Добавляем новых ревьюверов
 	for _, reviewerID := range newReviewerIDs {
-		err = s.prRepo.AddReviewer(prID, reviewerID)
+		err = s.prRepo.AddReviewer(ctx, prID, reviewerID)
 		if err != nil {
-			return nil, err
+			return nil, MapStorageError(err)
 		}
 	}
 
 	// Возвращаем обновленный PR
-	return s.prRepo.GetPR(prID)
+	return s.prRepo.GetPR(ctx, prID)
+}
+
+// effectiveReviewersCount возвращает количество ревьюверов для нового PR
+// requested должен лежать в диапазоне [MinReviewers, MaxReviewers] команды
+func effectiveReviewersCount(settings *storage.TeamSettings, requested *int) (int, error) {
+	if requested == nil {
+		return settings.MaxReviewers, nil
+	}
+	if *requested < settings.MinReviewers || *requested > settings.MaxReviewers {
+		return 0, ErrInvalidReviewersCount
+	}
+	return *requested, nil
 }
 
 // filterCandidates фильтрует кандидатов, исключая указанных пользователей
@@ -268,43 +616,39 @@ func filterCandidates(candidates []api.User, excludeUserIDs ...string) []api.Use
 	return filtered
 }
 
-// selectRandomReviewers выбирает случайных ревьюверов из списка кандидатов (до maxCount)
-func (s *PRService) selectRandomReviewers(candidates []api.User, maxCount int) []string {
-	if len(candidates) == 0 {
-		return []string{}
+// GetReviewerStatistics получает статистику ревьюверов с фильтрами по команде, периоду и статусу PR
+func (s *PRService) GetReviewerStatistics(ctx context.Context, params *api.GetStatisticsParams) ([]storage.ReviewerStatistic, error) {
+	filter := &storage.StatisticsFilter{
+		TeamName: deref(params.TeamName),
+		From:     params.From,
+		To:       params.To,
 	}
-
-	// Если кандидатов меньше или равно maxCount, возвращаем всех
-	if len(candidates) <= maxCount {
-		result := make([]string, len(candidates))
-		for i, user := range candidates {
-			result[i] = user.UserId
+	if params.Status != nil {
+		if !isValidStatus(*params.Status) {
+			return nil, ErrInvalidStatus
 		}
-		return result
+		filter.Status = *params.Status
+	}
+	if !validRange(params.From, params.To) {
+		return nil, ErrInvalidTimeRange
 	}
 
-	// Выбираем случайных ревьюверов
-	rng := rand.New(rand.NewSource(time.Now().UnixNano()))
-	selected := make(map[int]bool)
-	result := make([]string, 0, maxCount)
-
-	for len(result) < maxCount {
-		idx := rng.Intn(len(candidates))
-		if !selected[idx] {
-			selected[idx] = true
-			result = append(result, candidates[idx].UserId)
+	// Проверяем существование команды
+	if filter.TeamName != "" {
+		if _, err := s.teamRepo.GetTeamSettings(ctx, filter.TeamName); err != nil {
+			return nil, MapStorageError(err)
 		}
 	}
 
-	return result
-}
-
-// GetReviewerStatistics получает статистику по назначениям ревьюверов
-func (s *PRService) GetReviewerStatistics() ([]storage.ReviewerStatistic, error) {
-	statistics, err := s.prRepo.GetReviewerStatistics()
+	statistics, err := s.prRepo.GetReviewerStatistics(ctx, filter)
 	if err != nil {
 		return nil, err
 	}
 
 	return statistics, nil
 }
+
+// ExportAssignments передает fn все назначения ревьюверов по одному, не загружая их в память целиком
+func (s *PRService) ExportAssignments(ctx context.Context, fn func(row *api.AssignmentExportRow) error) error {
+	return s.prRepo.ExportAssignments(ctx, fn)
+}
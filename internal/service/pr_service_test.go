@@ -1,6 +1,7 @@
 package service
 
 import (
+	"context"
 	"testing"
 	"time"
 
@@ -41,10 +42,11 @@ func TestPRService_CreatePR_Success(t *testing.T) {
 
 	mockUserRepo.On("GetUser", "u1").Return(author, nil)
 	mockPRRepo.On("GetPR", "pr-1").Return(nil, storage.ErrNotFound).Once()
-	mockUserRepo.On("GetActiveUsersByTeam", "backend", "u1").Return(candidates, nil)
+	mockTeamRepo.On("GetTeamSettings", "backend").Return(defaultTeamSettings("backend"), nil)
+	mockUserRepo.On("GetActiveUsersByTeam", "backend", "u1", mock.Anything).Return(candidates, nil)
 	mockPRRepo.On("CreatePR", mock.AnythingOfType("*api.PullRequest")).Return(expectedPR, nil)
 
-	result, err := service.CreatePR("pr-1", "Test PR", "u1")
+	result, err := service.CreatePR(context.Background(), &api.CreatePullRequestRequest{PullRequestId: "pr-1", PullRequestName: "Test PR", AuthorId: "u1"})
 
 	assert.NoError(t, err)
 	assert.NotNil(t, result)
@@ -64,7 +66,7 @@ func TestPRService_CreatePR_AuthorNotFound(t *testing.T) {
 
 	mockUserRepo.On("GetUser", "u1").Return(nil, storage.ErrNotFound)
 
-	result, err := service.CreatePR("pr-1", "Test PR", "u1")
+	result, err := service.CreatePR(context.Background(), &api.CreatePullRequestRequest{PullRequestId: "pr-1", PullRequestName: "Test PR", AuthorId: "u1"})
 
 	assert.Error(t, err)
 	assert.Nil(t, result)
@@ -94,7 +96,7 @@ func TestPRService_CreatePR_AlreadyExists(t *testing.T) {
 	mockUserRepo.On("GetUser", "u1").Return(author, nil)
 	mockPRRepo.On("GetPR", "pr-1").Return(existingPR, nil)
 
-	result, err := service.CreatePR("pr-1", "Test PR", "u1")
+	result, err := service.CreatePR(context.Background(), &api.CreatePullRequestRequest{PullRequestId: "pr-1", PullRequestName: "Test PR", AuthorId: "u1"})
 
 	assert.Error(t, err)
 	assert.Nil(t, result)
@@ -112,6 +114,7 @@ func TestPRService_MergePR_Success(t *testing.T) {
 
 	pr := &api.PullRequest{
 		PullRequestId: "pr-1",
+		AuthorId:      "u1",
 		Status:        api.PullRequestStatusOPEN,
 	}
 
@@ -122,9 +125,11 @@ func TestPRService_MergePR_Success(t *testing.T) {
 	}
 
 	mockPRRepo.On("GetPR", "pr-1").Return(pr, nil).Once()
+	mockUserRepo.On("GetUser", "u1").Return(&api.User{UserId: "u1", TeamName: "backend"}, nil)
+	mockTeamRepo.On("GetTeamSettings", "backend").Return(defaultTeamSettings("backend"), nil)
 	mockPRRepo.On("UpdatePRStatus", "pr-1", api.PullRequestStatusMERGED, mock.AnythingOfType("*time.Time")).Return(mergedPR, nil)
 
-	result, err := service.MergePR("pr-1")
+	result, err := service.MergePR(context.Background(), "pr-1")
 
 	assert.NoError(t, err)
 	assert.NotNil(t, result)
@@ -148,7 +153,7 @@ func TestPRService_MergePR_Idempotent(t *testing.T) {
 
 	mockPRRepo.On("GetPR", "pr-1").Return(mergedPR, nil)
 
-	result, err := service.MergePR("pr-1")
+	result, err := service.MergePR(context.Background(), "pr-1")
 
 	assert.NoError(t, err)
 	assert.NotNil(t, result)
@@ -166,7 +171,7 @@ func TestPRService_MergePR_NotFound(t *testing.T) {
 
 	mockPRRepo.On("GetPR", "pr-1").Return(nil, storage.ErrNotFound)
 
-	result, err := service.MergePR("pr-1")
+	result, err := service.MergePR(context.Background(), "pr-1")
 
 	assert.Error(t, err)
 	assert.Nil(t, result)
@@ -208,11 +213,12 @@ func TestPRService_ReassignReviewer_Success(t *testing.T) {
 	}
 
 	mockPRRepo.On("GetPR", "pr-1").Return(pr, nil).Once()
+	mockPRRepo.On("GetPRHistory", "pr-1").Return([]api.PullRequestHistoryEntry{}, nil)
 	mockUserRepo.On("GetUser", "u2").Return(oldReviewer, nil)
-	mockUserRepo.On("GetActiveUsersByTeam", "backend", "u2").Return(candidates, nil)
+	mockUserRepo.On("GetActiveUsersByTeam", "backend", "u2", mock.Anything).Return(candidates, nil)
 	mockPRRepo.On("ReassignReviewer", "pr-1", "u2", mock.AnythingOfType("string")).Return(updatedPR, nil)
 
-	result, newUserID, err := service.ReassignReviewer("pr-1", "u2")
+	result, newUserID, err := service.ReassignReviewer(context.Background(), "pr-1", "u2")
 
 	assert.NoError(t, err)
 	assert.NotNil(t, result)
@@ -236,7 +242,7 @@ func TestPRService_ReassignReviewer_PRMerged(t *testing.T) {
 
 	mockPRRepo.On("GetPR", "pr-1").Return(pr, nil)
 
-	result, newUserID, err := service.ReassignReviewer("pr-1", "u2")
+	result, newUserID, err := service.ReassignReviewer(context.Background(), "pr-1", "u2")
 
 	assert.Error(t, err)
 	assert.Nil(t, result)
@@ -260,7 +266,7 @@ func TestPRService_ReassignReviewer_NotAssigned(t *testing.T) {
 
 	mockPRRepo.On("GetPR", "pr-1").Return(pr, nil)
 
-	result, newUserID, err := service.ReassignReviewer("pr-1", "u2")
+	result, newUserID, err := service.ReassignReviewer(context.Background(), "pr-1", "u2")
 
 	assert.Error(t, err)
 	assert.Nil(t, result)
@@ -289,13 +295,14 @@ func TestPRService_GetPRsByReviewer_Success(t *testing.T) {
 	}
 
 	mockUserRepo.On("GetUser", "u2").Return(user, nil)
-	mockPRRepo.On("GetPRsByReviewer", "u2").Return(prs, nil)
+	mockPRRepo.On("GetPRsByReviewer", "u2", &storage.ReviewerPRsFilter{Limit: DefaultPRListLimit + 1}).Return(prs, nil)
 
-	result, err := service.GetPRsByReviewer("u2")
+	result, cursor, err := service.GetPRsByReviewer(context.Background(), &api.GetUsersGetReviewParams{UserId: "u2"})
 
 	assert.NoError(t, err)
 	assert.NotNil(t, result)
 	assert.Len(t, result, 2)
+	assert.Empty(t, cursor)
 	mockPRRepo.AssertExpectations(t)
 	mockUserRepo.AssertExpectations(t)
 }
@@ -309,7 +316,7 @@ func TestPRService_GetPRsByReviewer_UserNotFound(t *testing.T) {
 
 	mockUserRepo.On("GetUser", "u2").Return(nil, storage.ErrNotFound)
 
-	result, err := service.GetPRsByReviewer("u2")
+	result, _, err := service.GetPRsByReviewer(context.Background(), &api.GetUsersGetReviewParams{UserId: "u2"})
 
 	assert.Error(t, err)
 	assert.Nil(t, result)
@@ -353,12 +360,13 @@ func TestPRService_ReassignReviewer_AuthorNotIncluded(t *testing.T) {
 	}
 
 	mockPRRepo.On("GetPR", "pr-1").Return(pr, nil).Once()
+	mockPRRepo.On("GetPRHistory", "pr-1").Return([]api.PullRequestHistoryEntry{}, nil)
 	mockUserRepo.On("GetUser", "u2").Return(oldReviewer, nil)
-	mockUserRepo.On("GetActiveUsersByTeam", "backend", "u2").Return(candidates, nil)
+	mockUserRepo.On("GetActiveUsersByTeam", "backend", "u2", mock.Anything).Return(candidates, nil)
 	// Должен быть назначен u4, а не автор u1
 	mockPRRepo.On("ReassignReviewer", "pr-1", "u2", "u4").Return(updatedPR, nil)
 
-	result, newUserID, err := service.ReassignReviewer("pr-1", "u2")
+	result, newUserID, err := service.ReassignReviewer(context.Background(), "pr-1", "u2")
 
 	assert.NoError(t, err)
 	assert.NotNil(t, result)
@@ -403,12 +411,15 @@ func TestPRService_ReassignReviewer_OnlyAuthorAvailable(t *testing.T) {
 	}
 
 	mockPRRepo.On("GetPR", "pr-1").Return(pr, nil).Once()
+	mockPRRepo.On("GetPRHistory", "pr-1").Return([]api.PullRequestHistoryEntry{}, nil)
 	mockUserRepo.On("GetUser", "u2").Return(oldReviewer, nil)
-	mockUserRepo.On("GetActiveUsersByTeam", "backend", "u2").Return(candidates, nil)
+	mockUserRepo.On("GetActiveUsersByTeam", "backend", "u2", mock.Anything).Return(candidates, nil)
+	// Резервных пулов у команды нет
+	mockTeamRepo.On("GetTeamSettings", "backend").Return(defaultTeamSettings("backend"), nil)
 	// Должен быть вызван с пустым newUserID (просто удаление)
 	mockPRRepo.On("ReassignReviewer", "pr-1", "u2", "").Return(updatedPR, nil)
 
-	result, newUserID, err := service.ReassignReviewer("pr-1", "u2")
+	result, newUserID, err := service.ReassignReviewer(context.Background(), "pr-1", "u2")
 
 	assert.NoError(t, err)
 	assert.NotNil(t, result)
@@ -454,12 +465,15 @@ func TestPRService_ReassignReviewer_NoCandidates(t *testing.T) {
 	}
 
 	mockPRRepo.On("GetPR", "pr-1").Return(pr, nil).Once()
+	mockPRRepo.On("GetPRHistory", "pr-1").Return([]api.PullRequestHistoryEntry{}, nil)
 	mockUserRepo.On("GetUser", "u2").Return(oldReviewer, nil)
-	mockUserRepo.On("GetActiveUsersByTeam", "backend", "u2").Return(candidates, nil)
+	mockUserRepo.On("GetActiveUsersByTeam", "backend", "u2", mock.Anything).Return(candidates, nil)
+	// Резервных пулов у команды нет
+	mockTeamRepo.On("GetTeamSettings", "backend").Return(defaultTeamSettings("backend"), nil)
 	// Должен быть вызван с пустым newUserID (просто удаление u2)
 	mockPRRepo.On("ReassignReviewer", "pr-1", "u2", "").Return(updatedPR, nil)
 
-	result, newUserID, err := service.ReassignReviewer("pr-1", "u2")
+	result, newUserID, err := service.ReassignReviewer(context.Background(), "pr-1", "u2")
 
 	assert.NoError(t, err)
 	assert.NotNil(t, result)
@@ -498,12 +512,14 @@ func TestPRService_AutoAssignReviewers_AddTwo(t *testing.T) {
 	}
 
 	mockPRRepo.On("GetPR", "pr-1").Return(pr, nil).Once()
+	mockPRRepo.On("GetPRHistory", "pr-1").Return([]api.PullRequestHistoryEntry{}, nil)
 	mockUserRepo.On("GetUser", "u1").Return(author, nil)
-	mockUserRepo.On("GetActiveUsersByTeam", "backend", "u1").Return(candidates, nil)
+	mockTeamRepo.On("GetTeamSettings", "backend").Return(defaultTeamSettings("backend"), nil)
+	mockUserRepo.On("GetActiveUsersByTeam", "backend", "u1", mock.Anything).Return(candidates, nil)
 	mockPRRepo.On("AddReviewer", "pr-1", mock.AnythingOfType("string")).Return(nil).Times(2)
 	mockPRRepo.On("GetPR", "pr-1").Return(pr, nil).Once()
 
-	result, err := service.AutoAssignReviewers("pr-1")
+	result, err := service.AutoAssignReviewers(context.Background(), "pr-1")
 
 	assert.NoError(t, err)
 	assert.NotNil(t, result)
@@ -546,12 +562,14 @@ func TestPRService_AutoAssignReviewers_AddOne(t *testing.T) {
 	}
 
 	mockPRRepo.On("GetPR", "pr-1").Return(pr, nil).Once()
+	mockPRRepo.On("GetPRHistory", "pr-1").Return([]api.PullRequestHistoryEntry{}, nil)
 	mockUserRepo.On("GetUser", "u1").Return(author, nil)
-	mockUserRepo.On("GetActiveUsersByTeam", "backend", "u1").Return(candidates, nil)
+	mockTeamRepo.On("GetTeamSettings", "backend").Return(defaultTeamSettings("backend"), nil)
+	mockUserRepo.On("GetActiveUsersByTeam", "backend", "u1", mock.Anything).Return(candidates, nil)
 	mockPRRepo.On("AddReviewer", "pr-1", "u3").Return(nil)
 	mockPRRepo.On("GetPR", "pr-1").Return(updatedPR, nil).Once()
 
-	result, err := service.AutoAssignReviewers("pr-1")
+	result, err := service.AutoAssignReviewers(context.Background(), "pr-1")
 
 	assert.NoError(t, err)
 	assert.NotNil(t, result)
@@ -575,15 +593,24 @@ func TestPRService_AutoAssignReviewers_AlreadyFull(t *testing.T) {
 		AssignedReviewers: []string{"u2", "u3"},
 	}
 
+	author := &api.User{
+		UserId:   "u1",
+		Username: "Alice",
+		TeamName: "backend",
+		IsActive: true,
+	}
+
 	mockPRRepo.On("GetPR", "pr-1").Return(pr, nil).Once()
+	mockUserRepo.On("GetUser", "u1").Return(author, nil)
+	mockTeamRepo.On("GetTeamSettings", "backend").Return(defaultTeamSettings("backend"), nil)
 
-	result, err := service.AutoAssignReviewers("pr-1")
+	result, err := service.AutoAssignReviewers(context.Background(), "pr-1")
 
 	assert.NoError(t, err)
 	assert.NotNil(t, result)
 	assert.Len(t, result.AssignedReviewers, 2)
 	mockPRRepo.AssertExpectations(t)
-	mockUserRepo.AssertNotCalled(t, "GetUser")
+	mockUserRepo.AssertNotCalled(t, "GetActiveUsersByTeam")
 	mockPRRepo.AssertNotCalled(t, "AddReviewer")
 }
 
@@ -604,10 +631,506 @@ func TestPRService_AutoAssignReviewers_PRMerged(t *testing.T) {
 
 	mockPRRepo.On("GetPR", "pr-1").Return(pr, nil)
 
-	result, err := service.AutoAssignReviewers("pr-1")
+	result, err := service.AutoAssignReviewers(context.Background(), "pr-1")
 
 	assert.Error(t, err)
 	assert.Nil(t, result)
 	assert.Equal(t, ErrPRMerged, err)
 	mockPRRepo.AssertExpectations(t)
 }
+
+func TestPRService_CreatePR_ReviewersCountOverride(t *testing.T) {
+	mockPRRepo := new(MockPRRepository)
+	mockUserRepo := new(MockUserRepository)
+	mockTeamRepo := new(MockTeamRepository)
+
+	service := NewPRService(mockPRRepo, mockUserRepo, mockTeamRepo)
+
+	author := &api.User{UserId: "u1", Username: "Alice", TeamName: "platform", IsActive: true}
+	candidates := []api.User{
+		{UserId: "u2", Username: "Bob", TeamName: "platform", IsActive: true},
+		{UserId: "u3", Username: "Charlie", TeamName: "platform", IsActive: true},
+		{UserId: "u4", Username: "David", TeamName: "platform", IsActive: true},
+		{UserId: "u5", Username: "Eve", TeamName: "platform", IsActive: true},
+	}
+	settings := &storage.TeamSettings{TeamName: "platform", MinReviewers: 1, MaxReviewers: 4}
+	reviewersCount := 3
+
+	mockUserRepo.On("GetUser", "u1").Return(author, nil)
+	mockPRRepo.On("GetPR", "pr-1").Return(nil, storage.ErrNotFound).Once()
+	mockTeamRepo.On("GetTeamSettings", "platform").Return(settings, nil)
+	mockUserRepo.On("GetActiveUsersByTeam", "platform", "u1", mock.Anything).Return(candidates, nil)
+	mockPRRepo.On("CreatePR", mock.MatchedBy(func(pr *api.PullRequest) bool {
+		return len(pr.AssignedReviewers) == 3 && pr.ReviewersCount != nil && *pr.ReviewersCount == 3
+	})).Return(&api.PullRequest{PullRequestId: "pr-1", Status: api.PullRequestStatusOPEN}, nil)
+
+	result, err := service.CreatePR(context.Background(), &api.CreatePullRequestRequest{
+		PullRequestId:   "pr-1",
+		PullRequestName: "Test PR",
+		AuthorId:        "u1",
+		ReviewersCount:  &reviewersCount,
+	})
+
+	assert.NoError(t, err)
+	assert.NotNil(t, result)
+	mockPRRepo.AssertExpectations(t)
+	mockTeamRepo.AssertExpectations(t)
+}
+
+func TestPRService_CreatePR_TeamMaxReviewers(t *testing.T) {
+	mockPRRepo := new(MockPRRepository)
+	mockUserRepo := new(MockUserRepository)
+	mockTeamRepo := new(MockTeamRepository)
+
+	service := NewPRService(mockPRRepo, mockUserRepo, mockTeamRepo)
+
+	author := &api.User{UserId: "u1", Username: "Alice", TeamName: "docs", IsActive: true}
+	candidates := []api.User{
+		{UserId: "u2", Username: "Bob", TeamName: "docs", IsActive: true},
+		{UserId: "u3", Username: "Charlie", TeamName: "docs", IsActive: true},
+	}
+
+	mockUserRepo.On("GetUser", "u1").Return(author, nil)
+	mockPRRepo.On("GetPR", "pr-1").Return(nil, storage.ErrNotFound).Once()
+	mockTeamRepo.On("GetTeamSettings", "docs").Return(&storage.TeamSettings{TeamName: "docs", MinReviewers: 1, MaxReviewers: 1}, nil)
+	mockUserRepo.On("GetActiveUsersByTeam", "docs", "u1", mock.Anything).Return(candidates, nil)
+	mockPRRepo.On("CreatePR", mock.MatchedBy(func(pr *api.PullRequest) bool {
+		return len(pr.AssignedReviewers) == 1 && pr.ReviewersCount == nil
+	})).Return(&api.PullRequest{PullRequestId: "pr-1", Status: api.PullRequestStatusOPEN}, nil)
+
+	result, err := service.CreatePR(context.Background(), &api.CreatePullRequestRequest{PullRequestId: "pr-1", PullRequestName: "Docs", AuthorId: "u1"})
+
+	assert.NoError(t, err)
+	assert.NotNil(t, result)
+	mockPRRepo.AssertExpectations(t)
+}
+
+func TestPRService_CreatePR_ReviewersCountOutOfRange(t *testing.T) {
+	mockPRRepo := new(MockPRRepository)
+	mockUserRepo := new(MockUserRepository)
+	mockTeamRepo := new(MockTeamRepository)
+
+	service := NewPRService(mockPRRepo, mockUserRepo, mockTeamRepo)
+
+	author := &api.User{UserId: "u1", Username: "Alice", TeamName: "backend", IsActive: true}
+	reviewersCount := 5
+
+	mockUserRepo.On("GetUser", "u1").Return(author, nil)
+	mockPRRepo.On("GetPR", "pr-1").Return(nil, storage.ErrNotFound).Once()
+	mockTeamRepo.On("GetTeamSettings", "backend").Return(defaultTeamSettings("backend"), nil)
+
+	result, err := service.CreatePR(context.Background(), &api.CreatePullRequestRequest{
+		PullRequestId:   "pr-1",
+		PullRequestName: "Test PR",
+		AuthorId:        "u1",
+		ReviewersCount:  &reviewersCount,
+	})
+
+	assert.Nil(t, result)
+	assert.Equal(t, ErrInvalidReviewersCount, err)
+	mockPRRepo.AssertNotCalled(t, "CreatePR", mock.Anything)
+	mockUserRepo.AssertNotCalled(t, "GetActiveUsersByTeam", mock.Anything, mock.Anything)
+}
+
+func TestPRService_AutoAssignReviewers_TopsUpToPRReviewersCount(t *testing.T) {
+	mockPRRepo := new(MockPRRepository)
+	mockUserRepo := new(MockUserRepository)
+	mockTeamRepo := new(MockTeamRepository)
+
+	service := NewPRService(mockPRRepo, mockUserRepo, mockTeamRepo)
+
+	// Для PR при создании запрошено 3 ревьювера, назначен пока 1
+	reviewersCount := 3
+	pr := &api.PullRequest{
+		PullRequestId:     "pr-1",
+		AuthorId:          "u1",
+		Status:            api.PullRequestStatusOPEN,
+		AssignedReviewers: []string{"u2"},
+		ReviewersCount:    &reviewersCount,
+	}
+	author := &api.User{UserId: "u1", Username: "Alice", TeamName: "platform", IsActive: true}
+	candidates := []api.User{
+		{UserId: "u2", Username: "Bob", TeamName: "platform", IsActive: true},
+		{UserId: "u3", Username: "Charlie", TeamName: "platform", IsActive: true},
+		{UserId: "u4", Username: "David", TeamName: "platform", IsActive: true},
+	}
+
+	mockPRRepo.On("GetPR", "pr-1").Return(pr, nil).Once()
+	mockPRRepo.On("GetPRHistory", "pr-1").Return([]api.PullRequestHistoryEntry{}, nil)
+	mockUserRepo.On("GetUser", "u1").Return(author, nil)
+	mockTeamRepo.On("GetTeamSettings", "platform").Return(&storage.TeamSettings{TeamName: "platform", MinReviewers: 1, MaxReviewers: 3}, nil)
+	mockUserRepo.On("GetActiveUsersByTeam", "platform", "u1", mock.Anything).Return(candidates, nil)
+	mockPRRepo.On("AddReviewer", "pr-1", "u3").Return(nil).Once()
+	mockPRRepo.On("AddReviewer", "pr-1", "u4").Return(nil).Once()
+	mockPRRepo.On("GetPR", "pr-1").Return(pr, nil).Once()
+
+	result, err := service.AutoAssignReviewers(context.Background(), "pr-1")
+
+	assert.NoError(t, err)
+	assert.NotNil(t, result)
+	mockPRRepo.AssertExpectations(t)
+}
+
+func TestPRService_CreatePR_Draft(t *testing.T) {
+	mockPRRepo := new(MockPRRepository)
+	mockUserRepo := new(MockUserRepository)
+	mockTeamRepo := new(MockTeamRepository)
+
+	service := NewPRService(mockPRRepo, mockUserRepo, mockTeamRepo)
+
+	author := &api.User{UserId: "u1", Username: "Alice", TeamName: "backend", IsActive: true}
+	draft := true
+
+	mockUserRepo.On("GetUser", "u1").Return(author, nil)
+	mockPRRepo.On("GetPR", "pr-1").Return(nil, storage.ErrNotFound).Once()
+	mockTeamRepo.On("GetTeamSettings", "backend").Return(defaultTeamSettings("backend"), nil)
+	mockPRRepo.On("CreatePR", mock.MatchedBy(func(pr *api.PullRequest) bool {
+		return pr.Status == api.PullRequestStatusDRAFT && len(pr.AssignedReviewers) == 0
+	})).Return(&api.PullRequest{PullRequestId: "pr-1", Status: api.PullRequestStatusDRAFT}, nil)
+
+	result, err := service.CreatePR(context.Background(), &api.CreatePullRequestRequest{
+		PullRequestId:   "pr-1",
+		PullRequestName: "WIP",
+		AuthorId:        "u1",
+		Draft:           &draft,
+	})
+
+	assert.NoError(t, err)
+	assert.Equal(t, api.PullRequestStatusDRAFT, result.Status)
+	mockPRRepo.AssertExpectations(t)
+	mockUserRepo.AssertNotCalled(t, "GetActiveUsersByTeam", mock.Anything, mock.Anything)
+}
+
+func TestPRService_AutoAssignReviewers_DraftDeferred(t *testing.T) {
+	mockPRRepo := new(MockPRRepository)
+	mockUserRepo := new(MockUserRepository)
+	mockTeamRepo := new(MockTeamRepository)
+
+	service := NewPRService(mockPRRepo, mockUserRepo, mockTeamRepo)
+
+	pr := &api.PullRequest{
+		PullRequestId:     "pr-1",
+		AuthorId:          "u1",
+		Status:            api.PullRequestStatusDRAFT,
+		AssignedReviewers: []string{},
+	}
+	mockPRRepo.On("GetPR", "pr-1").Return(pr, nil)
+
+	result, err := service.AutoAssignReviewers(context.Background(), "pr-1")
+
+	assert.NoError(t, err)
+	assert.Equal(t, pr, result)
+	mockPRRepo.AssertNotCalled(t, "AddReviewer", mock.Anything, mock.Anything)
+}
+
+func TestPRService_MarkReady_AssignsReviewers(t *testing.T) {
+	mockPRRepo := new(MockPRRepository)
+	mockUserRepo := new(MockUserRepository)
+	mockTeamRepo := new(MockTeamRepository)
+
+	service := NewPRService(mockPRRepo, mockUserRepo, mockTeamRepo)
+
+	draftPR := &api.PullRequest{PullRequestId: "pr-1", AuthorId: "u1", Status: api.PullRequestStatusDRAFT, AssignedReviewers: []string{}}
+	openPR := &api.PullRequest{PullRequestId: "pr-1", AuthorId: "u1", Status: api.PullRequestStatusOPEN, AssignedReviewers: []string{}}
+	assignedPR := &api.PullRequest{PullRequestId: "pr-1", AuthorId: "u1", Status: api.PullRequestStatusOPEN, AssignedReviewers: []string{"u2", "u3"}}
+	author := &api.User{UserId: "u1", Username: "Alice", TeamName: "backend", IsActive: true}
+	candidates := []api.User{
+		{UserId: "u2", Username: "Bob", TeamName: "backend", IsActive: true},
+		{UserId: "u3", Username: "Charlie", TeamName: "backend", IsActive: true},
+	}
+
+	mockPRRepo.On("GetPR", "pr-1").Return(draftPR, nil).Once()
+	mockPRRepo.On("GetPRHistory", "pr-1").Return([]api.PullRequestHistoryEntry{}, nil)
+	mockPRRepo.On("UpdatePRStatus", "pr-1", api.PullRequestStatusOPEN, (*time.Time)(nil)).Return(openPR, nil)
+	mockPRRepo.On("GetPRChangedFiles", "pr-1").Return(&storage.PRChangedFiles{Paths: []string{}}, nil)
+	mockUserRepo.On("GetUser", "u1").Return(author, nil)
+	mockTeamRepo.On("GetTeamSettings", "backend").Return(defaultTeamSettings("backend"), nil)
+	mockUserRepo.On("GetActiveUsersByTeam", "backend", "u1", mock.Anything).Return(candidates, nil)
+	mockPRRepo.On("AddReviewer", "pr-1", mock.AnythingOfType("string")).Return(nil).Twice()
+	mockPRRepo.On("GetPR", "pr-1").Return(assignedPR, nil).Once()
+
+	result, err := service.MarkReady(context.Background(), "pr-1")
+
+	assert.NoError(t, err)
+	assert.Equal(t, api.PullRequestStatusOPEN, result.Status)
+	assert.Len(t, result.AssignedReviewers, 2)
+	mockPRRepo.AssertExpectations(t)
+}
+
+func TestPRService_MarkReady_ClosedPR(t *testing.T) {
+	mockPRRepo := new(MockPRRepository)
+	mockUserRepo := new(MockUserRepository)
+	mockTeamRepo := new(MockTeamRepository)
+
+	service := NewPRService(mockPRRepo, mockUserRepo, mockTeamRepo)
+
+	mockPRRepo.On("GetPR", "pr-1").Return(&api.PullRequest{PullRequestId: "pr-1", Status: api.PullRequestStatusCLOSED}, nil)
+
+	result, err := service.MarkReady(context.Background(), "pr-1")
+
+	assert.Nil(t, result)
+	assert.Equal(t, ErrPRClosed, err)
+	mockPRRepo.AssertNotCalled(t, "UpdatePRStatus", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestPRService_ClosePR_Success(t *testing.T) {
+	mockPRRepo := new(MockPRRepository)
+	mockUserRepo := new(MockUserRepository)
+	mockTeamRepo := new(MockTeamRepository)
+
+	service := NewPRService(mockPRRepo, mockUserRepo, mockTeamRepo)
+
+	pr := &api.PullRequest{PullRequestId: "pr-1", Status: api.PullRequestStatusOPEN, AssignedReviewers: []string{"u2"}}
+	closedPR := &api.PullRequest{PullRequestId: "pr-1", Status: api.PullRequestStatusCLOSED, AssignedReviewers: []string{"u2"}}
+
+	mockPRRepo.On("GetPR", "pr-1").Return(pr, nil)
+	mockPRRepo.On("UpdatePRStatus", "pr-1", api.PullRequestStatusCLOSED, mock.AnythingOfType("*time.Time")).Return(closedPR, nil)
+
+	result, err := service.ClosePR(context.Background(), "pr-1")
+
+	assert.NoError(t, err)
+	assert.Equal(t, api.PullRequestStatusCLOSED, result.Status)
+	mockPRRepo.AssertExpectations(t)
+}
+
+func TestPRService_ClosePR_MergedPR(t *testing.T) {
+	mockPRRepo := new(MockPRRepository)
+	mockUserRepo := new(MockUserRepository)
+	mockTeamRepo := new(MockTeamRepository)
+
+	service := NewPRService(mockPRRepo, mockUserRepo, mockTeamRepo)
+
+	mockPRRepo.On("GetPR", "pr-1").Return(&api.PullRequest{PullRequestId: "pr-1", Status: api.PullRequestStatusMERGED}, nil)
+
+	result, err := service.ClosePR(context.Background(), "pr-1")
+
+	assert.Nil(t, result)
+	assert.Equal(t, ErrPRMerged, err)
+	mockPRRepo.AssertNotCalled(t, "UpdatePRStatus", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestPRService_ReopenPR_TopsUpReviewers(t *testing.T) {
+	mockPRRepo := new(MockPRRepository)
+	mockUserRepo := new(MockUserRepository)
+	mockTeamRepo := new(MockTeamRepository)
+
+	service := NewPRService(mockPRRepo, mockUserRepo, mockTeamRepo)
+
+	closedPR := &api.PullRequest{PullRequestId: "pr-1", AuthorId: "u1", Status: api.PullRequestStatusCLOSED, AssignedReviewers: []string{"u2"}}
+	openPR := &api.PullRequest{PullRequestId: "pr-1", AuthorId: "u1", Status: api.PullRequestStatusOPEN, AssignedReviewers: []string{"u2"}}
+	author := &api.User{UserId: "u1", Username: "Alice", TeamName: "backend", IsActive: true}
+	candidates := []api.User{
+		{UserId: "u2", Username: "Bob", TeamName: "backend", IsActive: true},
+		{UserId: "u3", Username: "Charlie", TeamName: "backend", IsActive: true},
+	}
+
+	mockPRRepo.On("GetPR", "pr-1").Return(closedPR, nil).Once()
+	mockPRRepo.On("GetPRHistory", "pr-1").Return([]api.PullRequestHistoryEntry{}, nil)
+	mockPRRepo.On("UpdatePRStatus", "pr-1", api.PullRequestStatusOPEN, (*time.Time)(nil)).Return(openPR, nil)
+	mockUserRepo.On("GetUser", "u1").Return(author, nil)
+	mockTeamRepo.On("GetTeamSettings", "backend").Return(defaultTeamSettings("backend"), nil)
+	mockUserRepo.On("GetActiveUsersByTeam", "backend", "u1", mock.Anything).Return(candidates, nil)
+	mockPRRepo.On("AddReviewer", "pr-1", "u3").Return(nil).Once()
+	mockPRRepo.On("GetPR", "pr-1").Return(openPR, nil).Once()
+
+	result, err := service.ReopenPR(context.Background(), "pr-1")
+
+	assert.NoError(t, err)
+	assert.Equal(t, api.PullRequestStatusOPEN, result.Status)
+	mockPRRepo.AssertExpectations(t)
+}
+
+func TestPRService_ReassignReviewer_ClosedPR(t *testing.T) {
+	mockPRRepo := new(MockPRRepository)
+	mockUserRepo := new(MockUserRepository)
+	mockTeamRepo := new(MockTeamRepository)
+
+	service := NewPRService(mockPRRepo, mockUserRepo, mockTeamRepo)
+
+	pr := &api.PullRequest{PullRequestId: "pr-1", Status: api.PullRequestStatusCLOSED, AssignedReviewers: []string{"u2"}}
+	mockPRRepo.On("GetPR", "pr-1").Return(pr, nil)
+
+	result, newUserID, err := service.ReassignReviewer(context.Background(), "pr-1", "u2")
+
+	assert.Nil(t, result)
+	assert.Empty(t, newUserID)
+	assert.Equal(t, ErrPRClosed, err)
+	mockPRRepo.AssertNotCalled(t, "ReassignReviewer", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestPRService_MergePR_Draft(t *testing.T) {
+	mockPRRepo := new(MockPRRepository)
+	mockUserRepo := new(MockUserRepository)
+	mockTeamRepo := new(MockTeamRepository)
+
+	service := NewPRService(mockPRRepo, mockUserRepo, mockTeamRepo)
+
+	mockPRRepo.On("GetPR", "pr-1").Return(&api.PullRequest{PullRequestId: "pr-1", Status: api.PullRequestStatusDRAFT}, nil)
+
+	result, err := service.MergePR(context.Background(), "pr-1")
+
+	assert.Nil(t, result)
+	assert.Equal(t, ErrPRDraft, err)
+	mockPRRepo.AssertNotCalled(t, "UpdatePRStatus", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestPRService_MergePR_NotEnoughApprovals(t *testing.T) {
+	mockPRRepo := new(MockPRRepository)
+	mockUserRepo := new(MockUserRepository)
+	mockTeamRepo := new(MockTeamRepository)
+
+	service := NewPRService(mockPRRepo, mockUserRepo, mockTeamRepo)
+
+	pr := &api.PullRequest{
+		PullRequestId:     "pr-1",
+		AuthorId:          "u1",
+		Status:            api.PullRequestStatusOPEN,
+		AssignedReviewers: []string{"u2", "u3"},
+		Reviews: []api.PullRequestReview{
+			{UserId: "u2", Verdict: api.APPROVED, SubmittedAt: time.Now()},
+			{UserId: "u3", Verdict: api.CHANGESREQUESTED, SubmittedAt: time.Now()},
+		},
+	}
+	settings := &storage.TeamSettings{TeamName: "backend", MinReviewers: 1, MaxReviewers: 2, RequiredApprovals: 2}
+
+	mockPRRepo.On("GetPR", "pr-1").Return(pr, nil)
+	mockUserRepo.On("GetUser", "u1").Return(&api.User{UserId: "u1", TeamName: "backend"}, nil)
+	mockTeamRepo.On("GetTeamSettings", "backend").Return(settings, nil)
+
+	result, err := service.MergePR(context.Background(), "pr-1")
+
+	assert.Nil(t, result)
+	assert.Equal(t, ErrNotEnoughApprovals, err)
+	mockPRRepo.AssertNotCalled(t, "UpdatePRStatus", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestPRService_MergePR_RequiredApprovalsCappedByReviewersCount(t *testing.T) {
+	mockPRRepo := new(MockPRRepository)
+	mockUserRepo := new(MockUserRepository)
+	mockTeamRepo := new(MockTeamRepository)
+
+	service := NewPRService(mockPRRepo, mockUserRepo, mockTeamRepo)
+
+	// Для PR запрошен 1 ревьювер, команда требует 2 одобрения - достаточно одного
+	reviewersCount := 1
+	pr := &api.PullRequest{
+		PullRequestId:     "pr-1",
+		AuthorId:          "u1",
+		Status:            api.PullRequestStatusOPEN,
+		AssignedReviewers: []string{"u2"},
+		ReviewersCount:    &reviewersCount,
+		Reviews:           []api.PullRequestReview{{UserId: "u2", Verdict: api.APPROVED, SubmittedAt: time.Now()}},
+	}
+	settings := &storage.TeamSettings{TeamName: "backend", MinReviewers: 1, MaxReviewers: 2, RequiredApprovals: 2}
+	mergedPR := &api.PullRequest{PullRequestId: "pr-1", Status: api.PullRequestStatusMERGED}
+
+	mockPRRepo.On("GetPR", "pr-1").Return(pr, nil)
+	mockUserRepo.On("GetUser", "u1").Return(&api.User{UserId: "u1", TeamName: "backend"}, nil)
+	mockTeamRepo.On("GetTeamSettings", "backend").Return(settings, nil)
+	mockPRRepo.On("UpdatePRStatus", "pr-1", api.PullRequestStatusMERGED, mock.AnythingOfType("*time.Time")).Return(mergedPR, nil)
+
+	result, err := service.MergePR(context.Background(), "pr-1")
+
+	assert.NoError(t, err)
+	assert.Equal(t, api.PullRequestStatusMERGED, result.Status)
+	mockPRRepo.AssertExpectations(t)
+}
+
+func TestPRService_SubmitReview_Success(t *testing.T) {
+	mockPRRepo := new(MockPRRepository)
+	mockUserRepo := new(MockUserRepository)
+	mockTeamRepo := new(MockTeamRepository)
+
+	service := NewPRService(mockPRRepo, mockUserRepo, mockTeamRepo)
+
+	pr := &api.PullRequest{PullRequestId: "pr-1", AuthorId: "u1", Status: api.PullRequestStatusOPEN, AssignedReviewers: []string{"u2", "u3"}}
+	reviewedPR := &api.PullRequest{
+		PullRequestId:     "pr-1",
+		AuthorId:          "u1",
+		Status:            api.PullRequestStatusOPEN,
+		AssignedReviewers: []string{"u2", "u3"},
+		Reviews:           []api.PullRequestReview{{UserId: "u2", Verdict: api.APPROVED, SubmittedAt: time.Now()}},
+	}
+
+	mockPRRepo.On("GetPR", "pr-1").Return(pr, nil).Once()
+	mockPRRepo.On("AddReview", "pr-1", "u2", api.APPROVED).Return(nil)
+	mockPRRepo.On("GetPR", "pr-1").Return(reviewedPR, nil).Once()
+
+	result, err := service.SubmitReview(context.Background(), "pr-1", "u2", api.APPROVED)
+
+	assert.NoError(t, err)
+	assert.Len(t, result.Reviews, 1)
+	mockPRRepo.AssertExpectations(t)
+}
+
+func TestPRService_SubmitReview_NotAssigned(t *testing.T) {
+	mockPRRepo := new(MockPRRepository)
+	mockUserRepo := new(MockUserRepository)
+	mockTeamRepo := new(MockTeamRepository)
+
+	service := NewPRService(mockPRRepo, mockUserRepo, mockTeamRepo)
+
+	pr := &api.PullRequest{PullRequestId: "pr-1", AuthorId: "u1", Status: api.PullRequestStatusOPEN, AssignedReviewers: []string{"u2"}}
+	mockPRRepo.On("GetPR", "pr-1").Return(pr, nil)
+
+	result, err := service.SubmitReview(context.Background(), "pr-1", "u5", api.COMMENTED)
+
+	assert.Nil(t, result)
+	assert.Equal(t, ErrNotAssigned, err)
+	mockPRRepo.AssertNotCalled(t, "AddReview", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestPRService_SubmitReview_InvalidVerdict(t *testing.T) {
+	mockPRRepo := new(MockPRRepository)
+	mockUserRepo := new(MockUserRepository)
+	mockTeamRepo := new(MockTeamRepository)
+
+	service := NewPRService(mockPRRepo, mockUserRepo, mockTeamRepo)
+
+	result, err := service.SubmitReview(context.Background(), "pr-1", "u2", api.ReviewVerdict("LGTM"))
+
+	assert.Nil(t, result)
+	assert.Equal(t, ErrInvalidVerdict, err)
+	mockPRRepo.AssertNotCalled(t, "GetPR", mock.Anything)
+}
+
+func TestPRService_GetReviewerStatistics_Filters(t *testing.T) {
+	mockPRRepo := new(MockPRRepository)
+	mockTeamRepo := new(MockTeamRepository)
+	service := NewPRService(mockPRRepo, new(MockUserRepository), mockTeamRepo)
+	ctx := context.Background()
+
+	from := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	to := from.AddDate(0, 0, 14)
+	team, status := "backend", api.PullRequestStatusMERGED
+	expected := []storage.ReviewerStatistic{{UserID: "u2", Username: "Bob", TeamName: "backend", AssignmentsCount: 3}}
+
+	mockTeamRepo.On("GetTeamSettings", "backend").Return(defaultTeamSettings("backend"), nil)
+	mockPRRepo.On("GetReviewerStatistics", &storage.StatisticsFilter{TeamName: "backend", From: &from, To: &to, Status: status}).Return(expected, nil)
+
+	result, err := service.GetReviewerStatistics(ctx, &api.GetStatisticsParams{TeamName: &team, From: &from, To: &to, Status: &status})
+	assert.NoError(t, err)
+	assert.Equal(t, expected, result)
+	mockPRRepo.AssertExpectations(t)
+}
+
+func TestPRService_GetReviewerStatistics_InvalidParams(t *testing.T) {
+	mockPRRepo := new(MockPRRepository)
+	mockTeamRepo := new(MockTeamRepository)
+	service := NewPRService(mockPRRepo, new(MockUserRepository), mockTeamRepo)
+	ctx := context.Background()
+
+	from := time.Now()
+	_, err := service.GetReviewerStatistics(ctx, &api.GetStatisticsParams{From: &from, To: &from})
+	assert.Equal(t, ErrInvalidTimeRange, err)
+
+	badStatus := api.PullRequestStatus("UNKNOWN")
+	_, err = service.GetReviewerStatistics(ctx, &api.GetStatisticsParams{Status: &badStatus})
+	assert.Equal(t, ErrInvalidStatus, err)
+
+	team := "missing"
+	mockTeamRepo.On("GetTeamSettings", "missing").Return(nil, storage.ErrNotFound)
+	_, err = service.GetReviewerStatistics(ctx, &api.GetStatisticsParams{TeamName: &team})
+	assert.Equal(t, ErrNotFound, err)
+	mockPRRepo.AssertNotCalled(t, "GetReviewerStatistics", mock.Anything)
+}
@@ -0,0 +1,105 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"time"
+
+	"pr-review-assigner/internal/api"
+	"pr-review-assigner/internal/storage"
+)
+
+// SetReviewerPool создает пул ревьюверов или заменяет его участников
+// Участники могут быть из любых команд, но должны существовать
+func (s *TeamService) SetReviewerPool(ctx context.Context, pool *api.ReviewerPool) (*api.ReviewerPool, error) {
+	if strings.TrimSpace(pool.PoolName) == "" {
+		return nil, ErrInvalidReviewerPool
+	}
+	for _, userID := range pool.Members {
+		if _, err := s.userRepo.GetUser(ctx, userID); err != nil {
+			if errors.Is(err, storage.ErrNotFound) {
+				return nil, ErrInvalidReviewerPool
+			}
+			return nil, MapStorageError(err)
+		}
+	}
+
+	if err := s.teamRepo.SaveReviewerPool(ctx, pool); err != nil {
+		if errors.Is(err, storage.ErrForeignKeyViolation) {
+			return nil, ErrInvalidReviewerPool
+		}
+		return nil, MapStorageError(err)
+	}
+	return s.GetReviewerPool(ctx, pool.PoolName)
+}
+
+// GetReviewerPool получает пул ревьюверов
+func (s *TeamService) GetReviewerPool(ctx context.Context, poolName string) (*api.ReviewerPool, error) {
+	pool, err := s.teamRepo.GetReviewerPool(ctx, poolName)
+	if err != nil {
+		return nil, MapStorageError(err)
+	}
+	return pool, nil
+}
+
+// ListReviewerPools получает все пулы ревьюверов
+func (s *TeamService) ListReviewerPools(ctx context.Context) ([]api.ReviewerPool, error) {
+	return s.teamRepo.ListReviewerPools(ctx)
+}
+
+// DeleteReviewerPool удаляет пул ревьюверов; команды перестают использовать его как резервный
+func (s *TeamService) DeleteReviewerPool(ctx context.Context, poolName string) error {
+	return MapStorageError(s.teamRepo.DeleteReviewerPool(ctx, poolName))
+}
+
+// checkFallbackPools проверяет, что резервные пулы команды существуют
+func (s *TeamService) checkFallbackPools(ctx context.Context, poolNames []string) error {
+	for _, poolName := range poolNames {
+		if _, err := s.teamRepo.GetReviewerPool(ctx, poolName); err != nil {
+			if errors.Is(err, storage.ErrNotFound) {
+				return ErrInvalidFallbackPools
+			}
+			return MapStorageError(err)
+		}
+	}
+	return nil
+}
+
+// fillFromFallbackPools добирает ревьюверов до count из резервных пулов команды по порядку,
+// когда активных участников команды не хватило. Кандидаты пула выбираются стратегией selector,
+// exclude, уже выбранные ревьюверы и отсутствующие в момент now пропускаются
+// Используется при любом назначении: создание PR, дополнение, замена ревьювера, деактивация и передача ревью
+func fillFromFallbackPools(ctx context.Context, userRepo storage.UserRepositoryInterface, selector ReviewerSelector, settings *storage.TeamSettings, selected []string, count int, now time.Time, exclude ...string) ([]string, error) {
+	for _, poolName := range settings.FallbackPools {
+		if len(selected) >= count {
+			break
+		}
+
+		members, err := userRepo.GetActiveUsersByPool(ctx, poolName, now)
+		if err != nil {
+			return nil, err
+		}
+		available := filterCandidates(filterCandidates(members, exclude...), selected...)
+		picked, err := selector.Select(ctx, available, count-len(selected))
+		if err != nil {
+			return nil, err
+		}
+		selected = append(selected, picked...)
+	}
+	return selected, nil
+}
+
+// pickFromFallbackPools выбирает замену ревьюверу из резервных пулов команды teamName
+// Пустой результат без ошибки - в пулах нет подходящих кандидатов
+func pickFromFallbackPools(ctx context.Context, teamRepo storage.TeamRepositoryInterface, userRepo storage.UserRepositoryInterface, selector ReviewerSelector, teamName string, now time.Time, exclude ...string) (string, error) {
+	settings, err := teamRepo.GetTeamSettings(ctx, teamName)
+	if err != nil {
+		return "", err
+	}
+	picked, err := fillFromFallbackPools(ctx, userRepo, selector, settings, nil, 1, now, exclude...)
+	if err != nil || len(picked) == 0 {
+		return "", err
+	}
+	return picked[0], nil
+}
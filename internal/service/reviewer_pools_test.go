@@ -0,0 +1,130 @@
+package service
+
+import (
+	"context"
+	"testing"
+
+	"pr-review-assigner/internal/api"
+	"pr-review-assigner/internal/storage"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTeamService_SetReviewerPool(t *testing.T) {
+	mockTeamRepo := new(MockTeamRepository)
+	mockUserRepo := new(MockUserRepository)
+	service := NewTeamService(mockTeamRepo, mockUserRepo)
+	ctx := context.Background()
+
+	pool := &api.ReviewerPool{PoolName: "go", Members: []string{"u1", "p1"}}
+	mockUserRepo.On("GetUser", "u1").Return(&api.User{UserId: "u1", TeamName: "backend"}, nil)
+	mockUserRepo.On("GetUser", "p1").Return(&api.User{UserId: "p1", TeamName: "platform"}, nil)
+	mockUserRepo.On("GetUser", "missing").Return(nil, storage.ErrNotFound)
+	mockTeamRepo.On("SaveReviewerPool", pool).Return(nil).Once()
+	mockTeamRepo.On("GetReviewerPool", "go").Return(&api.ReviewerPool{PoolName: "go", Members: []string{"p1", "u1"}}, nil)
+
+	result, err := service.SetReviewerPool(ctx, pool)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"p1", "u1"}, result.Members)
+
+	_, err = service.SetReviewerPool(ctx, &api.ReviewerPool{PoolName: " ", Members: []string{"u1"}})
+	assert.Equal(t, ErrInvalidReviewerPool, err)
+	_, err = service.SetReviewerPool(ctx, &api.ReviewerPool{PoolName: "go", Members: []string{"u1", "missing"}})
+	assert.Equal(t, ErrInvalidReviewerPool, err)
+	mockTeamRepo.AssertExpectations(t)
+}
+
+func TestTeamService_FallbackPoolsValidation(t *testing.T) {
+	mockTeamRepo := new(MockTeamRepository)
+	mockUserRepo := new(MockUserRepository)
+	service := NewTeamService(mockTeamRepo, mockUserRepo)
+	ctx := context.Background()
+
+	mockTeamRepo.On("GetReviewerPool", "go").Return(&api.ReviewerPool{PoolName: "go", Members: []string{}}, nil)
+	mockTeamRepo.On("GetReviewerPool", "missing").Return(nil, storage.ErrNotFound)
+
+	duplicates := []string{"go", "go"}
+	_, err := service.CreateOrUpdateTeam(ctx, &api.Team{TeamName: "backend", FallbackPools: &duplicates})
+	assert.Equal(t, ErrInvalidFallbackPools, err)
+
+	unknown := []string{"go", "missing"}
+	_, err = service.CreateOrUpdateTeam(ctx, &api.Team{TeamName: "backend", FallbackPools: &unknown})
+	assert.Equal(t, ErrInvalidFallbackPools, err)
+	mockTeamRepo.AssertNotCalled(t, "CreateTeam", mock.Anything)
+
+	// При обновлении резервные пулы заменяются целиком
+	pools := []string{"go"}
+	mockTeamRepo.On("GetTeam", "backend").Return(&api.Team{TeamName: "backend", FallbackPools: &pools}, nil)
+	mockTeamRepo.On("GetTeamSettings", "backend").Return(defaultTeamSettings("backend"), nil)
+	expected := defaultTeamSettings("backend")
+	expected.FallbackPools = pools
+	mockTeamRepo.On("UpdateTeamSettings", expected).Return(nil).Once()
+
+	team, err := service.UpdateTeam(ctx, &api.Team{TeamName: "backend", Members: []api.TeamMember{}, FallbackPools: &pools})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"go"}, *team.FallbackPools)
+	mockTeamRepo.AssertExpectations(t)
+}
+
+func TestPRService_CreatePRFillsFromFallbackPools(t *testing.T) {
+	mockPRRepo := new(MockPRRepository)
+	mockUserRepo := new(MockUserRepository)
+	mockTeamRepo := new(MockTeamRepository)
+	service := NewPRService(mockPRRepo, mockUserRepo, mockTeamRepo)
+
+	settings := defaultTeamSettings("backend")
+	settings.MaxReviewers = 3
+	settings.FallbackPools = []string{"empty", "go"}
+	mockUserRepo.On("GetUser", "u1").Return(&api.User{UserId: "u1", TeamName: "backend", IsActive: true}, nil)
+	mockPRRepo.On("GetPR", "pr-1").Return(nil, storage.ErrNotFound)
+	mockTeamRepo.On("GetTeamSettings", "backend").Return(settings, nil)
+	mockUserRepo.On("GetActiveUsersByTeam", "backend", "u1", mock.Anything).Return([]api.User{{UserId: "u2", TeamName: "backend", IsActive: true}}, nil)
+	mockUserRepo.On("GetActiveUsersByPool", "empty", mock.Anything).Return(nil, nil)
+	// Автор и уже выбранные участники команды в пуле пропускаются
+	mockUserRepo.On("GetActiveUsersByPool", "go", mock.Anything).Return([]api.User{
+		{UserId: "p1", TeamName: "platform", IsActive: true},
+		{UserId: "p2", TeamName: "platform", IsActive: true},
+		{UserId: "u1", TeamName: "backend", IsActive: true},
+		{UserId: "u2", TeamName: "backend", IsActive: true},
+	}, nil)
+	var created *api.PullRequest
+	mockPRRepo.On("CreatePR", mock.MatchedBy(func(pr *api.PullRequest) bool {
+		created = pr
+		return true
+	})).Return(&api.PullRequest{PullRequestId: "pr-1"}, nil)
+
+	_, err := service.CreatePR(context.Background(), &api.CreatePullRequestRequest{PullRequestId: "pr-1", PullRequestName: "Feature", AuthorId: "u1"})
+	require.NoError(t, err)
+	require.Len(t, created.AssignedReviewers, 3)
+	assert.Equal(t, "u2", created.AssignedReviewers[0])
+	assert.ElementsMatch(t, []string{"p1", "p2"}, created.AssignedReviewers[1:])
+	mockUserRepo.AssertExpectations(t)
+}
+
+func TestPRService_ReassignReviewerFromFallbackPool(t *testing.T) {
+	mockPRRepo := new(MockPRRepository)
+	mockUserRepo := new(MockUserRepository)
+	mockTeamRepo := new(MockTeamRepository)
+	service := NewPRService(mockPRRepo, mockUserRepo, mockTeamRepo)
+
+	settings := defaultTeamSettings("backend")
+	settings.FallbackPools = []string{"go"}
+	pr := &api.PullRequest{PullRequestId: "pr-1", AuthorId: "u1", Status: api.PullRequestStatusOPEN, AssignedReviewers: []string{"u2", "p1"}}
+	mockPRRepo.On("GetPR", "pr-1").Return(pr, nil)
+	mockPRRepo.On("GetPRHistory", "pr-1").Return([]api.PullRequestHistoryEntry{}, nil)
+	mockUserRepo.On("GetUser", "u2").Return(&api.User{UserId: "u2", TeamName: "backend", IsActive: true}, nil)
+	mockUserRepo.On("GetActiveUsersByTeam", "backend", "u2", mock.Anything).Return([]api.User{{UserId: "u1", TeamName: "backend", IsActive: true}}, nil)
+	mockTeamRepo.On("GetTeamSettings", "backend").Return(settings, nil)
+	mockUserRepo.On("GetActiveUsersByPool", "go", mock.Anything).Return([]api.User{
+		{UserId: "p1", TeamName: "platform", IsActive: true},
+		{UserId: "p2", TeamName: "platform", IsActive: true},
+	}, nil)
+	mockPRRepo.On("ReassignReviewer", "pr-1", "u2", "p2").Return(&api.PullRequest{PullRequestId: "pr-1", AssignedReviewers: []string{"p2", "p1"}}, nil)
+
+	_, newUserID, err := service.ReassignReviewer(context.Background(), "pr-1", "u2")
+	require.NoError(t, err)
+	assert.Equal(t, "p2", newUserID)
+	mockPRRepo.AssertExpectations(t)
+}
@@ -0,0 +1,471 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"math/rand"
+	"sort"
+	"sync"
+	"time"
+
+	"pr-review-assigner/internal/api"
+	"pr-review-assigner/internal/storage"
+)
+
+// Названия стратегий выбора ревьюверов, используемые в конфигурации
+const (
+	StrategyRandom       = "random"
+	StrategyRoundRobin   = "round_robin"
+	StrategyLeastLoaded  = "least_loaded"
+	StrategyWeighted     = "weighted"
+	StrategyWorkingHours = "working_hours"
+)
+
+// ReviewerSelector определяет стратегию выбора ревьюверов из списка кандидатов
+type ReviewerSelector interface {
+	// Select возвращает user_id не более чем count кандидатов
+	Select(ctx context.Context, candidates []api.User, count int) ([]string, error)
+}
+
+// ReviewLoadSource предоставляет текущую нагрузку ревьюверов (количество открытых PR на ревью)
+type ReviewLoadSource interface {
+	OpenReviewCounts(ctx context.Context, userIDs []string) (map[string]int, error)
+}
+
+// RandomSelector выбирает ревьюверов равновероятно
+type RandomSelector struct {
+	mu  sync.Mutex
+	rng *rand.Rand
+}
+
+// NewRandomSelector создает случайную стратегию с указанным seed
+func NewRandomSelector(seed int64) *RandomSelector {
+	return &RandomSelector{rng: rand.New(rand.NewSource(seed))}
+}
+
+// Select выбирает случайных ревьюверов (до count)
+func (s *RandomSelector) Select(ctx context.Context, candidates []api.User, count int) ([]string, error) {
+	if count <= 0 || len(candidates) == 0 {
+		return []string{}, nil
+	}
+
+	// Если кандидатов меньше или равно count, возвращаем всех
+	if len(candidates) <= count {
+		return userIDs(candidates), nil
+	}
+
+	s.mu.Lock()
+	perm := s.rng.Perm(len(candidates))
+	s.mu.Unlock()
+
+	result := make([]string, 0, count)
+	for _, idx := range perm[:count] {
+		result = append(result, candidates[idx].UserId)
+	}
+	return result, nil
+}
+
+// RoundRobinSelector выбирает ревьюверов по кругу в порядке user_id
+// Запоминает последнего выбранного, поэтому устойчив к изменению набора кандидатов
+type RoundRobinSelector struct {
+	mu     sync.Mutex
+	lastID string
+}
+
+// NewRoundRobinSelector создает стратегию round-robin
+func NewRoundRobinSelector() *RoundRobinSelector {
+	return &RoundRobinSelector{}
+}
+
+// Select выбирает следующих по кругу ревьюверов (до count)
+func (s *RoundRobinSelector) Select(ctx context.Context, candidates []api.User, count int) ([]string, error) {
+	if count <= 0 || len(candidates) == 0 {
+		return []string{}, nil
+	}
+
+	ids := userIDs(candidates)
+	sort.Strings(ids)
+	if count > len(ids) {
+		count = len(ids)
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	// Начинаем с первого user_id, следующего за последним выбранным
+	start := sort.SearchStrings(ids, s.lastID)
+	if start < len(ids) && ids[start] == s.lastID {
+		start++
+	}
+
+	result := make([]string, 0, count)
+	for i := 0; i < count; i++ {
+		result = append(result, ids[(start+i)%len(ids)])
+	}
+	s.lastID = result[len(result)-1]
+
+	return result, nil
+}
+
+// LeastLoadedSelector выбирает ревьюверов с наименьшим количеством открытых PR на ревью
+type LeastLoadedSelector struct {
+	mu    sync.Mutex
+	rng   *rand.Rand
+	loads ReviewLoadSource
+}
+
+// NewLeastLoadedSelector создает стратегию выбора наименее загруженных ревьюверов
+// seed используется для случайного выбора среди одинаково загруженных кандидатов
+func NewLeastLoadedSelector(loads ReviewLoadSource, seed int64) *LeastLoadedSelector {
+	return &LeastLoadedSelector{
+		rng:   rand.New(rand.NewSource(seed)),
+		loads: loads,
+	}
+}
+
+// Select выбирает наименее загруженных ревьюверов (до count)
+func (s *LeastLoadedSelector) Select(ctx context.Context, candidates []api.User, count int) ([]string, error) {
+	if count <= 0 || len(candidates) == 0 {
+		return []string{}, nil
+	}
+
+	counts, err := s.loads.OpenReviewCounts(ctx, userIDs(candidates))
+	if err != nil {
+		return nil, err
+	}
+	return s.pick(candidates, counts, count), nil
+}
+
+// WithPlannedLoad возвращает стратегию, которая учитывает назначения из planned
+// поверх сохраненной нагрузки и запрашивает нагрузку каждого кандидата не более одного раза
+func (s *LeastLoadedSelector) WithPlannedLoad(planned map[string]int) ReviewerSelector {
+	return &plannedLoadSelector{
+		base:    s,
+		planned: planned,
+		stored:  make(map[string]int),
+		fetched: make(map[string]bool),
+	}
+}
+
+// pick упорядочивает кандидатов по нагрузке, перемешивая равные, и берет первых count
+func (s *LeastLoadedSelector) pick(candidates []api.User, counts map[string]int, count int) []string {
+	ids := userIDs(candidates)
+
+	s.mu.Lock()
+	s.rng.Shuffle(len(ids), func(i, j int) { ids[i], ids[j] = ids[j], ids[i] })
+	s.mu.Unlock()
+
+	sort.SliceStable(ids, func(i, j int) bool {
+		return counts[ids[i]] < counts[ids[j]]
+	})
+
+	if count > len(ids) {
+		count = len(ids)
+	}
+	return ids[:count]
+}
+
+// PlannedLoadAware реализуется стратегиями, которым при пакетном планировании
+// нужно учитывать еще не сохраненные назначения
+type PlannedLoadAware interface {
+	WithPlannedLoad(planned map[string]int) ReviewerSelector
+}
+
+// plannedLoadSelector - LeastLoadedSelector в рамках одной пакетной операции
+type plannedLoadSelector struct {
+	base    *LeastLoadedSelector
+	planned map[string]int
+	stored  map[string]int
+	fetched map[string]bool
+}
+
+// Select выбирает наименее загруженных с учетом запланированных назначений
+func (s *plannedLoadSelector) Select(ctx context.Context, candidates []api.User, count int) ([]string, error) {
+	if count <= 0 || len(candidates) == 0 {
+		return []string{}, nil
+	}
+
+	// Запрашиваем нагрузку только для кандидатов, которых еще не видели
+	var missing []string
+	for _, candidate := range candidates {
+		if !s.fetched[candidate.UserId] {
+			missing = append(missing, candidate.UserId)
+		}
+	}
+	if len(missing) > 0 {
+		counts, err := s.base.loads.OpenReviewCounts(ctx, missing)
+		if err != nil {
+			return nil, err
+		}
+		for _, userID := range missing {
+			s.stored[userID] = counts[userID]
+			s.fetched[userID] = true
+		}
+	}
+
+	total := make(map[string]int, len(candidates))
+	for _, candidate := range candidates {
+		total[candidate.UserId] = s.stored[candidate.UserId] + s.planned[candidate.UserId]
+	}
+	return s.base.pick(candidates, total, count), nil
+}
+
+// WeightedSelector выбирает ревьюверов случайно пропорционально их весам
+// Пользователи без явно заданного веса имеют вес 1, пользователи с весом 0
+// выбираются только если остальных кандидатов не хватает
+type WeightedSelector struct {
+	mu      sync.Mutex
+	rng     *rand.Rand
+	weights map[string]int
+}
+
+// NewWeightedSelector создает взвешенную стратегию с указанным seed
+func NewWeightedSelector(weights map[string]int, seed int64) *WeightedSelector {
+	return &WeightedSelector{
+		rng:     rand.New(rand.NewSource(seed)),
+		weights: weights,
+	}
+}
+
+// Select выбирает ревьюверов без повторений с вероятностью, пропорциональной весу (до count)
+func (s *WeightedSelector) Select(ctx context.Context, candidates []api.User, count int) ([]string, error) {
+	if count <= 0 || len(candidates) == 0 {
+		return []string{}, nil
+	}
+
+	var weighted, zero []string
+	for _, candidate := range candidates {
+		if s.weight(candidate.UserId) > 0 {
+			weighted = append(weighted, candidate.UserId)
+		} else {
+			zero = append(zero, candidate.UserId)
+		}
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	result := make([]string, 0, count)
+	for len(result) < count && len(weighted) > 0 {
+		total := 0
+		for _, id := range weighted {
+			total += s.weight(id)
+		}
+
+		// Выбираем точку на отрезке суммарного веса и ищем соответствующего кандидата
+		point := s.rng.Intn(total)
+		idx := 0
+		for ; idx < len(weighted); idx++ {
+			point -= s.weight(weighted[idx])
+			if point < 0 {
+				break
+			}
+		}
+
+		result = append(result, weighted[idx])
+		weighted = append(weighted[:idx], weighted[idx+1:]...)
+	}
+
+	// Добираем кандидатами с нулевым весом
+	for _, id := range zero {
+		if len(result) >= count {
+			break
+		}
+		result = append(result, id)
+	}
+
+	return result, nil
+}
+
+func (s *WeightedSelector) weight(userID string) int {
+	if weight, ok := s.weights[userID]; ok {
+		return weight
+	}
+	return 1
+}
+
+// WorkingHoursSelector предпочитает кандидатов, у которых сейчас рабочее время
+// Остальные кандидаты выбираются, только если кандидатов в рабочем времени не хватает
+// Внутри каждой группы выбор выполняет base
+type WorkingHoursSelector struct {
+	base ReviewerSelector
+	now  func() time.Time
+}
+
+// NewWorkingHoursSelector создает стратегию с учетом рабочих часов; now - источник текущего времени
+func NewWorkingHoursSelector(base ReviewerSelector, now func() time.Time) *WorkingHoursSelector {
+	return &WorkingHoursSelector{base: base, now: now}
+}
+
+// WithPlannedLoad передает planned базовой стратегии, если она учитывает нагрузку
+func (s *WorkingHoursSelector) WithPlannedLoad(planned map[string]int) ReviewerSelector {
+	aware, ok := s.base.(PlannedLoadAware)
+	if !ok {
+		return s
+	}
+	return &WorkingHoursSelector{base: aware.WithPlannedLoad(planned), now: s.now}
+}
+
+// Select выбирает ревьюверов сначала среди работающих сейчас кандидатов (до count)
+func (s *WorkingHoursSelector) Select(ctx context.Context, candidates []api.User, count int) ([]string, error) {
+	if count <= 0 || len(candidates) == 0 {
+		return []string{}, nil
+	}
+
+	now := s.now()
+	var working, others []api.User
+	for i := range candidates {
+		if isWorkingAt(&candidates[i], now) {
+			working = append(working, candidates[i])
+		} else {
+			others = append(others, candidates[i])
+		}
+	}
+
+	result, err := s.base.Select(ctx, working, count)
+	if err != nil {
+		return nil, err
+	}
+	if len(result) < count && len(others) > 0 {
+		rest, err := s.base.Select(ctx, others, count-len(result))
+		if err != nil {
+			return nil, err
+		}
+		result = append(result, rest...)
+	}
+	return result, nil
+}
+
+// ReviewerSelectors хранит стратегии выбора ревьюверов по командам
+// Для команд без явно заданной стратегии лениво создается стратегия по умолчанию,
+// чтобы состояние (например, позиция round-robin) не разделялось между командами
+type ReviewerSelectors struct {
+	mu         sync.Mutex
+	newDefault func() ReviewerSelector
+	teams      map[string]ReviewerSelector
+}
+
+// NewReviewerSelectors создает набор стратегий с фабрикой стратегии по умолчанию
+func NewReviewerSelectors(newDefault func() ReviewerSelector) *ReviewerSelectors {
+	return &ReviewerSelectors{
+		newDefault: newDefault,
+		teams:      make(map[string]ReviewerSelector),
+	}
+}
+
+// SetTeamSelector задает стратегию для конкретной команды
+func (r *ReviewerSelectors) SetTeamSelector(teamName string, selector ReviewerSelector) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.teams[teamName] = selector
+}
+
+// ForTeam возвращает стратегию выбора ревьюверов для команды
+func (r *ReviewerSelectors) ForTeam(teamName string) ReviewerSelector {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	selector, ok := r.teams[teamName]
+	if !ok {
+		selector = r.newDefault()
+		r.teams[teamName] = selector
+	}
+	return selector
+}
+
+// SelectorConfig описывает конфигурацию стратегий выбора ревьюверов
+type SelectorConfig struct {
+	// DefaultStrategy стратегия для команд без явной настройки
+	DefaultStrategy string
+	// TeamStrategies стратегии по командам: team_name -> стратегия
+	TeamStrategies map[string]string
+	// Weights веса пользователей для стратегии weighted: user_id -> вес
+	Weights map[string]int
+	// WorkingHoursBase стратегия выбора внутри групп working_hours (по умолчанию random)
+	WorkingHoursBase string
+	// Now источник текущего времени для стратегии working_hours (по умолчанию time.Now);
+	// должен совпадать с часами сервисов из WithClock
+	Now func() time.Time
+}
+
+// BuildReviewerSelectors создает набор стратегий по конфигурации
+func BuildReviewerSelectors(cfg SelectorConfig, loads ReviewLoadSource) (*ReviewerSelectors, error) {
+	defaultStrategy := cfg.DefaultStrategy
+	if defaultStrategy == "" {
+		defaultStrategy = StrategyRandom
+	}
+	workingHoursBase := cfg.WorkingHoursBase
+	if workingHoursBase == "" {
+		workingHoursBase = StrategyRandom
+	}
+	now := cfg.Now
+	if now == nil {
+		now = time.Now
+	}
+
+	var factory func(strategy string) (func() ReviewerSelector, error)
+	factory = func(strategy string) (func() ReviewerSelector, error) {
+		switch strategy {
+		case StrategyRandom:
+			return func() ReviewerSelector { return NewRandomSelector(time.Now().UnixNano()) }, nil
+		case StrategyRoundRobin:
+			return func() ReviewerSelector { return NewRoundRobinSelector() }, nil
+		case StrategyLeastLoaded:
+			return func() ReviewerSelector { return NewLeastLoadedSelector(loads, time.Now().UnixNano()) }, nil
+		case StrategyWeighted:
+			return func() ReviewerSelector { return NewWeightedSelector(cfg.Weights, time.Now().UnixNano()) }, nil
+		case StrategyWorkingHours:
+			if workingHoursBase == StrategyWorkingHours {
+				return nil, fmt.Errorf("reviewer selection strategy %q cannot be its own base", StrategyWorkingHours)
+			}
+			newBase, err := factory(workingHoursBase)
+			if err != nil {
+				return nil, fmt.Errorf("working hours base: %w", err)
+			}
+			return func() ReviewerSelector {
+				return NewWorkingHoursSelector(newBase(), now)
+			}, nil
+		}
+		return nil, fmt.Errorf("unknown reviewer selection strategy %q", strategy)
+	}
+
+	newDefault, err := factory(defaultStrategy)
+	if err != nil {
+		return nil, err
+	}
+	selectors := NewReviewerSelectors(newDefault)
+
+	for teamName, strategy := range cfg.TeamStrategies {
+		newSelector, err := factory(strategy)
+		if err != nil {
+			return nil, fmt.Errorf("team %s: %w", teamName, err)
+		}
+		selectors.SetTeamSelector(teamName, newSelector())
+	}
+
+	return selectors, nil
+}
+
+// prRepoLoadSource получает нагрузку ревьюверов одним агрегирующим запросом к PRRepository
+type prRepoLoadSource struct {
+	prRepo storage.PRRepositoryInterface
+}
+
+// NewReviewLoadSource создает источник нагрузки ревьюверов на основе репозитория PR
+func NewReviewLoadSource(prRepo storage.PRRepositoryInterface) ReviewLoadSource {
+	return &prRepoLoadSource{prRepo: prRepo}
+}
+
+// OpenReviewCounts возвращает количество открытых PR на ревью для каждого пользователя
+func (l *prRepoLoadSource) OpenReviewCounts(ctx context.Context, userIDs []string) (map[string]int, error) {
+	return l.prRepo.GetOpenReviewCounts(ctx, userIDs)
+}
+
+// userIDs возвращает идентификаторы пользователей в исходном порядке
+func userIDs(users []api.User) []string {
+	ids := make([]string, len(users))
+	for i, user := range users {
+		ids[i] = user.UserId
+	}
+	return ids
+}
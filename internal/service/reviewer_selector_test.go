@@ -0,0 +1,269 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"pr-review-assigner/internal/api"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// stubLoadSource - фиксированная нагрузка ревьюверов для тестов
+type stubLoadSource struct {
+	counts map[string]int
+	err    error
+}
+
+func (s *stubLoadSource) OpenReviewCounts(_ context.Context, userIDs []string) (map[string]int, error) {
+	if s.err != nil {
+		return nil, s.err
+	}
+	return s.counts, nil
+}
+
+func testCandidates(ids ...string) []api.User {
+	users := make([]api.User, len(ids))
+	for i, id := range ids {
+		users[i] = api.User{UserId: id, Username: id, TeamName: "backend", IsActive: true}
+	}
+	return users
+}
+
+func TestRandomSelector_DeterministicWithSeed(t *testing.T) {
+	candidates := testCandidates("u1", "u2", "u3", "u4", "u5")
+
+	first, err := NewRandomSelector(42).Select(context.Background(), candidates, 2)
+	assert.NoError(t, err)
+	second, err := NewRandomSelector(42).Select(context.Background(), candidates, 2)
+	assert.NoError(t, err)
+
+	assert.Len(t, first, 2)
+	assert.Equal(t, first, second, "одинаковый seed должен давать одинаковый выбор")
+	assert.NotEqual(t, first[0], first[1])
+}
+
+func TestRandomSelector_FewerCandidatesThanCount(t *testing.T) {
+	result, err := NewRandomSelector(1).Select(context.Background(), testCandidates("u1"), 2)
+
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"u1"}, result)
+}
+
+func TestRandomSelector_NoCandidates(t *testing.T) {
+	result, err := NewRandomSelector(1).Select(context.Background(), nil, 2)
+
+	assert.NoError(t, err)
+	assert.Empty(t, result)
+}
+
+func TestRoundRobinSelector_Rotates(t *testing.T) {
+	selector := NewRoundRobinSelector()
+	candidates := testCandidates("u3", "u1", "u2")
+
+	first, _ := selector.Select(context.Background(), candidates, 2)
+	second, _ := selector.Select(context.Background(), candidates, 2)
+	third, _ := selector.Select(context.Background(), candidates, 1)
+
+	assert.Equal(t, []string{"u1", "u2"}, first)
+	assert.Equal(t, []string{"u3", "u1"}, second)
+	assert.Equal(t, []string{"u2"}, third)
+}
+
+func TestRoundRobinSelector_CandidateSetChanges(t *testing.T) {
+	selector := NewRoundRobinSelector()
+
+	first, _ := selector.Select(context.Background(), testCandidates("u1", "u2", "u3"), 1)
+	// u2 больше не кандидат - выбирается следующий после u1
+	second, _ := selector.Select(context.Background(), testCandidates("u1", "u3"), 1)
+
+	assert.Equal(t, []string{"u1"}, first)
+	assert.Equal(t, []string{"u3"}, second)
+}
+
+func TestLeastLoadedSelector_PicksLeastLoaded(t *testing.T) {
+	loads := &stubLoadSource{counts: map[string]int{"u1": 10, "u2": 0, "u3": 3}}
+	selector := NewLeastLoadedSelector(loads, 1)
+
+	result, err := selector.Select(context.Background(), testCandidates("u1", "u2", "u3"), 2)
+
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"u2", "u3"}, result)
+}
+
+func TestLeastLoadedSelector_LoadError(t *testing.T) {
+	loadErr := errors.New("db is down")
+	selector := NewLeastLoadedSelector(&stubLoadSource{err: loadErr}, 1)
+
+	result, err := selector.Select(context.Background(), testCandidates("u1", "u2"), 1)
+
+	assert.ErrorIs(t, err, loadErr)
+	assert.Nil(t, result)
+}
+
+func TestLeastLoadedSelector_RandomTieBreak(t *testing.T) {
+	// u1 и u2 одинаково загружены, u3 загружен больше всех
+	loads := &stubLoadSource{counts: map[string]int{"u1": 1, "u2": 1, "u3": 5}}
+	selector := NewLeastLoadedSelector(loads, 5)
+	candidates := testCandidates("u1", "u2", "u3")
+
+	picks := map[string]int{}
+	for i := 0; i < 200; i++ {
+		result, err := selector.Select(context.Background(), candidates, 1)
+		assert.NoError(t, err)
+		picks[result[0]]++
+	}
+
+	assert.Zero(t, picks["u3"])
+	assert.Positive(t, picks["u1"], "равные по нагрузке должны выбираться случайно")
+	assert.Positive(t, picks["u2"], "равные по нагрузке должны выбираться случайно")
+}
+
+func TestLeastLoadedSelector_WithPlannedLoad(t *testing.T) {
+	mockPRRepo := new(MockPRRepository)
+	// Нагрузка запрашивается одним агрегирующим запросом и только один раз
+	mockPRRepo.On("GetOpenReviewCounts", []string{"u1", "u2"}).Return(map[string]int{"u1": 2}, nil).Once()
+
+	planned := map[string]int{}
+	selector := NewLeastLoadedSelector(NewReviewLoadSource(mockPRRepo), 1).WithPlannedLoad(planned)
+	candidates := testCandidates("u1", "u2")
+
+	var picks []string
+	for i := 0; i < 4; i++ {
+		result, err := selector.Select(context.Background(), candidates, 1)
+		assert.NoError(t, err)
+		picks = append(picks, result[0])
+		planned[result[0]]++
+	}
+
+	// u2 догоняет u1 по нагрузке, после чего назначения распределяются поровну
+	assert.Equal(t, "u2", picks[0])
+	assert.Equal(t, "u2", picks[1])
+	assert.ElementsMatch(t, []string{"u1", "u2"}, picks[2:])
+	mockPRRepo.AssertExpectations(t)
+}
+
+func TestWeightedSelector_ZeroWeightUsedOnlyAsFallback(t *testing.T) {
+	selector := NewWeightedSelector(map[string]int{"u1": 0, "u2": 5}, 7)
+
+	one, err := selector.Select(context.Background(), testCandidates("u1", "u2"), 1)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"u2"}, one)
+
+	both, err := selector.Select(context.Background(), testCandidates("u1", "u2"), 2)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"u2", "u1"}, both)
+}
+
+func TestWeightedSelector_PrefersHeavierCandidates(t *testing.T) {
+	selector := NewWeightedSelector(map[string]int{"u1": 9, "u2": 1}, 3)
+	candidates := testCandidates("u1", "u2")
+
+	picks := map[string]int{}
+	for i := 0; i < 1000; i++ {
+		result, err := selector.Select(context.Background(), candidates, 1)
+		assert.NoError(t, err)
+		picks[result[0]]++
+	}
+
+	assert.Greater(t, picks["u1"], picks["u2"]*4)
+}
+
+// withWorkingHours задает кандидату часовой пояс и рабочие часы
+func withWorkingHours(user api.User, timezone, start, end string) api.User {
+	user.Timezone, user.WorkingHoursStart, user.WorkingHoursEnd = &timezone, &start, &end
+	return user
+}
+
+func TestWorkingHoursSelector_PrefersWorkingCandidates(t *testing.T) {
+	// 17:00 UTC: 10:00 в Лос-Анджелесе, 20:00 в Москве, 22:00 в Ташкенте
+	clock := &fixedClock{now: time.Date(2025, 10, 25, 17, 0, 0, 0, time.UTC)}
+	candidates := testCandidates("u1", "u2", "u3", "u4")
+	candidates[0] = withWorkingHours(candidates[0], "America/Los_Angeles", "09:00", "18:00")
+	candidates[1] = withWorkingHours(candidates[1], "Europe/Moscow", "09:00", "18:00")
+	candidates[2] = withWorkingHours(candidates[2], "Asia/Tashkent", "22:00", "06:00")
+	selector := NewWorkingHoursSelector(NewRoundRobinSelector(), clock.Now)
+
+	// u4 без рабочих часов доступен всегда
+	result, err := selector.Select(context.Background(), candidates, 3)
+	assert.NoError(t, err)
+	assert.ElementsMatch(t, []string{"u1", "u3", "u4"}, result)
+
+	// Кандидатов в рабочем времени не хватает - добираем остальными
+	result, err = selector.Select(context.Background(), candidates[:2], 2)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"u1", "u2"}, result)
+}
+
+func TestWorkingHoursSelector_FallsBackWhenNobodyWorks(t *testing.T) {
+	clock := &fixedClock{now: time.Date(2025, 10, 25, 3, 0, 0, 0, time.UTC)}
+	candidates := testCandidates("u1", "u2")
+	candidates[0] = withWorkingHours(candidates[0], "Europe/Moscow", "09:00", "18:00")
+	candidates[1] = withWorkingHours(candidates[1], "UTC", "09:00", "18:00")
+	selector := NewWorkingHoursSelector(NewRoundRobinSelector(), clock.Now)
+
+	result, err := selector.Select(context.Background(), candidates, 1)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"u1"}, result)
+
+	// С утра по Москве u1 в рабочем времени, u2 - нет
+	clock.now = time.Date(2025, 10, 25, 7, 0, 0, 0, time.UTC)
+	result, err = selector.Select(context.Background(), candidates, 1)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"u1"}, result)
+}
+
+func TestBuildReviewerSelectors_PerTeam(t *testing.T) {
+	selectors, err := BuildReviewerSelectors(SelectorConfig{
+		DefaultStrategy: StrategyRandom,
+		TeamStrategies:  map[string]string{"platform": StrategyLeastLoaded, "docs": StrategyRoundRobin, "mobile": StrategyWorkingHours},
+	}, &stubLoadSource{})
+
+	assert.NoError(t, err)
+	assert.IsType(t, &LeastLoadedSelector{}, selectors.ForTeam("platform"))
+	assert.IsType(t, &RoundRobinSelector{}, selectors.ForTeam("docs"))
+	assert.IsType(t, &WorkingHoursSelector{}, selectors.ForTeam("mobile"))
+	assert.IsType(t, &RandomSelector{}, selectors.ForTeam("backend"))
+	assert.Same(t, selectors.ForTeam("backend"), selectors.ForTeam("backend"))
+}
+
+func TestBuildReviewerSelectors_WorkingHoursBase(t *testing.T) {
+	clock := &fixedClock{now: time.Date(2025, 10, 25, 17, 0, 0, 0, time.UTC)}
+	selectors, err := BuildReviewerSelectors(SelectorConfig{
+		DefaultStrategy:  StrategyWorkingHours,
+		WorkingHoursBase: StrategyLeastLoaded,
+		Now:              clock.Now,
+	}, &stubLoadSource{counts: map[string]int{"u1": 5, "u2": 0, "u3": 1}})
+	require.NoError(t, err)
+
+	// Внутри группы работающих сейчас кандидатов выбирается наименее загруженный
+	candidates := testCandidates("u1", "u2", "u3")
+	candidates[1] = withWorkingHours(candidates[1], "Europe/Moscow", "09:00", "18:00")
+	result, err := selectors.ForTeam("backend").Select(context.Background(), candidates, 1)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"u3"}, result)
+
+	// Нагрузка из плана переназначений передается базовой стратегии
+	planned, ok := selectors.ForTeam("backend").(PlannedLoadAware)
+	require.True(t, ok)
+	result, err = planned.WithPlannedLoad(map[string]int{"u3": 10}).Select(context.Background(), candidates, 1)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"u1"}, result)
+
+	_, err = BuildReviewerSelectors(SelectorConfig{DefaultStrategy: StrategyWorkingHours, WorkingHoursBase: StrategyWorkingHours}, &stubLoadSource{})
+	assert.Error(t, err)
+	_, err = BuildReviewerSelectors(SelectorConfig{DefaultStrategy: StrategyWorkingHours, WorkingHoursBase: "fastest"}, &stubLoadSource{})
+	assert.Error(t, err)
+}
+
+func TestBuildReviewerSelectors_UnknownStrategy(t *testing.T) {
+	selectors, err := BuildReviewerSelectors(SelectorConfig{
+		TeamStrategies: map[string]string{"platform": "fastest"},
+	}, &stubLoadSource{})
+
+	assert.Error(t, err)
+	assert.Nil(t, selectors)
+}
@@ -0,0 +1,90 @@
+package service
+
+import (
+	"context"
+	"time"
+
+	"pr-review-assigner/internal/api"
+	"pr-review-assigner/internal/storage"
+)
+
+// GetStaleReviews получает назначения на открытые PR, по которым ревьювер не дал вердикт
+// в течение SLA команды автора PR, на текущий момент
+func (s *PRService) GetStaleReviews(ctx context.Context, params *api.GetReviewsStaleParams) ([]api.StaleReview, error) {
+	teamName := deref(params.TeamName)
+	if teamName != "" {
+		if _, err := s.teamRepo.GetTeamSettings(ctx, teamName); err != nil {
+			return nil, MapStorageError(err)
+		}
+	}
+
+	now := s.deps.now().UTC()
+	stale, err := s.prRepo.GetStaleReviews(ctx, &storage.StaleReviewsFilter{
+		Now:        now,
+		TeamName:   teamName,
+		ReviewerID: deref(params.ReviewerId),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	result := make([]api.StaleReview, 0, len(stale))
+	for _, review := range stale {
+		dueAt := review.DueAt()
+		result = append(result, api.StaleReview{
+			PullRequestId:   review.PullRequestID,
+			PullRequestName: review.PullRequestName,
+			AuthorId:        review.AuthorID,
+			TeamName:        review.TeamName,
+			ReviewerId:      review.ReviewerID,
+			AssignedAt:      review.AssignedAt,
+			DueAt:           dueAt,
+			OverdueSeconds:  int64(now.Sub(dueAt) / time.Second),
+			SlaBreachedAt:   review.BreachedAt,
+		})
+	}
+	return result, nil
+}
+
+// SLAMonitor в фоне ищет назначения с нарушенным SLA ревью и записывает по каждому
+// событие review.sla_breached (один раз на назначение)
+type SLAMonitor struct {
+	prRepo   storage.PRRepositoryInterface
+	interval time.Duration
+	now      func() time.Time
+}
+
+// NewSLAMonitor создает фоновую проверку SLA; источник времени задается WithClock
+func NewSLAMonitor(prRepo storage.PRRepositoryInterface, interval time.Duration, opts ...Option) *SLAMonitor {
+	return &SLAMonitor{
+		prRepo:   prRepo,
+		interval: interval,
+		now:      newDependencies(opts).now,
+	}
+}
+
+// Run проверяет SLA каждые interval до отмены ctx
+func (m *SLAMonitor) Run(ctx context.Context) {
+	runPeriodically(ctx, m.interval, "check review SLA", m.CheckBreaches)
+}
+
+// CheckBreaches выполняет одну проверку и возвращает количество записанных событий
+func (m *SLAMonitor) CheckBreaches(ctx context.Context) (int, error) {
+	now := m.now().UTC()
+	stale, err := m.prRepo.GetStaleReviews(ctx, &storage.StaleReviewsFilter{Now: now, OnlyUnmarked: true})
+	if err != nil {
+		return 0, err
+	}
+
+	breached := 0
+	for i := range stale {
+		marked, err := m.prRepo.MarkSLABreached(ctx, &stale[i], now)
+		if err != nil {
+			return breached, err
+		}
+		if marked {
+			breached++
+		}
+	}
+	return breached, nil
+}
@@ -0,0 +1,102 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"pr-review-assigner/internal/api"
+	"pr-review-assigner/internal/storage"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+// fixedClock источник времени, который двигается только вручную
+type fixedClock struct {
+	now time.Time
+}
+
+func (c *fixedClock) Now() time.Time {
+	return c.now
+}
+
+func TestPRService_GetStaleReviews(t *testing.T) {
+	mockPRRepo := new(MockPRRepository)
+	mockUserRepo := new(MockUserRepository)
+	mockTeamRepo := new(MockTeamRepository)
+	clock := &fixedClock{now: time.Date(2025, 10, 25, 13, 0, 0, 0, time.UTC)}
+	service := NewPRService(mockPRRepo, mockUserRepo, mockTeamRepo, WithClock(clock.Now))
+
+	assignedAt := time.Date(2025, 10, 24, 12, 0, 0, 0, time.UTC)
+	teamName, reviewerID := "backend", "u2"
+	mockTeamRepo.On("GetTeamSettings", "backend").Return(defaultTeamSettings("backend"), nil)
+	mockPRRepo.On("GetStaleReviews", &storage.StaleReviewsFilter{Now: clock.now, TeamName: "backend", ReviewerID: "u2"}).Return([]storage.StaleReview{
+		{PullRequestID: "pr-1", PullRequestName: "Feature", AuthorID: "u1", TeamName: "backend", ReviewerID: "u2", AssignedAt: assignedAt, SLA: 24 * time.Hour},
+	}, nil)
+
+	stale, err := service.GetStaleReviews(context.Background(), &api.GetReviewsStaleParams{TeamName: &teamName, ReviewerId: &reviewerID})
+	require.NoError(t, err)
+	require.Len(t, stale, 1)
+	assert.Equal(t, "pr-1", stale[0].PullRequestId)
+	assert.Equal(t, assignedAt.Add(24*time.Hour), stale[0].DueAt)
+	assert.Equal(t, int64(3600), stale[0].OverdueSeconds)
+	assert.Nil(t, stale[0].SlaBreachedAt)
+	mockPRRepo.AssertExpectations(t)
+}
+
+func TestPRService_GetStaleReviewsUnknownTeam(t *testing.T) {
+	mockTeamRepo := new(MockTeamRepository)
+	service := NewPRService(new(MockPRRepository), new(MockUserRepository), mockTeamRepo)
+
+	teamName := "missing"
+	mockTeamRepo.On("GetTeamSettings", "missing").Return(nil, storage.ErrNotFound)
+	_, err := service.GetStaleReviews(context.Background(), &api.GetReviewsStaleParams{TeamName: &teamName})
+	assert.Equal(t, ErrNotFound, err)
+}
+
+func TestSLAMonitor_CheckBreaches(t *testing.T) {
+	mockPRRepo := new(MockPRRepository)
+	clock := &fixedClock{now: time.Date(2025, 10, 25, 13, 0, 0, 0, time.UTC)}
+	monitor := NewSLAMonitor(mockPRRepo, time.Minute, WithClock(clock.Now))
+
+	stale := []storage.StaleReview{
+		{PullRequestID: "pr-1", ReviewerID: "u2", SLA: time.Hour},
+		{PullRequestID: "pr-2", ReviewerID: "u3", SLA: time.Hour},
+	}
+	mockPRRepo.On("GetStaleReviews", &storage.StaleReviewsFilter{Now: clock.now, OnlyUnmarked: true}).Return(stale, nil)
+	// pr-2 уже отметил другой экземпляр сервиса
+	mockPRRepo.On("MarkSLABreached", &stale[0], clock.now).Return(true, nil)
+	mockPRRepo.On("MarkSLABreached", &stale[1], clock.now).Return(false, nil)
+
+	breached, err := monitor.CheckBreaches(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, 1, breached)
+	mockPRRepo.AssertExpectations(t)
+}
+
+func TestSLAMonitor_CheckBreachesUsesUTC(t *testing.T) {
+	mockPRRepo := new(MockPRRepository)
+	// Время назначения хранится без часового пояса в UTC, поэтому и текущее время передается в UTC
+	clock := &fixedClock{now: time.Date(2025, 10, 25, 16, 0, 0, 0, time.FixedZone("MSK", 3*60*60))}
+	monitor := NewSLAMonitor(mockPRRepo, time.Minute, WithClock(clock.Now))
+
+	mockPRRepo.On("GetStaleReviews", &storage.StaleReviewsFilter{Now: clock.now.UTC(), OnlyUnmarked: true}).Return([]storage.StaleReview{}, nil)
+
+	_, err := monitor.CheckBreaches(context.Background())
+	require.NoError(t, err)
+	mockPRRepo.AssertExpectations(t)
+}
+
+func TestSLAMonitor_CheckBreachesError(t *testing.T) {
+	mockPRRepo := new(MockPRRepository)
+	monitor := NewSLAMonitor(mockPRRepo, time.Minute)
+
+	errDB := errors.New("db down")
+	mockPRRepo.On("GetStaleReviews", mock.Anything).Return(nil, errDB)
+
+	_, err := monitor.CheckBreaches(context.Background())
+	assert.ErrorIs(t, err, errDB)
+}
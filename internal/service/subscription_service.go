@@ -0,0 +1,142 @@
+package service
+
+import (
+	"context"
+	"net/url"
+
+	"pr-review-assigner/internal/api"
+	"pr-review-assigner/internal/events"
+	"pr-review-assigner/internal/storage"
+)
+
+// Ограничения размера страницы журнала доставок
+const (
+	DefaultDeliveriesLimit = 50
+	MaxDeliveriesLimit     = 500
+)
+
+// SubscriptionService предоставляет бизнес-логику для работы с подписками на события
+type SubscriptionService struct {
+	subscriptionRepo storage.SubscriptionRepositoryInterface
+}
+
+// NewSubscriptionService создает новый экземпляр сервиса подписок
+func NewSubscriptionService(subscriptionRepo storage.SubscriptionRepositoryInterface) *SubscriptionService {
+	return &SubscriptionService{subscriptionRepo: subscriptionRepo}
+}
+
+// CreateSubscription создает подписку на события
+// Пустой список типов событий означает подписку на все события
+func (s *SubscriptionService) CreateSubscription(ctx context.Context, req *api.CreateWebhookSubscriptionRequest) (*api.WebhookSubscription, error) {
+	if !isValidWebhookURL(req.Url) {
+		return nil, ErrInvalidWebhookURL
+	}
+
+	eventTypes := make([]events.Type, 0, len(req.EventTypes))
+	for _, t := range req.EventTypes {
+		if !events.IsKnownType(events.Type(t)) {
+			return nil, ErrUnknownEventType
+		}
+		eventTypes = append(eventTypes, events.Type(t))
+	}
+
+	subscription := &events.Subscription{
+		URL:        req.Url,
+		EventTypes: eventTypes,
+	}
+	if req.Secret != nil {
+		subscription.Secret = *req.Secret
+	}
+
+	created, err := s.subscriptionRepo.CreateSubscription(ctx, subscription)
+	if err != nil {
+		return nil, MapStorageError(err)
+	}
+
+	return toAPISubscription(created), nil
+}
+
+// ListSubscriptions получает все подписки
+func (s *SubscriptionService) ListSubscriptions(ctx context.Context) ([]api.WebhookSubscription, error) {
+	subscriptions, err := s.subscriptionRepo.ListSubscriptions(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	result := make([]api.WebhookSubscription, 0, len(subscriptions))
+	for i := range subscriptions {
+		result = append(result, *toAPISubscription(&subscriptions[i]))
+	}
+	return result, nil
+}
+
+// DeleteSubscription удаляет подписку
+func (s *SubscriptionService) DeleteSubscription(ctx context.Context, subscriptionID int64) error {
+	return MapStorageError(s.subscriptionRepo.DeleteSubscription(ctx, subscriptionID))
+}
+
+// ListDeliveries получает последние попытки доставки по подписке
+// limit приводится к диапазону [1, MaxDeliveriesLimit], по умолчанию DefaultDeliveriesLimit
+func (s *SubscriptionService) ListDeliveries(ctx context.Context, subscriptionID int64, limit *int) ([]api.WebhookDelivery, error) {
+	exists, err := s.subscriptionRepo.SubscriptionExists(ctx, subscriptionID)
+	if err != nil {
+		return nil, err
+	}
+	if !exists {
+		return nil, ErrNotFound
+	}
+
+	pageSize := DefaultDeliveriesLimit
+	if limit != nil && *limit > 0 {
+		pageSize = min(*limit, MaxDeliveriesLimit)
+	}
+
+	deliveries, err := s.subscriptionRepo.ListDeliveries(ctx, subscriptionID, pageSize)
+	if err != nil {
+		return nil, err
+	}
+
+	result := make([]api.WebhookDelivery, 0, len(deliveries))
+	for _, delivery := range deliveries {
+		item := api.WebhookDelivery{
+			DeliveryId:     delivery.ID,
+			SubscriptionId: delivery.SubscriptionID,
+			EventId:        delivery.EventID,
+			EventType:      api.EventType(delivery.EventType),
+			Attempt:        delivery.Attempt,
+			StatusCode:     delivery.StatusCode,
+			Success:        delivery.Success,
+			DeliveredAt:    delivery.DeliveredAt,
+		}
+		if delivery.Error != "" {
+			errorText := delivery.Error
+			item.Error = &errorText
+		}
+		result = append(result, item)
+	}
+	return result, nil
+}
+
+// isValidWebhookURL проверяет, что URL абсолютный и использует http или https
+func isValidWebhookURL(rawURL string) bool {
+	parsed, err := url.Parse(rawURL)
+	if err != nil {
+		return false
+	}
+	return (parsed.Scheme == "http" || parsed.Scheme == "https") && parsed.Host != ""
+}
+
+// toAPISubscription преобразует подписку в формат API (секрет не возвращается)
+func toAPISubscription(subscription *events.Subscription) *api.WebhookSubscription {
+	eventTypes := make([]api.EventType, 0, len(subscription.EventTypes))
+	for _, t := range subscription.EventTypes {
+		eventTypes = append(eventTypes, api.EventType(t))
+	}
+	return &api.WebhookSubscription{
+		SubscriptionId: subscription.ID,
+		Url:            subscription.URL,
+		EventTypes:     eventTypes,
+		IsActive:       subscription.IsActive,
+		CreatedAt:      subscription.CreatedAt,
+	}
+}
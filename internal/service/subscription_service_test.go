@@ -0,0 +1,106 @@
+package service
+
+import (
+	"context"
+	"testing"
+
+	"pr-review-assigner/internal/api"
+	"pr-review-assigner/internal/events"
+	"pr-review-assigner/internal/storage"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestSubscriptionService_CreateSubscription_Success(t *testing.T) {
+	mockRepo := new(MockSubscriptionRepository)
+	service := NewSubscriptionService(mockRepo)
+
+	secret := "s3cret"
+	mockRepo.On("CreateSubscription", mock.MatchedBy(func(s *events.Subscription) bool {
+		return s.URL == "https://example.com/hook" && s.Secret == secret &&
+			len(s.EventTypes) == 1 && s.EventTypes[0] == events.PRMerged
+	})).Return(&events.Subscription{
+		ID:         7,
+		URL:        "https://example.com/hook",
+		Secret:     secret,
+		EventTypes: []events.Type{events.PRMerged},
+		IsActive:   true,
+	}, nil)
+
+	result, err := service.CreateSubscription(context.Background(), &api.CreateWebhookSubscriptionRequest{
+		Url:        "https://example.com/hook",
+		Secret:     &secret,
+		EventTypes: []api.EventType{api.PrMerged},
+	})
+
+	assert.NoError(t, err)
+	assert.Equal(t, int64(7), result.SubscriptionId)
+	assert.Equal(t, []api.EventType{api.PrMerged}, result.EventTypes)
+	mockRepo.AssertExpectations(t)
+}
+
+func TestSubscriptionService_CreateSubscription_InvalidURL(t *testing.T) {
+	mockRepo := new(MockSubscriptionRepository)
+	service := NewSubscriptionService(mockRepo)
+
+	for _, url := range []string{"", "example.com/hook", "ftp://example.com/hook", "http://"} {
+		_, err := service.CreateSubscription(context.Background(), &api.CreateWebhookSubscriptionRequest{Url: url})
+		assert.Equal(t, ErrInvalidWebhookURL, err, url)
+	}
+	mockRepo.AssertNotCalled(t, "CreateSubscription", mock.Anything)
+}
+
+func TestSubscriptionService_CreateSubscription_UnknownEventType(t *testing.T) {
+	mockRepo := new(MockSubscriptionRepository)
+	service := NewSubscriptionService(mockRepo)
+
+	_, err := service.CreateSubscription(context.Background(), &api.CreateWebhookSubscriptionRequest{
+		Url:        "https://example.com/hook",
+		EventTypes: []api.EventType{"pr.opened"},
+	})
+
+	assert.Equal(t, ErrUnknownEventType, err)
+	mockRepo.AssertNotCalled(t, "CreateSubscription", mock.Anything)
+}
+
+func TestSubscriptionService_DeleteSubscription_NotFound(t *testing.T) {
+	mockRepo := new(MockSubscriptionRepository)
+	service := NewSubscriptionService(mockRepo)
+
+	mockRepo.On("DeleteSubscription", int64(42)).Return(storage.ErrNotFound)
+
+	err := service.DeleteSubscription(context.Background(), 42)
+
+	assert.Equal(t, ErrNotFound, err)
+}
+
+func TestSubscriptionService_ListDeliveries_LimitClamped(t *testing.T) {
+	mockRepo := new(MockSubscriptionRepository)
+	service := NewSubscriptionService(mockRepo)
+
+	limit := 10000
+	mockRepo.On("SubscriptionExists", int64(1)).Return(true, nil)
+	mockRepo.On("ListDeliveries", int64(1), MaxDeliveriesLimit).Return([]events.Delivery{
+		{ID: 3, SubscriptionID: 1, EventID: "e1", EventType: events.PRMerged, Attempt: 2, StatusCode: 503, Error: "unexpected status 503"},
+	}, nil)
+
+	deliveries, err := service.ListDeliveries(context.Background(), 1, &limit)
+
+	assert.NoError(t, err)
+	assert.Len(t, deliveries, 1)
+	assert.Equal(t, api.PrMerged, deliveries[0].EventType)
+	assert.Equal(t, "unexpected status 503", *deliveries[0].Error)
+	mockRepo.AssertExpectations(t)
+}
+
+func TestSubscriptionService_ListDeliveries_SubscriptionNotFound(t *testing.T) {
+	mockRepo := new(MockSubscriptionRepository)
+	service := NewSubscriptionService(mockRepo)
+
+	mockRepo.On("SubscriptionExists", int64(1)).Return(false, nil)
+
+	_, err := service.ListDeliveries(context.Background(), 1, nil)
+
+	assert.Equal(t, ErrNotFound, err)
+}
@@ -1,7 +1,10 @@
 package service
 
 import (
+	"context"
 	"errors"
+	"slices"
+	"time"
 
 	"pr-review-assigner/internal/api"
 	"pr-review-assigner/internal/storage"
@@ -11,79 +14,199 @@ import (
 type TeamService struct {
 	teamRepo storage.TeamRepositoryInterface
 	userRepo storage.UserRepositoryInterface
+	deps     dependencies
 }
 
 // NewTeamService создает новый экземпляр сервиса команд
-func NewTeamService(teamRepo storage.TeamRepositoryInterface, userRepo storage.UserRepositoryInterface) *TeamService {
-	return &TeamService{
+func NewTeamService(teamRepo storage.TeamRepositoryInterface, userRepo storage.UserRepositoryInterface, opts ...Option) *TeamService {
+	s := &TeamService{
 		teamRepo: teamRepo,
 		userRepo: userRepo,
+		deps:     newDependencies(opts),
 	}
+	if s.deps.txManager == nil {
+		s.deps.txManager = directTxManager{repos: storage.Repositories{Teams: teamRepo, Users: userRepo}}
+	}
+	return s
 }
 
 // CreateOrUpdateTeam создает команду с участниками
 // Если команда уже существует, возвращает ErrTeamExists
 // Создает/обновляет всех пользователей из списка участников
-func (s *TeamService) CreateOrUpdateTeam(team *api.Team) (*api.Team, error) {
-	// Создаем команду
-	err := s.teamRepo.CreateTeam(team.TeamName)
+func (s *TeamService) CreateOrUpdateTeam(ctx context.Context, team *api.Team) (*api.Team, error) {
+	// Проверяем настройки количества ревьюверов до любых изменений
+	settings, changed, err := mergeTeamSettings(&storage.TeamSettings{
+		TeamName:     team.TeamName,
+		MinReviewers: storage.DefaultMinReviewers,
+		MaxReviewers: storage.DefaultMaxReviewers,
+
+		MaxAutoReassignments: storage.DefaultMaxAutoReassignments,
+	}, team)
 	if err != nil {
-		if errors.Is(err, storage.ErrDuplicateKey) {
-			return nil, ErrTeamExists
-		}
-		return nil, MapStorageError(err)
+		return nil, err
+	}
+	if err = s.checkFallbackPools(ctx, settings.FallbackPools); err != nil {
+		return nil, err
 	}
 
-	// Создаем/обновляем всех участников команды
-	for _, member := range team.Members {
-		user := &api.User{
-			UserId:   member.UserId,
-			Username: member.Username,
-			TeamName: team.TeamName,
-			IsActive: member.IsActive,
+	// Команда, ее настройки и участники создаются в одной транзакции: при ошибке
+	// не остается команды с настройками по умолчанию
+	err = s.deps.txManager.WithTx(ctx, func(repos storage.Repositories) error {
+		if err := repos.Teams.CreateTeam(ctx, team.TeamName); err != nil {
+			if errors.Is(err, storage.ErrDuplicateKey) {
+				return ErrTeamExists
+			}
+			return MapStorageError(err)
 		}
-		err = s.userRepo.CreateOrUpdateUser(user)
-		if err != nil {
-			return nil, err
+
+		if changed {
+			if err := repos.Teams.UpdateTeamSettings(ctx, settings); err != nil {
+				return MapStorageError(err)
+			}
+		}
+
+		// Создаем/обновляем всех участников команды
+		for _, member := range team.Members {
+			user := &api.User{
+				UserId:   member.UserId,
+				Username: member.Username,
+				TeamName: team.TeamName,
+				IsActive: member.IsActive,
+			}
+			if err := repos.Users.CreateOrUpdateUser(ctx, user); err != nil {
+				return err
+			}
 		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
 	}
 
 	// Возвращаем созданную команду
-	return s.GetTeam(team.TeamName)
+	return s.GetTeam(ctx, team.TeamName)
 }
 
 // UpdateTeam добавляет или обновляет участников существующей команды
 // Если команда не существует, возвращает ErrNotFound
-func (s *TeamService) UpdateTeam(team *api.Team) (*api.Team, error) {
+func (s *TeamService) UpdateTeam(ctx context.Context, team *api.Team) (*api.Team, error) {
 	// Проверяем существование команды
-	_, err := s.teamRepo.GetTeam(team.TeamName)
+	_, err := s.teamRepo.GetTeam(ctx, team.TeamName)
 	if err != nil {
 		return nil, MapStorageError(err)
 	}
 
-	// Создаем/обновляем всех участников команды
-	for _, member := range team.Members {
-		user := &api.User{
-			UserId:   member.UserId,
-			Username: member.Username,
-			TeamName: team.TeamName,
-			IsActive: member.IsActive,
+	// Проверяем настройки команды, если они переданы, до любых изменений
+	var settings *storage.TeamSettings
+	if team.MinReviewers != nil || team.MaxReviewers != nil || team.RequiredApprovals != nil || team.ReviewSlaSeconds != nil ||
+		team.AutoReassignAfterSeconds != nil || team.MaxAutoReassignments != nil || team.FallbackPools != nil {
+		current, err := s.teamRepo.GetTeamSettings(ctx, team.TeamName)
+		if err != nil {
+			return nil, MapStorageError(err)
 		}
-		err = s.userRepo.CreateOrUpdateUser(user)
+		settings, _, err = mergeTeamSettings(current, team)
 		if err != nil {
 			return nil, err
 		}
+		if err = s.checkFallbackPools(ctx, settings.FallbackPools); err != nil {
+			return nil, err
+		}
+	}
+
+	// Настройки и участники сохраняются в одной транзакции: при ошибке команда не меняется
+	err = s.deps.txManager.WithTx(ctx, func(repos storage.Repositories) error {
+		if settings != nil {
+			if err := repos.Teams.UpdateTeamSettings(ctx, settings); err != nil {
+				return MapStorageError(err)
+			}
+		}
+
+		// Создаем/обновляем всех участников команды
+		for _, member := range team.Members {
+			user := &api.User{
+				UserId:   member.UserId,
+				Username: member.Username,
+				TeamName: team.TeamName,
+				IsActive: member.IsActive,
+			}
+			if err := repos.Users.CreateOrUpdateUser(ctx, user); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
 	}
 
 	// Возвращаем обновленную команду
-	return s.GetTeam(team.TeamName)
+	return s.GetTeam(ctx, team.TeamName)
 }
 
 // GetTeam получает команду с участниками
-func (s *TeamService) GetTeam(teamName string) (*api.Team, error) {
-	team, err := s.teamRepo.GetTeam(teamName)
+func (s *TeamService) GetTeam(ctx context.Context, teamName string) (*api.Team, error) {
+	team, err := s.teamRepo.GetTeam(ctx, teamName)
 	if err != nil {
 		return nil, MapStorageError(err)
 	}
 	return team, nil
 }
+
+// mergeTeamSettings накладывает переданные в запросе настройки на текущие и проверяет результат
+// Возвращает признак того, что хотя бы одна настройка была передана
+func mergeTeamSettings(current *storage.TeamSettings, team *api.Team) (*storage.TeamSettings, bool, error) {
+	settings := *current
+	changed := false
+
+	if team.MinReviewers != nil {
+		settings.MinReviewers = *team.MinReviewers
+		changed = true
+	}
+	if team.MaxReviewers != nil {
+		settings.MaxReviewers = *team.MaxReviewers
+		changed = true
+	}
+	if team.RequiredApprovals != nil {
+		settings.RequiredApprovals = *team.RequiredApprovals
+		changed = true
+	}
+	if team.ReviewSlaSeconds != nil {
+		if *team.ReviewSlaSeconds < 0 {
+			return nil, false, ErrInvalidReviewSLA
+		}
+		settings.ReviewSLA = time.Duration(*team.ReviewSlaSeconds) * time.Second
+		changed = true
+	}
+	if team.AutoReassignAfterSeconds != nil {
+		if *team.AutoReassignAfterSeconds < 0 {
+			return nil, false, ErrInvalidAutoReassign
+		}
+		settings.AutoReassignAfter = time.Duration(*team.AutoReassignAfterSeconds) * time.Second
+		changed = true
+	}
+	if team.MaxAutoReassignments != nil {
+		if *team.MaxAutoReassignments < 0 {
+			return nil, false, ErrInvalidAutoReassign
+		}
+		settings.MaxAutoReassignments = *team.MaxAutoReassignments
+		changed = true
+	}
+	if team.FallbackPools != nil {
+		for i, poolName := range *team.FallbackPools {
+			if slices.Contains((*team.FallbackPools)[:i], poolName) {
+				return nil, false, ErrInvalidFallbackPools
+			}
+		}
+		settings.FallbackPools = *team.FallbackPools
+		changed = true
+	}
+
+	if settings.MinReviewers < 0 || settings.MaxReviewers < 1 || settings.MinReviewers > settings.MaxReviewers {
+		return nil, false, ErrInvalidReviewerLimits
+	}
+	if settings.RequiredApprovals < 0 || settings.RequiredApprovals > settings.MaxReviewers {
+		return nil, false, ErrInvalidRequiredApprovals
+	}
+
+	return &settings, changed, nil
+}
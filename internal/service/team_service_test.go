@@ -1,6 +1,8 @@
 package service
 
 import (
+	"context"
+	"errors"
 	"testing"
 
 	"pr-review-assigner/internal/api"
@@ -36,7 +38,7 @@ func TestTeamService_CreateOrUpdateTeam_Success(t *testing.T) {
 	mockUserRepo.On("CreateOrUpdateUser", mock.AnythingOfType("*api.User")).Return(nil).Times(2)
 	mockTeamRepo.On("GetTeam", "backend").Return(expectedTeam, nil)
 
-	result, err := service.CreateOrUpdateTeam(team)
+	result, err := service.CreateOrUpdateTeam(context.Background(), team)
 
 	assert.NoError(t, err)
 	assert.Equal(t, expectedTeam, result)
@@ -59,7 +61,7 @@ func TestTeamService_CreateOrUpdateTeam_TeamExists(t *testing.T) {
 
 	mockTeamRepo.On("CreateTeam", "backend").Return(storage.ErrDuplicateKey)
 
-	result, err := service.CreateOrUpdateTeam(team)
+	result, err := service.CreateOrUpdateTeam(context.Background(), team)
 
 	assert.Error(t, err)
 	assert.Nil(t, result)
@@ -83,7 +85,7 @@ func TestTeamService_GetTeam_Success(t *testing.T) {
 
 	mockTeamRepo.On("GetTeam", "backend").Return(expectedTeam, nil)
 
-	result, err := service.GetTeam("backend")
+	result, err := service.GetTeam(context.Background(), "backend")
 
 	assert.NoError(t, err)
 	assert.Equal(t, expectedTeam, result)
@@ -98,7 +100,7 @@ func TestTeamService_GetTeam_NotFound(t *testing.T) {
 
 	mockTeamRepo.On("GetTeam", "backend").Return(nil, storage.ErrNotFound)
 
-	result, err := service.GetTeam("backend")
+	result, err := service.GetTeam(context.Background(), "backend")
 
 	assert.Error(t, err)
 	assert.Nil(t, result)
@@ -143,7 +145,7 @@ func TestTeamService_UpdateTeam_Success(t *testing.T) {
 	// Получение обновленной команды
 	mockTeamRepo.On("GetTeam", "backend").Return(expectedTeam, nil).Once()
 
-	result, err := service.UpdateTeam(updateRequest)
+	result, err := service.UpdateTeam(context.Background(), updateRequest)
 
 	assert.NoError(t, err)
 	assert.Equal(t, expectedTeam, result)
@@ -166,10 +168,147 @@ func TestTeamService_UpdateTeam_TeamNotFound(t *testing.T) {
 
 	mockTeamRepo.On("GetTeam", "nonexistent").Return(nil, storage.ErrNotFound)
 
-	result, err := service.UpdateTeam(updateRequest)
+	result, err := service.UpdateTeam(context.Background(), updateRequest)
 
 	assert.Error(t, err)
 	assert.Nil(t, result)
 	assert.Equal(t, ErrNotFound, err)
 	mockTeamRepo.AssertExpectations(t)
 }
+
+func TestTeamService_CreateOrUpdateTeam_WithReviewerLimits(t *testing.T) {
+	mockTeamRepo := new(MockTeamRepository)
+	mockUserRepo := new(MockUserRepository)
+
+	service := NewTeamService(mockTeamRepo, mockUserRepo)
+
+	maxReviewers := 3
+	team := &api.Team{
+		TeamName:     "platform",
+		MaxReviewers: &maxReviewers,
+		Members:      []api.TeamMember{},
+	}
+	expectedTeam := &api.Team{TeamName: "platform", Members: []api.TeamMember{}}
+
+	mockTeamRepo.On("CreateTeam", "platform").Return(nil)
+	mockTeamRepo.On("UpdateTeamSettings", &storage.TeamSettings{TeamName: "platform", MinReviewers: 1, MaxReviewers: 3, MaxAutoReassignments: 1}).Return(nil)
+	mockTeamRepo.On("GetTeam", "platform").Return(expectedTeam, nil)
+
+	result, err := service.CreateOrUpdateTeam(context.Background(), team)
+
+	assert.NoError(t, err)
+	assert.Equal(t, expectedTeam, result)
+	mockTeamRepo.AssertExpectations(t)
+}
+
+func TestTeamService_CreateOrUpdateTeam_InvalidReviewerLimits(t *testing.T) {
+	mockTeamRepo := new(MockTeamRepository)
+	mockUserRepo := new(MockUserRepository)
+
+	service := NewTeamService(mockTeamRepo, mockUserRepo)
+
+	// min больше max по умолчанию
+	minReviewers := 3
+	team := &api.Team{TeamName: "platform", MinReviewers: &minReviewers}
+
+	result, err := service.CreateOrUpdateTeam(context.Background(), team)
+
+	assert.Nil(t, result)
+	assert.Equal(t, ErrInvalidReviewerLimits, err)
+	mockTeamRepo.AssertNotCalled(t, "CreateTeam", mock.Anything)
+}
+
+func TestTeamService_UpdateTeam_ReviewerLimits(t *testing.T) {
+	mockTeamRepo := new(MockTeamRepository)
+	mockUserRepo := new(MockUserRepository)
+
+	service := NewTeamService(mockTeamRepo, mockUserRepo)
+
+	minReviewers := 1
+	maxReviewers := 1
+	team := &api.Team{TeamName: "docs", MinReviewers: &minReviewers, MaxReviewers: &maxReviewers}
+	existingTeam := &api.Team{TeamName: "docs", Members: []api.TeamMember{}}
+
+	mockTeamRepo.On("GetTeam", "docs").Return(existingTeam, nil)
+	mockTeamRepo.On("GetTeamSettings", "docs").Return(defaultTeamSettings("docs"), nil)
+	mockTeamRepo.On("UpdateTeamSettings", &storage.TeamSettings{TeamName: "docs", MinReviewers: 1, MaxReviewers: 1, MaxAutoReassignments: 1}).Return(nil)
+
+	result, err := service.UpdateTeam(context.Background(), team)
+
+	assert.NoError(t, err)
+	assert.NotNil(t, result)
+	mockTeamRepo.AssertExpectations(t)
+}
+
+func TestTeamService_CreateOrUpdateTeam_InvalidRequiredApprovals(t *testing.T) {
+	mockTeamRepo := new(MockTeamRepository)
+	mockUserRepo := new(MockUserRepository)
+
+	service := NewTeamService(mockTeamRepo, mockUserRepo)
+
+	// Одобрений требуется больше, чем назначается ревьюверов
+	requiredApprovals := 3
+	team := &api.Team{TeamName: "platform", RequiredApprovals: &requiredApprovals}
+
+	result, err := service.CreateOrUpdateTeam(context.Background(), team)
+
+	assert.Nil(t, result)
+	assert.Equal(t, ErrInvalidRequiredApprovals, err)
+	mockTeamRepo.AssertNotCalled(t, "CreateTeam", mock.Anything)
+}
+
+// TestTeamService_CreateOrUpdateTeam_SettingsFailureRollsBack проверяет, что ошибка сохранения
+// настроек откатывает создание команды
+func TestTeamService_CreateOrUpdateTeam_SettingsFailureRollsBack(t *testing.T) {
+	mockTeamRepo := new(MockTeamRepository)
+	mockUserRepo := new(MockUserRepository)
+	// Репозитории транзакции отделены от репозиториев сервиса
+	txTeamRepo := new(MockTeamRepository)
+	txManager := &fakeTxManager{repos: storage.Repositories{Teams: txTeamRepo, Users: mockUserRepo}}
+
+	service := NewTeamService(mockTeamRepo, mockUserRepo, WithTxManager(txManager))
+
+	maxReviewers := 3
+	txTeamRepo.On("CreateTeam", "platform").Return(nil)
+	txTeamRepo.On("UpdateTeamSettings", mock.AnythingOfType("*storage.TeamSettings")).Return(errors.New("connection reset"))
+
+	result, err := service.CreateOrUpdateTeam(context.Background(), &api.Team{TeamName: "platform", MaxReviewers: &maxReviewers})
+
+	assert.EqualError(t, err, "connection reset")
+	assert.Nil(t, result)
+	assert.Equal(t, 1, txManager.rolledBack)
+	txTeamRepo.AssertExpectations(t)
+	mockTeamRepo.AssertNotCalled(t, "CreateTeam", mock.Anything)
+}
+
+// TestTeamService_UpdateTeam_MemberFailureRollsBack проверяет, что ошибка сохранения участника
+// откатывает изменение настроек команды
+func TestTeamService_UpdateTeam_MemberFailureRollsBack(t *testing.T) {
+	mockTeamRepo := new(MockTeamRepository)
+	mockUserRepo := new(MockUserRepository)
+	// Репозитории транзакции отделены от репозиториев сервиса
+	txTeamRepo := new(MockTeamRepository)
+	txUserRepo := new(MockUserRepository)
+	txManager := &fakeTxManager{repos: storage.Repositories{Teams: txTeamRepo, Users: txUserRepo}}
+
+	service := NewTeamService(mockTeamRepo, mockUserRepo, WithTxManager(txManager))
+
+	maxReviewers := 3
+	mockTeamRepo.On("GetTeam", "platform").Return(&api.Team{TeamName: "platform", Members: []api.TeamMember{}}, nil)
+	mockTeamRepo.On("GetTeamSettings", "platform").Return(defaultTeamSettings("platform"), nil)
+	txTeamRepo.On("UpdateTeamSettings", mock.AnythingOfType("*storage.TeamSettings")).Return(nil)
+	txUserRepo.On("CreateOrUpdateUser", mock.AnythingOfType("*api.User")).Return(errors.New("connection reset"))
+
+	result, err := service.UpdateTeam(context.Background(), &api.Team{
+		TeamName:     "platform",
+		MaxReviewers: &maxReviewers,
+		Members:      []api.TeamMember{{UserId: "u1", Username: "Alice", IsActive: true}},
+	})
+
+	assert.EqualError(t, err, "connection reset")
+	assert.Nil(t, result)
+	assert.Equal(t, 1, txManager.rolledBack)
+	assert.Equal(t, 0, txManager.committed)
+	mockTeamRepo.AssertNotCalled(t, "UpdateTeamSettings", mock.Anything)
+	mockUserRepo.AssertNotCalled(t, "CreateOrUpdateUser", mock.Anything)
+}
@@ -0,0 +1,129 @@
+package service
+
+import (
+	"context"
+	"log"
+	"time"
+
+	"pr-review-assigner/internal/api"
+	"pr-review-assigner/internal/storage"
+)
+
+// CreateUnavailability добавляет период отсутствия пользователя
+// Границы периода приводятся к UTC: в PostgreSQL они хранятся без часового пояса
+func (s *UserService) CreateUnavailability(ctx context.Context, req *api.CreateUserUnavailabilityRequest) (*api.UserUnavailability, error) {
+	if !req.EndsAt.After(req.StartsAt) {
+		return nil, ErrInvalidUnavailabilityPeriod
+	}
+	if _, err := s.userRepo.GetUser(ctx, req.UserId); err != nil {
+		return nil, MapStorageError(err)
+	}
+
+	period, err := s.userRepo.CreateUnavailability(ctx, &api.UserUnavailability{
+		UserId:         req.UserId,
+		StartsAt:       req.StartsAt.UTC(),
+		EndsAt:         req.EndsAt.UTC(),
+		Reason:         deref(req.Reason),
+		HandoffReviews: deref(req.HandoffReviews),
+	})
+	if err != nil {
+		return nil, MapStorageError(err)
+	}
+	return period, nil
+}
+
+// ListUnavailability получает периоды отсутствия; завершившиеся возвращаются только с include_past
+func (s *UserService) ListUnavailability(ctx context.Context, params *api.GetUsersUnavailabilityListParams) ([]api.UserUnavailability, error) {
+	filter := &storage.UnavailabilityFilter{UserID: deref(params.UserId)}
+	if filter.UserID != "" {
+		if _, err := s.userRepo.GetUser(ctx, filter.UserID); err != nil {
+			return nil, MapStorageError(err)
+		}
+	}
+	if !deref(params.IncludePast) {
+		now := s.deps.now().UTC()
+		filter.EndsAfter = &now
+	}
+
+	periods, err := s.userRepo.ListUnavailability(ctx, filter)
+	if err != nil {
+		return nil, MapStorageError(err)
+	}
+	return periods, nil
+}
+
+// UpdateUnavailability изменяет период отсутствия
+func (s *UserService) UpdateUnavailability(ctx context.Context, req *api.UpdateUserUnavailabilityRequest) (*api.UserUnavailability, error) {
+	if !req.EndsAt.After(req.StartsAt) {
+		return nil, ErrInvalidUnavailabilityPeriod
+	}
+
+	period, err := s.userRepo.UpdateUnavailability(ctx, &api.UserUnavailability{
+		UnavailabilityId: req.UnavailabilityId,
+		StartsAt:         req.StartsAt.UTC(),
+		EndsAt:           req.EndsAt.UTC(),
+		Reason:           deref(req.Reason),
+		HandoffReviews:   deref(req.HandoffReviews),
+	})
+	if err != nil {
+		return nil, MapStorageError(err)
+	}
+	return period, nil
+}
+
+// DeleteUnavailability удаляет период отсутствия
+func (s *UserService) DeleteUnavailability(ctx context.Context, unavailabilityID int64) error {
+	return MapStorageError(s.userRepo.DeleteUnavailability(ctx, unavailabilityID))
+}
+
+// HandOffReviews передает открытые ревью пользователей, у которых начался период отсутствия
+// с handoff_reviews, другим участникам их команд и возвращает количество обработанных периодов
+// Ревью передаются так же, как при деактивации пользователя: если замены нет, ревьювер снимается
+func (s *UserService) HandOffReviews(ctx context.Context) (int, error) {
+	now := s.deps.now().UTC()
+	periods, err := s.userRepo.GetPendingHandoffs(ctx, now)
+	if err != nil {
+		return 0, err
+	}
+
+	handedOff := 0
+	for _, period := range periods {
+		user, err := s.userRepo.GetUser(ctx, period.UserId)
+		if err != nil {
+			return handedOff, err
+		}
+		// Повторная передача после сбоя безопасна: переданных ревью у пользователя уже нет
+		if err := s.reassignUserPRs(ctx, user.UserId, user.TeamName, now); err != nil {
+			log.Printf("Warning: failed to hand off reviews of user %s: %v", user.UserId, err)
+			continue
+		}
+
+		marked, err := s.userRepo.MarkHandedOff(ctx, period.UnavailabilityId, now)
+		if err != nil {
+			return handedOff, err
+		}
+		if marked {
+			handedOff++
+		}
+	}
+	return handedOff, nil
+}
+
+// ReviewHandoff в фоне передает ревью пользователей, у которых начался период отсутствия
+type ReviewHandoff struct {
+	userService *UserService
+	interval    time.Duration
+}
+
+// NewReviewHandoff создает фоновую передачу ревью с проверкой каждые interval
+func NewReviewHandoff(userService *UserService, interval time.Duration) *ReviewHandoff {
+	return &ReviewHandoff{
+		userService: userService,
+		interval:    interval,
+	}
+}
+
+// Run проверяет начавшиеся периоды отсутствия каждые interval до отмены ctx
+func (h *ReviewHandoff) Run(ctx context.Context) {
+	runPeriodically(ctx, h.interval, "hand off reviews", h.userService.HandOffReviews)
+}
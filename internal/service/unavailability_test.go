@@ -0,0 +1,113 @@
+package service
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"pr-review-assigner/internal/api"
+	"pr-review-assigner/internal/storage"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestUserService_CreateUnavailability(t *testing.T) {
+	mockUserRepo := new(MockUserRepository)
+	service := NewUserService(mockUserRepo, new(MockPRRepository), new(MockTeamRepository))
+
+	startsAt := time.Date(2025, 11, 3, 0, 0, 0, 0, time.UTC)
+	endsAt := startsAt.Add(72 * time.Hour)
+	handoff := true
+	expected := &api.UserUnavailability{UnavailabilityId: 1, UserId: "u2", StartsAt: startsAt, EndsAt: endsAt, HandoffReviews: true}
+	mockUserRepo.On("GetUser", "u2").Return(&api.User{UserId: "u2", TeamName: "backend", IsActive: true}, nil)
+	mockUserRepo.On("CreateUnavailability", &api.UserUnavailability{UserId: "u2", StartsAt: startsAt, EndsAt: endsAt, HandoffReviews: true}).Return(expected, nil)
+
+	period, err := service.CreateUnavailability(context.Background(), &api.CreateUserUnavailabilityRequest{
+		UserId: "u2", StartsAt: startsAt, EndsAt: endsAt, HandoffReviews: &handoff,
+	})
+	require.NoError(t, err)
+	assert.Equal(t, expected, period)
+	mockUserRepo.AssertExpectations(t)
+}
+
+func TestUserService_CreateUnavailabilityNormalizesToUTC(t *testing.T) {
+	mockUserRepo := new(MockUserRepository)
+	service := NewUserService(mockUserRepo, new(MockPRRepository), new(MockTeamRepository))
+
+	moscow := time.FixedZone("MSK", 3*60*60)
+	startsAt := time.Date(2025, 11, 3, 9, 0, 0, 0, moscow)
+	endsAt := startsAt.Add(8 * time.Hour)
+	stored := &api.UserUnavailability{UserId: "u2", StartsAt: startsAt.UTC(), EndsAt: endsAt.UTC()}
+	mockUserRepo.On("GetUser", "u2").Return(&api.User{UserId: "u2", TeamName: "backend", IsActive: true}, nil)
+	mockUserRepo.On("CreateUnavailability", stored).Return(stored, nil)
+	mockUserRepo.On("UpdateUnavailability", &api.UserUnavailability{UnavailabilityId: 1, StartsAt: startsAt.UTC(), EndsAt: endsAt.UTC()}).Return(stored, nil)
+
+	// Смещение не теряется при записи в колонку без часового пояса
+	_, err := service.CreateUnavailability(context.Background(), &api.CreateUserUnavailabilityRequest{UserId: "u2", StartsAt: startsAt, EndsAt: endsAt})
+	require.NoError(t, err)
+	_, err = service.UpdateUnavailability(context.Background(), &api.UpdateUserUnavailabilityRequest{UnavailabilityId: 1, StartsAt: startsAt, EndsAt: endsAt})
+	require.NoError(t, err)
+	mockUserRepo.AssertExpectations(t)
+	assert.Equal(t, 6, stored.StartsAt.Hour())
+}
+
+func TestUserService_CreateUnavailabilityInvalidPeriod(t *testing.T) {
+	mockUserRepo := new(MockUserRepository)
+	service := NewUserService(mockUserRepo, new(MockPRRepository), new(MockTeamRepository))
+
+	now := time.Now()
+	_, err := service.CreateUnavailability(context.Background(), &api.CreateUserUnavailabilityRequest{UserId: "u2", StartsAt: now, EndsAt: now.Add(-time.Hour)})
+	assert.Equal(t, ErrInvalidUnavailabilityPeriod, err)
+	_, err = service.UpdateUnavailability(context.Background(), &api.UpdateUserUnavailabilityRequest{UnavailabilityId: 1, StartsAt: now, EndsAt: now})
+	assert.Equal(t, ErrInvalidUnavailabilityPeriod, err)
+	mockUserRepo.AssertNotCalled(t, "CreateUnavailability", mock.Anything)
+}
+
+func TestUserService_ListUnavailabilityHidesPast(t *testing.T) {
+	mockUserRepo := new(MockUserRepository)
+	clock := &fixedClock{now: time.Date(2025, 10, 25, 13, 0, 0, 0, time.UTC)}
+	service := NewUserService(mockUserRepo, new(MockPRRepository), new(MockTeamRepository), WithClock(clock.Now))
+
+	mockUserRepo.On("ListUnavailability", &storage.UnavailabilityFilter{EndsAfter: &clock.now}).Return([]api.UserUnavailability{}, nil).Once()
+	mockUserRepo.On("ListUnavailability", &storage.UnavailabilityFilter{}).Return([]api.UserUnavailability{}, nil).Once()
+
+	_, err := service.ListUnavailability(context.Background(), &api.GetUsersUnavailabilityListParams{})
+	require.NoError(t, err)
+	includePast := true
+	_, err = service.ListUnavailability(context.Background(), &api.GetUsersUnavailabilityListParams{IncludePast: &includePast})
+	require.NoError(t, err)
+	mockUserRepo.AssertExpectations(t)
+}
+
+func TestUserService_HandOffReviews(t *testing.T) {
+	mockUserRepo := new(MockUserRepository)
+	mockPRRepo := new(MockPRRepository)
+	clock := &fixedClock{now: time.Date(2025, 11, 3, 0, 1, 0, 0, time.UTC)}
+	service := NewUserService(mockUserRepo, mockPRRepo, new(MockTeamRepository), WithClock(clock.Now))
+
+	mockUserRepo.On("GetPendingHandoffs", clock.now).Return([]api.UserUnavailability{
+		{UnavailabilityId: 7, UserId: "u2", HandoffReviews: true},
+	}, nil)
+	mockUserRepo.On("GetUser", "u2").Return(&api.User{UserId: "u2", TeamName: "backend", IsActive: true}, nil)
+	mockPRRepo.On("GetPRsByReviewer", "u2", &storage.ReviewerPRsFilter{Status: api.PullRequestStatusOPEN}).Return([]api.PullRequestShort{
+		{PullRequestId: "pr-1", AuthorId: "u1", Status: api.PullRequestShortStatusOPEN},
+	}, nil)
+	mockPRRepo.On("GetPR", "pr-1").Return(&api.PullRequest{
+		PullRequestId: "pr-1", AuthorId: "u1", Status: api.PullRequestStatusOPEN, AssignedReviewers: []string{"u2"},
+	}, nil)
+	mockPRRepo.On("GetPRHistory", "pr-1").Return([]api.PullRequestHistoryEntry{}, nil)
+	mockUserRepo.On("GetActiveUsersByTeam", "backend", "u2", clock.now).Return([]api.User{
+		{UserId: "u1", TeamName: "backend", IsActive: true},
+		{UserId: "u3", TeamName: "backend", IsActive: true},
+	}, nil)
+	mockPRRepo.On("ReassignReviewer", "pr-1", "u2", "u3").Return(&api.PullRequest{PullRequestId: "pr-1"}, nil)
+	mockUserRepo.On("MarkHandedOff", int64(7), clock.now).Return(true, nil)
+
+	handedOff, err := service.HandOffReviews(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, 1, handedOff)
+	mockUserRepo.AssertExpectations(t)
+	mockPRRepo.AssertExpectations(t)
+}
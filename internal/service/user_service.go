@@ -1,7 +1,10 @@
 package service
 
 import (
+	"context"
 	"log"
+	"strings"
+	"time"
 
 	"pr-review-assigner/internal/api"
 	"pr-review-assigner/internal/storage"
@@ -12,29 +15,35 @@ type UserService struct {
 	userRepo storage.UserRepositoryInterface
 	prRepo   storage.PRRepositoryInterface
 	teamRepo storage.TeamRepositoryInterface
+	deps     dependencies
 }
 
 // NewUserService создает новый экземпляр сервиса пользователей
-func NewUserService(userRepo storage.UserRepositoryInterface, prRepo storage.PRRepositoryInterface, teamRepo storage.TeamRepositoryInterface) *UserService {
-	return &UserService{
+func NewUserService(userRepo storage.UserRepositoryInterface, prRepo storage.PRRepositoryInterface, teamRepo storage.TeamRepositoryInterface, opts ...Option) *UserService {
+	s := &UserService{
 		userRepo: userRepo,
 		prRepo:   prRepo,
 		teamRepo: teamRepo,
+		deps:     newDependencies(opts),
 	}
+	if s.deps.txManager == nil {
+		s.deps.txManager = directTxManager{repos: storage.Repositories{Teams: teamRepo, Users: userRepo, PRs: prRepo}}
+	}
+	return s
 }
 
 // SetUserIsActive устанавливает флаг активности пользователя
 // При деактивации автоматически переназначает все открытые PR, где пользователь является ревьювером
-func (s *UserService) SetUserIsActive(userID string, isActive bool) (*api.User, error) {
+func (s *UserService) SetUserIsActive(ctx context.Context, userID string, isActive bool) (*api.User, error) {
 	// Обновляем статус пользователя
-	user, err := s.userRepo.UpdateUserIsActive(userID, isActive)
+	user, err := s.userRepo.UpdateUserIsActive(ctx, userID, isActive)
 	if err != nil {
 		return nil, MapStorageError(err)
 	}
 
 	// Если пользователь деактивирован, переназначаем его PR
 	if !isActive {
-		if err := s.reassignUserPRs(userID, user.TeamName); err != nil {
+		if err := s.reassignUserPRs(ctx, userID, user.TeamName, s.deps.now()); err != nil {
 			log.Printf("Warning: failed to reassign PRs for user %s: %v", userID, err)
 			// Не возвращаем ошибку, чтобы деактивация пользователя прошла успешно
 		}
@@ -43,36 +52,80 @@ func (s *UserService) SetUserIsActive(userID string, isActive bool) (*api.User,
 	return user, nil
 }
 
+// LinkIdentity связывает логин пользователя во внешней системе с user_id
+// Логин сохраняется в нижнем регистре, т.к. логины GitHub и GitLab не чувствительны к регистру
+func (s *UserService) LinkIdentity(ctx context.Context, identity *api.UserIdentity) (*api.UserIdentity, error) {
+	if !isKnownProvider(identity.Provider) {
+		return nil, ErrUnknownProvider
+	}
+	login := normalizeLogin(identity.Login)
+	if login == "" {
+		return nil, ErrEmptyLogin
+	}
+
+	// Проверяем существование пользователя
+	if _, err := s.userRepo.GetUser(ctx, identity.UserId); err != nil {
+		return nil, MapStorageError(err)
+	}
+
+	if err := s.userRepo.LinkLogin(ctx, string(identity.Provider), login, identity.UserId); err != nil {
+		return nil, MapStorageError(err)
+	}
+
+	return &api.UserIdentity{
+		Provider: identity.Provider,
+		Login:    login,
+		UserId:   identity.UserId,
+	}, nil
+}
+
+// isKnownProvider проверяет, что внешняя система поддерживается
+func isKnownProvider(provider api.IdentityProvider) bool {
+	switch provider {
+	case api.Github, api.Gitlab:
+		return true
+	}
+	return false
+}
+
+// normalizeLogin приводит логин во внешней системе к виду, в котором он хранится
+func normalizeLogin(login string) string {
+	return strings.ToLower(strings.TrimSpace(login))
+}
+
 // reassignUserPRs переназначает все открытые PR, где пользователь является ревьювером
-func (s *UserService) reassignUserPRs(userID string, teamName string) error {
-	// Получаем все PR, где пользователь - ревьювер
-	prs, err := s.prRepo.GetPRsByReviewer(userID)
+// Если в команде замены нет, она ищется в резервных пулах команды; кандидаты, отсутствующие
+// в момент now, пропускаются
+func (s *UserService) reassignUserPRs(ctx context.Context, userID string, teamName string, now time.Time) error {
+	// Получаем открытые PR, где пользователь - ревьювер
+	prs, err := s.prRepo.GetPRsByReviewer(ctx, userID, &storage.ReviewerPRsFilter{Status: api.PullRequestStatusOPEN})
 	if err != nil {
 		return err
 	}
 
-	// Фильтруем только OPEN PR
 	for _, prShort := range prs {
-		if prShort.Status != api.PullRequestShortStatusOPEN {
-			continue
-		}
-
 		// Получаем полную информацию о PR
-		pr, err := s.prRepo.GetPR(prShort.PullRequestId)
+		pr, err := s.prRepo.GetPR(ctx, prShort.PullRequestId)
 		if err != nil {
 			log.Printf("Warning: failed to get PR %s: %v", prShort.PullRequestId, err)
 			continue
 		}
 
 		// Получаем активных кандидатов из команды (исключая деактивированного пользователя)
-		candidates, err := s.userRepo.GetActiveUsersByTeam(teamName, userID)
+		candidates, err := s.userRepo.GetActiveUsersByTeam(ctx, teamName, userID, now)
 		if err != nil {
 			log.Printf("Warning: failed to get candidates for PR %s: %v", prShort.PullRequestId, err)
 			continue
 		}
 
-		// Исключаем автора и уже назначенных ревьюверов (кроме деактивируемого)
-		excludeUserIDs := []string{pr.AuthorId}
+		// Исключаем автора, уже назначенных ревьюверов (кроме деактивируемого)
+		// и ревьюверов, снятых с этого PR автоматически
+		autoReassigned, err := autoReassignedReviewers(ctx, s.prRepo, pr.PullRequestId)
+		if err != nil {
+			log.Printf("Warning: failed to get history of PR %s: %v", prShort.PullRequestId, err)
+			continue
+		}
+		excludeUserIDs := append([]string{pr.AuthorId}, autoReassigned...)
 		for _, reviewerID := range pr.AssignedReviewers {
 			if reviewerID != userID {
 				excludeUserIDs = append(excludeUserIDs, reviewerID)
@@ -80,16 +133,28 @@ func (s *UserService) reassignUserPRs(userID string, teamName string) error {
 		}
 		availableCandidates := filterCandidates(candidates, excludeUserIDs...)
 
-		// Ищем доступного кандидата
+		// Ищем доступного кандидата по стратегии команды
 		var newReviewerID string
-		if len(availableCandidates) > 0 {
-			newReviewerID = availableCandidates[0].UserId
+		selected, err := s.deps.selectors.ForTeam(teamName).Select(ctx, availableCandidates, 1)
+		if err != nil {
+			log.Printf("Warning: failed to select candidate for PR %s: %v", prShort.PullRequestId, err)
+			continue
+		}
+		if len(selected) > 0 {
+			newReviewerID = selected[0]
+		}
+		if newReviewerID == "" {
+			newReviewerID, err = pickFromFallbackPools(ctx, s.teamRepo, s.userRepo, s.deps.selectors.ForTeam(teamName), teamName, now, append(excludeUserIDs, userID)...)
+			if err != nil {
+				log.Printf("Warning: failed to select fallback pool candidate for PR %s: %v", prShort.PullRequestId, err)
+				continue
+			}
 		}
 
 		// Если нет доступных кандидатов, просто удаляем ревьювера
 		if newReviewerID == "" {
 			log.Printf("Warning: no available candidates for PR %s, removing reviewer %s", prShort.PullRequestId, userID)
-			_, err = s.prRepo.ReassignReviewer(prShort.PullRequestId, userID, "")
+			_, err = s.prRepo.ReassignReviewer(ctx, prShort.PullRequestId, userID, "")
 			if err != nil {
 				log.Printf("Warning: failed to remove reviewer from PR %s: %v", prShort.PullRequestId, err)
 			}
@@ -97,7 +162,7 @@ func (s *UserService) reassignUserPRs(userID string, teamName string) error {
 		}
 
 		// Переназначаем ревьювера
-		_, err = s.prRepo.ReassignReviewer(prShort.PullRequestId, userID, newReviewerID)
+		_, err = s.prRepo.ReassignReviewer(ctx, prShort.PullRequestId, userID, newReviewerID)
 		if err != nil {
 			log.Printf("Warning: failed to reassign PR %s: %v", prShort.PullRequestId, err)
 			continue
@@ -110,19 +175,20 @@ func (s *UserService) reassignUserPRs(userID string, teamName string) error {
 }
 
 // DeactivateTeamUsers массово деактивирует пользователей команды и переназначает их открытые PR
-func (s *UserService) DeactivateTeamUsers(teamName string, userIDs []string) ([]api.User, int, error) {
+// Деактивация и переназначение выполняются в одной транзакции: при ошибке не меняется ничего
+func (s *UserService) DeactivateTeamUsers(ctx context.Context, teamName string, userIDs []string) ([]api.User, int, error) {
 	if len(userIDs) == 0 {
 		return []api.User{}, 0, nil
 	}
 
 	// Проверяем существование команды
-	_, err := s.teamRepo.GetTeam(teamName)
+	_, err := s.teamRepo.GetTeam(ctx, teamName)
 	if err != nil {
 		return nil, 0, MapStorageError(err)
 	}
 
 	// Получаем всех пользователей команды для валидации
-	teamUsers, err := s.userRepo.GetUsersByTeam(teamName)
+	teamUsers, err := s.userRepo.GetUsersByTeam(ctx, teamName)
 	if err != nil {
 		return nil, 0, MapStorageError(err)
 	}
@@ -147,23 +213,95 @@ func (s *UserService) DeactivateTeamUsers(teamName string, userIDs []string) ([]
 		deactivatingMap[userID] = true
 	}
 
+	// Кандидаты - активные участники команды без текущего периода отсутствия
+	now := s.deps.now()
+	teamCandidates, err := s.userRepo.GetActiveUsersByTeam(ctx, teamName, "", now)
+	if err != nil {
+		return nil, 0, MapStorageError(err)
+	}
 	var activeCandidates []api.User
-	for _, user := range teamUsers {
-		if user.IsActive && !deactivatingMap[user.UserId] {
+	for _, user := range teamCandidates {
+		if !deactivatingMap[user.UserId] {
 			activeCandidates = append(activeCandidates, user)
 		}
 	}
 
-	// Получаем все открытые PR деактивируемых пользователей одним запросом
-	openPRs, err := s.prRepo.GetOpenPRsByReviewers(userIDs)
+	var deactivatedUsers []api.User
+	reassignedCount := 0
+
+	err = s.deps.txManager.WithTx(ctx, func(repos storage.Repositories) error {
+		// Получаем все открытые PR деактивируемых пользователей одним запросом
+		openPRs, err := repos.PRs.GetOpenPRsByReviewers(ctx, userIDs)
+		if err != nil {
+			return err
+		}
+
+		reassignments, count, err := s.planReassignments(ctx, repos.PRs, teamName, openPRs, deactivatingMap, activeCandidates, now)
+		if err != nil {
+			return err
+		}
+		reassignedCount = count
+
+		// Выполняем массовую деактивацию
+		deactivatedUsers, err = repos.Users.BatchDeactivateUsers(ctx, userIDs)
+		if err != nil {
+			return err
+		}
+
+		// Выполняем массовое переназначение PR
+		if len(reassignments) > 0 {
+			if err = repos.PRs.BatchReassignReviewers(ctx, reassignments); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
 	if err != nil {
 		return nil, 0, MapStorageError(err)
 	}
 
-	// Подготавливаем план переназначений в памяти
-	reassignments := make(map[string]map[string]string) // prID -> {oldUserID -> newUserID}
+	log.Printf("Successfully deactivated %d users and reassigned %d PR assignments", len(deactivatedUsers), reassignedCount)
+
+	return deactivatedUsers, reassignedCount, nil
+}
+
+// planReassignments подготавливает план переназначений в памяти: prID -> {oldUserID -> newUserID}
+// Если в команде замены нет, она ищется в резервных пулах команды
+// Пустой newUserID означает удаление ревьювера без замены
+func (s *UserService) planReassignments(ctx context.Context, prRepo storage.PRRepositoryInterface, teamName string, openPRs []api.PullRequest, deactivatingMap map[string]bool, activeCandidates []api.User, now time.Time) (map[string]map[string]string, int, error) {
+	// planned учитывает назначения из плана, чтобы нагрузко-зависимые стратегии
+	// не отдавали все освободившиеся PR одному и тому же ревьюверу
+	planned := make(map[string]int)
+	selector := s.deps.selectors.ForTeam(teamName)
+	if aware, ok := selector.(PlannedLoadAware); ok {
+		selector = aware.WithPlannedLoad(planned)
+	}
+	reassignments := make(map[string]map[string]string)
 	reassignedCount := 0
 
+	// Настройки команды нужны только для резервных пулов и загружаются один раз
+	var settings *storage.TeamSettings
+	pickFromPools := func(assignedMap map[string]bool) (string, error) {
+		if settings == nil {
+			var err error
+			if settings, err = s.teamRepo.GetTeamSettings(ctx, teamName); err != nil {
+				return "", err
+			}
+		}
+		exclude := make([]string, 0, len(assignedMap)+len(deactivatingMap))
+		for userID := range assignedMap {
+			exclude = append(exclude, userID)
+		}
+		for userID := range deactivatingMap {
+			exclude = append(exclude, userID)
+		}
+		picked, err := fillFromFallbackPools(ctx, s.userRepo, selector, settings, nil, 1, now, exclude...)
+		if err != nil || len(picked) == 0 {
+			return "", err
+		}
+		return picked[0], nil
+	}
+
 	for _, pr := range openPRs {
 		// Создаем карту уже назначенных ревьюверов на этот PR
 		assignedMap := make(map[string]bool)
@@ -174,20 +312,41 @@ func (s *UserService) DeactivateTeamUsers(teamName string, userIDs []string) ([]
 				assignedMap[reviewerID] = true
 			}
 		}
+		// Ревьюверы, снятые с PR автоматически, повторно не назначаются
+		autoReassigned, err := autoReassignedReviewers(ctx, prRepo, pr.PullRequestId)
+		if err != nil {
+			return nil, 0, err
+		}
+		for _, userID := range autoReassigned {
+			assignedMap[userID] = true
+		}
 
 		// Для каждого деактивируемого ревьювера в этом PR
 		for _, reviewerID := range pr.AssignedReviewers {
 			if deactivatingMap[reviewerID] {
-				// Ищем кандидата на замену
-				var newReviewerID string
+				// Ищем кандидата на замену среди еще не назначенных на этот PR
+				var available []api.User
 				for _, candidate := range activeCandidates {
 					if !assignedMap[candidate.UserId] {
-						newReviewerID = candidate.UserId
-						assignedMap[candidate.UserId] = true // Помечаем как назначенного
-						break
+						available = append(available, candidate)
 					}
 				}
 
+				var newReviewerID string
+				selected, err := selector.Select(ctx, available, 1)
+				if err != nil {
+					return nil, 0, err
+				}
+				if len(selected) > 0 {
+					newReviewerID = selected[0]
+				} else if newReviewerID, err = pickFromPools(assignedMap); err != nil {
+					return nil, 0, err
+				}
+				if newReviewerID != "" {
+					assignedMap[newReviewerID] = true // Помечаем как назначенного
+					planned[newReviewerID]++
+				}
+
 				// Добавляем в план переназначений (даже если newReviewerID пустой - тогда просто удалим)
 				if reassignments[pr.PullRequestId] == nil {
 					reassignments[pr.PullRequestId] = make(map[string]string)
@@ -202,22 +361,5 @@ func (s *UserService) DeactivateTeamUsers(teamName string, userIDs []string) ([]
 		}
 	}
 
-	// Выполняем массовую деактивацию и переназначение
-	deactivatedUsers, err := s.userRepo.BatchDeactivateUsers(userIDs)
-	if err != nil {
-		return nil, 0, MapStorageError(err)
-	}
-
-	// Выполняем массовое переназначение PR
-	if len(reassignments) > 0 {
-		err = s.prRepo.BatchReassignReviewers(reassignments)
-		if err != nil {
-			log.Printf("Warning: failed to batch reassign PRs: %v", err)
-			// Не возвращаем ошибку, так как пользователи уже деактивированы
-		}
-	}
-
-	log.Printf("Successfully deactivated %d users and reassigned %d PR assignments", len(deactivatedUsers), reassignedCount)
-
-	return deactivatedUsers, reassignedCount, nil
+	return reassignments, reassignedCount, nil
 }
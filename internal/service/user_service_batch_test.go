@@ -1,12 +1,15 @@
 package service
 
 import (
+	"context"
+	"errors"
 	"testing"
 
 	"pr-review-assigner/internal/api"
 	"pr-review-assigner/internal/storage"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
 )
 
 // TestUserService_DeactivateTeamUsers_Success проверяет успешную массовую деактивацию с переназначением
@@ -15,7 +18,9 @@ func TestUserService_DeactivateTeamUsers_Success(t *testing.T) {
 	mockUserRepo := new(MockUserRepository)
 	mockPRRepo := new(MockPRRepository)
 
-	userService := NewUserService(mockUserRepo, mockPRRepo, mockTeamRepo)
+	// Round-robin делает выбор кандидатов детерминированным
+	selectors := NewReviewerSelectors(func() ReviewerSelector { return NewRoundRobinSelector() })
+	userService := NewUserService(mockUserRepo, mockPRRepo, mockTeamRepo, WithReviewerSelectors(selectors))
 
 	teamName := "backend"
 	userIDsToDeactivate := []string{"u2", "u3"}
@@ -35,6 +40,7 @@ func TestUserService_DeactivateTeamUsers_Success(t *testing.T) {
 		{UserId: "u5", Username: "Eve", TeamName: teamName, IsActive: true},
 	}
 	mockUserRepo.On("GetUsersByTeam", teamName).Return(allTeamUsers, nil)
+	mockUserRepo.On("GetActiveUsersByTeam", teamName, "", mock.Anything).Return(allTeamUsers, nil)
 
 	// Мокаем получение открытых PR
 	openPRs := []api.PullRequest{
@@ -54,6 +60,8 @@ func TestUserService_DeactivateTeamUsers_Success(t *testing.T) {
 		},
 	}
 	mockPRRepo.On("GetOpenPRsByReviewers", userIDsToDeactivate).Return(openPRs, nil)
+	// Автоматических переназначений в PR не было
+	mockPRRepo.On("GetPRHistory", mock.Anything).Return([]api.PullRequestHistoryEntry{}, nil)
 
 	// Мокаем массовую деактивацию
 	deactivatedUsers := []api.User{
@@ -69,7 +77,7 @@ func TestUserService_DeactivateTeamUsers_Success(t *testing.T) {
 	}).Return(nil)
 
 	// Вызываем метод
-	result, count, err := userService.DeactivateTeamUsers(teamName, userIDsToDeactivate)
+	result, count, err := userService.DeactivateTeamUsers(context.Background(), teamName, userIDsToDeactivate)
 
 	// Проверяем результат
 	assert.NoError(t, err)
@@ -96,7 +104,7 @@ func TestUserService_DeactivateTeamUsers_TeamNotFound(t *testing.T) {
 
 	mockTeamRepo.On("GetTeam", teamName).Return(nil, storage.ErrNotFound)
 
-	result, count, err := userService.DeactivateTeamUsers(teamName, userIDs)
+	result, count, err := userService.DeactivateTeamUsers(context.Background(), teamName, userIDs)
 
 	assert.Error(t, err)
 	assert.Nil(t, result)
@@ -126,7 +134,7 @@ func TestUserService_DeactivateTeamUsers_UserNotInTeam(t *testing.T) {
 	}
 	mockUserRepo.On("GetUsersByTeam", teamName).Return(allTeamUsers, nil)
 
-	result, count, err := userService.DeactivateTeamUsers(teamName, userIDsToDeactivate)
+	result, count, err := userService.DeactivateTeamUsers(context.Background(), teamName, userIDsToDeactivate)
 
 	assert.Error(t, err)
 	assert.Nil(t, result)
@@ -144,13 +152,51 @@ func TestUserService_DeactivateTeamUsers_EmptyList(t *testing.T) {
 
 	userService := NewUserService(mockUserRepo, mockPRRepo, mockTeamRepo)
 
-	result, count, err := userService.DeactivateTeamUsers("backend", []string{})
+	result, count, err := userService.DeactivateTeamUsers(context.Background(), "backend", []string{})
 
 	assert.NoError(t, err)
 	assert.Empty(t, result)
 	assert.Equal(t, 0, count)
 }
 
+// TestUserService_DeactivateTeamUsers_SkipsAutoReassigned проверяет, что ревьювер, снятый с PR
+// автоматическим переназначением, не возвращается на него при деактивации
+func TestUserService_DeactivateTeamUsers_SkipsAutoReassigned(t *testing.T) {
+	mockTeamRepo := new(MockTeamRepository)
+	mockUserRepo := new(MockUserRepository)
+	mockPRRepo := new(MockPRRepository)
+
+	userService := NewUserService(mockUserRepo, mockPRRepo, mockTeamRepo)
+
+	teamName := "backend"
+	mockTeamRepo.On("GetTeam", teamName).Return(&api.Team{TeamName: teamName}, nil)
+	teamUsers := []api.User{
+		{UserId: "u1", Username: "Alice", TeamName: teamName, IsActive: true},
+		{UserId: "u2", Username: "Bob", TeamName: teamName, IsActive: true},
+		{UserId: "u3", Username: "Charlie", TeamName: teamName, IsActive: true},
+		{UserId: "u4", Username: "David", TeamName: teamName, IsActive: true},
+	}
+	mockUserRepo.On("GetUsersByTeam", teamName).Return(teamUsers, nil)
+	mockUserRepo.On("GetActiveUsersByTeam", teamName, "", mock.Anything).Return(teamUsers, nil)
+	mockPRRepo.On("GetOpenPRsByReviewers", []string{"u2"}).Return([]api.PullRequest{
+		{PullRequestId: "pr-1", AuthorId: "u1", Status: api.PullRequestStatusOPEN, AssignedReviewers: []string{"u2"}},
+	}, nil)
+	// u3 уже был автоматически заменен на u2 в этом PR
+	mockPRRepo.On("GetPRHistory", "pr-1").Return([]api.PullRequestHistoryEntry{
+		{Action: api.PRHistoryAutoReassigned, OldReviewerId: "u3", NewReviewerId: "u2"},
+	}, nil)
+	mockUserRepo.On("BatchDeactivateUsers", []string{"u2"}).Return([]api.User{
+		{UserId: "u2", Username: "Bob", TeamName: teamName, IsActive: false},
+	}, nil)
+	mockPRRepo.On("BatchReassignReviewers", map[string]map[string]string{"pr-1": {"u2": "u4"}}).Return(nil)
+
+	_, count, err := userService.DeactivateTeamUsers(context.Background(), teamName, []string{"u2"})
+
+	assert.NoError(t, err)
+	assert.Equal(t, 1, count)
+	mockPRRepo.AssertExpectations(t)
+}
+
 // TestUserService_DeactivateTeamUsers_NoCandidatesAvailable проверяет случай когда нет кандидатов
 func TestUserService_DeactivateTeamUsers_NoCandidatesAvailable(t *testing.T) {
 	mockTeamRepo := new(MockTeamRepository)
@@ -172,6 +218,7 @@ func TestUserService_DeactivateTeamUsers_NoCandidatesAvailable(t *testing.T) {
 		{UserId: "u3", Username: "Charlie", TeamName: teamName, IsActive: true},
 	}
 	mockUserRepo.On("GetUsersByTeam", teamName).Return(allTeamUsers, nil)
+	mockUserRepo.On("GetActiveUsersByTeam", teamName, "", mock.Anything).Return(allTeamUsers, nil)
 
 	// PR с деактивируемыми ревьюверами
 	openPRs := []api.PullRequest{
@@ -184,6 +231,8 @@ func TestUserService_DeactivateTeamUsers_NoCandidatesAvailable(t *testing.T) {
 		},
 	}
 	mockPRRepo.On("GetOpenPRsByReviewers", userIDsToDeactivate).Return(openPRs, nil)
+	// Автоматических переназначений в PR не было
+	mockPRRepo.On("GetPRHistory", mock.Anything).Return([]api.PullRequestHistoryEntry{}, nil)
 
 	deactivatedUsers := []api.User{
 		{UserId: "u2", Username: "Bob", TeamName: teamName, IsActive: false},
@@ -195,8 +244,10 @@ func TestUserService_DeactivateTeamUsers_NoCandidatesAvailable(t *testing.T) {
 	mockPRRepo.On("BatchReassignReviewers", map[string]map[string]string{
 		"pr-1": {"u2": "", "u3": ""},
 	}).Return(nil)
+	// Резервных пулов у команды нет
+	mockTeamRepo.On("GetTeamSettings", "backend").Return(defaultTeamSettings("backend"), nil)
 
-	result, count, err := userService.DeactivateTeamUsers(teamName, userIDsToDeactivate)
+	result, count, err := userService.DeactivateTeamUsers(context.Background(), teamName, userIDsToDeactivate)
 
 	assert.NoError(t, err)
 	assert.Len(t, result, 2)
@@ -206,3 +257,118 @@ func TestUserService_DeactivateTeamUsers_NoCandidatesAvailable(t *testing.T) {
 	mockUserRepo.AssertExpectations(t)
 	mockPRRepo.AssertExpectations(t)
 }
+
+// TestUserService_DeactivateTeamUsers_LeastLoaded проверяет, что нагрузко-зависимая стратегия
+// учитывает назначения, уже запланированные в рамках той же операции
+func TestUserService_DeactivateTeamUsers_LeastLoaded(t *testing.T) {
+	mockTeamRepo := new(MockTeamRepository)
+	mockUserRepo := new(MockUserRepository)
+	mockPRRepo := new(MockPRRepository)
+
+	loads := NewReviewLoadSource(mockPRRepo)
+	selectors := NewReviewerSelectors(func() ReviewerSelector { return NewLeastLoadedSelector(loads, 1) })
+	userService := NewUserService(mockUserRepo, mockPRRepo, mockTeamRepo, WithReviewerSelectors(selectors))
+
+	teamName := "backend"
+	userIDsToDeactivate := []string{"u2"}
+
+	mockTeamRepo.On("GetTeam", teamName).Return(&api.Team{TeamName: teamName}, nil)
+	mockUserRepo.On("GetUsersByTeam", teamName).Return([]api.User{
+		{UserId: "u1", Username: "Alice", TeamName: teamName, IsActive: true},
+		{UserId: "u2", Username: "Bob", TeamName: teamName, IsActive: true},
+		{UserId: "u3", Username: "Charlie", TeamName: teamName, IsActive: true},
+		{UserId: "u4", Username: "David", TeamName: teamName, IsActive: true},
+	}, nil)
+	mockUserRepo.On("GetActiveUsersByTeam", teamName, "", mock.Anything).Return([]api.User{
+		{UserId: "u1", Username: "Alice", TeamName: teamName, IsActive: true},
+		{UserId: "u2", Username: "Bob", TeamName: teamName, IsActive: true},
+		{UserId: "u3", Username: "Charlie", TeamName: teamName, IsActive: true},
+		{UserId: "u4", Username: "David", TeamName: teamName, IsActive: true},
+	}, nil)
+
+	// Автор всех PR - u1, поэтому кандидаты на замену только u3 и u4
+	openPRs := []api.PullRequest{
+		{PullRequestId: "pr-1", AuthorId: "u1", Status: api.PullRequestStatusOPEN, AssignedReviewers: []string{"u2"}},
+		{PullRequestId: "pr-2", AuthorId: "u1", Status: api.PullRequestStatusOPEN, AssignedReviewers: []string{"u2"}},
+		{PullRequestId: "pr-3", AuthorId: "u1", Status: api.PullRequestStatusOPEN, AssignedReviewers: []string{"u2"}},
+	}
+	mockPRRepo.On("GetOpenPRsByReviewers", userIDsToDeactivate).Return(openPRs, nil)
+	// Автоматических переназначений в PR не было
+	mockPRRepo.On("GetPRHistory", mock.Anything).Return([]api.PullRequestHistoryEntry{}, nil)
+	// u3 уже ревьюит 1 открытый PR, u4 - ни одного
+	mockPRRepo.On("GetOpenReviewCounts", []string{"u3", "u4"}).Return(map[string]int{"u3": 1}, nil).Once()
+
+	mockUserRepo.On("BatchDeactivateUsers", userIDsToDeactivate).Return([]api.User{
+		{UserId: "u2", Username: "Bob", TeamName: teamName, IsActive: false},
+	}, nil)
+
+	var reassignments map[string]map[string]string
+	mockPRRepo.On("BatchReassignReviewers", mock.Anything).Run(func(args mock.Arguments) {
+		reassignments = args.Get(0).(map[string]map[string]string)
+	}).Return(nil)
+
+	_, count, err := userService.DeactivateTeamUsers(context.Background(), teamName, userIDsToDeactivate)
+
+	assert.NoError(t, err)
+	assert.Equal(t, 3, count)
+
+	// Итоговая нагрузка должна выровняться: u3 = 1 + 1, u4 = 0 + 2
+	assigned := map[string]int{}
+	for _, changes := range reassignments {
+		assigned[changes["u2"]]++
+	}
+	assert.Equal(t, map[string]int{"u3": 1, "u4": 2}, assigned)
+
+	mockTeamRepo.AssertExpectations(t)
+	mockUserRepo.AssertExpectations(t)
+	mockPRRepo.AssertExpectations(t)
+}
+
+// TestUserService_DeactivateTeamUsers_ReassignFailureRollsBack проверяет, что ошибка переназначения
+// откатывает деактивацию и возвращается вызывающему
+func TestUserService_DeactivateTeamUsers_ReassignFailureRollsBack(t *testing.T) {
+	mockTeamRepo := new(MockTeamRepository)
+	mockUserRepo := new(MockUserRepository)
+	mockPRRepo := new(MockPRRepository)
+	// Репозитории транзакции отделены от репозиториев сервиса
+	txUserRepo := new(MockUserRepository)
+	txPRRepo := new(MockPRRepository)
+	txManager := &fakeTxManager{repos: storage.Repositories{Teams: mockTeamRepo, Users: txUserRepo, PRs: txPRRepo}}
+
+	userService := NewUserService(mockUserRepo, mockPRRepo, mockTeamRepo, WithTxManager(txManager))
+
+	teamName := "backend"
+	mockTeamRepo.On("GetTeam", teamName).Return(&api.Team{TeamName: teamName}, nil)
+	mockUserRepo.On("GetUsersByTeam", teamName).Return([]api.User{
+		{UserId: "u1", Username: "Alice", TeamName: teamName, IsActive: true},
+		{UserId: "u2", Username: "Bob", TeamName: teamName, IsActive: true},
+		{UserId: "u3", Username: "Charlie", TeamName: teamName, IsActive: true},
+	}, nil)
+	mockUserRepo.On("GetActiveUsersByTeam", teamName, "", mock.Anything).Return([]api.User{
+		{UserId: "u1", Username: "Alice", TeamName: teamName, IsActive: true},
+		{UserId: "u2", Username: "Bob", TeamName: teamName, IsActive: true},
+		{UserId: "u3", Username: "Charlie", TeamName: teamName, IsActive: true},
+	}, nil)
+	txPRRepo.On("GetOpenPRsByReviewers", []string{"u2"}).Return([]api.PullRequest{
+		{PullRequestId: "pr-1", AuthorId: "u1", Status: api.PullRequestStatusOPEN, AssignedReviewers: []string{"u2"}},
+	}, nil)
+	txPRRepo.On("GetPRHistory", "pr-1").Return([]api.PullRequestHistoryEntry{}, nil)
+	txUserRepo.On("BatchDeactivateUsers", []string{"u2"}).Return([]api.User{
+		{UserId: "u2", Username: "Bob", TeamName: teamName, IsActive: false},
+	}, nil)
+	txPRRepo.On("BatchReassignReviewers", map[string]map[string]string{"pr-1": {"u2": "u3"}}).
+		Return(errors.New("connection reset"))
+
+	result, count, err := userService.DeactivateTeamUsers(context.Background(), teamName, []string{"u2"})
+
+	assert.EqualError(t, err, "connection reset")
+	assert.Nil(t, result)
+	assert.Equal(t, 0, count)
+	assert.Equal(t, 1, txManager.rolledBack)
+	assert.Equal(t, 0, txManager.committed)
+	// Изменения выполнялись только через репозитории транзакции
+	mockUserRepo.AssertNotCalled(t, "BatchDeactivateUsers", mock.Anything)
+	mockPRRepo.AssertNotCalled(t, "BatchReassignReviewers", mock.Anything)
+	txUserRepo.AssertExpectations(t)
+	txPRRepo.AssertExpectations(t)
+}
@@ -1,12 +1,14 @@
 package service
 
 import (
+	"context"
 	"testing"
 
 	"pr-review-assigner/internal/api"
 	"pr-review-assigner/internal/storage"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
 )
 
 func TestUserService_SetUserIsActive_Success_Activate(t *testing.T) {
@@ -25,7 +27,7 @@ func TestUserService_SetUserIsActive_Success_Activate(t *testing.T) {
 
 	mockUserRepo.On("UpdateUserIsActive", "u1", true).Return(expectedUser, nil)
 
-	result, err := service.SetUserIsActive("u1", true)
+	result, err := service.SetUserIsActive(context.Background(), "u1", true)
 
 	assert.NoError(t, err)
 	assert.Equal(t, expectedUser, result)
@@ -71,12 +73,13 @@ func TestUserService_SetUserIsActive_Deactivate_WithReassignment(t *testing.T) {
 	}
 
 	mockUserRepo.On("UpdateUserIsActive", "u2", false).Return(deactivatedUser, nil)
-	mockPRRepo.On("GetPRsByReviewer", "u2").Return(prs, nil)
+	mockPRRepo.On("GetPRsByReviewer", "u2", openReviewsFilter).Return(prs, nil)
 	mockPRRepo.On("GetPR", "pr-1").Return(fullPR, nil)
-	mockUserRepo.On("GetActiveUsersByTeam", "backend", "u2").Return(candidates, nil)
+	mockPRRepo.On("GetPRHistory", "pr-1").Return([]api.PullRequestHistoryEntry{}, nil)
+	mockUserRepo.On("GetActiveUsersByTeam", "backend", "u2", mock.Anything).Return(candidates, nil)
 	mockPRRepo.On("ReassignReviewer", "pr-1", "u2", "u4").Return(updatedPR, nil)
 
-	result, err := service.SetUserIsActive("u2", false)
+	result, err := service.SetUserIsActive(context.Background(), "u2", false)
 
 	assert.NoError(t, err)
 	assert.Equal(t, deactivatedUser, result)
@@ -100,9 +103,9 @@ func TestUserService_SetUserIsActive_Deactivate_NoPRs(t *testing.T) {
 	}
 
 	mockUserRepo.On("UpdateUserIsActive", "u2", false).Return(deactivatedUser, nil)
-	mockPRRepo.On("GetPRsByReviewer", "u2").Return([]api.PullRequestShort{}, nil)
+	mockPRRepo.On("GetPRsByReviewer", "u2", openReviewsFilter).Return([]api.PullRequestShort{}, nil)
 
-	result, err := service.SetUserIsActive("u2", false)
+	result, err := service.SetUserIsActive(context.Background(), "u2", false)
 
 	assert.NoError(t, err)
 	assert.Equal(t, deactivatedUser, result)
@@ -120,10 +123,62 @@ func TestUserService_SetUserIsActive_UserNotFound(t *testing.T) {
 
 	mockUserRepo.On("UpdateUserIsActive", "u1", false).Return(nil, storage.ErrNotFound)
 
-	result, err := service.SetUserIsActive("u1", false)
+	result, err := service.SetUserIsActive(context.Background(), "u1", false)
 
 	assert.Error(t, err)
 	assert.Nil(t, result)
 	assert.Equal(t, ErrNotFound, err)
 	mockUserRepo.AssertExpectations(t)
 }
+
+func TestUserService_LinkIdentity_Success(t *testing.T) {
+	mockUserRepo := new(MockUserRepository)
+	mockPRRepo := new(MockPRRepository)
+	mockTeamRepo := new(MockTeamRepository)
+
+	service := NewUserService(mockUserRepo, mockPRRepo, mockTeamRepo)
+
+	mockUserRepo.On("GetUser", "u1").Return(&api.User{UserId: "u1", TeamName: "backend"}, nil)
+	mockUserRepo.On("LinkLogin", "github", "alice-gh", "u1").Return(nil)
+
+	result, err := service.LinkIdentity(context.Background(), &api.UserIdentity{Provider: api.Github, Login: " Alice-GH ", UserId: "u1"})
+
+	assert.NoError(t, err)
+	assert.Equal(t, &api.UserIdentity{Provider: api.Github, Login: "alice-gh", UserId: "u1"}, result)
+	mockUserRepo.AssertExpectations(t)
+}
+
+func TestUserService_LinkIdentity_UnknownProvider(t *testing.T) {
+	mockUserRepo := new(MockUserRepository)
+	mockPRRepo := new(MockPRRepository)
+	mockTeamRepo := new(MockTeamRepository)
+
+	service := NewUserService(mockUserRepo, mockPRRepo, mockTeamRepo)
+
+	result, err := service.LinkIdentity(context.Background(), &api.UserIdentity{Provider: "bitbucket", Login: "alice", UserId: "u1"})
+
+	assert.Nil(t, result)
+	assert.Equal(t, ErrUnknownProvider, err)
+	mockUserRepo.AssertNotCalled(t, "LinkLogin")
+}
+
+func TestUserService_LinkIdentity_UserNotFound(t *testing.T) {
+	mockUserRepo := new(MockUserRepository)
+	mockPRRepo := new(MockPRRepository)
+	mockTeamRepo := new(MockTeamRepository)
+
+	service := NewUserService(mockUserRepo, mockPRRepo, mockTeamRepo)
+
+	mockUserRepo.On("GetUser", "u404").Return(nil, storage.ErrNotFound)
+
+	result, err := service.LinkIdentity(context.Background(), &api.UserIdentity{Provider: api.Github, Login: "ghost", UserId: "u404"})
+
+	assert.Nil(t, result)
+	assert.Equal(t, ErrNotFound, err)
+	mockUserRepo.AssertNotCalled(t, "LinkLogin")
+}
+
+// openReviewsFilter сопоставляет запрос открытых PR ревьювера при его деактивации
+var openReviewsFilter = mock.MatchedBy(func(filter *storage.ReviewerPRsFilter) bool {
+	return filter.Status == api.PullRequestStatusOPEN && filter.Limit == 0 && filter.After == nil
+})
@@ -0,0 +1,81 @@
+package service
+
+import (
+	"context"
+
+	"pr-review-assigner/internal/api"
+	"pr-review-assigner/internal/storage"
+)
+
+// PullRequestAction действие над PR во внешней системе, не зависящее от ее формата событий
+type PullRequestAction string
+
+const (
+	PullRequestOpened         PullRequestAction = "opened"
+	PullRequestReopened       PullRequestAction = "reopened"
+	PullRequestClosed         PullRequestAction = "closed"
+	PullRequestMerged         PullRequestAction = "merged"
+	PullRequestReadyForReview PullRequestAction = "ready_for_review"
+)
+
+// PullRequestEvent событие PR, полученное из вебхука внешней системы
+type PullRequestEvent struct {
+	Provider        api.IdentityProvider
+	Action          PullRequestAction
+	PullRequestID   string
+	PullRequestName string
+	// AuthorLogin логин автора во внешней системе, отображается в user_id через user_identities
+	AuthorLogin string
+	Draft       bool
+}
+
+// WebhookService применяет события PR из внешних систем через PRService
+type WebhookService struct {
+	prService *PRService
+	userRepo  storage.UserRepositoryInterface
+}
+
+// NewWebhookService создает новый экземпляр сервиса обработки вебхуков
+func NewWebhookService(prService *PRService, userRepo storage.UserRepositoryInterface) *WebhookService {
+	return &WebhookService{
+		prService: prService,
+		userRepo:  userRepo,
+	}
+}
+
+// ProcessPullRequestEvent выполняет операцию PRService, соответствующую событию
+func (s *WebhookService) ProcessPullRequestEvent(ctx context.Context, event *PullRequestEvent) (*api.PullRequest, error) {
+	switch event.Action {
+	case PullRequestOpened:
+		authorID, err := s.resolveUserID(ctx, event.Provider, event.AuthorLogin)
+		if err != nil {
+			return nil, err
+		}
+		draft := event.Draft
+		return s.prService.CreatePR(ctx, &api.CreatePullRequestRequest{
+			PullRequestId:   event.PullRequestID,
+			PullRequestName: event.PullRequestName,
+			AuthorId:        authorID,
+			Draft:           &draft,
+		})
+	case PullRequestReopened:
+		return s.prService.ReopenPR(ctx, event.PullRequestID)
+	case PullRequestClosed:
+		return s.prService.ClosePR(ctx, event.PullRequestID)
+	case PullRequestMerged:
+		// PR уже слит во внешней системе - фиксируем факт без проверки одобрений
+		return s.prService.mergePR(ctx, event.PullRequestID, false)
+	case PullRequestReadyForReview:
+		return s.prService.MarkReady(ctx, event.PullRequestID)
+	}
+	return nil, ErrUnsupportedAction
+}
+
+// resolveUserID находит user_id по логину во внешней системе
+func (s *WebhookService) resolveUserID(ctx context.Context, provider api.IdentityProvider, login string) (string, error) {
+	userID, err := s.userRepo.GetUserIDByLogin(ctx, string(provider), normalizeLogin(login))
+	if err != nil {
+		return "", MapStorageError(err)
+	}
+	return userID, nil
+}
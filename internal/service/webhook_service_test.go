@@ -0,0 +1,148 @@
+package service
+
+import (
+	"context"
+	"testing"
+
+	"pr-review-assigner/internal/api"
+	"pr-review-assigner/internal/storage"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestWebhookService_Opened_ResolvesAuthor(t *testing.T) {
+	mockPRRepo := new(MockPRRepository)
+	mockUserRepo := new(MockUserRepository)
+	mockTeamRepo := new(MockTeamRepository)
+
+	service := NewWebhookService(NewPRService(mockPRRepo, mockUserRepo, mockTeamRepo), mockUserRepo)
+
+	author := &api.User{UserId: "u1", Username: "Alice", TeamName: "backend", IsActive: true}
+
+	// Логин ищется без учета регистра
+	mockUserRepo.On("GetUserIDByLogin", "github", "alice-gh").Return("u1", nil)
+	mockUserRepo.On("GetUser", "u1").Return(author, nil)
+	mockPRRepo.On("GetPR", "octo-org/backend#43").Return(nil, storage.ErrNotFound)
+	mockTeamRepo.On("GetTeamSettings", "backend").Return(defaultTeamSettings("backend"), nil)
+	mockPRRepo.On("CreatePR", mock.MatchedBy(func(pr *api.PullRequest) bool {
+		return pr.PullRequestId == "octo-org/backend#43" && pr.AuthorId == "u1" && pr.Status == api.PullRequestStatusDRAFT
+	})).Return(&api.PullRequest{PullRequestId: "octo-org/backend#43", Status: api.PullRequestStatusDRAFT}, nil)
+
+	result, err := service.ProcessPullRequestEvent(context.Background(), &PullRequestEvent{
+		Provider:        api.Github,
+		Action:          PullRequestOpened,
+		PullRequestID:   "octo-org/backend#43",
+		PullRequestName: "WIP: search ranking",
+		AuthorLogin:     "Alice-GH",
+		Draft:           true,
+	})
+
+	assert.NoError(t, err)
+	assert.Equal(t, api.PullRequestStatusDRAFT, result.Status)
+	mockPRRepo.AssertExpectations(t)
+	mockUserRepo.AssertExpectations(t)
+}
+
+func TestWebhookService_Opened_UnknownLogin(t *testing.T) {
+	mockPRRepo := new(MockPRRepository)
+	mockUserRepo := new(MockUserRepository)
+	mockTeamRepo := new(MockTeamRepository)
+
+	service := NewWebhookService(NewPRService(mockPRRepo, mockUserRepo, mockTeamRepo), mockUserRepo)
+
+	mockUserRepo.On("GetUserIDByLogin", "github", "stranger").Return("", storage.ErrNotFound)
+
+	result, err := service.ProcessPullRequestEvent(context.Background(), &PullRequestEvent{
+		Provider:      api.Github,
+		Action:        PullRequestOpened,
+		PullRequestID: "octo-org/backend#42",
+		AuthorLogin:   "stranger",
+	})
+
+	assert.Nil(t, result)
+	assert.Equal(t, ErrNotFound, err)
+	mockPRRepo.AssertNotCalled(t, "CreatePR", mock.Anything)
+}
+
+func TestWebhookService_Merged_SkipsApprovalCheck(t *testing.T) {
+	mockPRRepo := new(MockPRRepository)
+	mockUserRepo := new(MockUserRepository)
+	mockTeamRepo := new(MockTeamRepository)
+
+	service := NewWebhookService(NewPRService(mockPRRepo, mockUserRepo, mockTeamRepo), mockUserRepo)
+
+	pr := &api.PullRequest{PullRequestId: "octo-org/backend#42", AuthorId: "u1", Status: api.PullRequestStatusOPEN}
+	mergedPR := &api.PullRequest{PullRequestId: "octo-org/backend#42", AuthorId: "u1", Status: api.PullRequestStatusMERGED}
+
+	mockPRRepo.On("GetPR", "octo-org/backend#42").Return(pr, nil)
+	mockPRRepo.On("UpdatePRStatus", "octo-org/backend#42", api.PullRequestStatusMERGED, mock.AnythingOfType("*time.Time")).Return(mergedPR, nil)
+
+	result, err := service.ProcessPullRequestEvent(context.Background(), &PullRequestEvent{
+		Provider:      api.Github,
+		Action:        PullRequestMerged,
+		PullRequestID: "octo-org/backend#42",
+	})
+
+	assert.NoError(t, err)
+	assert.Equal(t, api.PullRequestStatusMERGED, result.Status)
+	// PR уже слит в GitHub - настройки одобрений команды не запрашиваются
+	mockTeamRepo.AssertNotCalled(t, "GetTeamSettings", mock.Anything)
+}
+
+func TestWebhookService_Closed(t *testing.T) {
+	mockPRRepo := new(MockPRRepository)
+	mockUserRepo := new(MockUserRepository)
+	mockTeamRepo := new(MockTeamRepository)
+
+	service := NewWebhookService(NewPRService(mockPRRepo, mockUserRepo, mockTeamRepo), mockUserRepo)
+
+	pr := &api.PullRequest{PullRequestId: "octo-org/backend#42", Status: api.PullRequestStatusOPEN}
+	closedPR := &api.PullRequest{PullRequestId: "octo-org/backend#42", Status: api.PullRequestStatusCLOSED}
+
+	mockPRRepo.On("GetPR", "octo-org/backend#42").Return(pr, nil)
+	mockPRRepo.On("UpdatePRStatus", "octo-org/backend#42", api.PullRequestStatusCLOSED, mock.AnythingOfType("*time.Time")).Return(closedPR, nil)
+
+	result, err := service.ProcessPullRequestEvent(context.Background(), &PullRequestEvent{
+		Provider:      api.Github,
+		Action:        PullRequestClosed,
+		PullRequestID: "octo-org/backend#42",
+	})
+
+	assert.NoError(t, err)
+	assert.Equal(t, api.PullRequestStatusCLOSED, result.Status)
+	mockPRRepo.AssertExpectations(t)
+}
+
+func TestWebhookService_GitLabOpened_ResolvesUsername(t *testing.T) {
+	mockPRRepo := new(MockPRRepository)
+	mockUserRepo := new(MockUserRepository)
+	mockTeamRepo := new(MockTeamRepository)
+
+	service := NewWebhookService(NewPRService(mockPRRepo, mockUserRepo, mockTeamRepo), mockUserRepo)
+
+	author := &api.User{UserId: "u1", Username: "Alice", TeamName: "backend", IsActive: true}
+
+	// Логины GitLab ищутся в таблице связей отдельно от GitHub
+	mockUserRepo.On("GetUserIDByLogin", "gitlab", "alice.lab").Return("u1", nil)
+	mockUserRepo.On("GetUser", "u1").Return(author, nil)
+	mockPRRepo.On("GetPR", "platform/backend!17").Return(nil, storage.ErrNotFound)
+	mockTeamRepo.On("GetTeamSettings", "backend").Return(defaultTeamSettings("backend"), nil)
+	mockUserRepo.On("GetActiveUsersByTeam", "backend", "u1", mock.Anything).Return([]api.User{}, nil)
+	mockPRRepo.On("CreatePR", mock.MatchedBy(func(pr *api.PullRequest) bool {
+		return pr.PullRequestId == "platform/backend!17" && pr.AuthorId == "u1" && pr.Status == api.PullRequestStatusOPEN
+	})).Return(&api.PullRequest{PullRequestId: "platform/backend!17", Status: api.PullRequestStatusOPEN}, nil)
+
+	result, err := service.ProcessPullRequestEvent(context.Background(), &PullRequestEvent{
+		Provider:        api.Gitlab,
+		Action:          PullRequestOpened,
+		PullRequestID:   "platform/backend!17",
+		PullRequestName: "Add search endpoint",
+		AuthorLogin:     "Alice.Lab",
+	})
+
+	assert.NoError(t, err)
+	assert.NotNil(t, result)
+	mockUserRepo.AssertExpectations(t)
+	mockPRRepo.AssertExpectations(t)
+}
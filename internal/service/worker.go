@@ -0,0 +1,26 @@
+package service
+
+import (
+	"context"
+	"log"
+	"time"
+)
+
+// runPeriodically выполняет fn сразу и затем каждые interval до отмены ctx
+// Ошибка прохода записывается в лог, следующий проход выполняется по расписанию
+func runPeriodically(ctx context.Context, interval time.Duration, task string, fn func(ctx context.Context) (int, error)) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		if _, err := fn(ctx); err != nil && ctx.Err() == nil {
+			log.Printf("Warning: failed to %s: %v", task, err)
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
@@ -0,0 +1,125 @@
+package service
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"pr-review-assigner/internal/api"
+	"pr-review-assigner/internal/storage"
+)
+
+// clockLayout формат времени начала и конца рабочего дня
+const clockLayout = "15:04"
+
+// locations кэш часовых поясов по имени IANA: LoadLocation читает базу tzdata при каждом вызове,
+// а рабочие часы проверяются для каждого кандидата при каждом выборе ревьюверов
+// Хранятся только успешно загруженные пояса: имя приходит от клиента, а допустимых имен конечное число
+var locations sync.Map
+
+// loadLocation возвращает часовой пояс по имени IANA, загружая его один раз
+func loadLocation(name string) (*time.Location, error) {
+	if cached, ok := locations.Load(name); ok {
+		return cached.(*time.Location), nil
+	}
+	location, err := time.LoadLocation(name)
+	if err != nil {
+		return nil, err
+	}
+	locations.Store(name, location)
+	return location, nil
+}
+
+// workingHours рабочие часы пользователя в минутах от начала местных суток
+// Если end меньше start, рабочий день переходит через полночь
+type workingHours struct {
+	location *time.Location
+	start    int
+	end      int
+}
+
+// parseWorkingHours проверяет часовой пояс IANA и время начала и конца рабочего дня
+func parseWorkingHours(timezone, start, end string) (*workingHours, error) {
+	// Пустая строка и Local в LoadLocation означают UTC и часовой пояс сервера
+	if timezone == "" || timezone == "Local" {
+		return nil, ErrInvalidWorkingHours
+	}
+	location, err := loadLocation(timezone)
+	if err != nil {
+		return nil, ErrInvalidWorkingHours
+	}
+
+	startAt, err := time.Parse(clockLayout, start)
+	if err != nil {
+		return nil, ErrInvalidWorkingHours
+	}
+	endAt, err := time.Parse(clockLayout, end)
+	if err != nil {
+		return nil, ErrInvalidWorkingHours
+	}
+
+	hours := &workingHours{
+		location: location,
+		start:    startAt.Hour()*60 + startAt.Minute(),
+		end:      endAt.Hour()*60 + endAt.Minute(),
+	}
+	if hours.start == hours.end {
+		return nil, ErrInvalidWorkingHours
+	}
+	return hours, nil
+}
+
+// contains проверяет, что момент t попадает в рабочие часы
+func (h *workingHours) contains(t time.Time) bool {
+	local := t.In(h.location)
+	minute := local.Hour()*60 + local.Minute()
+	if h.start < h.end {
+		return minute >= h.start && minute < h.end
+	}
+	return minute >= h.start || minute < h.end
+}
+
+// isWorkingAt проверяет, что у пользователя в момент t рабочее время
+// Пользователь без рабочих часов (или с нераспознаваемыми) считается доступным всегда
+func isWorkingAt(user *api.User, t time.Time) bool {
+	if user.Timezone == nil || user.WorkingHoursStart == nil || user.WorkingHoursEnd == nil {
+		return true
+	}
+	hours, err := parseWorkingHours(*user.Timezone, *user.WorkingHoursStart, *user.WorkingHoursEnd)
+	if err != nil {
+		return true
+	}
+	return hours.contains(t)
+}
+
+// SetUserWorkingHours задает часовой пояс и рабочие часы пользователя
+// Поля задаются все вместе; запрос без них сбрасывает рабочие часы
+func (s *UserService) SetUserWorkingHours(ctx context.Context, req *api.SetUserWorkingHoursRequest) (*api.User, error) {
+	var hours *storage.WorkingHours
+	if req.Timezone != nil || req.WorkingHoursStart != nil || req.WorkingHoursEnd != nil {
+		if req.Timezone == nil || req.WorkingHoursStart == nil || req.WorkingHoursEnd == nil {
+			return nil, ErrInvalidWorkingHours
+		}
+		parsed, err := parseWorkingHours(*req.Timezone, *req.WorkingHoursStart, *req.WorkingHoursEnd)
+		if err != nil {
+			return nil, err
+		}
+		// Время сохраняется в каноническом виде HH:MM
+		hours = &storage.WorkingHours{
+			Timezone: *req.Timezone,
+			Start:    formatMinutes(parsed.start),
+			End:      formatMinutes(parsed.end),
+		}
+	}
+
+	user, err := s.userRepo.UpdateUserWorkingHours(ctx, req.UserId, hours)
+	if err != nil {
+		return nil, MapStorageError(err)
+	}
+	return user, nil
+}
+
+// formatMinutes форматирует минуты от начала суток как HH:MM
+func formatMinutes(minutes int) string {
+	return time.Date(0, 1, 1, minutes/60, minutes%60, 0, 0, time.UTC).Format(clockLayout)
+}
@@ -0,0 +1,103 @@
+package service
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"pr-review-assigner/internal/api"
+	"pr-review-assigner/internal/storage"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseWorkingHours(t *testing.T) {
+	tests := []struct {
+		name     string
+		timezone string
+		start    string
+		end      string
+		valid    bool
+	}{
+		{name: "day", timezone: "Europe/Moscow", start: "09:00", end: "18:00", valid: true},
+		{name: "overnight", timezone: "Asia/Tashkent", start: "22:00", end: "06:00", valid: true},
+		{name: "unknown timezone", timezone: "Mars/Olympus", start: "09:00", end: "18:00"},
+		{name: "server local timezone", timezone: "Local", start: "09:00", end: "18:00"},
+		{name: "empty timezone", timezone: "", start: "09:00", end: "18:00"},
+		{name: "bad format", timezone: "UTC", start: "9am", end: "18:00"},
+		{name: "out of range", timezone: "UTC", start: "09:00", end: "24:00"},
+		{name: "empty window", timezone: "UTC", start: "09:00", end: "09:00"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := parseWorkingHours(tt.timezone, tt.start, tt.end)
+			if tt.valid {
+				assert.NoError(t, err)
+			} else {
+				assert.Equal(t, ErrInvalidWorkingHours, err)
+			}
+		})
+	}
+}
+
+func TestWorkingHours_Contains(t *testing.T) {
+	overnight, err := parseWorkingHours("Asia/Tashkent", "22:00", "06:00")
+	require.NoError(t, err)
+
+	// Ташкент UTC+5
+	assert.True(t, overnight.contains(time.Date(2025, 10, 25, 17, 0, 0, 0, time.UTC)))
+	assert.True(t, overnight.contains(time.Date(2025, 10, 25, 0, 59, 0, 0, time.UTC)))
+	assert.False(t, overnight.contains(time.Date(2025, 10, 25, 1, 0, 0, 0, time.UTC)))
+	assert.False(t, overnight.contains(time.Date(2025, 10, 25, 16, 59, 0, 0, time.UTC)))
+}
+
+func TestLoadLocation_Cached(t *testing.T) {
+	first, err := loadLocation("Europe/Moscow")
+	require.NoError(t, err)
+	second, err := loadLocation("Europe/Moscow")
+	require.NoError(t, err)
+	assert.Same(t, first, second)
+
+	// Ошибочные имена не кэшируются
+	_, err = loadLocation("Mars/Olympus")
+	assert.Error(t, err)
+	_, ok := locations.Load("Mars/Olympus")
+	assert.False(t, ok)
+}
+
+func TestUserService_SetUserWorkingHours(t *testing.T) {
+	mockUserRepo := new(MockUserRepository)
+	service := NewUserService(mockUserRepo, new(MockPRRepository), new(MockTeamRepository))
+
+	timezone, start, end := "America/Los_Angeles", "9:00", "18:00"
+	expected := &api.User{UserId: "u2", TeamName: "backend", IsActive: true}
+	// Время сохраняется в формате HH:MM
+	mockUserRepo.On("UpdateUserWorkingHours", "u2", &storage.WorkingHours{Timezone: timezone, Start: "09:00", End: "18:00"}).Return(expected, nil)
+	mockUserRepo.On("UpdateUserWorkingHours", "u2", (*storage.WorkingHours)(nil)).Return(expected, nil)
+	mockUserRepo.On("UpdateUserWorkingHours", "missing", mock.Anything).Return(nil, storage.ErrNotFound)
+
+	user, err := service.SetUserWorkingHours(context.Background(), &api.SetUserWorkingHoursRequest{
+		UserId: "u2", Timezone: &timezone, WorkingHoursStart: &start, WorkingHoursEnd: &end,
+	})
+	require.NoError(t, err)
+	assert.Equal(t, expected, user)
+
+	_, err = service.SetUserWorkingHours(context.Background(), &api.SetUserWorkingHoursRequest{UserId: "u2"})
+	require.NoError(t, err)
+
+	_, err = service.SetUserWorkingHours(context.Background(), &api.SetUserWorkingHoursRequest{UserId: "missing"})
+	assert.Equal(t, ErrNotFound, err)
+	mockUserRepo.AssertExpectations(t)
+}
+
+func TestUserService_SetUserWorkingHoursPartial(t *testing.T) {
+	mockUserRepo := new(MockUserRepository)
+	service := NewUserService(mockUserRepo, new(MockPRRepository), new(MockTeamRepository))
+
+	timezone := "Europe/Moscow"
+	_, err := service.SetUserWorkingHours(context.Background(), &api.SetUserWorkingHoursRequest{UserId: "u2", Timezone: &timezone})
+	assert.Equal(t, ErrInvalidWorkingHours, err)
+	mockUserRepo.AssertNotCalled(t, "UpdateUserWorkingHours", mock.Anything, mock.Anything)
+}
@@ -0,0 +1,99 @@
+package storage
+
+import "context"
+
+// SaveCodeowners создает или заменяет правила CODEOWNERS репозитория
+func (r *PRRepository) SaveCodeowners(ctx context.Context, file *CodeownersFile) error {
+	query := `
+		INSERT INTO codeowners (repository, content, updated_at)
+		VALUES ($1, $2, $3)
+		ON CONFLICT (repository)
+		DO UPDATE SET content = EXCLUDED.content, updated_at = EXCLUDED.updated_at
+	`
+	if _, err := r.db.ExecContext(ctx, query, file.Repository, file.Content, file.UpdatedAt); err != nil {
+		return HandleDBError(err)
+	}
+	return nil
+}
+
+// GetCodeowners получает правила CODEOWNERS репозитория
+func (r *PRRepository) GetCodeowners(ctx context.Context, repository string) (*CodeownersFile, error) {
+	query := `SELECT repository, content, updated_at FROM codeowners WHERE repository = $1`
+	var file CodeownersFile
+	err := r.db.QueryRowContext(ctx, query, repository).Scan(&file.Repository, &file.Content, &file.UpdatedAt)
+	if err != nil {
+		return nil, HandleDBError(err)
+	}
+	return &file, nil
+}
+
+// DeleteCodeowners удаляет правила CODEOWNERS репозитория
+func (r *PRRepository) DeleteCodeowners(ctx context.Context, repository string) error {
+	result, err := r.db.ExecContext(ctx, `DELETE FROM codeowners WHERE repository = $1`, repository)
+	if err != nil {
+		return HandleDBError(err)
+	}
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return HandleDBError(err)
+	}
+	if affected == 0 {
+		return ErrNotFound
+	}
+	return nil
+}
+
+// SavePRChangedFiles сохраняет измененные файлы PR, заменяя сохраненные ранее
+func (r *PRRepository) SavePRChangedFiles(ctx context.Context, prID string, files *PRChangedFiles) error {
+	return r.inTx(ctx, func(tx dbtx) error {
+		if _, err := tx.ExecContext(ctx, `DELETE FROM pr_changed_files WHERE pull_request_id = $1`, prID); err != nil {
+			return HandleDBError(err)
+		}
+		query := `INSERT INTO pr_changed_files (pull_request_id, position, repository, path) VALUES ($1, $2, $3, $4)`
+		for i, path := range files.Paths {
+			if _, err := tx.ExecContext(ctx, query, prID, i, files.Repository, path); err != nil {
+				return HandleDBError(err)
+			}
+		}
+		return nil
+	})
+}
+
+// GetPRChangedFiles получает измененные файлы PR; если их нет, Paths пустой
+func (r *PRRepository) GetPRChangedFiles(ctx context.Context, prID string) (*PRChangedFiles, error) {
+	query := `SELECT repository, path FROM pr_changed_files WHERE pull_request_id = $1 ORDER BY position`
+	rows, err := r.db.QueryContext(ctx, query, prID)
+	if err != nil {
+		return nil, HandleDBError(err)
+	}
+	defer rows.Close()
+
+	files := &PRChangedFiles{Paths: []string{}}
+	for rows.Next() {
+		var path string
+		if err := rows.Scan(&files.Repository, &path); err != nil {
+			return nil, HandleDBError(err)
+		}
+		files.Paths = append(files.Paths, path)
+	}
+	if err = rows.Err(); err != nil {
+		return nil, HandleDBError(err)
+	}
+	return files, nil
+}
+
+// SetReviewersCount меняет количество ревьюверов PR
+func (r *PRRepository) SetReviewersCount(ctx context.Context, prID string, count int) error {
+	result, err := r.db.ExecContext(ctx, `UPDATE pull_requests SET reviewers_count = $1 WHERE pull_request_id = $2`, count, prID)
+	if err != nil {
+		return HandleDBError(err)
+	}
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return HandleDBError(err)
+	}
+	if affected == 0 {
+		return ErrNotFound
+	}
+	return nil
+}
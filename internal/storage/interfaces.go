@@ -1,44 +1,279 @@
 package storage
 
 import (
+	"context"
 	"time"
 
 	"pr-review-assigner/internal/api"
+	"pr-review-assigner/internal/events"
 )
 
+// Значения настроек команды по умолчанию (совпадают с DEFAULT в миграциях)
+const (
+	DefaultMinReviewers         = 1
+	DefaultMaxReviewers         = 2
+	DefaultMaxAutoReassignments = 1
+)
+
+// TeamSettings представляет настройки назначения ревьюверов команды
+type TeamSettings struct {
+	TeamName          string
+	MinReviewers      int
+	MaxReviewers      int
+	RequiredApprovals int
+	// ReviewSLA время на первый вердикт ревьювера после назначения; 0 - SLA не отслеживается
+	ReviewSLA time.Duration
+	// AutoReassignAfter время без вердикта, после которого ревьювер заменяется автоматически; 0 - не заменяется
+	AutoReassignAfter time.Duration
+	// MaxAutoReassignments максимальное количество автоматических переназначений одного PR
+	MaxAutoReassignments int
+	// FallbackPools резервные пулы ревьюверов в порядке использования
+	FallbackPools []string
+}
+
 // TeamRepositoryInterface определяет интерфейс для работы с командами
 type TeamRepositoryInterface interface {
-	CreateTeam(teamName string) error
-	GetTeam(teamName string) (*api.Team, error)
-	TeamExists(teamName string) (bool, error)
+	CreateTeam(ctx context.Context, teamName string) error
+	GetTeam(ctx context.Context, teamName string) (*api.Team, error)
+	TeamExists(ctx context.Context, teamName string) (bool, error)
+	GetTeamSettings(ctx context.Context, teamName string) (*TeamSettings, error)
+	UpdateTeamSettings(ctx context.Context, settings *TeamSettings) error
+
+	// SaveReviewerPool создает пул ревьюверов или заменяет его участников
+	SaveReviewerPool(ctx context.Context, pool *api.ReviewerPool) error
+	GetReviewerPool(ctx context.Context, poolName string) (*api.ReviewerPool, error)
+	// ListReviewerPools получает все пулы, упорядоченные по имени
+	ListReviewerPools(ctx context.Context) ([]api.ReviewerPool, error)
+	// DeleteReviewerPool удаляет пул, в том числе из резервных пулов команд
+	DeleteReviewerPool(ctx context.Context, poolName string) error
 }
 
 // UserRepositoryInterface определяет интерфейс для работы с пользователями
 type UserRepositoryInterface interface {
-	CreateOrUpdateUser(user *api.User) error
-	GetUser(userID string) (*api.User, error)
-	UpdateUserIsActive(userID string, isActive bool) (*api.User, error)
-	GetActiveUsersByTeam(teamName string, excludeUserID string) ([]api.User, error)
-	BatchDeactivateUsers(userIDs []string) ([]api.User, error)
-	GetUsersByTeam(teamName string) ([]api.User, error)
+	CreateOrUpdateUser(ctx context.Context, user *api.User) error
+	GetUser(ctx context.Context, userID string) (*api.User, error)
+	UpdateUserIsActive(ctx context.Context, userID string, isActive bool) (*api.User, error)
+	// UpdateUserWorkingHours задает часовой пояс и рабочие часы пользователя; nil сбрасывает их
+	UpdateUserWorkingHours(ctx context.Context, userID string, hours *WorkingHours) (*api.User, error)
+	// GetActiveUsersByTeam получает активных пользователей команды, исключая указанного пользователя
+	// и пользователей, у которых в момент now идет период отсутствия
+	GetActiveUsersByTeam(ctx context.Context, teamName string, excludeUserID string, now time.Time) ([]api.User, error)
+	// GetActiveUsersByPool получает активных участников пула, у которых в момент now нет периода отсутствия
+	GetActiveUsersByPool(ctx context.Context, poolName string, now time.Time) ([]api.User, error)
+	BatchDeactivateUsers(ctx context.Context, userIDs []string) ([]api.User, error)
+	GetUsersByTeam(ctx context.Context, teamName string) ([]api.User, error)
+	GetUserIDByLogin(ctx context.Context, provider string, login string) (string, error)
+	LinkLogin(ctx context.Context, provider string, login string, userID string) error
+
+	CreateUnavailability(ctx context.Context, period *api.UserUnavailability) (*api.UserUnavailability, error)
+	GetUnavailability(ctx context.Context, unavailabilityID int64) (*api.UserUnavailability, error)
+	// ListUnavailability получает периоды отсутствия, упорядоченные по началу
+	ListUnavailability(ctx context.Context, filter *UnavailabilityFilter) ([]api.UserUnavailability, error)
+	// UpdateUnavailability изменяет период; при переносе начала отметка о передаче ревью сбрасывается
+	UpdateUnavailability(ctx context.Context, period *api.UserUnavailability) (*api.UserUnavailability, error)
+	DeleteUnavailability(ctx context.Context, unavailabilityID int64) error
+	// GetPendingHandoffs получает начавшиеся к now и еще не завершившиеся периоды с handoff_reviews,
+	// по которым ревью еще не переданы
+	GetPendingHandoffs(ctx context.Context, now time.Time) ([]api.UserUnavailability, error)
+	// MarkHandedOff отмечает передачу ревью по периоду; возвращает false, если она уже отмечена
+	MarkHandedOff(ctx context.Context, unavailabilityID int64, at time.Time) (bool, error)
+}
+
+// WorkingHours часовой пояс IANA и рабочие часы пользователя в формате HH:MM по местному времени
+type WorkingHours struct {
+	Timezone string
+	Start    string
+	End      string
+}
+
+// UnavailabilityFilter фильтры списка периодов отсутствия; пустые поля не ограничивают выборку
+type UnavailabilityFilter struct {
+	UserID string
+	// EndsAfter возвращает только периоды, которые заканчиваются позже этого времени
+	EndsAfter *time.Time
 }
 
 // ReviewerStatistic представляет статистику по ревьюверу
 type ReviewerStatistic struct {
 	UserID           string
 	Username         string
+	TeamName         string
 	AssignmentsCount int
+	// OpenAssignmentsCount назначения на открытые PR
+	OpenAssignmentsCount int
+	// CompletedAssignmentsCount назначения на слитые или закрытые PR
+	CompletedAssignmentsCount int
+	AuthoredPRsCount          int
+	// AvgTimeToMerge среднее время от назначения до слияния PR; nil, если слитых PR нет
+	AvgTimeToMerge *time.Duration
+}
+
+// StatisticsFilter измерения статистики ревьюверов; пустые поля не ограничивают выборку
+type StatisticsFilter struct {
+	// TeamName команда пользователей, по которым считается статистика
+	TeamName string
+	// From и To ограничивают назначения по assigned_at и авторство по created_at: [From, To)
+	From *time.Time
+	To   *time.Time
+	// Status учитывает только PR в этом статусе
+	Status api.PullRequestStatus
 }
 
 // PRRepositoryInterface определяет интерфейс для работы с Pull Requests
 type PRRepositoryInterface interface {
-	CreatePR(pr *api.PullRequest) (*api.PullRequest, error)
-	GetPR(prID string) (*api.PullRequest, error)
-	UpdatePRStatus(prID string, status api.PullRequestStatus, mergedAt *time.Time) (*api.PullRequest, error)
-	GetPRsByReviewer(userID string) ([]api.PullRequestShort, error)
-	ReassignReviewer(prID string, oldUserID, newUserID string) (*api.PullRequest, error)
-	AddReviewer(prID string, userID string) error
-	GetReviewerStatistics() ([]ReviewerStatistic, error)
-	GetOpenPRsByReviewers(userIDs []string) ([]api.PullRequest, error)
-	BatchReassignReviewers(reassignments map[string]map[string]string) error
+	CreatePR(ctx context.Context, pr *api.PullRequest) (*api.PullRequest, error)
+	GetPR(ctx context.Context, prID string) (*api.PullRequest, error)
+	UpdatePRStatus(ctx context.Context, prID string, status api.PullRequestStatus, changedAt *time.Time) (*api.PullRequest, error)
+	// GetPRsByReviewer получает PR, где пользователь назначен ревьювером, последние назначения первыми
+	GetPRsByReviewer(ctx context.Context, userID string, filter *ReviewerPRsFilter) ([]api.PullRequestShort, error)
+	ReassignReviewer(ctx context.Context, prID string, oldUserID, newUserID string) (*api.PullRequest, error)
+	AddReviewer(ctx context.Context, prID string, userID string) error
+	GetReviewerStatistics(ctx context.Context, filter *StatisticsFilter) ([]ReviewerStatistic, error)
+	GetOpenPRsByReviewers(ctx context.Context, userIDs []string) ([]api.PullRequest, error)
+	GetOpenReviewCounts(ctx context.Context, userIDs []string) (map[string]int, error)
+	BatchReassignReviewers(ctx context.Context, reassignments map[string]map[string]string) error
+	AddReview(ctx context.Context, prID string, userID string, verdict api.ReviewVerdict) error
+	// ListPRs получает до filter.Limit PR с назначенными ревьюверами (без вердиктов)
+	ListPRs(ctx context.Context, filter *PRListFilter) ([]api.PullRequest, error)
+	// ExportAssignments передает fn все назначения ревьюверов по одному в порядке assigned_at,
+	// не загружая их в память целиком; ошибка fn прерывает выгрузку и возвращается
+	ExportAssignments(ctx context.Context, fn func(row *api.AssignmentExportRow) error) error
+	// GetStaleReviews получает назначения на открытые PR, по которым ревьювер не дал вердикт
+	// в течение SLA команды автора PR, самые старые первыми
+	GetStaleReviews(ctx context.Context, filter *StaleReviewsFilter) ([]StaleReview, error)
+	// MarkSLABreached отмечает нарушение SLA назначения и записывает событие review.sla_breached в outbox
+	// Возвращает false, если нарушение уже отмечено или ревьювер снят с PR
+	MarkSLABreached(ctx context.Context, review *StaleReview, at time.Time) (bool, error)
+	// GetReviewTimeouts получает назначения на открытые PR без вердикта дольше auto_reassign_after
+	// команды автора, если лимит автоматических переназначений PR не исчерпан; самые старые первыми
+	GetReviewTimeouts(ctx context.Context, now time.Time) ([]ReviewTimeout, error)
+	// AddPRHistory записывает событие в историю PR
+	AddPRHistory(ctx context.Context, prID string, entry *api.PullRequestHistoryEntry) error
+	// GetPRHistory получает историю PR в порядке записи
+	GetPRHistory(ctx context.Context, prID string) ([]api.PullRequestHistoryEntry, error)
+	// SaveCodeowners создает или заменяет правила CODEOWNERS репозитория
+	SaveCodeowners(ctx context.Context, file *CodeownersFile) error
+	GetCodeowners(ctx context.Context, repository string) (*CodeownersFile, error)
+	DeleteCodeowners(ctx context.Context, repository string) error
+	// SavePRChangedFiles сохраняет измененные файлы PR, заменяя сохраненные ранее
+	SavePRChangedFiles(ctx context.Context, prID string, files *PRChangedFiles) error
+	// GetPRChangedFiles получает измененные файлы PR; если их нет, Paths пустой
+	GetPRChangedFiles(ctx context.Context, prID string) (*PRChangedFiles, error)
+	// SetReviewersCount меняет количество ревьюверов PR (reviewers_count)
+	SetReviewersCount(ctx context.Context, prID string, count int) error
+}
+
+// CodeownersFile правила CODEOWNERS репозитория в исходном виде
+type CodeownersFile struct {
+	Repository string
+	Content    string
+	UpdatedAt  time.Time
+}
+
+// PRChangedFiles репозиторий и измененные файлы черновика PR
+// Сохраняются при создании черновика, чтобы назначить владельцев кода при переводе в OPEN
+type PRChangedFiles struct {
+	Repository string
+	Paths      []string
+}
+
+// ReviewTimeout назначение, по которому ревьювер не дал вердикт за auto_reassign_after команды автора PR
+type ReviewTimeout struct {
+	PullRequestID string
+	// TeamName команда автора PR, чья политика применяется
+	TeamName   string
+	ReviewerID string
+	AssignedAt time.Time
+	// AutoReassignments количество уже выполненных автоматических переназначений PR
+	AutoReassignments    int
+	MaxAutoReassignments int
+}
+
+// StaleReview назначение ревьювера, по которому нарушен SLA первого вердикта
+type StaleReview struct {
+	PullRequestID   string
+	PullRequestName string
+	AuthorID        string
+	// TeamName команда автора PR, чей SLA нарушен
+	TeamName   string
+	ReviewerID string
+	AssignedAt time.Time
+	SLA        time.Duration
+	// BreachedAt время записи события review.sla_breached; nil, если событие еще не записано
+	BreachedAt *time.Time
+}
+
+// DueAt срок первого вердикта ревьювера
+func (r *StaleReview) DueAt() time.Time {
+	return r.AssignedAt.Add(r.SLA)
+}
+
+// StaleReviewsFilter параметры поиска назначений с нарушенным SLA; пустые поля не ограничивают выборку
+type StaleReviewsFilter struct {
+	// Now момент, на который проверяется SLA: назначение просрочено, если assigned_at + SLA <= Now
+	Now        time.Time
+	TeamName   string
+	ReviewerID string
+	// OnlyUnmarked только назначения, по которым событие review.sla_breached еще не записано
+	OnlyUnmarked bool
+}
+
+// PRSortField поле сортировки списка PR
+type PRSortField string
+
+const (
+	PRSortCreatedAt PRSortField = "created_at"
+	PRSortName      PRSortField = "name"
+)
+
+// PRListFilter фильтры, сортировка и страница списка PR
+// Пустые поля не ограничивают выборку; интервалы дат полуоткрытые: [From, To)
+type PRListFilter struct {
+	Status       api.PullRequestStatus
+	AuthorID     string
+	ReviewerID   string
+	TeamName     string
+	CreatedFrom  *time.Time
+	CreatedTo    *time.Time
+	MergedFrom   *time.Time
+	MergedTo     *time.Time
+	NameContains string
+
+	SortBy     PRSortField
+	Descending bool
+	// After позиция последнего PR предыдущей страницы: выдача начинается строго после нее
+	After *PRListCursor
+	Limit int
+}
+
+// PRListCursor позиция в списке PR: значение поля сортировки и pull_request_id для одинаковых значений
+type PRListCursor struct {
+	CreatedAt     time.Time
+	Name          string
+	PullRequestID string
+}
+
+// ReviewerPRsFilter фильтр и страница PR ревьювера
+type ReviewerPRsFilter struct {
+	Status api.PullRequestStatus
+	// After позиция последнего PR предыдущей страницы: выдача начинается строго после нее
+	After *ReviewerPRsCursor
+	// Limit 0 - без ограничения
+	Limit int
+}
+
+// ReviewerPRsCursor позиция в списке PR ревьювера: время назначения и pull_request_id для одинакового времени
+type ReviewerPRsCursor struct {
+	AssignedAt    time.Time
+	PullRequestID string
+}
+
+// SubscriptionRepositoryInterface определяет интерфейс для работы с подписками на события
+type SubscriptionRepositoryInterface interface {
+	events.SubscriptionStore
+	CreateSubscription(ctx context.Context, subscription *events.Subscription) (*events.Subscription, error)
+	ListSubscriptions(ctx context.Context) ([]events.Subscription, error)
+	DeleteSubscription(ctx context.Context, subscriptionID int64) error
+	SubscriptionExists(ctx context.Context, subscriptionID int64) (bool, error)
+	ListDeliveries(ctx context.Context, subscriptionID int64, limit int) ([]events.Delivery, error)
 }
@@ -0,0 +1,83 @@
+package memory
+
+import (
+	"context"
+	"slices"
+
+	"pr-review-assigner/internal/storage"
+)
+
+// SaveCodeowners создает или заменяет правила CODEOWNERS репозитория
+func (r *PRRepository) SaveCodeowners(ctx context.Context, file *storage.CodeownersFile) error {
+	return r.db.update(ctx, func(st *state) error {
+		st.codeowners[file.Repository] = *file
+		return nil
+	})
+}
+
+// GetCodeowners получает правила CODEOWNERS репозитория
+func (r *PRRepository) GetCodeowners(ctx context.Context, repository string) (*storage.CodeownersFile, error) {
+	var file storage.CodeownersFile
+	err := r.db.view(ctx, func(st *state) error {
+		var ok bool
+		if file, ok = st.codeowners[repository]; !ok {
+			return storage.ErrNotFound
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &file, nil
+}
+
+// DeleteCodeowners удаляет правила CODEOWNERS репозитория
+func (r *PRRepository) DeleteCodeowners(ctx context.Context, repository string) error {
+	return r.db.update(ctx, func(st *state) error {
+		if _, ok := st.codeowners[repository]; !ok {
+			return storage.ErrNotFound
+		}
+		delete(st.codeowners, repository)
+		return nil
+	})
+}
+
+// SavePRChangedFiles сохраняет измененные файлы PR, заменяя сохраненные ранее
+func (r *PRRepository) SavePRChangedFiles(ctx context.Context, prID string, files *storage.PRChangedFiles) error {
+	return r.db.update(ctx, func(st *state) error {
+		stored, ok := st.prs[prID]
+		if !ok {
+			return storage.ErrForeignKeyViolation
+		}
+		stored.changedFiles = storage.PRChangedFiles{Repository: files.Repository, Paths: slices.Clone(files.Paths)}
+		return nil
+	})
+}
+
+// GetPRChangedFiles получает измененные файлы PR; если их нет, Paths пустой
+func (r *PRRepository) GetPRChangedFiles(ctx context.Context, prID string) (*storage.PRChangedFiles, error) {
+	files := &storage.PRChangedFiles{Paths: []string{}}
+	err := r.db.view(ctx, func(st *state) error {
+		if stored, ok := st.prs[prID]; ok && len(stored.changedFiles.Paths) > 0 {
+			files.Repository = stored.changedFiles.Repository
+			files.Paths = slices.Clone(stored.changedFiles.Paths)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return files, nil
+}
+
+// SetReviewersCount меняет количество ревьюверов PR
+func (r *PRRepository) SetReviewersCount(ctx context.Context, prID string, count int) error {
+	return r.db.update(ctx, func(st *state) error {
+		stored, ok := st.prs[prID]
+		if !ok {
+			return storage.ErrNotFound
+		}
+		stored.pr.ReviewersCount = &count
+		return nil
+	})
+}
@@ -0,0 +1,90 @@
+package memory
+
+import (
+	"context"
+	"slices"
+	"time"
+
+	"pr-review-assigner/internal/events"
+)
+
+// OutboxRepository реализует events.OutboxStore в памяти
+// События записываются репозиторием PR в том же изменении, что и ревьюверы
+type OutboxRepository struct {
+	db db
+}
+
+// NewOutboxRepository создает новый экземпляр репозитория outbox
+func NewOutboxRepository(store *Store) *OutboxRepository {
+	return &OutboxRepository{db: store}
+}
+
+// ClaimPending резервирует до limit недоставленных записей на время lease
+func (r *OutboxRepository) ClaimPending(ctx context.Context, limit int, lease time.Duration) ([]events.OutboxRecord, error) {
+	var records []events.OutboxRecord
+	err := r.db.update(ctx, func(st *state) error {
+		now := time.Now()
+		for i := range st.outbox {
+			if len(records) >= limit {
+				break
+			}
+			record := &st.outbox[i]
+			if record.delivered || record.availableAt.After(now) {
+				continue
+			}
+			record.availableAt = now.Add(lease)
+			records = append(records, events.OutboxRecord{
+				ID:             record.id,
+				Event:          record.event,
+				Attempts:       record.attempts,
+				DeliveredSinks: slices.Clone(record.deliveredSinks),
+			})
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return records, nil
+}
+
+// MarkDelivered помечает запись доставленной
+func (r *OutboxRepository) MarkDelivered(ctx context.Context, id int64) error {
+	return r.db.update(ctx, func(st *state) error {
+		if record := st.outboxRecord(id); record != nil {
+			record.delivered = true
+		}
+		return nil
+	})
+}
+
+// MarkFailed сохраняет ошибку доставки и получателей, принявших событие,
+// и откладывает следующую попытку на retryAfter
+func (r *OutboxRepository) MarkFailed(ctx context.Context, id int64, deliveredSinks []string, deliveryErr error, retryAfter time.Duration) error {
+	return r.db.update(ctx, func(st *state) error {
+		if record := st.outboxRecord(id); record != nil {
+			record.attempts++
+			record.lastError = deliveryErr.Error()
+			record.availableAt = time.Now().Add(retryAfter)
+			// Срез общий с прошлыми снимками состояния, поэтому не дописывается на месте
+			sinks := slices.Clone(record.deliveredSinks)
+			for _, sink := range deliveredSinks {
+				if !slices.Contains(sinks, sink) {
+					sinks = append(sinks, sink)
+				}
+			}
+			record.deliveredSinks = sinks
+		}
+		return nil
+	})
+}
+
+// outboxRecord находит запись outbox по идентификатору
+func (st *state) outboxRecord(id int64) *outboxRecord {
+	for i := range st.outbox {
+		if st.outbox[i].id == id {
+			return &st.outbox[i]
+		}
+	}
+	return nil
+}
@@ -0,0 +1,818 @@
+package memory
+
+import (
+	"cmp"
+	"context"
+	"maps"
+	"slices"
+	"strings"
+	"time"
+
+	"pr-review-assigner/internal/api"
+	"pr-review-assigner/internal/events"
+	"pr-review-assigner/internal/storage"
+)
+
+// PRRepository реализует storage.PRRepositoryInterface в памяти
+type PRRepository struct {
+	db db
+}
+
+// NewPRRepository создает новый экземпляр репозитория PR
+func NewPRRepository(store *Store) *PRRepository {
+	return &PRRepository{db: store}
+}
+
+// CreatePR создает новый Pull Request и возвращает созданный PR
+// PR, ревьюверы и события их назначения сохраняются атомарно
+func (r *PRRepository) CreatePR(ctx context.Context, pr *api.PullRequest) (*api.PullRequest, error) {
+	var created api.PullRequest
+	err := r.db.update(ctx, func(st *state) error {
+		if _, ok := st.prs[pr.PullRequestId]; ok {
+			return storage.ErrDuplicateKey
+		}
+		if _, ok := st.users[pr.AuthorId]; !ok {
+			return storage.ErrForeignKeyViolation
+		}
+		if !isValidStatus(pr.Status) || (pr.ReviewersCount != nil && *pr.ReviewersCount < 0) {
+			return storage.ErrCheckViolation
+		}
+
+		createdAt := time.Now()
+		if pr.CreatedAt != nil {
+			createdAt = *pr.CreatedAt
+		}
+		created = api.PullRequest{
+			PullRequestId:   pr.PullRequestId,
+			PullRequestName: pr.PullRequestName,
+			AuthorId:        pr.AuthorId,
+			Status:          pr.Status,
+			CreatedAt:       &createdAt,
+			ReviewersCount:  copyInt(pr.ReviewersCount),
+		}
+		st.prs[pr.PullRequestId] = &pullRequest{
+			pr:            created,
+			assignedAt:    make(map[string]time.Time),
+			slaBreachedAt: make(map[string]time.Time),
+		}
+
+		// Назначаем ревьюверов, если они указаны
+		return st.assignReviewers(pr.PullRequestId, pr.AssignedReviewers)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if len(pr.AssignedReviewers) > 0 {
+		created.AssignedReviewers = pr.AssignedReviewers
+	} else {
+		created.AssignedReviewers = []string{}
+	}
+	return &created, nil
+}
+
+// GetPR получает Pull Request по ID со всеми назначенными ревьюверами
+func (r *PRRepository) GetPR(ctx context.Context, prID string) (*api.PullRequest, error) {
+	var pr *api.PullRequest
+	err := r.db.view(ctx, func(st *state) error {
+		var err error
+		pr, err = st.getPR(prID)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	return pr, nil
+}
+
+// UpdatePRStatus обновляет статус PR и возвращает обновленный PR
+// changedAt сохраняется как merged_at для MERGED и как closed_at для CLOSED;
+// при переходе в OPEN или DRAFT closed_at сбрасывается
+func (r *PRRepository) UpdatePRStatus(ctx context.Context, prID string, status api.PullRequestStatus, changedAt *time.Time) (*api.PullRequest, error) {
+	var pr *api.PullRequest
+	err := r.db.update(ctx, func(st *state) error {
+		stored, ok := st.prs[prID]
+		if !ok {
+			return storage.ErrNotFound
+		}
+		if !isValidStatus(status) {
+			return storage.ErrCheckViolation
+		}
+
+		stored.pr.Status = status
+		switch {
+		case status == api.PullRequestStatusMERGED && changedAt != nil:
+			stored.pr.MergedAt = copyTime(changedAt)
+		case status == api.PullRequestStatusCLOSED && changedAt != nil:
+			stored.pr.ClosedAt = copyTime(changedAt)
+		case status == api.PullRequestStatusOPEN || status == api.PullRequestStatusDRAFT:
+			stored.pr.ClosedAt = nil
+		}
+
+		if status == api.PullRequestStatusMERGED {
+			st.addOutboxEvents(events.NewPRMerged(prID))
+		}
+
+		var err error
+		pr, err = st.getPR(prID)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	return pr, nil
+}
+
+// GetPRsByReviewer получает PR, где пользователь назначен ревьювером, последние назначения первыми
+// Страница задается keyset-курсором по (assigned_at, pull_request_id)
+func (r *PRRepository) GetPRsByReviewer(ctx context.Context, userID string, filter *storage.ReviewerPRsFilter) ([]api.PullRequestShort, error) {
+	var prs []api.PullRequestShort
+	err := r.db.view(ctx, func(st *state) error {
+		for _, stored := range st.prs {
+			assignedAt, ok := stored.assignedAt[userID]
+			if !ok || (filter.Status != "" && stored.pr.Status != filter.Status) {
+				continue
+			}
+			if filter.After != nil && compareReviewerPRs(assignedAt, stored.pr.PullRequestId, filter.After) >= 0 {
+				continue
+			}
+			prs = append(prs, api.PullRequestShort{
+				PullRequestId:   stored.pr.PullRequestId,
+				PullRequestName: stored.pr.PullRequestName,
+				AuthorId:        stored.pr.AuthorId,
+				Status:          api.PullRequestShortStatus(stored.pr.Status),
+				AssignedAt:      &assignedAt,
+			})
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	slices.SortFunc(prs, func(a, b api.PullRequestShort) int {
+		return cmp.Or(b.AssignedAt.Compare(*a.AssignedAt), strings.Compare(b.PullRequestId, a.PullRequestId))
+	})
+	if filter.Limit > 0 && len(prs) > filter.Limit {
+		prs = prs[:filter.Limit]
+	}
+	return prs, nil
+}
+
+// compareReviewerPRs сравнивает позицию PR с курсором по возрастанию (assigned_at, pull_request_id)
+func compareReviewerPRs(assignedAt time.Time, prID string, cursor *storage.ReviewerPRsCursor) int {
+	return cmp.Or(assignedAt.Compare(cursor.AssignedAt), strings.Compare(prID, cursor.PullRequestID))
+}
+
+// ReassignReviewer переназначает одного ревьювера на другого и возвращает обновленный PR
+// Если newUserID пустой, то просто удаляет старого ревьювера без назначения нового
+func (r *PRRepository) ReassignReviewer(ctx context.Context, prID string, oldUserID, newUserID string) (*api.PullRequest, error) {
+	var pr *api.PullRequest
+	err := r.db.update(ctx, func(st *state) error {
+		stored, ok := st.prs[prID]
+		if !ok || !slices.Contains(stored.reviewers, oldUserID) {
+			return storage.ErrNotFound
+		}
+
+		stored.removeReviewer(oldUserID)
+		if newUserID != "" {
+			if _, err := st.insertReviewer(prID, newUserID, false); err != nil {
+				return err
+			}
+		}
+		st.addOutboxEvents(events.NewReviewerChanged(prID, oldUserID, newUserID))
+
+		var err error
+		pr, err = st.getPR(prID)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	return pr, nil
+}
+
+// AddReviewer добавляет ревьювера к PR
+func (r *PRRepository) AddReviewer(ctx context.Context, prID string, userID string) error {
+	return r.db.update(ctx, func(st *state) error {
+		return st.assignReviewers(prID, []string{userID})
+	})
+}
+
+// AddReview сохраняет вердикт ревьювера по PR
+func (r *PRRepository) AddReview(ctx context.Context, prID string, userID string, verdict api.ReviewVerdict) error {
+	return r.db.update(ctx, func(st *state) error {
+		switch verdict {
+		case api.APPROVED, api.CHANGESREQUESTED, api.COMMENTED:
+		default:
+			return storage.ErrCheckViolation
+		}
+		if _, ok := st.prs[prID]; !ok {
+			return storage.ErrForeignKeyViolation
+		}
+		if _, ok := st.users[userID]; !ok {
+			return storage.ErrForeignKeyViolation
+		}
+
+		st.lastReviewID++
+		st.reviews = append(st.reviews, review{
+			id:          st.lastReviewID,
+			prID:        prID,
+			userID:      userID,
+			verdict:     verdict,
+			submittedAt: time.Now(),
+		})
+		return nil
+	})
+}
+
+// GetReviewerStatistics получает статистику ревьюверов: назначения за период и их исход,
+// авторство PR и среднее время от назначения до слияния
+func (r *PRRepository) GetReviewerStatistics(ctx context.Context, filter *storage.StatisticsFilter) ([]storage.ReviewerStatistic, error) {
+	// matches проверяет период и статус PR; at - время, ограничиваемое периодом
+	matches := func(pr *api.PullRequest, at time.Time) bool {
+		return (filter.From == nil || !at.Before(*filter.From)) &&
+			(filter.To == nil || at.Before(*filter.To)) &&
+			(filter.Status == "" || pr.Status == filter.Status)
+	}
+
+	var statistics []storage.ReviewerStatistic
+	err := r.db.view(ctx, func(st *state) error {
+		byUser := make(map[string]*storage.ReviewerStatistic)
+		mergeTime := make(map[string]time.Duration)
+		merged := make(map[string]int)
+		for _, user := range st.users {
+			if filter.TeamName != "" && user.TeamName != filter.TeamName {
+				continue
+			}
+			byUser[user.UserId] = &storage.ReviewerStatistic{UserID: user.UserId, Username: user.Username, TeamName: user.TeamName}
+		}
+
+		for _, stored := range st.prs {
+			pr := &stored.pr
+			if stat, ok := byUser[pr.AuthorId]; ok && matches(pr, *pr.CreatedAt) {
+				stat.AuthoredPRsCount++
+			}
+			for userID, assignedAt := range stored.assignedAt {
+				stat, ok := byUser[userID]
+				if !ok || !matches(pr, assignedAt) {
+					continue
+				}
+				stat.AssignmentsCount++
+				switch pr.Status {
+				case api.PullRequestStatusOPEN:
+					stat.OpenAssignmentsCount++
+				case api.PullRequestStatusMERGED:
+					stat.CompletedAssignmentsCount++
+					mergeTime[userID] += pr.MergedAt.Sub(assignedAt)
+					merged[userID]++
+				case api.PullRequestStatusCLOSED:
+					stat.CompletedAssignmentsCount++
+				}
+			}
+		}
+
+		for userID, stat := range byUser {
+			if merged[userID] > 0 {
+				avg := mergeTime[userID] / time.Duration(merged[userID])
+				stat.AvgTimeToMerge = &avg
+			}
+			statistics = append(statistics, *stat)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	slices.SortFunc(statistics, func(a, b storage.ReviewerStatistic) int {
+		return cmp.Or(
+			cmp.Compare(b.AssignmentsCount, a.AssignmentsCount),
+			strings.Compare(a.Username, b.Username),
+			strings.Compare(a.UserID, b.UserID),
+		)
+	})
+	return statistics, nil
+}
+
+// GetOpenPRsByReviewers получает все открытые PR, где указанные пользователи являются ревьюверами
+func (r *PRRepository) GetOpenPRsByReviewers(ctx context.Context, userIDs []string) ([]api.PullRequest, error) {
+	if len(userIDs) == 0 {
+		return []api.PullRequest{}, nil
+	}
+
+	var prs []api.PullRequest
+	err := r.db.view(ctx, func(st *state) error {
+		for _, stored := range st.sortedPRs() {
+			if stored.pr.Status != api.PullRequestStatusOPEN {
+				continue
+			}
+			if !slices.ContainsFunc(stored.reviewers, func(id string) bool { return slices.Contains(userIDs, id) }) {
+				continue
+			}
+			pr := copyPR(stored)
+			prs = append(prs, *pr)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return prs, nil
+}
+
+// GetOpenReviewCounts возвращает количество открытых PR на ревью для каждого из указанных пользователей
+// Пользователи без открытых PR в результат не попадают
+func (r *PRRepository) GetOpenReviewCounts(ctx context.Context, userIDs []string) (map[string]int, error) {
+	counts := make(map[string]int, len(userIDs))
+	if len(userIDs) == 0 {
+		return counts, nil
+	}
+
+	err := r.db.view(ctx, func(st *state) error {
+		for _, stored := range st.prs {
+			if stored.pr.Status != api.PullRequestStatusOPEN {
+				continue
+			}
+			for _, reviewerID := range stored.reviewers {
+				if slices.Contains(userIDs, reviewerID) {
+					counts[reviewerID]++
+				}
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return counts, nil
+}
+
+// BatchReassignReviewers массово переназначает ревьюверов атомарно
+// reassignments - карта: prID -> {oldUserID -> newUserID}
+// Если newUserID пустой, ревьювер просто удаляется
+func (r *PRRepository) BatchReassignReviewers(ctx context.Context, reassignments map[string]map[string]string) error {
+	if len(reassignments) == 0 {
+		return nil
+	}
+
+	return r.db.update(ctx, func(st *state) error {
+		// Порядок обхода фиксирован, чтобы события в outbox шли в предсказуемом порядке
+		for _, prID := range slices.Sorted(maps.Keys(reassignments)) {
+			changes := reassignments[prID]
+			for _, oldUserID := range slices.Sorted(maps.Keys(changes)) {
+				newUserID := changes[oldUserID]
+
+				// Удаляем старого ревьювера
+				deleted := false
+				if stored, ok := st.prs[prID]; ok && slices.Contains(stored.reviewers, oldUserID) {
+					stored.removeReviewer(oldUserID)
+					deleted = true
+				}
+
+				// Добавляем нового ревьювера, если он указан и еще не назначен
+				inserted := false
+				if newUserID != "" {
+					var err error
+					if inserted, err = st.insertReviewer(prID, newUserID, true); err != nil {
+						return err
+					}
+				}
+
+				// События пишутся только о фактических изменениях
+				st.addOutboxEvents(events.NewReviewerChanges(prID, oldUserID, newUserID, deleted, inserted)...)
+			}
+		}
+		return nil
+	})
+}
+
+// ListPRs получает страницу PR по фильтрам с keyset-пагинацией по (поле сортировки, pull_request_id)
+func (r *PRRepository) ListPRs(ctx context.Context, filter *storage.PRListFilter) ([]api.PullRequest, error) {
+	// order приводит сравнение позиций к порядку выдачи
+	order := func(c int) int {
+		if filter.Descending {
+			return -c
+		}
+		return c
+	}
+
+	var page []*pullRequest
+	err := r.db.view(ctx, func(st *state) error {
+		for _, stored := range st.prs {
+			if !st.matchesPRFilter(stored, filter) {
+				continue
+			}
+			if filter.After != nil && order(comparePRListCursors(prListCursor(stored), *filter.After, filter.SortBy)) <= 0 {
+				continue
+			}
+			page = append(page, stored)
+		}
+		slices.SortFunc(page, func(a, b *pullRequest) int {
+			return order(comparePRListCursors(prListCursor(a), prListCursor(b), filter.SortBy))
+		})
+		if len(page) > filter.Limit {
+			page = page[:filter.Limit]
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	prs := make([]api.PullRequest, 0, len(page))
+	for _, stored := range page {
+		pr := copyPR(stored)
+		if pr.AssignedReviewers == nil {
+			pr.AssignedReviewers = []string{}
+		}
+		prs = append(prs, *pr)
+	}
+	return prs, nil
+}
+
+// ExportAssignments передает fn все назначения ревьюверов по одному в порядке assigned_at
+// Строки собираются из снимка состояния (данные и так хранятся в памяти) и не блокируют запись
+func (r *PRRepository) ExportAssignments(ctx context.Context, fn func(row *api.AssignmentExportRow) error) error {
+	var rows []api.AssignmentExportRow
+	err := r.db.view(ctx, func(st *state) error {
+		for _, stored := range st.prs {
+			for userID, assignedAt := range stored.assignedAt {
+				user := st.users[userID]
+				rows = append(rows, api.AssignmentExportRow{
+					PullRequestId:    stored.pr.PullRequestId,
+					PullRequestName:  stored.pr.PullRequestName,
+					Status:           stored.pr.Status,
+					AuthorId:         stored.pr.AuthorId,
+					ReviewerId:       userID,
+					ReviewerUsername: user.Username,
+					ReviewerTeamName: user.TeamName,
+					AssignedAt:       &assignedAt,
+					CreatedAt:        copyTime(stored.pr.CreatedAt),
+					MergedAt:         copyTime(stored.pr.MergedAt),
+				})
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	slices.SortFunc(rows, func(a, b api.AssignmentExportRow) int {
+		return cmp.Or(a.AssignedAt.Compare(*b.AssignedAt), strings.Compare(a.PullRequestId, b.PullRequestId), strings.Compare(a.ReviewerId, b.ReviewerId))
+	})
+	for i := range rows {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		if err := fn(&rows[i]); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// GetStaleReviews получает назначения на открытые PR без вердикта ревьювера после назначения,
+// срок которых (assigned_at + ReviewSLA команды автора) наступил к filter.Now
+func (r *PRRepository) GetStaleReviews(ctx context.Context, filter *storage.StaleReviewsFilter) ([]storage.StaleReview, error) {
+	var stale []storage.StaleReview
+	err := r.db.view(ctx, func(st *state) error {
+		for _, stored := range st.prs {
+			if stored.pr.Status != api.PullRequestStatusOPEN {
+				continue
+			}
+			teamName := st.users[stored.pr.AuthorId].TeamName
+			sla := st.teams[teamName].ReviewSLA
+			if sla <= 0 || (filter.TeamName != "" && teamName != filter.TeamName) {
+				continue
+			}
+			for userID, assignedAt := range stored.assignedAt {
+				if filter.ReviewerID != "" && userID != filter.ReviewerID {
+					continue
+				}
+				if assignedAt.Add(sla).After(filter.Now) || st.hasReviewSince(stored.pr.PullRequestId, userID, assignedAt) {
+					continue
+				}
+				breachedAt, breached := stored.slaBreachedAt[userID]
+				if breached && filter.OnlyUnmarked {
+					continue
+				}
+
+				review := storage.StaleReview{
+					PullRequestID:   stored.pr.PullRequestId,
+					PullRequestName: stored.pr.PullRequestName,
+					AuthorID:        stored.pr.AuthorId,
+					TeamName:        teamName,
+					ReviewerID:      userID,
+					AssignedAt:      assignedAt,
+					SLA:             sla,
+				}
+				if breached {
+					review.BreachedAt = &breachedAt
+				}
+				stale = append(stale, review)
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	slices.SortFunc(stale, func(a, b storage.StaleReview) int {
+		return cmp.Or(a.AssignedAt.Compare(b.AssignedAt), strings.Compare(a.PullRequestID, b.PullRequestID), strings.Compare(a.ReviewerID, b.ReviewerID))
+	})
+	return stale, nil
+}
+
+// MarkSLABreached отмечает нарушение SLA назначения и записывает событие review.sla_breached в outbox
+func (r *PRRepository) MarkSLABreached(ctx context.Context, review *storage.StaleReview, at time.Time) (bool, error) {
+	var marked bool
+	err := r.db.update(ctx, func(st *state) error {
+		stored, ok := st.prs[review.PullRequestID]
+		if !ok {
+			return nil
+		}
+		if _, assigned := stored.assignedAt[review.ReviewerID]; !assigned {
+			return nil
+		}
+		if _, breached := stored.slaBreachedAt[review.ReviewerID]; breached {
+			return nil
+		}
+
+		stored.slaBreachedAt[review.ReviewerID] = at
+		st.addOutboxEvents(events.NewReviewSLABreached(review.PullRequestID, review.ReviewerID, review.AssignedAt, review.DueAt()))
+		marked = true
+		return nil
+	})
+	if err != nil {
+		return false, err
+	}
+	return marked, nil
+}
+
+// GetReviewTimeouts получает назначения на открытые PR без вердикта ревьювера дольше
+// auto_reassign_after команды автора, если лимит автоматических переназначений PR не исчерпан
+func (r *PRRepository) GetReviewTimeouts(ctx context.Context, now time.Time) ([]storage.ReviewTimeout, error) {
+	var timeouts []storage.ReviewTimeout
+	err := r.db.view(ctx, func(st *state) error {
+		for _, stored := range st.prs {
+			if stored.pr.Status != api.PullRequestStatusOPEN {
+				continue
+			}
+			teamName := st.users[stored.pr.AuthorId].TeamName
+			settings := st.teams[teamName]
+			if settings.AutoReassignAfter <= 0 {
+				continue
+			}
+			reassigned := 0
+			for _, entry := range stored.history {
+				if entry.Action == api.PRHistoryAutoReassigned {
+					reassigned++
+				}
+			}
+			if reassigned >= settings.MaxAutoReassignments {
+				continue
+			}
+			for userID, assignedAt := range stored.assignedAt {
+				if assignedAt.Add(settings.AutoReassignAfter).After(now) || st.hasReviewSince(stored.pr.PullRequestId, userID, assignedAt) {
+					continue
+				}
+				timeouts = append(timeouts, storage.ReviewTimeout{
+					PullRequestID:        stored.pr.PullRequestId,
+					TeamName:             teamName,
+					ReviewerID:           userID,
+					AssignedAt:           assignedAt,
+					AutoReassignments:    reassigned,
+					MaxAutoReassignments: settings.MaxAutoReassignments,
+				})
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	slices.SortFunc(timeouts, func(a, b storage.ReviewTimeout) int {
+		return cmp.Or(a.AssignedAt.Compare(b.AssignedAt), strings.Compare(a.PullRequestID, b.PullRequestID), strings.Compare(a.ReviewerID, b.ReviewerID))
+	})
+	return timeouts, nil
+}
+
+// AddPRHistory записывает событие в историю PR
+func (r *PRRepository) AddPRHistory(ctx context.Context, prID string, entry *api.PullRequestHistoryEntry) error {
+	return r.db.update(ctx, func(st *state) error {
+		stored, ok := st.prs[prID]
+		if !ok {
+			return storage.ErrForeignKeyViolation
+		}
+		if entry.Action != api.PRHistoryAutoReassigned {
+			return storage.ErrCheckViolation
+		}
+		stored.history = append(stored.history, *entry)
+		return nil
+	})
+}
+
+// GetPRHistory получает историю PR в порядке записи
+func (r *PRRepository) GetPRHistory(ctx context.Context, prID string) ([]api.PullRequestHistoryEntry, error) {
+	history := []api.PullRequestHistoryEntry{}
+	err := r.db.view(ctx, func(st *state) error {
+		if stored, ok := st.prs[prID]; ok {
+			history = append(history, stored.history...)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return history, nil
+}
+
+// hasReviewSince проверяет, что ревьювер отправил вердикт по PR не раньше since
+func (st *state) hasReviewSince(prID, userID string, since time.Time) bool {
+	for _, rv := range st.reviews {
+		if rv.prID == prID && rv.userID == userID && !rv.submittedAt.Before(since) {
+			return true
+		}
+	}
+	return false
+}
+
+// matchesPRFilter проверяет PR на соответствие фильтрам списка (как WHERE в ListPRs PostgreSQL)
+func (st *state) matchesPRFilter(stored *pullRequest, filter *storage.PRListFilter) bool {
+	pr := stored.pr
+	inRange := func(t *time.Time, from, to *time.Time) bool {
+		if from == nil && to == nil {
+			return true
+		}
+		return t != nil && (from == nil || !t.Before(*from)) && (to == nil || t.Before(*to))
+	}
+
+	switch {
+	case filter.Status != "" && pr.Status != filter.Status,
+		filter.AuthorID != "" && pr.AuthorId != filter.AuthorID,
+		filter.ReviewerID != "" && !slices.Contains(stored.reviewers, filter.ReviewerID),
+		filter.TeamName != "" && st.users[pr.AuthorId].TeamName != filter.TeamName,
+		!inRange(pr.CreatedAt, filter.CreatedFrom, filter.CreatedTo),
+		!inRange(pr.MergedAt, filter.MergedFrom, filter.MergedTo),
+		filter.NameContains != "" && !strings.Contains(strings.ToLower(pr.PullRequestName), strings.ToLower(filter.NameContains)):
+		return false
+	}
+	return true
+}
+
+// prListCursor возвращает позицию PR в списке
+func prListCursor(stored *pullRequest) storage.PRListCursor {
+	return storage.PRListCursor{
+		CreatedAt:     *stored.pr.CreatedAt,
+		Name:          stored.pr.PullRequestName,
+		PullRequestID: stored.pr.PullRequestId,
+	}
+}
+
+// comparePRListCursors сравнивает позиции по возрастанию поля сортировки, затем pull_request_id
+func comparePRListCursors(a, b storage.PRListCursor, sortBy storage.PRSortField) int {
+	if sortBy == storage.PRSortName {
+		return cmp.Or(strings.Compare(a.Name, b.Name), strings.Compare(a.PullRequestID, b.PullRequestID))
+	}
+	return cmp.Or(a.CreatedAt.Compare(b.CreatedAt), strings.Compare(a.PullRequestID, b.PullRequestID))
+}
+
+// assignReviewers назначает ревьюверов на PR и записывает события назначения в outbox
+// Для уже назначенных ревьюверов событие не создается
+func (st *state) assignReviewers(prID string, reviewerIDs []string) error {
+	for _, reviewerID := range reviewerIDs {
+		inserted, err := st.insertReviewer(prID, reviewerID, true)
+		if err != nil {
+			return err
+		}
+		if inserted {
+			st.addOutboxEvents(events.NewReviewerAssigned(prID, reviewerID))
+		}
+	}
+	return nil
+}
+
+// insertReviewer добавляет строку pr_reviewers с проверками триггера trg_pr_reviewers_limit,
+// внешних ключей и первичного ключа; ignoreConflict соответствует ON CONFLICT DO NOTHING
+func (st *state) insertReviewer(prID, userID string, ignoreConflict bool) (bool, error) {
+	stored, ok := st.prs[prID]
+	if !ok {
+		return false, storage.ErrForeignKeyViolation
+	}
+
+	// Лимит: reviewers_count PR или max_reviewers команды автора
+	limit := st.teams[st.users[stored.pr.AuthorId].TeamName].MaxReviewers
+	if stored.pr.ReviewersCount != nil {
+		limit = *stored.pr.ReviewersCount
+	}
+	others := 0
+	for _, id := range stored.reviewers {
+		if id != userID {
+			others++
+		}
+	}
+	if others >= limit {
+		return false, storage.ErrCheckViolation
+	}
+
+	if _, ok := st.users[userID]; !ok {
+		return false, storage.ErrForeignKeyViolation
+	}
+	if slices.Contains(stored.reviewers, userID) {
+		if ignoreConflict {
+			return false, nil
+		}
+		return false, storage.ErrDuplicateKey
+	}
+
+	stored.reviewers = append(stored.reviewers, userID)
+	stored.assignedAt[userID] = time.Now()
+	return true, nil
+}
+
+// getPR возвращает копию PR с ревьюверами и их последними вердиктами
+func (st *state) getPR(prID string) (*api.PullRequest, error) {
+	stored, ok := st.prs[prID]
+	if !ok {
+		return nil, storage.ErrNotFound
+	}
+	pr := copyPR(stored)
+	pr.Reviews = st.latestReviews(stored)
+	return pr, nil
+}
+
+// latestReviews возвращает последний вердикт каждого назначенного ревьювера, упорядоченные по user_id
+func (st *state) latestReviews(stored *pullRequest) []api.PullRequestReview {
+	latest := make(map[string]review)
+	for _, rv := range st.reviews {
+		if rv.prID != stored.pr.PullRequestId || !slices.Contains(stored.reviewers, rv.userID) {
+			continue
+		}
+		prev, ok := latest[rv.userID]
+		if !ok || !rv.submittedAt.Before(prev.submittedAt) {
+			latest[rv.userID] = rv
+		}
+	}
+
+	var reviews []api.PullRequestReview
+	for _, userID := range slices.Sorted(maps.Keys(latest)) {
+		rv := latest[userID]
+		reviews = append(reviews, api.PullRequestReview{
+			UserId:      rv.userID,
+			Verdict:     rv.verdict,
+			SubmittedAt: rv.submittedAt,
+		})
+	}
+	return reviews
+}
+
+// sortedPRs возвращает PR в порядке создания
+func (st *state) sortedPRs() []*pullRequest {
+	prs := slices.Collect(maps.Values(st.prs))
+	slices.SortFunc(prs, func(a, b *pullRequest) int {
+		return cmp.Or(
+			a.pr.CreatedAt.Compare(*b.pr.CreatedAt),
+			strings.Compare(a.pr.PullRequestId, b.pr.PullRequestId),
+		)
+	})
+	return prs
+}
+
+// copyPR возвращает копию PR (без вердиктов), не разделяющую память с хранилищем
+func copyPR(stored *pullRequest) *api.PullRequest {
+	pr := stored.pr
+	pr.CreatedAt = copyTime(pr.CreatedAt)
+	pr.MergedAt = copyTime(pr.MergedAt)
+	pr.ClosedAt = copyTime(pr.ClosedAt)
+	pr.ReviewersCount = copyInt(pr.ReviewersCount)
+	pr.AssignedReviewers = slices.Clone(stored.reviewers)
+	return &pr
+}
+
+func isValidStatus(status api.PullRequestStatus) bool {
+	switch status {
+	case api.PullRequestStatusOPEN, api.PullRequestStatusMERGED, api.PullRequestStatusCLOSED, api.PullRequestStatusDRAFT:
+		return true
+	}
+	return false
+}
+
+func copyTime(t *time.Time) *time.Time {
+	if t == nil {
+		return nil
+	}
+	v := *t
+	return &v
+}
+
+func copyInt(n *int) *int {
+	if n == nil {
+		return nil
+	}
+	v := *n
+	return &v
+}
@@ -0,0 +1,102 @@
+package memory
+
+import (
+	"context"
+	"slices"
+	"strings"
+	"time"
+
+	"pr-review-assigner/internal/api"
+	"pr-review-assigner/internal/storage"
+)
+
+// SaveReviewerPool создает пул ревьюверов или заменяет его участников
+// Неизвестные пользователи дают ErrForeignKeyViolation, как ограничение reviewer_pool_members
+func (r *TeamRepository) SaveReviewerPool(ctx context.Context, pool *api.ReviewerPool) error {
+	return r.db.update(ctx, func(st *state) error {
+		members := make([]string, 0, len(pool.Members))
+		for _, userID := range pool.Members {
+			if _, ok := st.users[userID]; !ok {
+				return storage.ErrForeignKeyViolation
+			}
+			if !slices.Contains(members, userID) {
+				members = append(members, userID)
+			}
+		}
+		slices.Sort(members)
+		st.pools[pool.PoolName] = members
+		return nil
+	})
+}
+
+// GetReviewerPool получает пул ревьюверов с участниками, упорядоченными по user_id
+func (r *TeamRepository) GetReviewerPool(ctx context.Context, poolName string) (*api.ReviewerPool, error) {
+	var pool *api.ReviewerPool
+	err := r.db.view(ctx, func(st *state) error {
+		members, ok := st.pools[poolName]
+		if !ok {
+			return storage.ErrNotFound
+		}
+		pool = &api.ReviewerPool{PoolName: poolName, Members: slices.Clone(members)}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return pool, nil
+}
+
+// ListReviewerPools получает все пулы, упорядоченные по имени
+func (r *TeamRepository) ListReviewerPools(ctx context.Context) ([]api.ReviewerPool, error) {
+	pools := []api.ReviewerPool{}
+	err := r.db.view(ctx, func(st *state) error {
+		for poolName, members := range st.pools {
+			pools = append(pools, api.ReviewerPool{PoolName: poolName, Members: slices.Clone(members)})
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	slices.SortFunc(pools, func(a, b api.ReviewerPool) int {
+		return strings.Compare(a.PoolName, b.PoolName)
+	})
+	return pools, nil
+}
+
+// DeleteReviewerPool удаляет пул, в том числе из резервных пулов команд
+func (r *TeamRepository) DeleteReviewerPool(ctx context.Context, poolName string) error {
+	return r.db.update(ctx, func(st *state) error {
+		if _, ok := st.pools[poolName]; !ok {
+			return storage.ErrNotFound
+		}
+		delete(st.pools, poolName)
+		for teamName, settings := range st.teams {
+			if slices.Contains(settings.FallbackPools, poolName) {
+				settings.FallbackPools = slices.DeleteFunc(slices.Clone(settings.FallbackPools), func(name string) bool {
+					return name == poolName
+				})
+				st.teams[teamName] = settings
+			}
+		}
+		return nil
+	})
+}
+
+// GetActiveUsersByPool получает активных участников пула, у которых в момент now нет периода отсутствия
+func (r *UserRepository) GetActiveUsersByPool(ctx context.Context, poolName string, now time.Time) ([]api.User, error) {
+	var users []api.User
+	err := r.db.view(ctx, func(st *state) error {
+		for _, userID := range st.pools[poolName] {
+			user, ok := st.users[userID]
+			if ok && user.IsActive && !st.isUnavailable(userID, now) {
+				users = append(users, user)
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return users, nil
+}
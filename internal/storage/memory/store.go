@@ -0,0 +1,214 @@
+// Package memory реализует хранилище в памяти процесса
+// Используется для локальных демонстраций и интеграционных тестов без PostgreSQL.
+// Ограничения схемы (уникальность, внешние ключи, CHECK и лимит ревьюверов)
+// проверяются так же, как в миграциях, и возвращают те же ошибки storage
+package memory
+
+import (
+	"context"
+	"maps"
+	"slices"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"pr-review-assigner/internal/api"
+	"pr-review-assigner/internal/events"
+	"pr-review-assigner/internal/storage"
+)
+
+// Store хранит данные всех репозиториев
+// Каждая операция выполняется атомарно: изменения применяются к копии состояния,
+// которая подменяет текущее только при успехе. Сохраненное состояние не изменяется,
+// поэтому чтение не ждет записи и видит последнее зафиксированное состояние
+type Store struct {
+	// mu сериализует запись и транзакции TxManager
+	mu    sync.Mutex
+	state atomic.Pointer[state]
+}
+
+// NewStore создает пустое хранилище
+func NewStore() *Store {
+	s := &Store{}
+	s.state.Store(newState())
+	return s
+}
+
+// db доступ к состоянию хранилища: напрямую или внутри транзакции TxManager
+type db interface {
+	view(ctx context.Context, fn func(st *state) error) error
+	update(ctx context.Context, fn func(st *state) error) error
+}
+
+// view выполняет fn над текущим состоянием без изменений
+func (s *Store) view(ctx context.Context, fn func(st *state) error) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	return fn(s.state.Load())
+}
+
+// update выполняет fn над копией состояния и сохраняет ее, если fn не вернула ошибку
+func (s *Store) update(ctx context.Context, fn func(st *state) error) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	next := s.state.Load().clone()
+	if err := fn(next); err != nil {
+		return err
+	}
+	s.state.Store(next)
+	return nil
+}
+
+// tx состояние транзакции TxManager; блокировка Store удерживается в WithTx
+type tx struct {
+	state *state
+}
+
+func (t *tx) view(ctx context.Context, fn func(st *state) error) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	return fn(t.state)
+}
+
+func (t *tx) update(ctx context.Context, fn func(st *state) error) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	next := t.state.clone()
+	if err := fn(next); err != nil {
+		return err
+	}
+	t.state = next
+	return nil
+}
+
+// identityKey первичный ключ связи логина во внешней системе с пользователем
+type identityKey struct {
+	provider string
+	login    string
+}
+
+// pullRequest PR с ревьюверами в порядке назначения
+type pullRequest struct {
+	pr        api.PullRequest
+	reviewers []string
+	// assignedAt время назначения текущих ревьюверов (pr_reviewers.assigned_at)
+	assignedAt map[string]time.Time
+	// slaBreachedAt время записи события review.sla_breached по назначению (pr_reviewers.sla_breached_at)
+	slaBreachedAt map[string]time.Time
+	// history история PR в порядке записи (pr_history)
+	history []api.PullRequestHistoryEntry
+	// changedFiles измененные файлы черновика (pr_changed_files)
+	changedFiles storage.PRChangedFiles
+}
+
+// removeReviewer удаляет строку pr_reviewers
+func (p *pullRequest) removeReviewer(userID string) {
+	p.reviewers = slices.DeleteFunc(p.reviewers, func(id string) bool { return id == userID })
+	delete(p.assignedAt, userID)
+	delete(p.slaBreachedAt, userID)
+}
+
+// review вердикт ревьювера (история, как в pr_reviews)
+type review struct {
+	id          int64
+	prID        string
+	userID      string
+	verdict     api.ReviewVerdict
+	submittedAt time.Time
+}
+
+// outboxRecord запись outbox событий
+type outboxRecord struct {
+	id          int64
+	event       events.Event
+	availableAt time.Time
+	attempts    int
+	lastError   string
+	delivered   bool
+	// deliveredSinks получатели, принявшие событие при прошлых попытках (event_outbox_sinks)
+	deliveredSinks []string
+}
+
+// state данные хранилища
+type state struct {
+	teams      map[string]storage.TeamSettings
+	users      map[string]api.User
+	identities map[identityKey]string
+	prs        map[string]*pullRequest
+	reviews    []review
+	// unavailability периоды отсутствия пользователей (user_unavailability)
+	unavailability map[int64]api.UserUnavailability
+	// codeowners правила CODEOWNERS по репозиториям
+	codeowners map[string]storage.CodeownersFile
+	// pools участники пулов ревьюверов, упорядоченные по user_id
+	pools map[string][]string
+
+	subscriptions map[int64]events.Subscription
+	deliveries    []events.Delivery
+	outbox        []outboxRecord
+
+	lastReviewID         int64
+	lastUnavailabilityID int64
+	lastSubscriptionID   int64
+	lastDeliveryID       int64
+	lastOutboxID         int64
+}
+
+func newState() *state {
+	return &state{
+		teams:         make(map[string]storage.TeamSettings),
+		users:         make(map[string]api.User),
+		identities:    make(map[identityKey]string),
+		prs:           make(map[string]*pullRequest),
+		subscriptions: make(map[int64]events.Subscription),
+
+		unavailability: make(map[int64]api.UserUnavailability),
+		codeowners:     make(map[string]storage.CodeownersFile),
+		pools:          make(map[string][]string),
+	}
+}
+
+// clone создает копию состояния; срезы внутри значений не изменяются на месте, кроме ревьюверов PR, их назначений и истории
+func (st *state) clone() *state {
+	next := *st
+	next.teams = maps.Clone(st.teams)
+	next.users = maps.Clone(st.users)
+	next.identities = maps.Clone(st.identities)
+	next.prs = make(map[string]*pullRequest, len(st.prs))
+	for id, pr := range st.prs {
+		next.prs[id] = &pullRequest{
+			pr:            pr.pr,
+			reviewers:     slices.Clone(pr.reviewers),
+			assignedAt:    maps.Clone(pr.assignedAt),
+			slaBreachedAt: maps.Clone(pr.slaBreachedAt),
+			history:       slices.Clone(pr.history),
+			changedFiles:  pr.changedFiles,
+		}
+	}
+	next.reviews = slices.Clone(st.reviews)
+	next.unavailability = maps.Clone(st.unavailability)
+	next.codeowners = maps.Clone(st.codeowners)
+	next.pools = maps.Clone(st.pools)
+	next.subscriptions = maps.Clone(st.subscriptions)
+	next.deliveries = slices.Clone(st.deliveries)
+	next.outbox = slices.Clone(st.outbox)
+	return &next
+}
+
+// addOutboxEvents сохраняет события в outbox в рамках текущего изменения
+func (st *state) addOutboxEvents(evts ...events.Event) {
+	for _, event := range evts {
+		st.lastOutboxID++
+		st.outbox = append(st.outbox, outboxRecord{
+			id:          st.lastOutboxID,
+			event:       event,
+			availableAt: event.OccurredAt,
+		})
+	}
+}
@@ -0,0 +1,181 @@
+package memory
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"pr-review-assigner/internal/api"
+	"pr-review-assigner/internal/events"
+	"pr-review-assigner/internal/storage"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// Проверка соответствия интерфейсам storage и events
+var (
+	_ storage.TeamRepositoryInterface         = (*TeamRepository)(nil)
+	_ storage.UserRepositoryInterface         = (*UserRepository)(nil)
+	_ storage.PRRepositoryInterface           = (*PRRepository)(nil)
+	_ storage.SubscriptionRepositoryInterface = (*SubscriptionRepository)(nil)
+	_ storage.TxManager                       = (*TxManager)(nil)
+	_ events.OutboxStore                      = (*OutboxRepository)(nil)
+)
+
+type testRepos struct {
+	store         *Store
+	teams         *TeamRepository
+	users         *UserRepository
+	prs           *PRRepository
+	subscriptions *SubscriptionRepository
+	outbox        *OutboxRepository
+}
+
+func newTestRepos() *testRepos {
+	store := NewStore()
+	return &testRepos{
+		store:         store,
+		teams:         NewTeamRepository(store),
+		users:         NewUserRepository(store),
+		prs:           NewPRRepository(store),
+		subscriptions: NewSubscriptionRepository(store),
+		outbox:        NewOutboxRepository(store),
+	}
+}
+
+// seedTeam создает команду с активными пользователями
+func (r *testRepos) seedTeam(t *testing.T, teamName string, userIDs ...string) {
+	t.Helper()
+	ctx := context.Background()
+	require.NoError(t, r.teams.CreateTeam(ctx, teamName))
+	for _, userID := range userIDs {
+		require.NoError(t, r.users.CreateOrUpdateUser(ctx, &api.User{UserId: userID, Username: "name-" + userID, TeamName: teamName, IsActive: true}))
+	}
+}
+
+func TestStore_FailedUpdateLeavesStateUnchanged(t *testing.T) {
+	repos := newTestRepos()
+	repos.seedTeam(t, "backend", "u1", "u2")
+	ctx := context.Background()
+
+	// Второй ревьювер не существует: PR и первое назначение не должны сохраниться
+	_, err := repos.prs.CreatePR(ctx, &api.PullRequest{
+		PullRequestId:     "pr-1",
+		AuthorId:          "u1",
+		Status:            api.PullRequestStatusOPEN,
+		AssignedReviewers: []string{"u2", "ghost"},
+	})
+	assert.ErrorIs(t, err, storage.ErrForeignKeyViolation)
+
+	_, err = repos.prs.GetPR(ctx, "pr-1")
+	assert.ErrorIs(t, err, storage.ErrNotFound)
+	pending, err := repos.outbox.ClaimPending(ctx, 10, 0)
+	require.NoError(t, err)
+	assert.Empty(t, pending)
+}
+
+func TestOutboxRepository_DeliveredSinks(t *testing.T) {
+	repos := newTestRepos()
+	repos.seedTeam(t, "backend", "u1", "u2")
+	ctx := context.Background()
+	_, err := repos.prs.CreatePR(ctx, &api.PullRequest{PullRequestId: "pr-1", AuthorId: "u1", Status: api.PullRequestStatusOPEN, AssignedReviewers: []string{"u2"}})
+	require.NoError(t, err)
+
+	pending, err := repos.outbox.ClaimPending(ctx, 10, time.Minute)
+	require.NoError(t, err)
+	require.Len(t, pending, 1)
+	assert.Empty(t, pending[0].DeliveredSinks)
+
+	require.NoError(t, repos.outbox.MarkFailed(ctx, pending[0].ID, []string{"file"}, errors.New("webhook down"), 0))
+	require.NoError(t, repos.outbox.MarkFailed(ctx, pending[0].ID, []string{"log", "file"}, errors.New("webhook down"), 0))
+	pending, err = repos.outbox.ClaimPending(ctx, 10, time.Minute)
+	require.NoError(t, err)
+	require.Len(t, pending, 1)
+	assert.Equal(t, []string{"file", "log"}, pending[0].DeliveredSinks)
+	assert.Equal(t, 2, pending[0].Attempts)
+}
+
+func TestStore_CanceledContext(t *testing.T) {
+	repos := newTestRepos()
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	err := repos.teams.CreateTeam(ctx, "backend")
+	assert.ErrorIs(t, err, context.Canceled)
+
+	exists, err := repos.teams.TeamExists(context.Background(), "backend")
+	require.NoError(t, err)
+	assert.False(t, exists)
+}
+
+func TestTxManager_RollbackOnError(t *testing.T) {
+	repos := newTestRepos()
+	repos.seedTeam(t, "backend", "u1", "u2", "u3")
+	ctx := context.Background()
+	_, err := repos.prs.CreatePR(ctx, &api.PullRequest{PullRequestId: "pr-1", AuthorId: "u1", Status: api.PullRequestStatusOPEN, AssignedReviewers: []string{"u2"}})
+	require.NoError(t, err)
+
+	errReassign := errors.New("reassign failed")
+	err = NewTxManager(repos.store).WithTx(ctx, func(tx storage.Repositories) error {
+		if _, err := tx.Users.BatchDeactivateUsers(ctx, []string{"u2"}); err != nil {
+			return err
+		}
+		// Изменение видно внутри транзакции
+		user, err := tx.Users.GetUser(ctx, "u2")
+		require.NoError(t, err)
+		assert.False(t, user.IsActive)
+		return errReassign
+	})
+	assert.ErrorIs(t, err, errReassign)
+
+	user, err := repos.users.GetUser(ctx, "u2")
+	require.NoError(t, err)
+	assert.True(t, user.IsActive)
+}
+
+func TestTxManager_Commit(t *testing.T) {
+	repos := newTestRepos()
+	repos.seedTeam(t, "backend", "u1", "u2", "u3")
+	ctx := context.Background()
+	_, err := repos.prs.CreatePR(ctx, &api.PullRequest{PullRequestId: "pr-1", AuthorId: "u1", Status: api.PullRequestStatusOPEN, AssignedReviewers: []string{"u2"}})
+	require.NoError(t, err)
+
+	err = NewTxManager(repos.store).WithTx(ctx, func(tx storage.Repositories) error {
+		if _, err := tx.Users.BatchDeactivateUsers(ctx, []string{"u2"}); err != nil {
+			return err
+		}
+		return tx.PRs.BatchReassignReviewers(ctx, map[string]map[string]string{"pr-1": {"u2": "u3"}})
+	})
+	require.NoError(t, err)
+
+	pr, err := repos.prs.GetPR(ctx, "pr-1")
+	require.NoError(t, err)
+	assert.Equal(t, []string{"u3"}, pr.AssignedReviewers)
+	user, err := repos.users.GetUser(ctx, "u2")
+	require.NoError(t, err)
+	assert.False(t, user.IsActive)
+}
+
+func TestTxManager_ReadOutsideTxDoesNotBlock(t *testing.T) {
+	repos := newTestRepos()
+	repos.seedTeam(t, "backend", "u1", "u2")
+	ctx := context.Background()
+
+	// Нагрузко-зависимые стратегии читают нагрузку через обычный репозиторий внутри WithTx
+	err := NewTxManager(repos.store).WithTx(ctx, func(tx storage.Repositories) error {
+		if _, err := tx.Users.BatchDeactivateUsers(ctx, []string{"u2"}); err != nil {
+			return err
+		}
+		user, err := repos.users.GetUser(ctx, "u2")
+		require.NoError(t, err)
+		assert.True(t, user.IsActive, "uncommitted change must not be visible outside the transaction")
+		return nil
+	})
+	require.NoError(t, err)
+
+	user, err := repos.users.GetUser(ctx, "u2")
+	require.NoError(t, err)
+	assert.False(t, user.IsActive)
+}
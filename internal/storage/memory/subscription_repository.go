@@ -0,0 +1,145 @@
+package memory
+
+import (
+	"context"
+	"maps"
+	"slices"
+	"time"
+
+	"pr-review-assigner/internal/events"
+	"pr-review-assigner/internal/storage"
+)
+
+// SubscriptionRepository реализует storage.SubscriptionRepositoryInterface в памяти
+type SubscriptionRepository struct {
+	db db
+}
+
+// NewSubscriptionRepository создает новый экземпляр репозитория подписок
+func NewSubscriptionRepository(store *Store) *SubscriptionRepository {
+	return &SubscriptionRepository{db: store}
+}
+
+// CreateSubscription создает подписку
+func (r *SubscriptionRepository) CreateSubscription(ctx context.Context, subscription *events.Subscription) (*events.Subscription, error) {
+	var created events.Subscription
+	err := r.db.update(ctx, func(st *state) error {
+		st.lastSubscriptionID++
+		created = events.Subscription{
+			ID:         st.lastSubscriptionID,
+			URL:        subscription.URL,
+			Secret:     subscription.Secret,
+			EventTypes: append([]events.Type{}, subscription.EventTypes...),
+			IsActive:   true,
+			CreatedAt:  time.Now(),
+		}
+		st.subscriptions[created.ID] = created
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &created, nil
+}
+
+// ListSubscriptions получает все подписки
+func (r *SubscriptionRepository) ListSubscriptions(ctx context.Context) ([]events.Subscription, error) {
+	return r.querySubscriptions(ctx, func(events.Subscription) bool { return true })
+}
+
+// GetSubscriptionsForEvent получает активные подписки на указанный тип события
+// Подписка без типов событий получает все события
+func (r *SubscriptionRepository) GetSubscriptionsForEvent(ctx context.Context, eventType events.Type) ([]events.Subscription, error) {
+	return r.querySubscriptions(ctx, func(subscription events.Subscription) bool {
+		return subscription.IsActive && (len(subscription.EventTypes) == 0 || slices.Contains(subscription.EventTypes, eventType))
+	})
+}
+
+func (r *SubscriptionRepository) querySubscriptions(ctx context.Context, match func(events.Subscription) bool) ([]events.Subscription, error) {
+	subscriptions := []events.Subscription{}
+	err := r.db.view(ctx, func(st *state) error {
+		for _, id := range slices.Sorted(maps.Keys(st.subscriptions)) {
+			subscription := st.subscriptions[id]
+			if match(subscription) {
+				subscription.EventTypes = slices.Clone(subscription.EventTypes)
+				subscriptions = append(subscriptions, subscription)
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return subscriptions, nil
+}
+
+// DeleteSubscription удаляет подписку вместе с журналом ее доставок
+func (r *SubscriptionRepository) DeleteSubscription(ctx context.Context, subscriptionID int64) error {
+	return r.db.update(ctx, func(st *state) error {
+		if _, ok := st.subscriptions[subscriptionID]; !ok {
+			return storage.ErrNotFound
+		}
+		delete(st.subscriptions, subscriptionID)
+		st.deliveries = slices.DeleteFunc(st.deliveries, func(delivery events.Delivery) bool {
+			return delivery.SubscriptionID == subscriptionID
+		})
+		return nil
+	})
+}
+
+// SubscriptionExists проверяет существование подписки
+func (r *SubscriptionRepository) SubscriptionExists(ctx context.Context, subscriptionID int64) (bool, error) {
+	var exists bool
+	err := r.db.view(ctx, func(st *state) error {
+		_, exists = st.subscriptions[subscriptionID]
+		return nil
+	})
+	return exists, err
+}
+
+// LogDelivery записывает попытку доставки события в журнал
+func (r *SubscriptionRepository) LogDelivery(ctx context.Context, delivery *events.Delivery) error {
+	return r.db.update(ctx, func(st *state) error {
+		if _, ok := st.subscriptions[delivery.SubscriptionID]; !ok {
+			return storage.ErrForeignKeyViolation
+		}
+		st.lastDeliveryID++
+		delivery.ID = st.lastDeliveryID
+		st.deliveries = append(st.deliveries, *delivery)
+		return nil
+	})
+}
+
+// ListDeliveries получает последние попытки доставки по подписке (новые первыми)
+func (r *SubscriptionRepository) ListDeliveries(ctx context.Context, subscriptionID int64, limit int) ([]events.Delivery, error) {
+	deliveries := []events.Delivery{}
+	err := r.db.view(ctx, func(st *state) error {
+		for i := len(st.deliveries) - 1; i >= 0 && len(deliveries) < limit; i-- {
+			if st.deliveries[i].SubscriptionID == subscriptionID {
+				deliveries = append(deliveries, st.deliveries[i])
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return deliveries, nil
+}
+
+// ListEventDeliveries получает все попытки доставки события в порядке записи
+func (r *SubscriptionRepository) ListEventDeliveries(ctx context.Context, eventID string) ([]events.Delivery, error) {
+	deliveries := []events.Delivery{}
+	err := r.db.view(ctx, func(st *state) error {
+		for _, delivery := range st.deliveries {
+			if delivery.EventID == eventID {
+				deliveries = append(deliveries, delivery)
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return deliveries, nil
+}
@@ -0,0 +1,146 @@
+package memory
+
+import (
+	"context"
+	"slices"
+	"strings"
+	"time"
+
+	"pr-review-assigner/internal/api"
+	"pr-review-assigner/internal/storage"
+)
+
+// TeamRepository реализует storage.TeamRepositoryInterface в памяти
+type TeamRepository struct {
+	db db
+}
+
+// NewTeamRepository создает новый экземпляр репозитория команд
+func NewTeamRepository(store *Store) *TeamRepository {
+	return &TeamRepository{db: store}
+}
+
+// CreateTeam создает новую команду с настройками по умолчанию
+func (r *TeamRepository) CreateTeam(ctx context.Context, teamName string) error {
+	return r.db.update(ctx, func(st *state) error {
+		if _, ok := st.teams[teamName]; ok {
+			return storage.ErrDuplicateKey
+		}
+		st.teams[teamName] = storage.TeamSettings{
+			TeamName:     teamName,
+			MinReviewers: storage.DefaultMinReviewers,
+			MaxReviewers: storage.DefaultMaxReviewers,
+
+			MaxAutoReassignments: storage.DefaultMaxAutoReassignments,
+		}
+		return nil
+	})
+}
+
+// GetTeam получает команду с участниками по имени
+func (r *TeamRepository) GetTeam(ctx context.Context, teamName string) (*api.Team, error) {
+	var team *api.Team
+	err := r.db.view(ctx, func(st *state) error {
+		settings, ok := st.teams[teamName]
+		if !ok {
+			return storage.ErrNotFound
+		}
+
+		var members []api.TeamMember
+		for _, user := range st.usersByTeam(teamName) {
+			members = append(members, api.TeamMember{
+				UserId:   user.UserId,
+				Username: user.Username,
+				IsActive: user.IsActive,
+			})
+		}
+
+		reviewSLASeconds := int(settings.ReviewSLA / time.Second)
+		autoReassignAfterSeconds := int(settings.AutoReassignAfter / time.Second)
+		fallbackPools := append([]string{}, settings.FallbackPools...)
+		team = &api.Team{
+			TeamName:          teamName,
+			Members:           members,
+			MinReviewers:      &settings.MinReviewers,
+			MaxReviewers:      &settings.MaxReviewers,
+			RequiredApprovals: &settings.RequiredApprovals,
+			ReviewSlaSeconds:  &reviewSLASeconds,
+
+			AutoReassignAfterSeconds: &autoReassignAfterSeconds,
+			MaxAutoReassignments:     &settings.MaxAutoReassignments,
+			FallbackPools:            &fallbackPools,
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return team, nil
+}
+
+// TeamExists проверяет существование команды
+func (r *TeamRepository) TeamExists(ctx context.Context, teamName string) (bool, error) {
+	var exists bool
+	err := r.db.view(ctx, func(st *state) error {
+		_, exists = st.teams[teamName]
+		return nil
+	})
+	return exists, err
+}
+
+// GetTeamSettings получает настройки назначения ревьюверов команды
+func (r *TeamRepository) GetTeamSettings(ctx context.Context, teamName string) (*storage.TeamSettings, error) {
+	var settings storage.TeamSettings
+	err := r.db.view(ctx, func(st *state) error {
+		var ok bool
+		if settings, ok = st.teams[teamName]; !ok {
+			return storage.ErrNotFound
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &settings, nil
+}
+
+// UpdateTeamSettings обновляет настройки назначения ревьюверов команды и заменяет ее резервные пулы
+// Проверяются те же ограничения, что и в CHECK таблицы teams и в team_fallback_pools
+func (r *TeamRepository) UpdateTeamSettings(ctx context.Context, settings *storage.TeamSettings) error {
+	return r.db.update(ctx, func(st *state) error {
+		if _, ok := st.teams[settings.TeamName]; !ok {
+			return storage.ErrNotFound
+		}
+		if settings.MinReviewers < 0 || settings.MaxReviewers < 1 || settings.MinReviewers > settings.MaxReviewers ||
+			settings.RequiredApprovals < 0 || settings.RequiredApprovals > settings.MaxReviewers || settings.ReviewSLA < 0 ||
+			settings.AutoReassignAfter < 0 || settings.MaxAutoReassignments < 0 {
+			return storage.ErrCheckViolation
+		}
+		for i, poolName := range settings.FallbackPools {
+			if _, ok := st.pools[poolName]; !ok {
+				return storage.ErrForeignKeyViolation
+			}
+			if slices.Contains(settings.FallbackPools[:i], poolName) {
+				return storage.ErrDuplicateKey
+			}
+		}
+		updated := *settings
+		updated.FallbackPools = slices.Clone(settings.FallbackPools)
+		st.teams[settings.TeamName] = updated
+		return nil
+	})
+}
+
+// usersByTeam возвращает пользователей команды, упорядоченных по user_id
+func (st *state) usersByTeam(teamName string) []api.User {
+	var users []api.User
+	for _, user := range st.users {
+		if user.TeamName == teamName {
+			users = append(users, user)
+		}
+	}
+	slices.SortFunc(users, func(a, b api.User) int {
+		return strings.Compare(a.UserId, b.UserId)
+	})
+	return users
+}
@@ -0,0 +1,147 @@
+package memory
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"pr-review-assigner/internal/api"
+	"pr-review-assigner/internal/storage"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTeamRepository_CreateAndGet(t *testing.T) {
+	repos := newTestRepos()
+	repos.seedTeam(t, "backend", "u2", "u1")
+	ctx := context.Background()
+
+	team, err := repos.teams.GetTeam(ctx, "backend")
+	require.NoError(t, err)
+	assert.Equal(t, "backend", team.TeamName)
+	require.Len(t, team.Members, 2)
+	assert.Equal(t, "u1", team.Members[0].UserId)
+	assert.Equal(t, "u2", team.Members[1].UserId)
+	assert.Equal(t, storage.DefaultMinReviewers, *team.MinReviewers)
+	assert.Equal(t, storage.DefaultMaxReviewers, *team.MaxReviewers)
+	assert.Equal(t, 0, *team.RequiredApprovals)
+}
+
+func TestTeamRepository_Duplicate(t *testing.T) {
+	repos := newTestRepos()
+	repos.seedTeam(t, "backend")
+
+	err := repos.teams.CreateTeam(context.Background(), "backend")
+	assert.ErrorIs(t, err, storage.ErrDuplicateKey)
+}
+
+func TestTeamRepository_NotFound(t *testing.T) {
+	repos := newTestRepos()
+	ctx := context.Background()
+
+	_, err := repos.teams.GetTeam(ctx, "missing")
+	assert.ErrorIs(t, err, storage.ErrNotFound)
+	_, err = repos.teams.GetTeamSettings(ctx, "missing")
+	assert.ErrorIs(t, err, storage.ErrNotFound)
+	err = repos.teams.UpdateTeamSettings(ctx, &storage.TeamSettings{TeamName: "missing", MinReviewers: 1, MaxReviewers: 2})
+	assert.ErrorIs(t, err, storage.ErrNotFound)
+}
+
+func TestTeamRepository_UpdateSettingsChecks(t *testing.T) {
+	repos := newTestRepos()
+	repos.seedTeam(t, "backend")
+	ctx := context.Background()
+
+	tests := []struct {
+		name     string
+		settings storage.TeamSettings
+	}{
+		{"negative min", storage.TeamSettings{MinReviewers: -1, MaxReviewers: 2}},
+		{"zero max", storage.TeamSettings{MinReviewers: 0, MaxReviewers: 0}},
+		{"min above max", storage.TeamSettings{MinReviewers: 3, MaxReviewers: 2}},
+		{"approvals above max", storage.TeamSettings{MinReviewers: 1, MaxReviewers: 2, RequiredApprovals: 3}},
+		{"negative review sla", storage.TeamSettings{MinReviewers: 1, MaxReviewers: 2, ReviewSLA: -time.Hour}},
+		{"negative auto reassign", storage.TeamSettings{MinReviewers: 1, MaxReviewers: 2, AutoReassignAfter: -time.Hour}},
+		{"negative auto reassign limit", storage.TeamSettings{MinReviewers: 1, MaxReviewers: 2, MaxAutoReassignments: -1}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.settings.TeamName = "backend"
+			assert.ErrorIs(t, repos.teams.UpdateTeamSettings(ctx, &tt.settings), storage.ErrCheckViolation)
+		})
+	}
+
+	settings, err := repos.teams.GetTeamSettings(ctx, "backend")
+	require.NoError(t, err)
+	assert.Zero(t, settings.AutoReassignAfter)
+	assert.Equal(t, storage.DefaultMaxAutoReassignments, settings.MaxAutoReassignments)
+
+	require.NoError(t, repos.teams.UpdateTeamSettings(ctx, &storage.TeamSettings{TeamName: "backend", MinReviewers: 2, MaxReviewers: 3, RequiredApprovals: 1, ReviewSLA: 24 * time.Hour,
+		AutoReassignAfter: 4 * time.Hour, MaxAutoReassignments: 2}))
+	settings, err = repos.teams.GetTeamSettings(ctx, "backend")
+	require.NoError(t, err)
+	assert.Equal(t, 3, settings.MaxReviewers)
+	assert.Equal(t, 1, settings.RequiredApprovals)
+	assert.Equal(t, 24*time.Hour, settings.ReviewSLA)
+	assert.Equal(t, 4*time.Hour, settings.AutoReassignAfter)
+	assert.Equal(t, 2, settings.MaxAutoReassignments)
+
+	team, err := repos.teams.GetTeam(ctx, "backend")
+	require.NoError(t, err)
+	assert.Equal(t, 86400, *team.ReviewSlaSeconds)
+	assert.Equal(t, 14400, *team.AutoReassignAfterSeconds)
+	assert.Equal(t, 2, *team.MaxAutoReassignments)
+}
+
+func TestTeamRepository_ReviewerPools(t *testing.T) {
+	repos := newTestRepos()
+	repos.seedTeam(t, "backend", "u1", "u2")
+	repos.seedTeam(t, "platform", "p1", "p2")
+	ctx := context.Background()
+
+	require.NoError(t, repos.teams.SaveReviewerPool(ctx, &api.ReviewerPool{PoolName: "go", Members: []string{"p2", "u2", "p1"}}))
+	require.NoError(t, repos.teams.SaveReviewerPool(ctx, &api.ReviewerPool{PoolName: "empty", Members: []string{}}))
+	// Повторное сохранение заменяет участников
+	require.NoError(t, repos.teams.SaveReviewerPool(ctx, &api.ReviewerPool{PoolName: "go", Members: []string{"p2", "p1"}}))
+	assert.ErrorIs(t, repos.teams.SaveReviewerPool(ctx, &api.ReviewerPool{PoolName: "bad", Members: []string{"missing"}}), storage.ErrForeignKeyViolation)
+
+	pool, err := repos.teams.GetReviewerPool(ctx, "go")
+	require.NoError(t, err)
+	assert.Equal(t, &api.ReviewerPool{PoolName: "go", Members: []string{"p1", "p2"}}, pool)
+	_, err = repos.teams.GetReviewerPool(ctx, "missing")
+	assert.ErrorIs(t, err, storage.ErrNotFound)
+
+	pools, err := repos.teams.ListReviewerPools(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, []api.ReviewerPool{
+		{PoolName: "empty", Members: []string{}},
+		{PoolName: "go", Members: []string{"p1", "p2"}},
+	}, pools)
+
+	_, err = repos.users.UpdateUserIsActive(ctx, "p1", false)
+	require.NoError(t, err)
+	users, err := repos.users.GetActiveUsersByPool(ctx, "go", time.Now())
+	require.NoError(t, err)
+	require.Len(t, users, 1)
+	assert.Equal(t, "p2", users[0].UserId)
+	assert.Equal(t, "platform", users[0].TeamName)
+
+	// Резервные пулы сохраняются в заданном порядке
+	settings, err := repos.teams.GetTeamSettings(ctx, "backend")
+	require.NoError(t, err)
+	settings.FallbackPools = []string{"go", "empty"}
+	require.NoError(t, repos.teams.UpdateTeamSettings(ctx, settings))
+	settings, err = repos.teams.GetTeamSettings(ctx, "backend")
+	require.NoError(t, err)
+	assert.Equal(t, []string{"go", "empty"}, settings.FallbackPools)
+	settings.FallbackPools = []string{"missing"}
+	assert.ErrorIs(t, repos.teams.UpdateTeamSettings(ctx, settings), storage.ErrForeignKeyViolation)
+
+	// Удаленный пул пропадает из резервных пулов команды
+	require.NoError(t, repos.teams.DeleteReviewerPool(ctx, "go"))
+	assert.ErrorIs(t, repos.teams.DeleteReviewerPool(ctx, "go"), storage.ErrNotFound)
+	team, err := repos.teams.GetTeam(ctx, "backend")
+	require.NoError(t, err)
+	assert.Equal(t, []string{"empty"}, *team.FallbackPools)
+}
@@ -0,0 +1,45 @@
+package memory
+
+import (
+	"context"
+
+	"pr-review-assigner/internal/storage"
+)
+
+// TxManager реализует storage.TxManager поверх Store
+// На время WithTx запись в хранилище блокируется; чтение вне транзакции, как и в БД,
+// видит состояние до ее фиксации
+type TxManager struct {
+	store *Store
+}
+
+// NewTxManager создает новый экземпляр менеджера транзакций
+func NewTxManager(store *Store) *TxManager {
+	return &TxManager{store: store}
+}
+
+// WithTx выполняет fn над копией состояния; копия сохраняется, только если fn не вернула ошибку
+func (m *TxManager) WithTx(ctx context.Context, fn func(repos storage.Repositories) error) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	m.store.mu.Lock()
+	defer m.store.mu.Unlock()
+
+	t := &tx{state: m.store.state.Load().clone()}
+	repos := storage.Repositories{
+		Teams: &TeamRepository{db: t},
+		Users: &UserRepository{db: t},
+		PRs:   &PRRepository{db: t},
+	}
+	if err := fn(repos); err != nil {
+		return err
+	}
+
+	// Отмена контекста до фиксации откатывает транзакцию, как и в БД
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	m.store.state.Store(t.state)
+	return nil
+}
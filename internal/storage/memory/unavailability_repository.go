@@ -0,0 +1,181 @@
+package memory
+
+import (
+	"cmp"
+	"context"
+	"maps"
+	"slices"
+	"time"
+
+	"pr-review-assigner/internal/api"
+	"pr-review-assigner/internal/storage"
+)
+
+// CreateUnavailability создает период отсутствия пользователя
+func (r *UserRepository) CreateUnavailability(ctx context.Context, period *api.UserUnavailability) (*api.UserUnavailability, error) {
+	var created api.UserUnavailability
+	err := r.db.update(ctx, func(st *state) error {
+		if _, ok := st.users[period.UserId]; !ok {
+			return storage.ErrForeignKeyViolation
+		}
+		if !period.EndsAt.After(period.StartsAt) {
+			return storage.ErrCheckViolation
+		}
+
+		st.lastUnavailabilityID++
+		created = api.UserUnavailability{
+			UnavailabilityId: st.lastUnavailabilityID,
+			UserId:           period.UserId,
+			StartsAt:         period.StartsAt,
+			EndsAt:           period.EndsAt,
+			Reason:           period.Reason,
+			HandoffReviews:   period.HandoffReviews,
+		}
+		st.unavailability[created.UnavailabilityId] = created
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &created, nil
+}
+
+// GetUnavailability получает период отсутствия по ID
+func (r *UserRepository) GetUnavailability(ctx context.Context, unavailabilityID int64) (*api.UserUnavailability, error) {
+	var period api.UserUnavailability
+	err := r.db.view(ctx, func(st *state) error {
+		var ok bool
+		if period, ok = st.unavailability[unavailabilityID]; !ok {
+			return storage.ErrNotFound
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	period.HandedOffAt = copyTime(period.HandedOffAt)
+	return &period, nil
+}
+
+// ListUnavailability получает периоды отсутствия, упорядоченные по началу
+func (r *UserRepository) ListUnavailability(ctx context.Context, filter *storage.UnavailabilityFilter) ([]api.UserUnavailability, error) {
+	periods := []api.UserUnavailability{}
+	err := r.db.view(ctx, func(st *state) error {
+		for _, period := range st.unavailability {
+			if filter.UserID != "" && period.UserId != filter.UserID {
+				continue
+			}
+			if filter.EndsAfter != nil && !period.EndsAt.After(*filter.EndsAfter) {
+				continue
+			}
+			period.HandedOffAt = copyTime(period.HandedOffAt)
+			periods = append(periods, period)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	sortUnavailability(periods)
+	return periods, nil
+}
+
+// UpdateUnavailability изменяет период отсутствия
+// При переносе начала отметка о передаче ревью сбрасывается, чтобы передать их в новое начало
+func (r *UserRepository) UpdateUnavailability(ctx context.Context, period *api.UserUnavailability) (*api.UserUnavailability, error) {
+	var updated api.UserUnavailability
+	err := r.db.update(ctx, func(st *state) error {
+		var ok bool
+		if updated, ok = st.unavailability[period.UnavailabilityId]; !ok {
+			return storage.ErrNotFound
+		}
+		if !period.EndsAt.After(period.StartsAt) {
+			return storage.ErrCheckViolation
+		}
+
+		if !updated.StartsAt.Equal(period.StartsAt) {
+			updated.HandedOffAt = nil
+		}
+		updated.StartsAt = period.StartsAt
+		updated.EndsAt = period.EndsAt
+		updated.Reason = period.Reason
+		updated.HandoffReviews = period.HandoffReviews
+		st.unavailability[updated.UnavailabilityId] = updated
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	updated.HandedOffAt = copyTime(updated.HandedOffAt)
+	return &updated, nil
+}
+
+// DeleteUnavailability удаляет период отсутствия
+func (r *UserRepository) DeleteUnavailability(ctx context.Context, unavailabilityID int64) error {
+	return r.db.update(ctx, func(st *state) error {
+		if _, ok := st.unavailability[unavailabilityID]; !ok {
+			return storage.ErrNotFound
+		}
+		delete(st.unavailability, unavailabilityID)
+		return nil
+	})
+}
+
+// GetPendingHandoffs получает текущие периоды с handoff_reviews, по которым ревью еще не переданы
+func (r *UserRepository) GetPendingHandoffs(ctx context.Context, now time.Time) ([]api.UserUnavailability, error) {
+	periods := []api.UserUnavailability{}
+	err := r.db.view(ctx, func(st *state) error {
+		for _, period := range st.unavailability {
+			if period.HandoffReviews && period.HandedOffAt == nil && periodCovers(&period, now) {
+				periods = append(periods, period)
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	sortUnavailability(periods)
+	return periods, nil
+}
+
+// MarkHandedOff отмечает передачу ревью по периоду
+func (r *UserRepository) MarkHandedOff(ctx context.Context, unavailabilityID int64, at time.Time) (bool, error) {
+	var marked bool
+	err := r.db.update(ctx, func(st *state) error {
+		period, ok := st.unavailability[unavailabilityID]
+		if !ok || period.HandedOffAt != nil {
+			return nil
+		}
+		period.HandedOffAt = &at
+		st.unavailability[unavailabilityID] = period
+		marked = true
+		return nil
+	})
+	if err != nil {
+		return false, err
+	}
+	return marked, nil
+}
+
+// isUnavailable проверяет, что у пользователя идет период отсутствия
+func (st *state) isUnavailable(userID string, now time.Time) bool {
+	for period := range maps.Values(st.unavailability) {
+		if period.UserId == userID && periodCovers(&period, now) {
+			return true
+		}
+	}
+	return false
+}
+
+// periodCovers проверяет, что момент входит в период [starts_at, ends_at)
+func periodCovers(period *api.UserUnavailability, at time.Time) bool {
+	return !period.StartsAt.After(at) && period.EndsAt.After(at)
+}
+
+// sortUnavailability упорядочивает периоды по началу, затем по ID
+func sortUnavailability(periods []api.UserUnavailability) {
+	slices.SortFunc(periods, func(a, b api.UserUnavailability) int {
+		return cmp.Or(a.StartsAt.Compare(b.StartsAt), cmp.Compare(a.UnavailabilityId, b.UnavailabilityId))
+	})
+}
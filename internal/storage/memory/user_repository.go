@@ -0,0 +1,180 @@
+package memory
+
+import (
+	"context"
+	"slices"
+	"strings"
+	"time"
+
+	"pr-review-assigner/internal/api"
+	"pr-review-assigner/internal/storage"
+)
+
+// UserRepository реализует storage.UserRepositoryInterface в памяти
+type UserRepository struct {
+	db db
+}
+
+// NewUserRepository создает новый экземпляр репозитория пользователей
+func NewUserRepository(store *Store) *UserRepository {
+	return &UserRepository{db: store}
+}
+
+// CreateOrUpdateUser создает или обновляет пользователя; команда должна существовать
+func (r *UserRepository) CreateOrUpdateUser(ctx context.Context, user *api.User) error {
+	return r.db.update(ctx, func(st *state) error {
+		if _, ok := st.teams[user.TeamName]; !ok {
+			return storage.ErrForeignKeyViolation
+		}
+		// Рабочие часы задаются отдельно и при обновлении сохраняются, как в SQL-хранилищах
+		updated := *user
+		existing := st.users[user.UserId]
+		updated.Timezone, updated.WorkingHoursStart, updated.WorkingHoursEnd = existing.Timezone, existing.WorkingHoursStart, existing.WorkingHoursEnd
+		st.users[user.UserId] = updated
+		return nil
+	})
+}
+
+// GetUser получает пользователя по ID
+func (r *UserRepository) GetUser(ctx context.Context, userID string) (*api.User, error) {
+	var user api.User
+	err := r.db.view(ctx, func(st *state) error {
+		var ok bool
+		if user, ok = st.users[userID]; !ok {
+			return storage.ErrNotFound
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &user, nil
+}
+
+// UpdateUserIsActive обновляет флаг активности пользователя и возвращает обновленного пользователя
+func (r *UserRepository) UpdateUserIsActive(ctx context.Context, userID string, isActive bool) (*api.User, error) {
+	var user api.User
+	err := r.db.update(ctx, func(st *state) error {
+		var ok bool
+		if user, ok = st.users[userID]; !ok {
+			return storage.ErrNotFound
+		}
+		user.IsActive = isActive
+		st.users[userID] = user
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &user, nil
+}
+
+// UpdateUserWorkingHours задает часовой пояс и рабочие часы пользователя; nil сбрасывает их
+func (r *UserRepository) UpdateUserWorkingHours(ctx context.Context, userID string, hours *storage.WorkingHours) (*api.User, error) {
+	var user api.User
+	err := r.db.update(ctx, func(st *state) error {
+		var ok bool
+		if user, ok = st.users[userID]; !ok {
+			return storage.ErrNotFound
+		}
+		user.Timezone, user.WorkingHoursStart, user.WorkingHoursEnd = nil, nil, nil
+		if hours != nil {
+			timezone, start, end := hours.Timezone, hours.Start, hours.End
+			user.Timezone, user.WorkingHoursStart, user.WorkingHoursEnd = &timezone, &start, &end
+		}
+		st.users[userID] = user
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &user, nil
+}
+
+// GetActiveUsersByTeam получает список активных пользователей команды, исключая указанного пользователя
+// и пользователей, у которых в момент now идет период отсутствия
+func (r *UserRepository) GetActiveUsersByTeam(ctx context.Context, teamName string, excludeUserID string, now time.Time) ([]api.User, error) {
+	var users []api.User
+	err := r.db.view(ctx, func(st *state) error {
+		for _, user := range st.usersByTeam(teamName) {
+			if user.IsActive && user.UserId != excludeUserID && !st.isUnavailable(user.UserId, now) {
+				users = append(users, user)
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return users, nil
+}
+
+// BatchDeactivateUsers массово деактивирует указанных пользователей
+// Несуществующие user_id пропускаются, как в UPDATE ... WHERE user_id = ANY($1)
+func (r *UserRepository) BatchDeactivateUsers(ctx context.Context, userIDs []string) ([]api.User, error) {
+	if len(userIDs) == 0 {
+		return []api.User{}, nil
+	}
+
+	var users []api.User
+	err := r.db.update(ctx, func(st *state) error {
+		for _, userID := range userIDs {
+			user, ok := st.users[userID]
+			if !ok || slices.ContainsFunc(users, func(u api.User) bool { return u.UserId == userID }) {
+				continue
+			}
+			user.IsActive = false
+			st.users[userID] = user
+			users = append(users, user)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	slices.SortFunc(users, func(a, b api.User) int {
+		return strings.Compare(a.UserId, b.UserId)
+	})
+	return users, nil
+}
+
+// GetUsersByTeam получает всех пользователей команды (включая неактивных)
+func (r *UserRepository) GetUsersByTeam(ctx context.Context, teamName string) ([]api.User, error) {
+	var users []api.User
+	err := r.db.view(ctx, func(st *state) error {
+		users = st.usersByTeam(teamName)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return users, nil
+}
+
+// GetUserIDByLogin получает user_id по логину во внешней системе
+func (r *UserRepository) GetUserIDByLogin(ctx context.Context, provider string, login string) (string, error) {
+	var userID string
+	err := r.db.view(ctx, func(st *state) error {
+		var ok bool
+		if userID, ok = st.identities[identityKey{provider: provider, login: login}]; !ok {
+			return storage.ErrNotFound
+		}
+		return nil
+	})
+	if err != nil {
+		return "", err
+	}
+	return userID, nil
+}
+
+// LinkLogin связывает логин во внешней системе с пользователем (перепривязывает, если связь уже есть)
+func (r *UserRepository) LinkLogin(ctx context.Context, provider string, login string, userID string) error {
+	return r.db.update(ctx, func(st *state) error {
+		if _, ok := st.users[userID]; !ok {
+			return storage.ErrForeignKeyViolation
+		}
+		st.identities[identityKey{provider: provider, login: login}] = userID
+		return nil
+	})
+}
@@ -0,0 +1,169 @@
+package storage
+
+import (
+	"cmp"
+	"context"
+	"encoding/json"
+	"fmt"
+	"slices"
+	"time"
+
+	"pr-review-assigner/internal/events"
+
+	"github.com/lib/pq"
+)
+
+// insertOutboxEvents сохраняет события в outbox
+// Вызывается в транзакции изменения ревьюверов, чтобы событие не потерялось при сбое
+func insertOutboxEvents(ctx context.Context, exec dbtx, evts ...events.Event) error {
+	query := `
+		INSERT INTO event_outbox (event_id, event_type, payload, created_at)
+		VALUES ($1, $2, $3, $4)
+	`
+	for _, event := range evts {
+		payload, err := json.Marshal(event)
+		if err != nil {
+			return fmt.Errorf("failed to encode event: %w", err)
+		}
+		if _, err = exec.ExecContext(ctx, query, event.ID, string(event.Type), payload, event.OccurredAt); err != nil {
+			return HandleDBError(err)
+		}
+	}
+	return nil
+}
+
+// OutboxRepository предоставляет методы для разбора outbox событий
+type OutboxRepository struct {
+	*Repository
+}
+
+// NewOutboxRepository создает новый экземпляр репозитория outbox
+func NewOutboxRepository(repo *Repository) *OutboxRepository {
+	return &OutboxRepository{Repository: repo}
+}
+
+// ClaimPending резервирует до limit недоставленных записей на время lease
+// SKIP LOCKED позволяет нескольким экземплярам сервиса разбирать outbox параллельно
+func (r *OutboxRepository) ClaimPending(ctx context.Context, limit int, lease time.Duration) ([]events.OutboxRecord, error) {
+	query := `
+		UPDATE event_outbox
+		SET available_at = CURRENT_TIMESTAMP + make_interval(secs => $2)
+		WHERE outbox_id IN (
+			SELECT outbox_id
+			FROM event_outbox
+			WHERE delivered_at IS NULL AND available_at <= CURRENT_TIMESTAMP
+			ORDER BY outbox_id
+			LIMIT $1
+			FOR UPDATE SKIP LOCKED
+		)
+		RETURNING outbox_id, payload, attempts
+	`
+	rows, err := r.db.QueryContext(ctx, query, limit, lease.Seconds())
+	if err != nil {
+		return nil, HandleDBError(err)
+	}
+	defer rows.Close()
+
+	var records []events.OutboxRecord
+	for rows.Next() {
+		var record events.OutboxRecord
+		var payload []byte
+		if err := rows.Scan(&record.ID, &payload, &record.Attempts); err != nil {
+			return nil, HandleDBError(err)
+		}
+		if err := json.Unmarshal(payload, &record.Event); err != nil {
+			return nil, fmt.Errorf("failed to decode outbox event %d: %w", record.ID, err)
+		}
+		records = append(records, record)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, HandleDBError(err)
+	}
+
+	// UPDATE ... RETURNING не гарантирует порядок строк
+	slices.SortFunc(records, func(a, b events.OutboxRecord) int {
+		return cmp.Compare(a.ID, b.ID)
+	})
+
+	if err = r.loadDeliveredSinks(ctx, records); err != nil {
+		return nil, err
+	}
+
+	return records, nil
+}
+
+// loadDeliveredSinks заполняет получателей, принявших события при прошлых попытках
+func (r *OutboxRepository) loadDeliveredSinks(ctx context.Context, records []events.OutboxRecord) error {
+	if len(records) == 0 {
+		return nil
+	}
+
+	index := make(map[int64]int, len(records))
+	ids := make([]int64, len(records))
+	for i, record := range records {
+		index[record.ID] = i
+		ids[i] = record.ID
+	}
+
+	query := `
+		SELECT outbox_id, sink
+		FROM event_outbox_sinks
+		WHERE outbox_id = ANY($1)
+		ORDER BY outbox_id, sink
+	`
+	rows, err := r.db.QueryContext(ctx, query, pq.Array(ids))
+	if err != nil {
+		return HandleDBError(err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var id int64
+		var sink string
+		if err := rows.Scan(&id, &sink); err != nil {
+			return HandleDBError(err)
+		}
+		record := &records[index[id]]
+		record.DeliveredSinks = append(record.DeliveredSinks, sink)
+	}
+
+	if err = rows.Err(); err != nil {
+		return HandleDBError(err)
+	}
+	return nil
+}
+
+// MarkDelivered помечает запись доставленной
+func (r *OutboxRepository) MarkDelivered(ctx context.Context, id int64) error {
+	_, err := r.db.ExecContext(ctx, `UPDATE event_outbox SET delivered_at = CURRENT_TIMESTAMP WHERE outbox_id = $1`, id)
+	if err != nil {
+		return HandleDBError(err)
+	}
+	return nil
+}
+
+// MarkFailed сохраняет ошибку доставки и получателей, принявших событие,
+// и откладывает следующую попытку на retryAfter
+func (r *OutboxRepository) MarkFailed(ctx context.Context, id int64, deliveredSinks []string, deliveryErr error, retryAfter time.Duration) error {
+	return r.inTx(ctx, func(tx dbtx) error {
+		query := `
+			UPDATE event_outbox
+			SET attempts = attempts + 1,
+				last_error = $2,
+				available_at = CURRENT_TIMESTAMP + make_interval(secs => $3)
+			WHERE outbox_id = $1
+		`
+		if _, err := tx.ExecContext(ctx, query, id, deliveryErr.Error(), retryAfter.Seconds()); err != nil {
+			return HandleDBError(err)
+		}
+
+		for _, sink := range deliveredSinks {
+			_, err := tx.ExecContext(ctx, `INSERT INTO event_outbox_sinks (outbox_id, sink) VALUES ($1, $2) ON CONFLICT DO NOTHING`, id, sink)
+			if err != nil {
+				return HandleDBError(err)
+			}
+		}
+		return nil
+	})
+}
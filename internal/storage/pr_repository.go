@@ -1,10 +1,14 @@
 package storage
 
 import (
+	"context"
 	"database/sql"
+	"fmt"
+	"strings"
 	"time"
 
 	"pr-review-assigner/internal/api"
+	"pr-review-assigner/internal/events"
 
 	"github.com/lib/pq"
 )
@@ -19,163 +23,209 @@ func NewPRRepository(repo *Repository) *PRRepository {
 	return &PRRepository{Repository: repo}
 }
 
+// prColumns список колонок pull_requests в порядке, ожидаемом scanPR
+const prColumns = `pull_request_id, pull_request_name, author_id, status, created_at, merged_at, closed_at, reviewers_count`
+
+// rowScanner общий интерфейс для *sql.Row и *sql.Rows
+type rowScanner interface {
+	Scan(dest ...any) error
+}
+
+// scanPR считывает PR из строки результата (без назначенных ревьюверов)
+func scanPR(row rowScanner) (*api.PullRequest, error) {
+	var pr api.PullRequest
+	var createdAt, mergedAt, closedAt sql.NullTime
+	var reviewersCount sql.NullInt64
+
+	err := row.Scan(
+		&pr.PullRequestId,
+		&pr.PullRequestName,
+		&pr.AuthorId,
+		&pr.Status,
+		&createdAt,
+		&mergedAt,
+		&closedAt,
+		&reviewersCount,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	if createdAt.Valid {
+		pr.CreatedAt = &createdAt.Time
+	}
+	if mergedAt.Valid {
+		pr.MergedAt = &mergedAt.Time
+	}
+	if closedAt.Valid {
+		pr.ClosedAt = &closedAt.Time
+	}
+	if reviewersCount.Valid {
+		count := int(reviewersCount.Int64)
+		pr.ReviewersCount = &count
+	}
+
+	return &pr, nil
+}
+
 // CreatePR создает новый Pull Request и возвращает созданный PR
-func (r *PRRepository) CreatePR(pr *api.PullRequest) (*api.PullRequest, error) {
+// PR, ревьюверы и события их назначения сохраняются в одной транзакции
+func (r *PRRepository) CreatePR(ctx context.Context, pr *api.PullRequest) (*api.PullRequest, error) {
 	query := `
-		INSERT INTO pull_requests (pull_request_id, pull_request_name, author_id, status, created_at)
-		VALUES ($1, $2, $3, $4, $5)
-		RETURNING pull_request_id, pull_request_name, author_id, status, created_at, merged_at
-	`
+		INSERT INTO pull_requests (pull_request_id, pull_request_name, author_id, status, created_at, reviewers_count)
+		VALUES ($1, $2, $3, $4, $5, $6)
+		RETURNING ` + prColumns
 	createdAt := time.Now()
 	if pr.CreatedAt != nil {
 		createdAt = *pr.CreatedAt
 	}
 
-	var createdPR api.PullRequest
-	var createdAtTime, mergedAtTime sql.NullTime
+	var createdPR *api.PullRequest
+	err := r.inTx(ctx, func(tx dbtx) error {
+		var err error
+		createdPR, err = scanPR(tx.QueryRowContext(ctx, query, pr.PullRequestId, pr.PullRequestName, pr.AuthorId, string(pr.Status), createdAt, pr.ReviewersCount))
+		if err != nil {
+			return HandleDBError(err)
+		}
 
-	err := r.db.QueryRow(query, pr.PullRequestId, pr.PullRequestName, pr.AuthorId, string(pr.Status), createdAt).Scan(
-		&createdPR.PullRequestId,
-		&createdPR.PullRequestName,
-		&createdPR.AuthorId,
-		&createdPR.Status,
-		&createdAtTime,
-		&mergedAtTime,
-	)
+		// Назначаем ревьюверов, если они указаны
+		return assignReviewers(ctx, tx, pr.PullRequestId, pr.AssignedReviewers)
+	})
 	if err != nil {
-		return nil, HandleDBError(err)
-	}
-
-	if createdAtTime.Valid {
-		createdPR.CreatedAt = &createdAtTime.Time
-	}
-	if mergedAtTime.Valid {
-		createdPR.MergedAt = &mergedAtTime.Time
+		return nil, err
 	}
 
-	// Назначаем ревьюверов, если они указаны
 	if len(pr.AssignedReviewers) > 0 {
-		err = r.assignReviewers(pr.PullRequestId, pr.AssignedReviewers)
-		if err != nil {
-			return nil, HandleDBError(err)
-		}
 		createdPR.AssignedReviewers = pr.AssignedReviewers
 	} else {
 		createdPR.AssignedReviewers = []string{}
 	}
 
-	return &createdPR, nil
+	return createdPR, nil
 }
 
 // GetPR получает Pull Request по ID со всеми назначенными ревьюверами
-func (r *PRRepository) GetPR(prID string) (*api.PullRequest, error) {
-	query := `
-		SELECT pull_request_id, pull_request_name, author_id, status, created_at, merged_at
-		FROM pull_requests
-		WHERE pull_request_id = $1
-	`
-	var pr api.PullRequest
-	var createdAt, mergedAt sql.NullTime
+func (r *PRRepository) GetPR(ctx context.Context, prID string) (*api.PullRequest, error) {
+	query := `SELECT ` + prColumns + ` FROM pull_requests WHERE pull_request_id = $1`
 
-	err := r.db.QueryRow(query, prID).Scan(
-		&pr.PullRequestId,
-		&pr.PullRequestName,
-		&pr.AuthorId,
-		&pr.Status,
-		&createdAt,
-		&mergedAt,
-	)
+	pr, err := scanPR(r.db.QueryRowContext(ctx, query, prID))
 	if err != nil {
 		return nil, HandleDBError(err)
 	}
 
-	if createdAt.Valid {
-		pr.CreatedAt = &createdAt.Time
-	}
-	if mergedAt.Valid {
-		pr.MergedAt = &mergedAt.Time
+	// Получаем назначенных ревьюверов и их последние вердикты
+	reviewers, err := r.getReviewersByPR(ctx, prID)
+	if err != nil {
+		return nil, HandleDBError(err)
 	}
+	pr.AssignedReviewers = reviewers
 
-	// Получаем назначенных ревьюверов
-	reviewers, err := r.getReviewersByPR(prID)
+	reviews, err := r.getLatestReviewsByPR(ctx, prID)
 	if err != nil {
 		return nil, HandleDBError(err)
 	}
-	pr.AssignedReviewers = reviewers
+	pr.Reviews = reviews
 
-	return &pr, nil
+	return pr, nil
 }
 
 // UpdatePRStatus обновляет статус PR и возвращает обновленный PR
-func (r *PRRepository) UpdatePRStatus(prID string, status api.PullRequestStatus, mergedAt *time.Time) (*api.PullRequest, error) {
-	var query string
-	var pr api.PullRequest
-	var createdAtTime, mergedAtTime sql.NullTime
-	var err error
-
-	if status == api.PullRequestStatusMERGED && mergedAt != nil {
-		query = `
-			UPDATE pull_requests
-			SET status = $1, merged_at = $2
-			WHERE pull_request_id = $3
-			RETURNING pull_request_id, pull_request_name, author_id, status, created_at, merged_at
-		`
-		err = r.db.QueryRow(query, string(status), mergedAt, prID).Scan(
-			&pr.PullRequestId,
-			&pr.PullRequestName,
-			&pr.AuthorId,
-			&pr.Status,
-			&createdAtTime,
-			&mergedAtTime,
-		)
-	} else {
-		query = `
-			UPDATE pull_requests
-			SET status = $1
-			WHERE pull_request_id = $2
-			RETURNING pull_request_id, pull_request_name, author_id, status, created_at, merged_at
-		`
-		err = r.db.QueryRow(query, string(status), prID).Scan(
-			&pr.PullRequestId,
-			&pr.PullRequestName,
-			&pr.AuthorId,
-			&pr.Status,
-			&createdAtTime,
-			&mergedAtTime,
-		)
-	}
+// changedAt сохраняется как merged_at для MERGED и как closed_at для CLOSED;
+// при переходе в OPEN или DRAFT closed_at сбрасывается
+func (r *PRRepository) UpdatePRStatus(ctx context.Context, prID string, status api.PullRequestStatus, changedAt *time.Time) (*api.PullRequest, error) {
+	var pr *api.PullRequest
+	err := r.inTx(ctx, func(tx dbtx) error {
+		var row *sql.Row
+
+		switch {
+		case status == api.PullRequestStatusMERGED && changedAt != nil:
+			query := `
+				UPDATE pull_requests
+				SET status = $1, merged_at = $2
+				WHERE pull_request_id = $3
+				RETURNING ` + prColumns
+			row = tx.QueryRowContext(ctx, query, string(status), changedAt, prID)
+		case status == api.PullRequestStatusCLOSED && changedAt != nil:
+			query := `
+				UPDATE pull_requests
+				SET status = $1, closed_at = $2
+				WHERE pull_request_id = $3
+				RETURNING ` + prColumns
+			row = tx.QueryRowContext(ctx, query, string(status), changedAt, prID)
+		case status == api.PullRequestStatusOPEN || status == api.PullRequestStatusDRAFT:
+			query := `
+				UPDATE pull_requests
+				SET status = $1, closed_at = NULL
+				WHERE pull_request_id = $2
+				RETURNING ` + prColumns
+			row = tx.QueryRowContext(ctx, query, string(status), prID)
+		default:
+			query := `
+				UPDATE pull_requests
+				SET status = $1
+				WHERE pull_request_id = $2
+				RETURNING ` + prColumns
+			row = tx.QueryRowContext(ctx, query, string(status), prID)
+		}
 
+		var err error
+		pr, err = scanPR(row)
+		if err != nil {
+			return HandleDBError(err)
+		}
+
+		if status == api.PullRequestStatusMERGED {
+			return insertOutboxEvents(ctx, tx, events.NewPRMerged(prID))
+		}
+		return nil
+	})
 	if err != nil {
-		return nil, HandleDBError(err)
+		return nil, err
 	}
 
-	if createdAtTime.Valid {
-		pr.CreatedAt = &createdAtTime.Time
-	}
-	if mergedAtTime.Valid {
-		pr.MergedAt = &mergedAtTime.Time
+	// Получаем назначенных ревьюверов и их последние вердикты
+	reviewers, err := r.getReviewersByPR(ctx, prID)
+	if err != nil {
+		return nil, HandleDBError(err)
 	}
+	pr.AssignedReviewers = reviewers
 
-	// Получаем назначенных ревьюверов
-	reviewers, err := r.getReviewersByPR(prID)
+	reviews, err := r.getLatestReviewsByPR(ctx, prID)
 	if err != nil {
 		return nil, HandleDBError(err)
 	}
-	pr.AssignedReviewers = reviewers
+	pr.Reviews = reviews
 
-	return &pr, nil
+	return pr, nil
 }
 
-// GetPRsByReviewer получает список PR, где пользователь назначен ревьювером
-func (r *PRRepository) GetPRsByReviewer(userID string) ([]api.PullRequestShort, error) {
+// GetPRsByReviewer получает PR, где пользователь назначен ревьювером, последние назначения первыми
+// Страница задается keyset-курсором по (assigned_at, pull_request_id)
+func (r *PRRepository) GetPRsByReviewer(ctx context.Context, userID string, filter *ReviewerPRsFilter) ([]api.PullRequestShort, error) {
+	args := []any{userID}
+	arg := func(value any) string {
+		args = append(args, value)
+		return fmt.Sprintf("$%d", len(args))
+	}
+	conditions := []string{"prr.user_id = $1"}
+	if filter.Status != "" {
+		conditions = append(conditions, "pr.status = "+arg(string(filter.Status)))
+	}
+	if filter.After != nil {
+		conditions = append(conditions, fmt.Sprintf("(prr.assigned_at, pr.pull_request_id) < (%s, %s)", arg(filter.After.AssignedAt), arg(filter.After.PullRequestID)))
+	}
+
 	query := `
-		SELECT pr.pull_request_id, pr.pull_request_name, pr.author_id, pr.status
+		SELECT pr.pull_request_id, pr.pull_request_name, pr.author_id, pr.status, prr.assigned_at
 		FROM pull_requests pr
 		INNER JOIN pr_reviewers prr ON pr.pull_request_id = prr.pull_request_id
-		WHERE prr.user_id = $1
-		ORDER BY pr.created_at DESC
-	`
-	rows, err := r.db.Query(query, userID)
+		WHERE ` + strings.Join(conditions, " AND ") + `
+		ORDER BY prr.assigned_at DESC, pr.pull_request_id DESC`
+	if filter.Limit > 0 {
+		query += " LIMIT " + arg(filter.Limit)
+	}
+
+	rows, err := r.db.QueryContext(ctx, query, args...)
 	if err != nil {
 		return nil, HandleDBError(err)
 	}
@@ -189,6 +239,7 @@ func (r *PRRepository) GetPRsByReviewer(userID string) ([]api.PullRequestShort,
 			&pr.PullRequestName,
 			&pr.AuthorId,
 			&pr.Status,
+			&pr.AssignedAt,
 		)
 		if err != nil {
 			return nil, HandleDBError(err)
@@ -203,12 +254,9 @@ func (r *PRRepository) GetPRsByReviewer(userID string) ([]api.PullRequestShort,
 	return prs, nil
 }
 
-// assignReviewers назначает ревьюверов на PR
-func (r *PRRepository) assignReviewers(prID string, reviewerIDs []string) error {
-	if len(reviewerIDs) == 0 {
-		return nil
-	}
-
+// assignReviewers назначает ревьюверов на PR и записывает события назначения в outbox
+// Для уже назначенных ревьюверов событие не создается
+func assignReviewers(ctx context.Context, exec dbtx, prID string, reviewerIDs []string) error {
 	query := `
 		INSERT INTO pr_reviewers (pull_request_id, user_id)
 		VALUES ($1, $2)
@@ -216,10 +264,20 @@ func (r *PRRepository) assignReviewers(prID string, reviewerIDs []string) error
 	`
 
 	for _, reviewerID := range reviewerIDs {
-		_, err := r.db.Exec(query, prID, reviewerID)
+		result, err := exec.ExecContext(ctx, query, prID, reviewerID)
+		if err != nil {
+			return HandleDBError(err)
+		}
+		inserted, err := result.RowsAffected()
 		if err != nil {
 			return HandleDBError(err)
 		}
+		if inserted == 0 {
+			continue
+		}
+		if err = insertOutboxEvents(ctx, exec, events.NewReviewerAssigned(prID, reviewerID)); err != nil {
+			return err
+		}
 	}
 
 	return nil
@@ -227,11 +285,11 @@ func (r *PRRepository) assignReviewers(prID string, reviewerIDs []string) error
 
 // ReassignReviewer переназначает одного ревьювера на другого и возвращает обновленный PR
 // Если newUserID пустой, то просто удаляет старого ревьювера без назначения нового
-func (r *PRRepository) ReassignReviewer(prID string, oldUserID, newUserID string) (*api.PullRequest, error) {
+func (r *PRRepository) ReassignReviewer(ctx context.Context, prID string, oldUserID, newUserID string) (*api.PullRequest, error) {
 	// Проверяем, что старый ревьювер назначен на этот PR
 	var exists bool
 	checkQuery := `SELECT EXISTS(SELECT 1 FROM pr_reviewers WHERE pull_request_id = $1 AND user_id = $2)`
-	err := r.db.QueryRow(checkQuery, prID, oldUserID).Scan(&exists)
+	err := r.db.QueryRowContext(ctx, checkQuery, prID, oldUserID).Scan(&exists)
 	if err != nil {
 		return nil, HandleDBError(err)
 	}
@@ -240,91 +298,169 @@ func (r *PRRepository) ReassignReviewer(prID string, oldUserID, newUserID string
 	}
 
 	// Удаляем старого ревьювера и добавляем нового в одной транзакции
-	tx, err := r.db.Begin()
+	err = r.inTx(ctx, func(tx dbtx) error {
+		// Удаляем старого ревьювера
+		deleteQuery := `DELETE FROM pr_reviewers WHERE pull_request_id = $1 AND user_id = $2`
+		if _, err := tx.ExecContext(ctx, deleteQuery, prID, oldUserID); err != nil {
+			return HandleDBError(err)
+		}
+
+		// Добавляем нового ревьювера, если он указан
+		if newUserID != "" {
+			insertQuery := `INSERT INTO pr_reviewers (pull_request_id, user_id) VALUES ($1, $2)`
+			if _, err := tx.ExecContext(ctx, insertQuery, prID, newUserID); err != nil {
+				return HandleDBError(err)
+			}
+		}
+
+		return insertOutboxEvents(ctx, tx, events.NewReviewerChanged(prID, oldUserID, newUserID))
+	})
 	if err != nil {
-		return nil, HandleDBError(err)
+		return nil, err
 	}
-	defer tx.Rollback()
 
-	// Удаляем старого ревьювера
-	deleteQuery := `DELETE FROM pr_reviewers WHERE pull_request_id = $1 AND user_id = $2`
-	_, err = tx.Exec(deleteQuery, prID, oldUserID)
+	// Возвращаем обновленный PR
+	return r.GetPR(ctx, prID)
+}
+
+// AddReviewer добавляет ревьювера к PR
+func (r *PRRepository) AddReviewer(ctx context.Context, prID string, userID string) error {
+	return r.inTx(ctx, func(tx dbtx) error {
+		return assignReviewers(ctx, tx, prID, []string{userID})
+	})
+}
+
+// getReviewersByPR получает список ревьюверов для PR
+func (r *PRRepository) getReviewersByPR(ctx context.Context, prID string) ([]string, error) {
+	query := `
+		SELECT user_id
+		FROM pr_reviewers
+		WHERE pull_request_id = $1
+		ORDER BY assigned_at
+	`
+	rows, err := r.db.QueryContext(ctx, query, prID)
 	if err != nil {
 		return nil, HandleDBError(err)
 	}
+	defer rows.Close()
 
-	// Добавляем нового ревьювера, если он указан
-	if newUserID != "" {
-		insertQuery := `INSERT INTO pr_reviewers (pull_request_id, user_id) VALUES ($1, $2)`
-		_, err = tx.Exec(insertQuery, prID, newUserID)
+	var reviewers []string
+	for rows.Next() {
+		var reviewerID string
+		err := rows.Scan(&reviewerID)
 		if err != nil {
 			return nil, HandleDBError(err)
 		}
+		reviewers = append(reviewers, reviewerID)
 	}
 
-	if err = tx.Commit(); err != nil {
+	if err = rows.Err(); err != nil {
 		return nil, HandleDBError(err)
 	}
 
-	// Возвращаем обновленный PR
-	return r.GetPR(prID)
+	return reviewers, nil
 }
 
-// AddReviewer добавляет ревьювера к PR
-func (r *PRRepository) AddReviewer(prID string, userID string) error {
+// AddReview сохраняет вердикт ревьювера по PR
+func (r *PRRepository) AddReview(ctx context.Context, prID string, userID string, verdict api.ReviewVerdict) error {
 	query := `
-		INSERT INTO pr_reviewers (pull_request_id, user_id)
-		VALUES ($1, $2)
-		ON CONFLICT (pull_request_id, user_id) DO NOTHING
+		INSERT INTO pr_reviews (pull_request_id, user_id, verdict)
+		VALUES ($1, $2, $3)
 	`
-	_, err := r.db.Exec(query, prID, userID)
+	_, err := r.db.ExecContext(ctx, query, prID, userID, string(verdict))
 	if err != nil {
 		return HandleDBError(err)
 	}
 	return nil
 }
 
-// getReviewersByPR получает список ревьюверов для PR
-func (r *PRRepository) getReviewersByPR(prID string) ([]string, error) {
+// getLatestReviewsByPR получает последний вердикт каждого назначенного ревьювера PR
+func (r *PRRepository) getLatestReviewsByPR(ctx context.Context, prID string) ([]api.PullRequestReview, error) {
 	query := `
-		SELECT user_id
-		FROM pr_reviewers
-		WHERE pull_request_id = $1
-		ORDER BY assigned_at
+		SELECT DISTINCT ON (rv.user_id) rv.user_id, rv.verdict, rv.submitted_at
+		FROM pr_reviews rv
+		INNER JOIN pr_reviewers prr ON prr.pull_request_id = rv.pull_request_id AND prr.user_id = rv.user_id
+		WHERE rv.pull_request_id = $1
+		ORDER BY rv.user_id, rv.submitted_at DESC, rv.review_id DESC
 	`
-	rows, err := r.db.Query(query, prID)
+	rows, err := r.db.QueryContext(ctx, query, prID)
 	if err != nil {
 		return nil, HandleDBError(err)
 	}
 	defer rows.Close()
 
-	var reviewers []string
+	var reviews []api.PullRequestReview
 	for rows.Next() {
-		var reviewerID string
-		err := rows.Scan(&reviewerID)
+		var review api.PullRequestReview
+		err := rows.Scan(&review.UserId, &review.Verdict, &review.SubmittedAt)
 		if err != nil {
 			return nil, HandleDBError(err)
 		}
-		reviewers = append(reviewers, reviewerID)
+		reviews = append(reviews, review)
 	}
 
 	if err = rows.Err(); err != nil {
 		return nil, HandleDBError(err)
 	}
 
-	return reviewers, nil
+	return reviews, nil
 }
 
-// GetReviewerStatistics получает статистику по назначениям ревьюверов
-func (r *PRRepository) GetReviewerStatistics() ([]ReviewerStatistic, error) {
+// GetReviewerStatistics получает статистику ревьюверов: назначения за период и их исход,
+// авторство PR и среднее время от назначения до слияния
+func (r *PRRepository) GetReviewerStatistics(ctx context.Context, filter *StatisticsFilter) ([]ReviewerStatistic, error) {
+	var args []any
+	arg := func(value any) string {
+		args = append(args, value)
+		return fmt.Sprintf("$%d", len(args))
+	}
+	// conditions условия по периоду и статусу PR; column - время, ограничиваемое периодом
+	conditions := func(column string) string {
+		where := []string{"TRUE"}
+		if filter.From != nil {
+			where = append(where, column+" >= "+arg(*filter.From))
+		}
+		if filter.To != nil {
+			where = append(where, column+" < "+arg(*filter.To))
+		}
+		if filter.Status != "" {
+			where = append(where, "pr.status = "+arg(string(filter.Status)))
+		}
+		return strings.Join(where, " AND ")
+	}
+
 	query := `
-		SELECT u.user_id, u.username, COUNT(pr.pull_request_id) as assignments_count
+		WITH assignments AS (
+			SELECT prr.user_id,
+				COUNT(*) AS assignments_count,
+				COUNT(*) FILTER (WHERE pr.status = 'OPEN') AS open_count,
+				COUNT(*) FILTER (WHERE pr.status IN ('MERGED', 'CLOSED')) AS completed_count,
+				AVG(EXTRACT(EPOCH FROM pr.merged_at - prr.assigned_at)) FILTER (WHERE pr.status = 'MERGED') AS avg_merge_seconds
+			FROM pr_reviewers prr
+			INNER JOIN pull_requests pr ON pr.pull_request_id = prr.pull_request_id
+			WHERE ` + conditions("prr.assigned_at") + `
+			GROUP BY prr.user_id
+		), authored AS (
+			SELECT pr.author_id AS user_id, COUNT(*) AS authored_count
+			FROM pull_requests pr
+			WHERE ` + conditions("pr.created_at") + `
+			GROUP BY pr.author_id
+		)
+		SELECT u.user_id, u.username, u.team_name,
+			COALESCE(a.assignments_count, 0) AS assignments_count,
+			COALESCE(a.open_count, 0), COALESCE(a.completed_count, 0),
+			COALESCE(au.authored_count, 0), a.avg_merge_seconds
 		FROM users u
-		LEFT JOIN pr_reviewers pr ON u.user_id = pr.user_id
-		GROUP BY u.user_id, u.username
-		ORDER BY assignments_count DESC, u.username
-	`
-	rows, err := r.db.Query(query)
+		LEFT JOIN assignments a ON a.user_id = u.user_id
+		LEFT JOIN authored au ON au.user_id = u.user_id`
+	if filter.TeamName != "" {
+		query += `
+		WHERE u.team_name = ` + arg(filter.TeamName)
+	}
+	query += `
+		ORDER BY assignments_count DESC, u.username`
+
+	rows, err := r.db.QueryContext(ctx, query, args...)
 	if err != nil {
 		return nil, HandleDBError(err)
 	}
@@ -333,10 +469,24 @@ func (r *PRRepository) GetReviewerStatistics() ([]ReviewerStatistic, error) {
 	var statistics []ReviewerStatistic
 	for rows.Next() {
 		var stat ReviewerStatistic
-		err := rows.Scan(&stat.UserID, &stat.Username, &stat.AssignmentsCount)
+		var avgMergeSeconds sql.NullFloat64
+		err := rows.Scan(
+			&stat.UserID,
+			&stat.Username,
+			&stat.TeamName,
+			&stat.AssignmentsCount,
+			&stat.OpenAssignmentsCount,
+			&stat.CompletedAssignmentsCount,
+			&stat.AuthoredPRsCount,
+			&avgMergeSeconds,
+		)
 		if err != nil {
 			return nil, HandleDBError(err)
 		}
+		if avgMergeSeconds.Valid {
+			avg := time.Duration(avgMergeSeconds.Float64 * float64(time.Second))
+			stat.AvgTimeToMerge = &avg
+		}
 		statistics = append(statistics, stat)
 	}
 
@@ -348,19 +498,19 @@ func (r *PRRepository) GetReviewerStatistics() ([]ReviewerStatistic, error) {
 }
 
 // GetOpenPRsByReviewers получает все открытые PR, где указанные пользователи являются ревьюверами
-func (r *PRRepository) GetOpenPRsByReviewers(userIDs []string) ([]api.PullRequest, error) {
+func (r *PRRepository) GetOpenPRsByReviewers(ctx context.Context, userIDs []string) ([]api.PullRequest, error) {
 	if len(userIDs) == 0 {
 		return []api.PullRequest{}, nil
 	}
 
 	query := `
-		SELECT DISTINCT pr.pull_request_id, pr.pull_request_name, pr.author_id, pr.status, pr.created_at, pr.merged_at
-		FROM pull_requests pr
-		INNER JOIN pr_reviewers prr ON pr.pull_request_id = prr.pull_request_id
-		WHERE prr.user_id = ANY($1) AND pr.status = 'OPEN'
-		ORDER BY pr.created_at
+		SELECT ` + prColumns + `
+		FROM pull_requests
+		WHERE pull_request_id IN (SELECT pull_request_id FROM pr_reviewers WHERE user_id = ANY($1))
+			AND status = 'OPEN'
+		ORDER BY created_at
 	`
-	rows, err := r.db.Query(query, pq.Array(userIDs))
+	rows, err := r.db.QueryContext(ctx, query, pq.Array(userIDs))
 	if err != nil {
 		return nil, HandleDBError(err)
 	}
@@ -368,36 +518,19 @@ func (r *PRRepository) GetOpenPRsByReviewers(userIDs []string) ([]api.PullReques
 
 	var prs []api.PullRequest
 	for rows.Next() {
-		var pr api.PullRequest
-		var createdAt, mergedAt sql.NullTime
-
-		err := rows.Scan(
-			&pr.PullRequestId,
-			&pr.PullRequestName,
-			&pr.AuthorId,
-			&pr.Status,
-			&createdAt,
-			&mergedAt,
-		)
+		pr, err := scanPR(rows)
 		if err != nil {
 			return nil, HandleDBError(err)
 		}
 
-		if createdAt.Valid {
-			pr.CreatedAt = &createdAt.Time
-		}
-		if mergedAt.Valid {
-			pr.MergedAt = &mergedAt.Time
-		}
-
 		// Получаем ревьюверов для каждого PR
-		reviewers, err := r.getReviewersByPR(pr.PullRequestId)
+		reviewers, err := r.getReviewersByPR(ctx, pr.PullRequestId)
 		if err != nil {
 			return nil, HandleDBError(err)
 		}
 		pr.AssignedReviewers = reviewers
 
-		prs = append(prs, pr)
+		prs = append(prs, *pr)
 	}
 
 	if err = rows.Err(); err != nil {
@@ -407,44 +540,461 @@ func (r *PRRepository) GetOpenPRsByReviewers(userIDs []string) ([]api.PullReques
 	return prs, nil
 }
 
+// GetOpenReviewCounts возвращает количество открытых PR на ревью для каждого из указанных пользователей
+// Пользователи без открытых PR в результат не попадают
+func (r *PRRepository) GetOpenReviewCounts(ctx context.Context, userIDs []string) (map[string]int, error) {
+	counts := make(map[string]int, len(userIDs))
+	if len(userIDs) == 0 {
+		return counts, nil
+	}
+
+	query := `
+		SELECT prr.user_id, COUNT(*)
+		FROM pr_reviewers prr
+		INNER JOIN pull_requests pr ON pr.pull_request_id = prr.pull_request_id
+		WHERE prr.user_id = ANY($1) AND pr.status = 'OPEN'
+		GROUP BY prr.user_id
+	`
+	rows, err := r.db.QueryContext(ctx, query, pq.Array(userIDs))
+	if err != nil {
+		return nil, HandleDBError(err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var userID string
+		var count int
+		if err := rows.Scan(&userID, &count); err != nil {
+			return nil, HandleDBError(err)
+		}
+		counts[userID] = count
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, HandleDBError(err)
+	}
+
+	return counts, nil
+}
+
 // BatchReassignReviewers массово переназначает ревьюверов в одной транзакции
 // reassignments - карта: prID -> {oldUserID -> newUserID}
 // Если newUserID пустой, ревьювер просто удаляется
-func (r *PRRepository) BatchReassignReviewers(reassignments map[string]map[string]string) error {
+func (r *PRRepository) BatchReassignReviewers(ctx context.Context, reassignments map[string]map[string]string) error {
 	if len(reassignments) == 0 {
 		return nil
 	}
 
-	tx, err := r.db.Begin()
-	if err != nil {
-		return HandleDBError(err)
-	}
-	defer tx.Rollback()
-
 	deleteQuery := `DELETE FROM pr_reviewers WHERE pull_request_id = $1 AND user_id = $2`
 	insertQuery := `INSERT INTO pr_reviewers (pull_request_id, user_id) VALUES ($1, $2) ON CONFLICT DO NOTHING`
 
-	for prID, changes := range reassignments {
-		for oldUserID, newUserID := range changes {
-			// Удаляем старого ревьювера
-			_, err = tx.Exec(deleteQuery, prID, oldUserID)
-			if err != nil {
-				return HandleDBError(err)
-			}
-
-			// Добавляем нового ревьювера, если он указан
-			if newUserID != "" {
-				_, err = tx.Exec(insertQuery, prID, newUserID)
+	return r.inTx(ctx, func(tx dbtx) error {
+		for prID, changes := range reassignments {
+			for oldUserID, newUserID := range changes {
+				// Удаляем старого ревьювера
+				result, err := tx.ExecContext(ctx, deleteQuery, prID, oldUserID)
+				if err != nil {
+					return HandleDBError(err)
+				}
+				deleted, err := result.RowsAffected()
 				if err != nil {
 					return HandleDBError(err)
 				}
+
+				// Добавляем нового ревьювера, если он указан и еще не назначен
+				var inserted int64
+				if newUserID != "" {
+					result, err := tx.ExecContext(ctx, insertQuery, prID, newUserID)
+					if err != nil {
+						return HandleDBError(err)
+					}
+					if inserted, err = result.RowsAffected(); err != nil {
+						return HandleDBError(err)
+					}
+				}
+
+				// События пишутся только о фактических изменениях
+				if err := insertOutboxEvents(ctx, tx, events.NewReviewerChanges(prID, oldUserID, newUserID, deleted > 0, inserted > 0)...); err != nil {
+					return err
+				}
 			}
 		}
+		return nil
+	})
+}
+
+// ListPRs получает страницу PR по фильтрам с keyset-пагинацией по (поле сортировки, pull_request_id)
+func (r *PRRepository) ListPRs(ctx context.Context, filter *PRListFilter) ([]api.PullRequest, error) {
+	var conditions []string
+	var args []any
+	arg := func(value any) string {
+		args = append(args, value)
+		return fmt.Sprintf("$%d", len(args))
+	}
+
+	if filter.Status != "" {
+		conditions = append(conditions, "status = "+arg(string(filter.Status)))
+	}
+	if filter.AuthorID != "" {
+		conditions = append(conditions, "author_id = "+arg(filter.AuthorID))
+	}
+	if filter.ReviewerID != "" {
+		conditions = append(conditions, "pull_request_id IN (SELECT pull_request_id FROM pr_reviewers WHERE user_id = "+arg(filter.ReviewerID)+")")
+	}
+	if filter.TeamName != "" {
+		conditions = append(conditions, "author_id IN (SELECT user_id FROM users WHERE team_name = "+arg(filter.TeamName)+")")
+	}
+	if filter.CreatedFrom != nil {
+		conditions = append(conditions, "created_at >= "+arg(*filter.CreatedFrom))
+	}
+	if filter.CreatedTo != nil {
+		conditions = append(conditions, "created_at < "+arg(*filter.CreatedTo))
+	}
+	if filter.MergedFrom != nil {
+		conditions = append(conditions, "merged_at >= "+arg(*filter.MergedFrom))
+	}
+	if filter.MergedTo != nil {
+		conditions = append(conditions, "merged_at < "+arg(*filter.MergedTo))
+	}
+	if filter.NameContains != "" {
+		conditions = append(conditions, "pull_request_name ILIKE "+arg("%"+escapeLike(filter.NameContains)+"%")+` ESCAPE '\'`)
+	}
+
+	sortColumn, direction, compare := "created_at", "ASC", ">"
+	if filter.SortBy == PRSortName {
+		sortColumn = "pull_request_name"
+	}
+	if filter.Descending {
+		direction, compare = "DESC", "<"
+	}
+	if filter.After != nil {
+		var after any = filter.After.CreatedAt
+		if filter.SortBy == PRSortName {
+			after = filter.After.Name
+		}
+		conditions = append(conditions, fmt.Sprintf("(%s, pull_request_id) %s (%s, %s)", sortColumn, compare, arg(after), arg(filter.After.PullRequestID)))
+	}
+
+	query := `SELECT ` + prColumns + ` FROM pull_requests`
+	if len(conditions) > 0 {
+		query += ` WHERE ` + strings.Join(conditions, " AND ")
+	}
+	query += fmt.Sprintf(" ORDER BY %s %s, pull_request_id %s LIMIT %s", sortColumn, direction, direction, arg(filter.Limit))
+
+	rows, err := r.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, HandleDBError(err)
+	}
+	defer rows.Close()
+
+	prs := []api.PullRequest{}
+	prIDs := []string{}
+	for rows.Next() {
+		pr, err := scanPR(rows)
+		if err != nil {
+			return nil, HandleDBError(err)
+		}
+		prs = append(prs, *pr)
+		prIDs = append(prIDs, pr.PullRequestId)
+	}
+	if err = rows.Err(); err != nil {
+		return nil, HandleDBError(err)
+	}
+	rows.Close()
+
+	// Ревьюверы всей страницы одним запросом
+	reviewers, err := r.getReviewersByPRs(ctx, prIDs)
+	if err != nil {
+		return nil, err
+	}
+	for i := range prs {
+		prs[i].AssignedReviewers = reviewers[prs[i].PullRequestId]
+		if prs[i].AssignedReviewers == nil {
+			prs[i].AssignedReviewers = []string{}
+		}
+	}
+
+	return prs, nil
+}
+
+// getReviewersByPRs получает ревьюверов нескольких PR: prID -> user_id в порядке назначения
+func (r *PRRepository) getReviewersByPRs(ctx context.Context, prIDs []string) (map[string][]string, error) {
+	reviewers := make(map[string][]string, len(prIDs))
+	if len(prIDs) == 0 {
+		return reviewers, nil
+	}
+
+	query := `
+		SELECT pull_request_id, user_id
+		FROM pr_reviewers
+		WHERE pull_request_id = ANY($1)
+		ORDER BY assigned_at
+	`
+	rows, err := r.db.QueryContext(ctx, query, pq.Array(prIDs))
+	if err != nil {
+		return nil, HandleDBError(err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var prID, userID string
+		if err := rows.Scan(&prID, &userID); err != nil {
+			return nil, HandleDBError(err)
+		}
+		reviewers[prID] = append(reviewers[prID], userID)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, HandleDBError(err)
 	}
 
-	if err = tx.Commit(); err != nil {
+	return reviewers, nil
+}
+
+// escapeLike экранирует спецсимволы LIKE, чтобы подстрока искалась буквально
+func escapeLike(value string) string {
+	return strings.NewReplacer(`\`, `\\`, `%`, `\%`, `_`, `\_`).Replace(value)
+}
+
+// ExportAssignments передает fn все назначения ревьюверов по одному в порядке assigned_at
+// Строки читаются из курсора по мере обработки и не накапливаются в памяти
+func (r *PRRepository) ExportAssignments(ctx context.Context, fn func(row *api.AssignmentExportRow) error) error {
+	query := `
+		SELECT pr.pull_request_id, pr.pull_request_name, pr.status, pr.author_id,
+			u.user_id, u.username, u.team_name, prr.assigned_at, pr.created_at, pr.merged_at
+		FROM pr_reviewers prr
+		INNER JOIN pull_requests pr ON pr.pull_request_id = prr.pull_request_id
+		INNER JOIN users u ON u.user_id = prr.user_id
+		ORDER BY prr.assigned_at, prr.pull_request_id, prr.user_id
+	`
+	rows, err := r.db.QueryContext(ctx, query)
+	if err != nil {
 		return HandleDBError(err)
 	}
+	defer rows.Close()
 
-	return nil
+	for rows.Next() {
+		var row api.AssignmentExportRow
+		err := rows.Scan(
+			&row.PullRequestId,
+			&row.PullRequestName,
+			&row.Status,
+			&row.AuthorId,
+			&row.ReviewerId,
+			&row.ReviewerUsername,
+			&row.ReviewerTeamName,
+			&row.AssignedAt,
+			&row.CreatedAt,
+			&row.MergedAt,
+		)
+		if err != nil {
+			return HandleDBError(err)
+		}
+		if err = fn(&row); err != nil {
+			return err
+		}
+	}
+
+	return HandleDBError(rows.Err())
+}
+
+// GetStaleReviews получает назначения на открытые PR без вердикта ревьювера после назначения,
+// срок которых (assigned_at + review_sla_seconds команды автора) наступил к filter.Now
+func (r *PRRepository) GetStaleReviews(ctx context.Context, filter *StaleReviewsFilter) ([]StaleReview, error) {
+	args := []any{filter.Now}
+	arg := func(value any) string {
+		args = append(args, value)
+		return fmt.Sprintf("$%d", len(args))
+	}
+	conditions := []string{
+		"pr.status = 'OPEN'",
+		"t.review_sla_seconds > 0",
+		"prr.assigned_at + make_interval(secs => t.review_sla_seconds) <= $1",
+		`NOT EXISTS (
+			SELECT 1 FROM pr_reviews rv
+			WHERE rv.pull_request_id = prr.pull_request_id AND rv.user_id = prr.user_id AND rv.submitted_at >= prr.assigned_at
+		)`,
+	}
+	if filter.TeamName != "" {
+		conditions = append(conditions, "t.team_name = "+arg(filter.TeamName))
+	}
+	if filter.ReviewerID != "" {
+		conditions = append(conditions, "prr.user_id = "+arg(filter.ReviewerID))
+	}
+	if filter.OnlyUnmarked {
+		conditions = append(conditions, "prr.sla_breached_at IS NULL")
+	}
+
+	query := `
+		SELECT pr.pull_request_id, pr.pull_request_name, pr.author_id, t.team_name, prr.user_id,
+			prr.assigned_at, t.review_sla_seconds, prr.sla_breached_at
+		FROM pr_reviewers prr
+		INNER JOIN pull_requests pr ON pr.pull_request_id = prr.pull_request_id
+		INNER JOIN users a ON a.user_id = pr.author_id
+		INNER JOIN teams t ON t.team_name = a.team_name
+		WHERE ` + strings.Join(conditions, " AND ") + `
+		ORDER BY prr.assigned_at, prr.pull_request_id, prr.user_id`
+
+	rows, err := r.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, HandleDBError(err)
+	}
+	defer rows.Close()
+
+	var stale []StaleReview
+	for rows.Next() {
+		var review StaleReview
+		var slaSeconds int64
+		err := rows.Scan(
+			&review.PullRequestID,
+			&review.PullRequestName,
+			&review.AuthorID,
+			&review.TeamName,
+			&review.ReviewerID,
+			&review.AssignedAt,
+			&slaSeconds,
+			&review.BreachedAt,
+		)
+		if err != nil {
+			return nil, HandleDBError(err)
+		}
+		review.SLA = time.Duration(slaSeconds) * time.Second
+		stale = append(stale, review)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, HandleDBError(err)
+	}
+
+	return stale, nil
+}
+
+// MarkSLABreached отмечает нарушение SLA назначения и записывает событие review.sla_breached в outbox
+// Условие sla_breached_at IS NULL не дает нескольким экземплярам сервиса записать событие дважды
+func (r *PRRepository) MarkSLABreached(ctx context.Context, review *StaleReview, at time.Time) (bool, error) {
+	var marked bool
+	err := r.inTx(ctx, func(tx dbtx) error {
+		query := `
+			UPDATE pr_reviewers
+			SET sla_breached_at = $1
+			WHERE pull_request_id = $2 AND user_id = $3 AND sla_breached_at IS NULL
+		`
+		result, err := tx.ExecContext(ctx, query, at, review.PullRequestID, review.ReviewerID)
+		if err != nil {
+			return HandleDBError(err)
+		}
+		affected, err := result.RowsAffected()
+		if err != nil {
+			return HandleDBError(err)
+		}
+		if affected == 0 {
+			return nil
+		}
+
+		marked = true
+		return insertOutboxEvents(ctx, tx, events.NewReviewSLABreached(review.PullRequestID, review.ReviewerID, review.AssignedAt, review.DueAt()))
+	})
+	if err != nil {
+		return false, err
+	}
+	return marked, nil
+}
+
+// GetReviewTimeouts получает назначения на открытые PR без вердикта ревьювера дольше
+// auto_reassign_after_seconds команды автора, если лимит автоматических переназначений PR не исчерпан
+func (r *PRRepository) GetReviewTimeouts(ctx context.Context, now time.Time) ([]ReviewTimeout, error) {
+	query := `
+		WITH auto_reassigned AS (
+			SELECT pull_request_id, COUNT(*) AS cnt
+			FROM pr_history
+			WHERE action = 'AUTO_REASSIGNED'
+			GROUP BY pull_request_id
+		)
+		SELECT prr.pull_request_id, t.team_name, prr.user_id, prr.assigned_at,
+			COALESCE(ar.cnt, 0), t.max_auto_reassignments
+		FROM pr_reviewers prr
+		INNER JOIN pull_requests pr ON pr.pull_request_id = prr.pull_request_id
+		INNER JOIN users a ON a.user_id = pr.author_id
+		INNER JOIN teams t ON t.team_name = a.team_name
+		LEFT JOIN auto_reassigned ar ON ar.pull_request_id = prr.pull_request_id
+		WHERE pr.status = 'OPEN'
+			AND t.auto_reassign_after_seconds > 0
+			AND prr.assigned_at + make_interval(secs => t.auto_reassign_after_seconds) <= $1
+			AND COALESCE(ar.cnt, 0) < t.max_auto_reassignments
+			AND NOT EXISTS (
+				SELECT 1 FROM pr_reviews rv
+				WHERE rv.pull_request_id = prr.pull_request_id AND rv.user_id = prr.user_id AND rv.submitted_at >= prr.assigned_at
+			)
+		ORDER BY prr.assigned_at, prr.pull_request_id, prr.user_id
+	`
+
+	rows, err := r.db.QueryContext(ctx, query, now)
+	if err != nil {
+		return nil, HandleDBError(err)
+	}
+	defer rows.Close()
+
+	var timeouts []ReviewTimeout
+	for rows.Next() {
+		var timeout ReviewTimeout
+		err := rows.Scan(
+			&timeout.PullRequestID,
+			&timeout.TeamName,
+			&timeout.ReviewerID,
+			&timeout.AssignedAt,
+			&timeout.AutoReassignments,
+			&timeout.MaxAutoReassignments,
+		)
+		if err != nil {
+			return nil, HandleDBError(err)
+		}
+		timeouts = append(timeouts, timeout)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, HandleDBError(err)
+	}
+
+	return timeouts, nil
+}
+
+// AddPRHistory записывает событие в историю PR
+func (r *PRRepository) AddPRHistory(ctx context.Context, prID string, entry *api.PullRequestHistoryEntry) error {
+	query := `
+		INSERT INTO pr_history (pull_request_id, action, old_reviewer_id, new_reviewer_id, created_at)
+		VALUES ($1, $2, $3, $4, $5)
+	`
+	_, err := r.db.ExecContext(ctx, query, prID, string(entry.Action), entry.OldReviewerId, entry.NewReviewerId, entry.CreatedAt)
+	return HandleDBError(err)
+}
+
+// GetPRHistory получает историю PR в порядке записи
+func (r *PRRepository) GetPRHistory(ctx context.Context, prID string) ([]api.PullRequestHistoryEntry, error) {
+	query := `
+		SELECT action, old_reviewer_id, new_reviewer_id, created_at
+		FROM pr_history
+		WHERE pull_request_id = $1
+		ORDER BY history_id
+	`
+
+	rows, err := r.db.QueryContext(ctx, query, prID)
+	if err != nil {
+		return nil, HandleDBError(err)
+	}
+	defer rows.Close()
+
+	history := []api.PullRequestHistoryEntry{}
+	for rows.Next() {
+		var entry api.PullRequestHistoryEntry
+		if err := rows.Scan(&entry.Action, &entry.OldReviewerId, &entry.NewReviewerId, &entry.CreatedAt); err != nil {
+			return nil, HandleDBError(err)
+		}
+		history = append(history, entry)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, HandleDBError(err)
+	}
+
+	return history, nil
 }
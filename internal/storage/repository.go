@@ -1,6 +1,7 @@
 package storage
 
 import (
+	"context"
 	"database/sql"
 	"errors"
 	"fmt"
@@ -12,11 +13,20 @@ var (
 	ErrNotFound            = errors.New("not found")
 	ErrDuplicateKey        = errors.New("duplicate key")
 	ErrForeignKeyViolation = errors.New("foreign key violation")
+	ErrCheckViolation      = errors.New("check constraint violation")
 )
 
+// dbtx общий интерфейс для *sql.DB и *sql.Tx
+type dbtx interface {
+	ExecContext(ctx context.Context, query string, args ...any) (sql.Result, error)
+	QueryContext(ctx context.Context, query string, args ...any) (*sql.Rows, error)
+	QueryRowContext(ctx context.Context, query string, args ...any) *sql.Row
+}
+
 // Repository представляет базовый репозиторий для работы с БД
+// Внутри TxManager.WithTx репозитории работают через транзакцию вместо подключения
 type Repository struct {
-	db *sql.DB
+	db dbtx
 }
 
 // NewRepository создает новый экземпляр репозитория
@@ -24,9 +34,29 @@ func NewRepository(db *sql.DB) *Repository {
 	return &Repository{db: db}
 }
 
-// DB возвращает подключение к БД
-func (r *Repository) DB() *sql.DB {
-	return r.db
+// inTx выполняет fn в транзакции
+// Если репозиторий уже работает в транзакции TxManager, fn выполняется в ней,
+// и фиксация откладывается до завершения WithTx
+func (r *Repository) inTx(ctx context.Context, fn func(tx dbtx) error) error {
+	db, ok := r.db.(*sql.DB)
+	if !ok {
+		return fn(r.db)
+	}
+
+	tx, err := db.BeginTx(ctx, nil)
+	if err != nil {
+		return HandleDBError(err)
+	}
+	defer tx.Rollback()
+
+	if err = fn(tx); err != nil {
+		return err
+	}
+
+	if err = tx.Commit(); err != nil {
+		return HandleDBError(err)
+	}
+	return nil
 }
 
 // HandleDBError обрабатывает ошибки БД и возвращает соответствующие ошибки приложения
@@ -47,6 +77,8 @@ func HandleDBError(err error) error {
 			return ErrDuplicateKey
 		case "23503": // foreign_key_violation
 			return ErrForeignKeyViolation
+		case "23514": // check_violation
+			return ErrCheckViolation
 		}
 	}
 
@@ -0,0 +1,103 @@
+package sqlite
+
+import (
+	"context"
+
+	"pr-review-assigner/internal/storage"
+)
+
+// SaveCodeowners создает или заменяет правила CODEOWNERS репозитория
+func (r *PRRepository) SaveCodeowners(ctx context.Context, file *storage.CodeownersFile) error {
+	query := `
+		INSERT INTO codeowners (repository, content, updated_at)
+		VALUES (?, ?, ?)
+		ON CONFLICT (repository)
+		DO UPDATE SET content = excluded.content, updated_at = excluded.updated_at
+	`
+	if _, err := r.db.ExecContext(ctx, query, file.Repository, file.Content, file.UpdatedAt); err != nil {
+		return HandleDBError(err)
+	}
+	return nil
+}
+
+// GetCodeowners получает правила CODEOWNERS репозитория
+func (r *PRRepository) GetCodeowners(ctx context.Context, repository string) (*storage.CodeownersFile, error) {
+	query := `SELECT repository, content, updated_at FROM codeowners WHERE repository = ?`
+	var file storage.CodeownersFile
+	err := r.db.QueryRowContext(ctx, query, repository).Scan(&file.Repository, &file.Content, &file.UpdatedAt)
+	if err != nil {
+		return nil, HandleDBError(err)
+	}
+	return &file, nil
+}
+
+// DeleteCodeowners удаляет правила CODEOWNERS репозитория
+func (r *PRRepository) DeleteCodeowners(ctx context.Context, repository string) error {
+	result, err := r.db.ExecContext(ctx, `DELETE FROM codeowners WHERE repository = ?`, repository)
+	if err != nil {
+		return HandleDBError(err)
+	}
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return HandleDBError(err)
+	}
+	if affected == 0 {
+		return storage.ErrNotFound
+	}
+	return nil
+}
+
+// SavePRChangedFiles сохраняет измененные файлы PR, заменяя сохраненные ранее
+func (r *PRRepository) SavePRChangedFiles(ctx context.Context, prID string, files *storage.PRChangedFiles) error {
+	return r.inTx(ctx, func(tx dbtx) error {
+		if _, err := tx.ExecContext(ctx, `DELETE FROM pr_changed_files WHERE pull_request_id = ?`, prID); err != nil {
+			return HandleDBError(err)
+		}
+		query := `INSERT INTO pr_changed_files (pull_request_id, position, repository, path) VALUES (?, ?, ?, ?)`
+		for i, path := range files.Paths {
+			if _, err := tx.ExecContext(ctx, query, prID, i, files.Repository, path); err != nil {
+				return HandleDBError(err)
+			}
+		}
+		return nil
+	})
+}
+
+// GetPRChangedFiles получает измененные файлы PR; если их нет, Paths пустой
+func (r *PRRepository) GetPRChangedFiles(ctx context.Context, prID string) (*storage.PRChangedFiles, error) {
+	query := `SELECT repository, path FROM pr_changed_files WHERE pull_request_id = ? ORDER BY position`
+	rows, err := r.db.QueryContext(ctx, query, prID)
+	if err != nil {
+		return nil, HandleDBError(err)
+	}
+	defer rows.Close()
+
+	files := &storage.PRChangedFiles{Paths: []string{}}
+	for rows.Next() {
+		var path string
+		if err := rows.Scan(&files.Repository, &path); err != nil {
+			return nil, HandleDBError(err)
+		}
+		files.Paths = append(files.Paths, path)
+	}
+	if err = rows.Err(); err != nil {
+		return nil, HandleDBError(err)
+	}
+	return files, nil
+}
+
+// SetReviewersCount меняет количество ревьюверов PR
+func (r *PRRepository) SetReviewersCount(ctx context.Context, prID string, count int) error {
+	result, err := r.db.ExecContext(ctx, `UPDATE pull_requests SET reviewers_count = ? WHERE pull_request_id = ?`, count, prID)
+	if err != nil {
+		return HandleDBError(err)
+	}
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return HandleDBError(err)
+	}
+	if affected == 0 {
+		return storage.ErrNotFound
+	}
+	return nil
+}
@@ -0,0 +1,13 @@
+package sqlite
+
+import (
+	"embed"
+	"io/fs"
+)
+
+//go:embed migrations/*.sql
+var migrationFiles embed.FS
+
+// Migrations миграции схемы SQLite в формате golang-migrate
+// Схема отличается от PostgreSQL, поэтому версии ведутся независимо от migrations/
+var Migrations, _ = fs.Sub(migrationFiles, "migrations")
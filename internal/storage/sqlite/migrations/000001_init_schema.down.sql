@@ -0,0 +1,11 @@
+-- Откат миграции: удаление таблиц в обратном порядке зависимостей
+DROP TABLE IF EXISTS event_outbox;
+DROP TABLE IF EXISTS webhook_deliveries;
+DROP TABLE IF EXISTS webhook_subscriptions;
+DROP TABLE IF EXISTS pr_reviews;
+DROP TRIGGER IF EXISTS trg_pr_reviewers_limit;
+DROP TABLE IF EXISTS pr_reviewers;
+DROP TABLE IF EXISTS pull_requests;
+DROP TABLE IF EXISTS user_identities;
+DROP TABLE IF EXISTS users;
+DROP TABLE IF EXISTS teams;
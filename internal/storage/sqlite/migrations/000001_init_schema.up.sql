@@ -0,0 +1,134 @@
+-- Схема SQLite, эквивалентная миграциям PostgreSQL 000001-000007
+-- Время хранится в UTC в формате "2006-01-02 15:04:05.999999999-07:00" и записывается приложением,
+-- поэтому строки сравниваются и сортируются как время
+
+CREATE TABLE teams (
+    team_name TEXT PRIMARY KEY,
+    min_reviewers INTEGER NOT NULL DEFAULT 1,
+    max_reviewers INTEGER NOT NULL DEFAULT 2,
+    required_approvals INTEGER NOT NULL DEFAULT 0,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    CONSTRAINT chk_team_reviewer_limits CHECK (min_reviewers >= 0 AND max_reviewers >= 1 AND min_reviewers <= max_reviewers),
+    CONSTRAINT chk_team_required_approvals CHECK (required_approvals >= 0 AND required_approvals <= max_reviewers)
+);
+
+CREATE TABLE users (
+    user_id TEXT PRIMARY KEY,
+    username TEXT NOT NULL,
+    team_name TEXT NOT NULL,
+    is_active BOOLEAN NOT NULL DEFAULT 1,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    CONSTRAINT fk_user_team FOREIGN KEY (team_name) REFERENCES teams(team_name) ON DELETE CASCADE
+);
+
+CREATE INDEX idx_users_team_active ON users(team_name, is_active);
+
+CREATE TABLE user_identities (
+    provider TEXT NOT NULL,
+    login TEXT NOT NULL,
+    user_id TEXT NOT NULL,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    PRIMARY KEY (provider, login),
+    CONSTRAINT fk_user_identity_user FOREIGN KEY (user_id) REFERENCES users(user_id) ON DELETE CASCADE
+);
+
+CREATE INDEX idx_user_identities_user_id ON user_identities(user_id);
+
+CREATE TABLE pull_requests (
+    pull_request_id TEXT PRIMARY KEY,
+    pull_request_name TEXT NOT NULL,
+    author_id TEXT NOT NULL,
+    status TEXT NOT NULL,
+    created_at TIMESTAMP NOT NULL,
+    merged_at TIMESTAMP,
+    closed_at TIMESTAMP,
+    reviewers_count INTEGER,
+    CONSTRAINT pull_requests_status_check CHECK (status IN ('OPEN', 'MERGED', 'CLOSED', 'DRAFT')),
+    CONSTRAINT chk_pr_reviewers_count CHECK (reviewers_count IS NULL OR reviewers_count >= 0),
+    CONSTRAINT fk_pr_author FOREIGN KEY (author_id) REFERENCES users(user_id) ON DELETE RESTRICT
+);
+
+CREATE INDEX idx_pull_requests_author_id ON pull_requests(author_id);
+CREATE INDEX idx_pull_requests_status ON pull_requests(status);
+
+CREATE TABLE pr_reviewers (
+    pull_request_id TEXT NOT NULL,
+    user_id TEXT NOT NULL,
+    assigned_at TIMESTAMP NOT NULL,
+    PRIMARY KEY (pull_request_id, user_id),
+    CONSTRAINT fk_pr_reviewer_pr FOREIGN KEY (pull_request_id) REFERENCES pull_requests(pull_request_id) ON DELETE CASCADE,
+    CONSTRAINT fk_pr_reviewer_user FOREIGN KEY (user_id) REFERENCES users(user_id) ON DELETE RESTRICT
+);
+
+CREATE INDEX idx_pr_reviewers_user_id ON pr_reviewers(user_id);
+
+-- Ограничение на количество ревьюверов PR: reviewers_count PR или max_reviewers команды автора
+-- (аналог trg_pr_reviewers_limit в PostgreSQL; ошибка RAISE(ABORT) отображается в ErrCheckViolation)
+CREATE TRIGGER trg_pr_reviewers_limit
+BEFORE INSERT ON pr_reviewers
+FOR EACH ROW
+WHEN (
+    SELECT COUNT(*) FROM pr_reviewers
+    WHERE pull_request_id = NEW.pull_request_id AND user_id <> NEW.user_id
+) >= (
+    SELECT COALESCE(pr.reviewers_count, t.max_reviewers)
+    FROM pull_requests pr
+    INNER JOIN users u ON u.user_id = pr.author_id
+    INNER JOIN teams t ON t.team_name = u.team_name
+    WHERE pr.pull_request_id = NEW.pull_request_id
+)
+BEGIN
+    SELECT RAISE(ABORT, 'pull request reviewers limit exceeded');
+END;
+
+CREATE TABLE pr_reviews (
+    review_id INTEGER PRIMARY KEY AUTOINCREMENT,
+    pull_request_id TEXT NOT NULL,
+    user_id TEXT NOT NULL,
+    verdict TEXT NOT NULL CHECK (verdict IN ('APPROVED', 'CHANGES_REQUESTED', 'COMMENTED')),
+    submitted_at TIMESTAMP NOT NULL,
+    CONSTRAINT fk_pr_review_pr FOREIGN KEY (pull_request_id) REFERENCES pull_requests(pull_request_id) ON DELETE CASCADE,
+    CONSTRAINT fk_pr_review_user FOREIGN KEY (user_id) REFERENCES users(user_id) ON DELETE RESTRICT
+);
+
+CREATE INDEX idx_pr_reviews_pr_user_submitted ON pr_reviews(pull_request_id, user_id, submitted_at DESC);
+
+-- event_types - JSON массив; пустой массив означает подписку на все события
+CREATE TABLE webhook_subscriptions (
+    subscription_id INTEGER PRIMARY KEY AUTOINCREMENT,
+    url TEXT NOT NULL,
+    secret TEXT NOT NULL DEFAULT '',
+    event_types TEXT NOT NULL DEFAULT '[]',
+    is_active BOOLEAN NOT NULL DEFAULT 1,
+    created_at TIMESTAMP NOT NULL
+);
+
+CREATE TABLE webhook_deliveries (
+    delivery_id INTEGER PRIMARY KEY AUTOINCREMENT,
+    subscription_id INTEGER NOT NULL,
+    event_id TEXT NOT NULL,
+    event_type TEXT NOT NULL,
+    attempt INTEGER NOT NULL,
+    status_code INTEGER NOT NULL DEFAULT 0,
+    error TEXT NOT NULL DEFAULT '',
+    success BOOLEAN NOT NULL,
+    delivered_at TIMESTAMP NOT NULL,
+    CONSTRAINT fk_webhook_delivery_subscription FOREIGN KEY (subscription_id) REFERENCES webhook_subscriptions(subscription_id) ON DELETE CASCADE
+);
+
+CREATE INDEX idx_webhook_deliveries_subscription ON webhook_deliveries(subscription_id, delivery_id DESC);
+
+CREATE TABLE event_outbox (
+    outbox_id INTEGER PRIMARY KEY AUTOINCREMENT,
+    event_id TEXT NOT NULL UNIQUE,
+    event_type TEXT NOT NULL,
+    payload TEXT NOT NULL,
+    created_at TIMESTAMP NOT NULL,
+    available_at TIMESTAMP NOT NULL,
+    attempts INTEGER NOT NULL DEFAULT 0,
+    last_error TEXT NOT NULL DEFAULT '',
+    delivered_at TIMESTAMP
+);
+
+CREATE INDEX idx_event_outbox_pending ON event_outbox(available_at, outbox_id) WHERE delivered_at IS NULL;
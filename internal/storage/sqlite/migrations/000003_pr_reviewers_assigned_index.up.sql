@@ -0,0 +1,2 @@
+-- Индекс для списка PR ревьювера (GET /users/getReview): последние назначения первыми
+CREATE INDEX idx_pr_reviewers_user_assigned ON pr_reviewers(user_id, assigned_at DESC, pull_request_id DESC);
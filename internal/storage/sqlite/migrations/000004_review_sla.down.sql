@@ -0,0 +1,3 @@
+-- Откат миграции: удаление SLA ревью
+ALTER TABLE pr_reviewers DROP COLUMN sla_breached_at;
+ALTER TABLE teams DROP COLUMN review_sla_seconds;
@@ -0,0 +1,5 @@
+-- SLA первого ответа ревьювера на уровне команды (0 - не отслеживается)
+ALTER TABLE teams ADD COLUMN review_sla_seconds INTEGER NOT NULL DEFAULT 0 CHECK (review_sla_seconds >= 0);
+
+-- Время, когда по назначению было записано событие review.sla_breached (NULL - еще не записано)
+ALTER TABLE pr_reviewers ADD COLUMN sla_breached_at TIMESTAMP;
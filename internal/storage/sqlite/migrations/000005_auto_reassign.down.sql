@@ -0,0 +1,4 @@
+-- Откат миграции: удаление автоматического переназначения и истории PR
+DROP TABLE IF EXISTS pr_history;
+ALTER TABLE teams DROP COLUMN max_auto_reassignments;
+ALTER TABLE teams DROP COLUMN auto_reassign_after_seconds;
@@ -0,0 +1,16 @@
+-- Политика автоматического переназначения ревьюверов без вердикта (0 - отключена)
+ALTER TABLE teams ADD COLUMN auto_reassign_after_seconds INTEGER NOT NULL DEFAULT 0 CHECK (auto_reassign_after_seconds >= 0);
+ALTER TABLE teams ADD COLUMN max_auto_reassignments INTEGER NOT NULL DEFAULT 1 CHECK (max_auto_reassignments >= 0);
+
+-- История PR: автоматические переназначения ревьюверов
+CREATE TABLE pr_history (
+    history_id INTEGER PRIMARY KEY AUTOINCREMENT,
+    pull_request_id TEXT NOT NULL,
+    action TEXT NOT NULL CHECK (action IN ('AUTO_REASSIGNED')),
+    old_reviewer_id TEXT NOT NULL DEFAULT '',
+    new_reviewer_id TEXT NOT NULL DEFAULT '',
+    created_at TIMESTAMP NOT NULL,
+    CONSTRAINT fk_pr_history_pr FOREIGN KEY (pull_request_id) REFERENCES pull_requests(pull_request_id) ON DELETE CASCADE
+);
+
+CREATE INDEX idx_pr_history_pr ON pr_history(pull_request_id, history_id);
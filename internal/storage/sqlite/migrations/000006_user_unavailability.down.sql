@@ -0,0 +1,2 @@
+-- Откат миграции: удаление периодов отсутствия пользователей
+DROP TABLE IF EXISTS user_unavailability;
@@ -0,0 +1,15 @@
+-- Периоды отсутствия пользователей [starts_at, ends_at): в это время пользователь не назначается ревьювером
+CREATE TABLE user_unavailability (
+    unavailability_id INTEGER PRIMARY KEY AUTOINCREMENT,
+    user_id TEXT NOT NULL,
+    starts_at TIMESTAMP NOT NULL,
+    ends_at TIMESTAMP NOT NULL,
+    reason TEXT NOT NULL DEFAULT '',
+    handoff_reviews INTEGER NOT NULL DEFAULT 0,
+    handed_off_at TIMESTAMP,
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    CONSTRAINT fk_user_unavailability_user FOREIGN KEY (user_id) REFERENCES users(user_id) ON DELETE CASCADE,
+    CONSTRAINT chk_user_unavailability_period CHECK (unixepoch(ends_at, 'subsec') > unixepoch(starts_at, 'subsec'))
+);
+
+CREATE INDEX idx_user_unavailability_user ON user_unavailability(user_id, ends_at);
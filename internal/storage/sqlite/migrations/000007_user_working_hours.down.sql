@@ -0,0 +1,4 @@
+-- Откат миграции: удаление рабочих часов пользователей
+ALTER TABLE users DROP COLUMN working_hours_end;
+ALTER TABLE users DROP COLUMN working_hours_start;
+ALTER TABLE users DROP COLUMN timezone;
@@ -0,0 +1,4 @@
+-- Часовой пояс IANA и рабочие часы пользователя (HH:MM по местному времени)
+ALTER TABLE users ADD COLUMN timezone TEXT;
+ALTER TABLE users ADD COLUMN working_hours_start TEXT;
+ALTER TABLE users ADD COLUMN working_hours_end TEXT;
@@ -0,0 +1,6 @@
+-- Правила CODEOWNERS репозиториев в исходном виде; разбираются при назначении ревьюверов
+CREATE TABLE codeowners (
+    repository TEXT PRIMARY KEY,
+    content TEXT NOT NULL,
+    updated_at TIMESTAMP NOT NULL
+);
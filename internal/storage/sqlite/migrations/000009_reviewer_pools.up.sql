@@ -0,0 +1,24 @@
+-- Именованные пулы ревьюверов из разных команд
+CREATE TABLE reviewer_pools (
+    pool_name TEXT PRIMARY KEY,
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE TABLE reviewer_pool_members (
+    pool_name TEXT NOT NULL,
+    user_id TEXT NOT NULL,
+    PRIMARY KEY (pool_name, user_id),
+    CONSTRAINT fk_pool_member_pool FOREIGN KEY (pool_name) REFERENCES reviewer_pools(pool_name) ON DELETE CASCADE,
+    CONSTRAINT fk_pool_member_user FOREIGN KEY (user_id) REFERENCES users(user_id) ON DELETE CASCADE
+);
+
+-- Резервные пулы команды: из них по порядку position добираются ревьюверы,
+-- когда активных участников команды не хватает
+CREATE TABLE team_fallback_pools (
+    team_name TEXT NOT NULL,
+    pool_name TEXT NOT NULL,
+    position INTEGER NOT NULL,
+    PRIMARY KEY (team_name, pool_name),
+    CONSTRAINT fk_fallback_pool_team FOREIGN KEY (team_name) REFERENCES teams(team_name) ON DELETE CASCADE,
+    CONSTRAINT fk_fallback_pool_pool FOREIGN KEY (pool_name) REFERENCES reviewer_pools(pool_name) ON DELETE CASCADE
+);
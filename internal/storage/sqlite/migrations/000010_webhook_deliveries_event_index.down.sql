@@ -0,0 +1,2 @@
+-- Откат миграции: удаление индекса попыток доставки события
+DROP INDEX IF EXISTS idx_webhook_deliveries_event;
@@ -0,0 +1,2 @@
+-- Откат миграции: удаление измененных файлов черновиков
+DROP TABLE IF EXISTS pr_changed_files;
@@ -0,0 +1,2 @@
+-- Откат миграции: удаление получателей, принявших события outbox
+DROP TABLE IF EXISTS event_outbox_sinks;
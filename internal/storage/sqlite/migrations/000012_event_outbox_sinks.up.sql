@@ -0,0 +1,8 @@
+-- Получатели, уже принявшие событие outbox: при повторной доставке событие отправляется только остальным
+CREATE TABLE event_outbox_sinks (
+    outbox_id INTEGER NOT NULL,
+    sink TEXT NOT NULL,
+    delivered_at TIMESTAMP NOT NULL,
+    PRIMARY KEY (outbox_id, sink),
+    CONSTRAINT fk_event_outbox_sinks_outbox FOREIGN KEY (outbox_id) REFERENCES event_outbox(outbox_id) ON DELETE CASCADE
+);
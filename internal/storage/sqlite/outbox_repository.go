@@ -0,0 +1,170 @@
+package sqlite
+
+import (
+	"cmp"
+	"context"
+	"encoding/json"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	"pr-review-assigner/internal/events"
+)
+
+// insertOutboxEvents сохраняет события в outbox
+// Вызывается в транзакции изменения ревьюверов, чтобы событие не потерялось при сбое
+func insertOutboxEvents(ctx context.Context, exec dbtx, evts ...events.Event) error {
+	query := `
+		INSERT INTO event_outbox (event_id, event_type, payload, created_at, available_at)
+		VALUES (?, ?, ?, ?, ?)
+	`
+	for _, event := range evts {
+		payload, err := json.Marshal(event)
+		if err != nil {
+			return fmt.Errorf("failed to encode event: %w", err)
+		}
+		if _, err = exec.ExecContext(ctx, query, event.ID, string(event.Type), string(payload), event.OccurredAt, event.OccurredAt); err != nil {
+			return HandleDBError(err)
+		}
+	}
+	return nil
+}
+
+// OutboxRepository предоставляет методы для разбора outbox событий
+type OutboxRepository struct {
+	*Repository
+}
+
+// NewOutboxRepository создает новый экземпляр репозитория outbox
+func NewOutboxRepository(repo *Repository) *OutboxRepository {
+	return &OutboxRepository{Repository: repo}
+}
+
+// ClaimPending резервирует до limit недоставленных записей на время lease
+// Запись в SQLite сериализуется блокировкой базы, поэтому SKIP LOCKED не нужен;
+// время вычисляется в приложении, чтобы храниться в том же формате, что и остальные метки
+func (r *OutboxRepository) ClaimPending(ctx context.Context, limit int, lease time.Duration) ([]events.OutboxRecord, error) {
+	query := `
+		UPDATE event_outbox
+		SET available_at = ?
+		WHERE outbox_id IN (
+			SELECT outbox_id
+			FROM event_outbox
+			WHERE delivered_at IS NULL AND available_at <= ?
+			ORDER BY outbox_id
+			LIMIT ?
+		)
+		RETURNING outbox_id, payload, attempts
+	`
+	now := time.Now()
+	rows, err := r.db.QueryContext(ctx, query, now.Add(lease), now, limit)
+	if err != nil {
+		return nil, HandleDBError(err)
+	}
+	defer rows.Close()
+
+	var records []events.OutboxRecord
+	for rows.Next() {
+		var record events.OutboxRecord
+		var payload string
+		if err := rows.Scan(&record.ID, &payload, &record.Attempts); err != nil {
+			return nil, HandleDBError(err)
+		}
+		if err := json.Unmarshal([]byte(payload), &record.Event); err != nil {
+			return nil, fmt.Errorf("failed to decode outbox event %d: %w", record.ID, err)
+		}
+		records = append(records, record)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, HandleDBError(err)
+	}
+
+	// UPDATE ... RETURNING не гарантирует порядок строк
+	slices.SortFunc(records, func(a, b events.OutboxRecord) int {
+		return cmp.Compare(a.ID, b.ID)
+	})
+
+	if err = r.loadDeliveredSinks(ctx, records); err != nil {
+		return nil, err
+	}
+
+	return records, nil
+}
+
+// loadDeliveredSinks заполняет получателей, принявших события при прошлых попытках
+func (r *OutboxRepository) loadDeliveredSinks(ctx context.Context, records []events.OutboxRecord) error {
+	if len(records) == 0 {
+		return nil
+	}
+
+	index := make(map[int64]int, len(records))
+	args := make([]any, len(records))
+	for i, record := range records {
+		index[record.ID] = i
+		args[i] = record.ID
+	}
+
+	query := `
+		SELECT outbox_id, sink
+		FROM event_outbox_sinks
+		WHERE outbox_id IN (` + strings.TrimSuffix(strings.Repeat("?, ", len(args)), ", ") + `)
+		ORDER BY outbox_id, sink
+	`
+	rows, err := r.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return HandleDBError(err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var id int64
+		var sink string
+		if err := rows.Scan(&id, &sink); err != nil {
+			return HandleDBError(err)
+		}
+		record := &records[index[id]]
+		record.DeliveredSinks = append(record.DeliveredSinks, sink)
+	}
+
+	if err = rows.Err(); err != nil {
+		return HandleDBError(err)
+	}
+	return nil
+}
+
+// MarkDelivered помечает запись доставленной
+func (r *OutboxRepository) MarkDelivered(ctx context.Context, id int64) error {
+	_, err := r.db.ExecContext(ctx, `UPDATE event_outbox SET delivered_at = ? WHERE outbox_id = ?`, time.Now(), id)
+	if err != nil {
+		return HandleDBError(err)
+	}
+	return nil
+}
+
+// MarkFailed сохраняет ошибку доставки и получателей, принявших событие,
+// и откладывает следующую попытку на retryAfter
+func (r *OutboxRepository) MarkFailed(ctx context.Context, id int64, deliveredSinks []string, deliveryErr error, retryAfter time.Duration) error {
+	return r.inTx(ctx, func(tx dbtx) error {
+		query := `
+			UPDATE event_outbox
+			SET attempts = attempts + 1,
+				last_error = ?,
+				available_at = ?
+			WHERE outbox_id = ?
+		`
+		now := time.Now()
+		if _, err := tx.ExecContext(ctx, query, deliveryErr.Error(), now.Add(retryAfter), id); err != nil {
+			return HandleDBError(err)
+		}
+
+		for _, sink := range deliveredSinks {
+			_, err := tx.ExecContext(ctx, `INSERT INTO event_outbox_sinks (outbox_id, sink, delivered_at) VALUES (?, ?, ?) ON CONFLICT DO NOTHING`, id, sink, now)
+			if err != nil {
+				return HandleDBError(err)
+			}
+		}
+		return nil
+	})
+}
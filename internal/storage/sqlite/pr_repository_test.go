@@ -0,0 +1,598 @@
+package sqlite
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"pr-review-assigner/internal/api"
+	"pr-review-assigner/internal/events"
+	"pr-review-assigner/internal/storage"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func createTestPR(t *testing.T, repos *testRepos, prID, authorID string, reviewers ...string) *api.PullRequest {
+	t.Helper()
+	pr, err := repos.prs.CreatePR(context.Background(), &api.PullRequest{
+		PullRequestId:     prID,
+		PullRequestName:   "PR " + prID,
+		AuthorId:          authorID,
+		Status:            api.PullRequestStatusOPEN,
+		AssignedReviewers: reviewers,
+	})
+	require.NoError(t, err)
+	return pr
+}
+
+// claimEventTypes забирает все события из outbox
+func claimEventTypes(t *testing.T, repos *testRepos) []events.Type {
+	t.Helper()
+	records, err := repos.outbox.ClaimPending(context.Background(), 100, time.Minute)
+	require.NoError(t, err)
+	var types []events.Type
+	for _, record := range records {
+		types = append(types, record.Event.Type)
+	}
+	return types
+}
+
+func TestPRRepository_CreatePR(t *testing.T) {
+	repos := newTestRepos(t)
+	repos.seedTeam(t, "backend", "u1", "u2", "u3")
+	ctx := context.Background()
+
+	created := createTestPR(t, repos, "pr-1", "u1", "u2", "u3")
+	assert.Equal(t, []string{"u2", "u3"}, created.AssignedReviewers)
+	assert.NotNil(t, created.CreatedAt)
+
+	_, err := repos.prs.CreatePR(ctx, &api.PullRequest{PullRequestId: "pr-1", AuthorId: "u1", Status: api.PullRequestStatusOPEN})
+	assert.ErrorIs(t, err, storage.ErrDuplicateKey)
+	_, err = repos.prs.CreatePR(ctx, &api.PullRequest{PullRequestId: "pr-2", AuthorId: "missing", Status: api.PullRequestStatusOPEN})
+	assert.ErrorIs(t, err, storage.ErrForeignKeyViolation)
+	_, err = repos.prs.CreatePR(ctx, &api.PullRequest{PullRequestId: "pr-3", AuthorId: "u1", Status: "UNKNOWN"})
+	assert.ErrorIs(t, err, storage.ErrCheckViolation)
+
+	assert.Equal(t, []events.Type{events.ReviewerAssigned, events.ReviewerAssigned}, claimEventTypes(t, repos))
+}
+
+func TestPRRepository_ReviewersLimit(t *testing.T) {
+	repos := newTestRepos(t)
+	repos.seedTeam(t, "backend", "u1", "u2", "u3", "u4")
+	ctx := context.Background()
+
+	// max_reviewers команды по умолчанию - 2
+	_, err := repos.prs.CreatePR(ctx, &api.PullRequest{PullRequestId: "pr-1", AuthorId: "u1", Status: api.PullRequestStatusOPEN, AssignedReviewers: []string{"u2", "u3", "u4"}})
+	assert.ErrorIs(t, err, storage.ErrCheckViolation)
+
+	// reviewers_count PR имеет приоритет над настройкой команды
+	one := 1
+	_, err = repos.prs.CreatePR(ctx, &api.PullRequest{PullRequestId: "pr-2", AuthorId: "u1", Status: api.PullRequestStatusOPEN, ReviewersCount: &one, AssignedReviewers: []string{"u2"}})
+	require.NoError(t, err)
+	assert.ErrorIs(t, repos.prs.AddReviewer(ctx, "pr-2", "u3"), storage.ErrCheckViolation)
+
+	// Повторное назначение того же ревьювера не нарушает лимит и не создает событие
+	require.NoError(t, repos.prs.AddReviewer(ctx, "pr-2", "u2"))
+	assert.Equal(t, []events.Type{events.ReviewerAssigned}, claimEventTypes(t, repos))
+}
+
+func TestPRRepository_AddReviewerForeignKeys(t *testing.T) {
+	repos := newTestRepos(t)
+	repos.seedTeam(t, "backend", "u1", "u2")
+	createTestPR(t, repos, "pr-1", "u1")
+	ctx := context.Background()
+
+	assert.ErrorIs(t, repos.prs.AddReviewer(ctx, "missing", "u2"), storage.ErrForeignKeyViolation)
+	assert.ErrorIs(t, repos.prs.AddReviewer(ctx, "pr-1", "missing"), storage.ErrForeignKeyViolation)
+}
+
+func TestPRRepository_UpdatePRStatus(t *testing.T) {
+	repos := newTestRepos(t)
+	repos.seedTeam(t, "backend", "u1", "u2")
+	createTestPR(t, repos, "pr-1", "u1", "u2")
+	claimEventTypes(t, repos)
+	ctx := context.Background()
+	now := time.Now()
+
+	closed, err := repos.prs.UpdatePRStatus(ctx, "pr-1", api.PullRequestStatusCLOSED, &now)
+	require.NoError(t, err)
+	assert.NotNil(t, closed.ClosedAt)
+
+	reopened, err := repos.prs.UpdatePRStatus(ctx, "pr-1", api.PullRequestStatusOPEN, nil)
+	require.NoError(t, err)
+	assert.Nil(t, reopened.ClosedAt)
+
+	merged, err := repos.prs.UpdatePRStatus(ctx, "pr-1", api.PullRequestStatusMERGED, &now)
+	require.NoError(t, err)
+	assert.Equal(t, api.PullRequestStatusMERGED, merged.Status)
+	assert.Equal(t, []string{"u2"}, merged.AssignedReviewers)
+	require.NotNil(t, merged.MergedAt)
+	assert.True(t, now.Equal(*merged.MergedAt))
+
+	_, err = repos.prs.UpdatePRStatus(ctx, "missing", api.PullRequestStatusMERGED, &now)
+	assert.ErrorIs(t, err, storage.ErrNotFound)
+	assert.Equal(t, []events.Type{events.PRMerged}, claimEventTypes(t, repos))
+}
+
+func TestPRRepository_ReassignReviewer(t *testing.T) {
+	repos := newTestRepos(t)
+	repos.seedTeam(t, "backend", "u1", "u2", "u3", "u4")
+	createTestPR(t, repos, "pr-1", "u1", "u2", "u3")
+	claimEventTypes(t, repos)
+	ctx := context.Background()
+
+	_, err := repos.prs.ReassignReviewer(ctx, "pr-1", "u4", "u2")
+	assert.ErrorIs(t, err, storage.ErrNotFound)
+	_, err = repos.prs.ReassignReviewer(ctx, "pr-1", "u2", "u3")
+	assert.ErrorIs(t, err, storage.ErrDuplicateKey)
+
+	pr, err := repos.prs.ReassignReviewer(ctx, "pr-1", "u2", "u4")
+	require.NoError(t, err)
+	assert.Equal(t, []string{"u3", "u4"}, pr.AssignedReviewers)
+
+	pr, err = repos.prs.ReassignReviewer(ctx, "pr-1", "u3", "")
+	require.NoError(t, err)
+	assert.Equal(t, []string{"u4"}, pr.AssignedReviewers)
+
+	assert.Equal(t, []events.Type{events.ReviewerReassigned, events.ReviewerRemoved}, claimEventTypes(t, repos))
+}
+
+func TestPRRepository_LatestReviews(t *testing.T) {
+	repos := newTestRepos(t)
+	repos.seedTeam(t, "backend", "u1", "u2", "u3", "u4")
+	createTestPR(t, repos, "pr-1", "u1", "u2", "u3")
+	ctx := context.Background()
+
+	require.NoError(t, repos.prs.AddReview(ctx, "pr-1", "u3", api.CHANGESREQUESTED))
+	require.NoError(t, repos.prs.AddReview(ctx, "pr-1", "u3", api.APPROVED))
+	require.NoError(t, repos.prs.AddReview(ctx, "pr-1", "u2", api.COMMENTED))
+	assert.ErrorIs(t, repos.prs.AddReview(ctx, "pr-1", "u2", "LGTM"), storage.ErrCheckViolation)
+	assert.ErrorIs(t, repos.prs.AddReview(ctx, "missing", "u2", api.APPROVED), storage.ErrForeignKeyViolation)
+
+	pr, err := repos.prs.GetPR(ctx, "pr-1")
+	require.NoError(t, err)
+	require.Len(t, pr.Reviews, 2)
+	assert.Equal(t, "u2", pr.Reviews[0].UserId)
+	assert.Equal(t, api.COMMENTED, pr.Reviews[0].Verdict)
+	assert.Equal(t, "u3", pr.Reviews[1].UserId)
+	assert.Equal(t, api.APPROVED, pr.Reviews[1].Verdict)
+
+	// Вердикт снятого ревьювера не учитывается
+	_, err = repos.prs.ReassignReviewer(ctx, "pr-1", "u3", "u4")
+	require.NoError(t, err)
+	pr, err = repos.prs.GetPR(ctx, "pr-1")
+	require.NoError(t, err)
+	require.Len(t, pr.Reviews, 1)
+	assert.Equal(t, "u2", pr.Reviews[0].UserId)
+}
+
+func TestPRRepository_ReviewerQueries(t *testing.T) {
+	repos := newTestRepos(t)
+	repos.seedTeam(t, "backend", "u1", "u2", "u3")
+	ctx := context.Background()
+	createTestPR(t, repos, "pr-1", "u1", "u2")
+	createTestPR(t, repos, "pr-2", "u1", "u2", "u3")
+	createTestPR(t, repos, "pr-3", "u1", "u3")
+	now := time.Now()
+	_, err := repos.prs.UpdatePRStatus(ctx, "pr-3", api.PullRequestStatusMERGED, &now)
+	require.NoError(t, err)
+
+	short, err := repos.prs.GetPRsByReviewer(ctx, "u2", &storage.ReviewerPRsFilter{})
+	require.NoError(t, err)
+	require.Len(t, short, 2)
+	assert.Equal(t, "pr-2", short[0].PullRequestId)
+	assert.Equal(t, "pr-1", short[1].PullRequestId)
+
+	open, err := repos.prs.GetOpenPRsByReviewers(ctx, []string{"u3"})
+	require.NoError(t, err)
+	require.Len(t, open, 1)
+	assert.Equal(t, "pr-2", open[0].PullRequestId)
+
+	counts, err := repos.prs.GetOpenReviewCounts(ctx, []string{"u2", "u3", "u1"})
+	require.NoError(t, err)
+	assert.Equal(t, map[string]int{"u2": 2, "u3": 1}, counts)
+
+	stats, err := repos.prs.GetReviewerStatistics(ctx, &storage.StatisticsFilter{})
+	require.NoError(t, err)
+	require.Len(t, stats, 3)
+	assert.Equal(t, storage.ReviewerStatistic{UserID: "u2", Username: "name-u2", TeamName: "backend", AssignmentsCount: 2, OpenAssignmentsCount: 2}, stats[0])
+	assert.Equal(t, "u3", stats[1].UserID)
+	assert.Equal(t, 2, stats[1].AssignmentsCount)
+	assert.Equal(t, 1, stats[1].CompletedAssignmentsCount)
+	assert.NotNil(t, stats[1].AvgTimeToMerge)
+	assert.Equal(t, 0, stats[2].AssignmentsCount)
+	assert.Equal(t, 3, stats[2].AuthoredPRsCount)
+}
+
+func TestPRRepository_ListPRs(t *testing.T) {
+	repos := newTestRepos(t)
+	repos.seedTeam(t, "backend", "u1", "u2", "u3")
+	repos.seedTeam(t, "frontend", "f1", "f2")
+	ctx := context.Background()
+
+	base := time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC)
+	create := func(prID, name, authorID string, offset time.Duration, reviewers ...string) {
+		createdAt := base.Add(offset)
+		_, err := repos.prs.CreatePR(ctx, &api.PullRequest{
+			PullRequestId:     prID,
+			PullRequestName:   name,
+			AuthorId:          authorID,
+			Status:            api.PullRequestStatusOPEN,
+			CreatedAt:         &createdAt,
+			AssignedReviewers: reviewers,
+		})
+		require.NoError(t, err)
+	}
+	create("pr-1", "Fix login", "u1", 0, "u2")
+	create("pr-2", "Add 100% coverage", "u2", time.Hour, "u1", "u3")
+	create("pr-3", "fix LOGOUT", "f1", 2*time.Hour, "f2")
+	create("pr-4", "Refactor", "u1", 2*time.Hour)
+	mergedAt := base.Add(3 * time.Hour)
+	_, err := repos.prs.UpdatePRStatus(ctx, "pr-2", api.PullRequestStatusMERGED, &mergedAt)
+	require.NoError(t, err)
+
+	ids := func(filter storage.PRListFilter) []string {
+		t.Helper()
+		if filter.Limit == 0 {
+			filter.Limit = 10
+		}
+		prs, err := repos.prs.ListPRs(ctx, &filter)
+		require.NoError(t, err)
+		result := []string{}
+		for _, pr := range prs {
+			result = append(result, pr.PullRequestId)
+		}
+		return result
+	}
+
+	// Одинаковое время создания упорядочивается по pull_request_id
+	assert.Equal(t, []string{"pr-1", "pr-2", "pr-3", "pr-4"}, ids(storage.PRListFilter{}))
+	assert.Equal(t, []string{"pr-4", "pr-3", "pr-2", "pr-1"}, ids(storage.PRListFilter{Descending: true}))
+	assert.Equal(t, []string{"pr-2", "pr-1", "pr-4", "pr-3"}, ids(storage.PRListFilter{SortBy: storage.PRSortName}))
+
+	assert.Equal(t, []string{"pr-2"}, ids(storage.PRListFilter{Status: api.PullRequestStatusMERGED}))
+	assert.Equal(t, []string{"pr-1", "pr-4"}, ids(storage.PRListFilter{AuthorID: "u1"}))
+	assert.Equal(t, []string{"pr-2"}, ids(storage.PRListFilter{ReviewerID: "u3"}))
+	assert.Equal(t, []string{"pr-3"}, ids(storage.PRListFilter{TeamName: "frontend"}))
+	assert.Equal(t, []string{"pr-1", "pr-3"}, ids(storage.PRListFilter{NameContains: "FIX lo"}))
+	// Спецсимволы LIKE ищутся буквально
+	assert.Equal(t, []string{"pr-2"}, ids(storage.PRListFilter{NameContains: "0%"}))
+	assert.Empty(t, ids(storage.PRListFilter{NameContains: "_"}))
+
+	from, to := base.Add(time.Hour), base.Add(2*time.Hour)
+	assert.Equal(t, []string{"pr-2"}, ids(storage.PRListFilter{CreatedFrom: &from, CreatedTo: &to}))
+	assert.Equal(t, []string{"pr-2"}, ids(storage.PRListFilter{MergedFrom: &from}))
+	assert.Empty(t, ids(storage.PRListFilter{MergedTo: &to}))
+
+	// Страница начинается строго после курсора
+	assert.Equal(t, []string{"pr-3"}, ids(storage.PRListFilter{
+		Limit: 1,
+		After: &storage.PRListCursor{CreatedAt: base.Add(time.Hour), PullRequestID: "pr-2"},
+	}))
+	assert.Equal(t, []string{"pr-3", "pr-2", "pr-1"}, ids(storage.PRListFilter{
+		Descending: true,
+		After:      &storage.PRListCursor{CreatedAt: base.Add(2 * time.Hour), PullRequestID: "pr-4"},
+	}))
+	assert.Equal(t, []string{"pr-4", "pr-3"}, ids(storage.PRListFilter{
+		SortBy: storage.PRSortName,
+		After:  &storage.PRListCursor{Name: "Fix login", PullRequestID: "pr-1"},
+	}))
+
+	prs, err := repos.prs.ListPRs(ctx, &storage.PRListFilter{AuthorID: "u1", Limit: 10})
+	require.NoError(t, err)
+	require.Len(t, prs, 2)
+	assert.Equal(t, []string{"u2"}, prs[0].AssignedReviewers)
+	assert.NotNil(t, prs[1].AssignedReviewers)
+	assert.Empty(t, prs[1].AssignedReviewers)
+}
+
+func TestPRRepository_GetPRsByReviewerPage(t *testing.T) {
+	repos := newTestRepos(t)
+	repos.seedTeam(t, "backend", "u1", "u2", "u3")
+	ctx := context.Background()
+	createTestPR(t, repos, "pr-1", "u1", "u2")
+	createTestPR(t, repos, "pr-2", "u1", "u2")
+	createTestPR(t, repos, "pr-3", "u1", "u3")
+	require.NoError(t, repos.prs.AddReviewer(ctx, "pr-3", "u2"))
+	now := time.Now()
+	_, err := repos.prs.UpdatePRStatus(ctx, "pr-1", api.PullRequestStatusMERGED, &now)
+	require.NoError(t, err)
+
+	// Последние назначения первыми, время назначения берется из pr_reviewers
+	all, err := repos.prs.GetPRsByReviewer(ctx, "u2", &storage.ReviewerPRsFilter{})
+	require.NoError(t, err)
+	require.Len(t, all, 3)
+	assert.Equal(t, []string{"pr-3", "pr-2", "pr-1"}, []string{all[0].PullRequestId, all[1].PullRequestId, all[2].PullRequestId})
+	require.NotNil(t, all[0].AssignedAt)
+	require.NotNil(t, all[2].AssignedAt)
+	assert.False(t, all[0].AssignedAt.Before(*all[2].AssignedAt))
+
+	open, err := repos.prs.GetPRsByReviewer(ctx, "u2", &storage.ReviewerPRsFilter{Status: api.PullRequestStatusOPEN})
+	require.NoError(t, err)
+	assert.Len(t, open, 2)
+
+	page, err := repos.prs.GetPRsByReviewer(ctx, "u2", &storage.ReviewerPRsFilter{
+		Limit: 1,
+		After: &storage.ReviewerPRsCursor{AssignedAt: *all[0].AssignedAt, PullRequestID: all[0].PullRequestId},
+	})
+	require.NoError(t, err)
+	require.Len(t, page, 1)
+	assert.Equal(t, "pr-2", page[0].PullRequestId)
+}
+
+func TestPRRepository_GetReviewerStatisticsFilters(t *testing.T) {
+	repos := newTestRepos(t)
+	repos.seedTeam(t, "backend", "u1", "u2", "u3")
+	repos.seedTeam(t, "frontend", "f1", "f2")
+	ctx := context.Background()
+
+	before := time.Now()
+	createTestPR(t, repos, "pr-1", "u1", "u2")
+	createTestPR(t, repos, "pr-2", "u1", "u2", "u3")
+	createTestPR(t, repos, "pr-3", "u3", "u2")
+	createTestPR(t, repos, "pr-4", "f1", "f2")
+	after := time.Now()
+	mergedAt := after.Add(time.Hour)
+	_, err := repos.prs.UpdatePRStatus(ctx, "pr-1", api.PullRequestStatusMERGED, &mergedAt)
+	require.NoError(t, err)
+	_, err = repos.prs.UpdatePRStatus(ctx, "pr-2", api.PullRequestStatusCLOSED, &mergedAt)
+	require.NoError(t, err)
+
+	byUser := func(filter storage.StatisticsFilter) map[string]storage.ReviewerStatistic {
+		t.Helper()
+		stats, err := repos.prs.GetReviewerStatistics(ctx, &filter)
+		require.NoError(t, err)
+		result := make(map[string]storage.ReviewerStatistic)
+		for _, stat := range stats {
+			result[stat.UserID] = stat
+		}
+		return result
+	}
+
+	stats := byUser(storage.StatisticsFilter{TeamName: "backend"})
+	require.Len(t, stats, 3)
+	u2 := stats["u2"]
+	assert.Equal(t, "backend", u2.TeamName)
+	assert.Equal(t, 3, u2.AssignmentsCount)
+	assert.Equal(t, 1, u2.OpenAssignmentsCount)
+	assert.Equal(t, 2, u2.CompletedAssignmentsCount)
+	require.NotNil(t, u2.AvgTimeToMerge)
+	assert.GreaterOrEqual(t, *u2.AvgTimeToMerge, time.Hour-time.Millisecond)
+	assert.LessOrEqual(t, *u2.AvgTimeToMerge, time.Hour+after.Sub(before)+time.Millisecond)
+	assert.Equal(t, 2, stats["u1"].AuthoredPRsCount)
+	assert.Equal(t, 1, stats["u3"].AuthoredPRsCount)
+	// Закрытый без слияния PR не участвует в среднем времени до слияния
+	assert.Equal(t, 1, stats["u3"].CompletedAssignmentsCount)
+	assert.Nil(t, stats["u3"].AvgTimeToMerge)
+
+	stats = byUser(storage.StatisticsFilter{Status: api.PullRequestStatusOPEN})
+	require.Len(t, stats, 5)
+	assert.Equal(t, 1, stats["u2"].AssignmentsCount)
+	assert.Equal(t, 0, stats["u1"].AuthoredPRsCount)
+	assert.Equal(t, 1, stats["f2"].AssignmentsCount)
+
+	// Период после всех назначений и созданий
+	stats = byUser(storage.StatisticsFilter{From: &mergedAt})
+	assert.Zero(t, stats["u2"].AssignmentsCount)
+	assert.Zero(t, stats["u1"].AuthoredPRsCount)
+	stats = byUser(storage.StatisticsFilter{To: &mergedAt})
+	assert.Equal(t, 3, stats["u2"].AssignmentsCount)
+}
+
+func TestPRRepository_ExportAssignments(t *testing.T) {
+	repos := newTestRepos(t)
+	repos.seedTeam(t, "backend", "u1", "u2", "u3")
+	ctx := context.Background()
+	createTestPR(t, repos, "pr-1", "u1", "u2")
+	createTestPR(t, repos, "pr-2", "u2", "u1", "u3")
+	mergedAt := time.Now()
+	_, err := repos.prs.UpdatePRStatus(ctx, "pr-1", api.PullRequestStatusMERGED, &mergedAt)
+	require.NoError(t, err)
+
+	var rows []api.AssignmentExportRow
+	require.NoError(t, repos.prs.ExportAssignments(ctx, func(row *api.AssignmentExportRow) error {
+		rows = append(rows, *row)
+		return nil
+	}))
+	require.Len(t, rows, 3)
+	assert.Equal(t, "pr-1", rows[0].PullRequestId)
+	assert.Equal(t, api.PullRequestStatusMERGED, rows[0].Status)
+	assert.Equal(t, "name-u2", rows[0].ReviewerUsername)
+	assert.Equal(t, "backend", rows[0].ReviewerTeamName)
+	require.NotNil(t, rows[0].MergedAt)
+	assert.NotNil(t, rows[0].AssignedAt)
+	assert.NotNil(t, rows[0].CreatedAt)
+	assert.Equal(t, []string{"u1", "u3"}, []string{rows[1].ReviewerId, rows[2].ReviewerId})
+
+	// Ошибка обработчика прерывает выгрузку
+	calls := 0
+	errStop := errors.New("stop")
+	err = repos.prs.ExportAssignments(ctx, func(*api.AssignmentExportRow) error {
+		calls++
+		return errStop
+	})
+	assert.ErrorIs(t, err, errStop)
+	assert.Equal(t, 1, calls)
+}
+
+func TestPRRepository_StaleReviews(t *testing.T) {
+	repos := newTestRepos(t)
+	repos.seedTeam(t, "backend", "u1", "u2", "u3")
+	repos.seedTeam(t, "frontend", "u4", "u5")
+	ctx := context.Background()
+	require.NoError(t, repos.teams.UpdateTeamSettings(ctx, &storage.TeamSettings{TeamName: "backend", MinReviewers: 1, MaxReviewers: 2, ReviewSLA: time.Hour}))
+
+	createTestPR(t, repos, "pr-1", "u1", "u2", "u3")
+	createTestPR(t, repos, "pr-2", "u4", "u5") // SLA команды frontend не задан
+	createTestPR(t, repos, "pr-3", "u2", "u1")
+	closedAt := time.Now()
+	_, err := repos.prs.UpdatePRStatus(ctx, "pr-3", api.PullRequestStatusCLOSED, &closedAt)
+	require.NoError(t, err)
+	require.NoError(t, repos.prs.AddReview(ctx, "pr-1", "u3", api.COMMENTED))
+	claimEventTypes(t, repos)
+
+	// До срока просроченных назначений нет
+	stale, err := repos.prs.GetStaleReviews(ctx, &storage.StaleReviewsFilter{Now: time.Now()})
+	require.NoError(t, err)
+	assert.Empty(t, stale)
+
+	now := time.Now().Add(2 * time.Hour)
+	stale, err = repos.prs.GetStaleReviews(ctx, &storage.StaleReviewsFilter{Now: now, OnlyUnmarked: true})
+	require.NoError(t, err)
+	require.Len(t, stale, 1)
+	assert.Equal(t, "pr-1", stale[0].PullRequestID)
+	assert.Equal(t, "u2", stale[0].ReviewerID)
+	assert.Equal(t, "u1", stale[0].AuthorID)
+	assert.Equal(t, "backend", stale[0].TeamName)
+	assert.Equal(t, time.Hour, stale[0].SLA)
+	assert.Nil(t, stale[0].BreachedAt)
+
+	// Событие записывается один раз на назначение
+	marked, err := repos.prs.MarkSLABreached(ctx, &stale[0], now)
+	require.NoError(t, err)
+	assert.True(t, marked)
+	marked, err = repos.prs.MarkSLABreached(ctx, &stale[0], now)
+	require.NoError(t, err)
+	assert.False(t, marked)
+	assert.Equal(t, []events.Type{events.ReviewSLABreached}, claimEventTypes(t, repos))
+
+	stale, err = repos.prs.GetStaleReviews(ctx, &storage.StaleReviewsFilter{Now: now, OnlyUnmarked: true})
+	require.NoError(t, err)
+	assert.Empty(t, stale)
+
+	stale, err = repos.prs.GetStaleReviews(ctx, &storage.StaleReviewsFilter{Now: now, TeamName: "backend", ReviewerID: "u2"})
+	require.NoError(t, err)
+	require.Len(t, stale, 1)
+	assert.NotNil(t, stale[0].BreachedAt)
+
+	stale, err = repos.prs.GetStaleReviews(ctx, &storage.StaleReviewsFilter{Now: now, TeamName: "frontend"})
+	require.NoError(t, err)
+	assert.Empty(t, stale)
+}
+
+func TestPRRepository_ReviewTimeoutsAndHistory(t *testing.T) {
+	repos := newTestRepos(t)
+	repos.seedTeam(t, "backend", "u1", "u2", "u3", "u4")
+	repos.seedTeam(t, "frontend", "u5", "u6")
+	ctx := context.Background()
+	require.NoError(t, repos.teams.UpdateTeamSettings(ctx, &storage.TeamSettings{TeamName: "backend", MinReviewers: 1, MaxReviewers: 2,
+		AutoReassignAfter: time.Hour, MaxAutoReassignments: 1}))
+
+	createTestPR(t, repos, "pr-1", "u1", "u2", "u3")
+	createTestPR(t, repos, "pr-2", "u5", "u6") // политика команды frontend не задана
+	require.NoError(t, repos.prs.AddReview(ctx, "pr-1", "u3", api.APPROVED))
+
+	timeouts, err := repos.prs.GetReviewTimeouts(ctx, time.Now())
+	require.NoError(t, err)
+	assert.Empty(t, timeouts)
+
+	now := time.Now().Add(2 * time.Hour)
+	timeouts, err = repos.prs.GetReviewTimeouts(ctx, now)
+	require.NoError(t, err)
+	require.Len(t, timeouts, 1)
+	assert.Equal(t, storage.ReviewTimeout{
+		PullRequestID:        "pr-1",
+		TeamName:             "backend",
+		ReviewerID:           "u2",
+		AssignedAt:           timeouts[0].AssignedAt,
+		AutoReassignments:    0,
+		MaxAutoReassignments: 1,
+	}, timeouts[0])
+
+	history, err := repos.prs.GetPRHistory(ctx, "pr-1")
+	require.NoError(t, err)
+	assert.Empty(t, history)
+
+	createdAt := time.Date(2025, 10, 25, 12, 0, 0, 0, time.UTC)
+	_, err = repos.prs.ReassignReviewer(ctx, "pr-1", "u2", "u4")
+	require.NoError(t, err)
+	entry := &api.PullRequestHistoryEntry{Action: api.PRHistoryAutoReassigned, OldReviewerId: "u2", NewReviewerId: "u4", CreatedAt: createdAt}
+	require.NoError(t, repos.prs.AddPRHistory(ctx, "pr-1", entry))
+	assert.ErrorIs(t, repos.prs.AddPRHistory(ctx, "missing", entry), storage.ErrForeignKeyViolation)
+
+	history, err = repos.prs.GetPRHistory(ctx, "pr-1")
+	require.NoError(t, err)
+	require.Len(t, history, 1)
+	assert.Equal(t, api.PRHistoryAutoReassigned, history[0].Action)
+	assert.Equal(t, "u2", history[0].OldReviewerId)
+	assert.Equal(t, "u4", history[0].NewReviewerId)
+	assert.True(t, createdAt.Equal(history[0].CreatedAt))
+
+	// Лимит автоматических переназначений PR исчерпан
+	timeouts, err = repos.prs.GetReviewTimeouts(ctx, now.Add(2*time.Hour))
+	require.NoError(t, err)
+	assert.Empty(t, timeouts)
+}
+
+func TestPRRepository_Codeowners(t *testing.T) {
+	repos := newTestRepos(t)
+	ctx := context.Background()
+
+	_, err := repos.prs.GetCodeowners(ctx, "acme/api")
+	assert.ErrorIs(t, err, storage.ErrNotFound)
+	assert.ErrorIs(t, repos.prs.DeleteCodeowners(ctx, "acme/api"), storage.ErrNotFound)
+
+	uploadedAt := time.Date(2025, 10, 25, 12, 0, 0, 0, time.UTC)
+	require.NoError(t, repos.prs.SaveCodeowners(ctx, &storage.CodeownersFile{Repository: "acme/api", Content: "*.go @u1", UpdatedAt: uploadedAt}))
+	// Повторная загрузка заменяет правила
+	require.NoError(t, repos.prs.SaveCodeowners(ctx, &storage.CodeownersFile{Repository: "acme/api", Content: "*.sql @u2", UpdatedAt: uploadedAt.Add(time.Hour)}))
+	require.NoError(t, repos.prs.SaveCodeowners(ctx, &storage.CodeownersFile{Repository: "acme/web", Content: "*.js @u3", UpdatedAt: uploadedAt}))
+
+	file, err := repos.prs.GetCodeowners(ctx, "acme/api")
+	require.NoError(t, err)
+	assert.Equal(t, "*.sql @u2", file.Content)
+	assert.True(t, uploadedAt.Add(time.Hour).Equal(file.UpdatedAt))
+
+	require.NoError(t, repos.prs.DeleteCodeowners(ctx, "acme/api"))
+	_, err = repos.prs.GetCodeowners(ctx, "acme/api")
+	assert.ErrorIs(t, err, storage.ErrNotFound)
+	_, err = repos.prs.GetCodeowners(ctx, "acme/web")
+	assert.NoError(t, err)
+}
+
+func TestPRRepository_BatchReassignEventsOnlyForChanges(t *testing.T) {
+	repos := newTestRepos(t)
+	repos.seedTeam(t, "backend", "u1", "u2", "u3", "u4")
+	createTestPR(t, repos, "pr-1", "u1", "u2", "u3")
+	ctx := context.Background()
+	_, err := repos.outbox.ClaimPending(ctx, 10, time.Minute)
+	require.NoError(t, err)
+
+	// u3 уже ревьювер, поэтому u2 только снимается; u4 в PR не было
+	require.NoError(t, repos.prs.BatchReassignReviewers(ctx, map[string]map[string]string{"pr-1": {"u2": "u3", "u4": ""}}))
+
+	records, err := repos.outbox.ClaimPending(ctx, 10, time.Minute)
+	require.NoError(t, err)
+	require.Len(t, records, 1)
+	assert.Equal(t, events.ReviewerRemoved, records[0].Event.Type)
+	assert.Equal(t, "u2", records[0].Event.Data.ReviewerID)
+}
+
+func TestPRRepository_ChangedFiles(t *testing.T) {
+	repos := newTestRepos(t)
+	repos.seedTeam(t, "backend", "u1", "u2")
+	createTestPR(t, repos, "pr-1", "u1")
+	ctx := context.Background()
+
+	files, err := repos.prs.GetPRChangedFiles(ctx, "pr-1")
+	require.NoError(t, err)
+	assert.Empty(t, files.Paths)
+
+	require.NoError(t, repos.prs.SavePRChangedFiles(ctx, "pr-1", &storage.PRChangedFiles{Repository: "acme/api", Paths: []string{"main.go", "db/001.sql"}}))
+	require.NoError(t, repos.prs.SavePRChangedFiles(ctx, "pr-1", &storage.PRChangedFiles{Repository: "acme/api", Paths: []string{"docs/intro.md", "main.go"}}))
+	files, err = repos.prs.GetPRChangedFiles(ctx, "pr-1")
+	require.NoError(t, err)
+	assert.Equal(t, &storage.PRChangedFiles{Repository: "acme/api", Paths: []string{"docs/intro.md", "main.go"}}, files)
+
+	err = repos.prs.SavePRChangedFiles(ctx, "missing", &storage.PRChangedFiles{Repository: "acme/api", Paths: []string{"main.go"}})
+	assert.ErrorIs(t, err, storage.ErrForeignKeyViolation)
+
+	require.NoError(t, repos.prs.SetReviewersCount(ctx, "pr-1", 3))
+	pr, err := repos.prs.GetPR(ctx, "pr-1")
+	require.NoError(t, err)
+	require.NotNil(t, pr.ReviewersCount)
+	assert.Equal(t, 3, *pr.ReviewersCount)
+	assert.ErrorIs(t, repos.prs.SetReviewersCount(ctx, "missing", 3), storage.ErrNotFound)
+}
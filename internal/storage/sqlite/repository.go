@@ -0,0 +1,113 @@
+// Package sqlite реализует хранилище на SQLite для установки одним бинарным файлом без PostgreSQL
+// Репозитории повторяют поведение репозиториев PostgreSQL из пакета storage и возвращают те же ошибки
+package sqlite
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"strings"
+
+	"pr-review-assigner/internal/storage"
+
+	sqlite "modernc.org/sqlite"
+	sqlite3 "modernc.org/sqlite/lib"
+)
+
+// dbtx общий интерфейс для *sql.DB и *sql.Tx
+type dbtx interface {
+	ExecContext(ctx context.Context, query string, args ...any) (sql.Result, error)
+	QueryContext(ctx context.Context, query string, args ...any) (*sql.Rows, error)
+	QueryRowContext(ctx context.Context, query string, args ...any) *sql.Row
+}
+
+// Repository представляет базовый репозиторий для работы с SQLite
+// Внутри TxManager.WithTx репозитории работают через транзакцию вместо подключения
+type Repository struct {
+	db dbtx
+}
+
+// NewRepository создает новый экземпляр репозитория
+func NewRepository(db *sql.DB) *Repository {
+	return &Repository{db: db}
+}
+
+// Open открывает файл базы SQLite; схема создается миграциями Migrations
+// WAL позволяет читать вне транзакции, пока идет запись; транзакции сразу берут блокировку
+// на запись и ждут ее до busy_timeout. Время пишется в UTC в формате, который сортируется как строка
+func Open(ctx context.Context, path string) (*sql.DB, error) {
+	dsn := "file:" + path + "?_pragma=foreign_keys(1)&_pragma=busy_timeout(5000)&_pragma=journal_mode(WAL)" +
+		"&_time_format=sqlite&_timezone=UTC&_txlock=immediate"
+	db, err := sql.Open("sqlite", dsn)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open sqlite database: %w", err)
+	}
+
+	if err = db.PingContext(ctx); err != nil {
+		db.Close()
+		return nil, fmt.Errorf("failed to open sqlite database: %w", err)
+	}
+	return db, nil
+}
+
+// inTx выполняет fn в транзакции
+// Если репозиторий уже работает в транзакции TxManager, fn выполняется в ней,
+// и фиксация откладывается до завершения WithTx
+func (r *Repository) inTx(ctx context.Context, fn func(tx dbtx) error) error {
+	db, ok := r.db.(*sql.DB)
+	if !ok {
+		return fn(r.db)
+	}
+
+	tx, err := db.BeginTx(ctx, nil)
+	if err != nil {
+		return HandleDBError(err)
+	}
+	defer tx.Rollback()
+
+	if err = fn(tx); err != nil {
+		return err
+	}
+
+	if err = tx.Commit(); err != nil {
+		return HandleDBError(err)
+	}
+	return nil
+}
+
+// HandleDBError обрабатывает ошибки SQLite и возвращает соответствующие ошибки storage
+func HandleDBError(err error) error {
+	if err == nil {
+		return nil
+	}
+
+	if errors.Is(err, sql.ErrNoRows) {
+		return storage.ErrNotFound
+	}
+
+	// Расширенные коды ограничений SQLite вместо SQLSTATE PostgreSQL
+	var sqliteErr *sqlite.Error
+	if errors.As(err, &sqliteErr) {
+		switch sqliteErr.Code() {
+		case sqlite3.SQLITE_CONSTRAINT_PRIMARYKEY, sqlite3.SQLITE_CONSTRAINT_UNIQUE:
+			return storage.ErrDuplicateKey
+		case sqlite3.SQLITE_CONSTRAINT_FOREIGNKEY:
+			return storage.ErrForeignKeyViolation
+		case sqlite3.SQLITE_CONSTRAINT_CHECK, sqlite3.SQLITE_CONSTRAINT_TRIGGER:
+			// RAISE(ABORT) в trg_pr_reviewers_limit соответствует check_violation в PostgreSQL
+			return storage.ErrCheckViolation
+		}
+	}
+
+	return fmt.Errorf("database error: %w", err)
+}
+
+// inClause возвращает список параметров "?, ?, ?" и их значения вместо pq.Array и = ANY($1)
+func inClause(values []string) (string, []any) {
+	args := make([]any, len(values))
+	for i, v := range values {
+		args[i] = v
+	}
+	return strings.TrimSuffix(strings.Repeat("?, ", len(values)), ", "), args
+}
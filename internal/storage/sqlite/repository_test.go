@@ -0,0 +1,275 @@
+package sqlite
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"pr-review-assigner/internal/api"
+	"pr-review-assigner/internal/events"
+	"pr-review-assigner/internal/migrate"
+	"pr-review-assigner/internal/storage"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// Проверка соответствия интерфейсам storage и events
+var (
+	_ storage.TeamRepositoryInterface         = (*TeamRepository)(nil)
+	_ storage.UserRepositoryInterface         = (*UserRepository)(nil)
+	_ storage.PRRepositoryInterface           = (*PRRepository)(nil)
+	_ storage.SubscriptionRepositoryInterface = (*SubscriptionRepository)(nil)
+	_ storage.TxManager                       = (*SQLTxManager)(nil)
+	_ events.OutboxStore                      = (*OutboxRepository)(nil)
+)
+
+type testRepos struct {
+	db            *sql.DB
+	teams         *TeamRepository
+	users         *UserRepository
+	prs           *PRRepository
+	subscriptions *SubscriptionRepository
+	outbox        *OutboxRepository
+}
+
+// newTestRepos создает репозитории поверх новой базы во временном каталоге теста
+func newTestRepos(t *testing.T) *testRepos {
+	t.Helper()
+	db, err := Open(context.Background(), filepath.Join(t.TempDir(), "test.db"))
+	require.NoError(t, err)
+	t.Cleanup(func() { db.Close() })
+	_, err = migrate.New(db, Migrations, migrate.SQLite).Up(context.Background())
+	require.NoError(t, err)
+
+	repo := NewRepository(db)
+	return &testRepos{
+		db:            db,
+		teams:         NewTeamRepository(repo),
+		users:         NewUserRepository(repo),
+		prs:           NewPRRepository(repo),
+		subscriptions: NewSubscriptionRepository(repo),
+		outbox:        NewOutboxRepository(repo),
+	}
+}
+
+// seedTeam создает команду с активными пользователями
+func (r *testRepos) seedTeam(t *testing.T, teamName string, userIDs ...string) {
+	t.Helper()
+	ctx := context.Background()
+	require.NoError(t, r.teams.CreateTeam(ctx, teamName))
+	for _, userID := range userIDs {
+		require.NoError(t, r.users.CreateOrUpdateUser(ctx, &api.User{UserId: userID, Username: "name-" + userID, TeamName: teamName, IsActive: true}))
+	}
+}
+
+func TestRepository_FailedUpdateLeavesStateUnchanged(t *testing.T) {
+	repos := newTestRepos(t)
+	repos.seedTeam(t, "backend", "u1", "u2")
+	ctx := context.Background()
+
+	// Второй ревьювер не существует: PR и первое назначение не должны сохраниться
+	_, err := repos.prs.CreatePR(ctx, &api.PullRequest{
+		PullRequestId:     "pr-1",
+		AuthorId:          "u1",
+		Status:            api.PullRequestStatusOPEN,
+		AssignedReviewers: []string{"u2", "ghost"},
+	})
+	assert.ErrorIs(t, err, storage.ErrForeignKeyViolation)
+
+	_, err = repos.prs.GetPR(ctx, "pr-1")
+	assert.ErrorIs(t, err, storage.ErrNotFound)
+	pending, err := repos.outbox.ClaimPending(ctx, 10, 0)
+	require.NoError(t, err)
+	assert.Empty(t, pending)
+}
+
+func TestRepository_CanceledContext(t *testing.T) {
+	repos := newTestRepos(t)
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	err := repos.teams.CreateTeam(ctx, "backend")
+	assert.ErrorIs(t, err, context.Canceled)
+
+	exists, err := repos.teams.TeamExists(context.Background(), "backend")
+	require.NoError(t, err)
+	assert.False(t, exists)
+}
+
+func TestTxManager_RollbackOnError(t *testing.T) {
+	repos := newTestRepos(t)
+	repos.seedTeam(t, "backend", "u1", "u2", "u3")
+	ctx := context.Background()
+	_, err := repos.prs.CreatePR(ctx, &api.PullRequest{PullRequestId: "pr-1", AuthorId: "u1", Status: api.PullRequestStatusOPEN, AssignedReviewers: []string{"u2"}})
+	require.NoError(t, err)
+
+	errReassign := errors.New("reassign failed")
+	err = NewTxManager(repos.db).WithTx(ctx, func(tx storage.Repositories) error {
+		if _, err := tx.Users.BatchDeactivateUsers(ctx, []string{"u2"}); err != nil {
+			return err
+		}
+		// Изменение видно внутри транзакции
+		user, err := tx.Users.GetUser(ctx, "u2")
+		require.NoError(t, err)
+		assert.False(t, user.IsActive)
+		return errReassign
+	})
+	assert.ErrorIs(t, err, errReassign)
+
+	user, err := repos.users.GetUser(ctx, "u2")
+	require.NoError(t, err)
+	assert.True(t, user.IsActive)
+}
+
+func TestTxManager_Commit(t *testing.T) {
+	repos := newTestRepos(t)
+	repos.seedTeam(t, "backend", "u1", "u2", "u3")
+	ctx := context.Background()
+	_, err := repos.prs.CreatePR(ctx, &api.PullRequest{PullRequestId: "pr-1", AuthorId: "u1", Status: api.PullRequestStatusOPEN, AssignedReviewers: []string{"u2"}})
+	require.NoError(t, err)
+
+	err = NewTxManager(repos.db).WithTx(ctx, func(tx storage.Repositories) error {
+		if _, err := tx.Users.BatchDeactivateUsers(ctx, []string{"u2"}); err != nil {
+			return err
+		}
+		return tx.PRs.BatchReassignReviewers(ctx, map[string]map[string]string{"pr-1": {"u2": "u3"}})
+	})
+	require.NoError(t, err)
+
+	pr, err := repos.prs.GetPR(ctx, "pr-1")
+	require.NoError(t, err)
+	assert.Equal(t, []string{"u3"}, pr.AssignedReviewers)
+	user, err := repos.users.GetUser(ctx, "u2")
+	require.NoError(t, err)
+	assert.False(t, user.IsActive)
+}
+
+func TestTxManager_ReadOutsideTxDoesNotBlock(t *testing.T) {
+	repos := newTestRepos(t)
+	repos.seedTeam(t, "backend", "u1", "u2")
+	ctx := context.Background()
+
+	// Нагрузко-зависимые стратегии читают нагрузку через обычный репозиторий внутри WithTx
+	err := NewTxManager(repos.db).WithTx(ctx, func(tx storage.Repositories) error {
+		if _, err := tx.Users.BatchDeactivateUsers(ctx, []string{"u2"}); err != nil {
+			return err
+		}
+		user, err := repos.users.GetUser(ctx, "u2")
+		require.NoError(t, err)
+		assert.True(t, user.IsActive, "uncommitted change must not be visible outside the transaction")
+		return nil
+	})
+	require.NoError(t, err)
+
+	user, err := repos.users.GetUser(ctx, "u2")
+	require.NoError(t, err)
+	assert.False(t, user.IsActive)
+}
+
+func TestMigrations_DownAndUp(t *testing.T) {
+	repos := newTestRepos(t)
+	ctx := context.Background()
+	migrator := migrate.New(repos.db, Migrations, migrate.SQLite)
+
+	status, err := migrator.Status(ctx)
+	require.NoError(t, err)
+	assert.False(t, status.Behind())
+	migrations, err := migrator.Migrations()
+	require.NoError(t, err)
+
+	// down-миграции удаляют все объекты схемы, поэтому схему можно создать заново
+	reverted, err := migrator.Down(ctx, len(migrations))
+	require.NoError(t, err)
+	assert.Len(t, reverted, len(migrations))
+	var tables int
+	require.NoError(t, repos.db.QueryRow(`SELECT COUNT(*) FROM sqlite_master WHERE name <> 'schema_migrations' AND name NOT LIKE 'sqlite_%'`).Scan(&tables))
+	assert.Zero(t, tables)
+
+	_, err = migrator.Up(ctx)
+	require.NoError(t, err)
+	require.NoError(t, repos.teams.CreateTeam(ctx, "backend"))
+}
+
+func TestOutboxRepository_ClaimAndRetry(t *testing.T) {
+	repos := newTestRepos(t)
+	repos.seedTeam(t, "backend", "u1", "u2")
+	createTestPR(t, repos, "pr-1", "u1", "u2")
+	ctx := context.Background()
+
+	records, err := repos.outbox.ClaimPending(ctx, 10, time.Minute)
+	require.NoError(t, err)
+	require.Len(t, records, 1)
+	assert.Equal(t, events.ReviewerAssigned, records[0].Event.Type)
+	assert.Equal(t, "pr-1", records[0].Event.Data.PullRequestID)
+
+	// Зарезервированная запись не выдается повторно до истечения lease
+	records, err = repos.outbox.ClaimPending(ctx, 10, time.Minute)
+	require.NoError(t, err)
+	assert.Empty(t, records)
+
+	var id int64
+	require.NoError(t, repos.db.QueryRow(`SELECT outbox_id FROM event_outbox`).Scan(&id))
+	require.NoError(t, repos.outbox.MarkFailed(ctx, id, []string{"log"}, errors.New("sink down"), 0))
+	records, err = repos.outbox.ClaimPending(ctx, 10, time.Minute)
+	require.NoError(t, err)
+	require.Len(t, records, 1)
+	assert.Equal(t, 1, records[0].Attempts)
+	assert.Equal(t, []string{"log"}, records[0].DeliveredSinks)
+
+	// Получатели, принявшие событие, накапливаются между попытками
+	require.NoError(t, repos.outbox.MarkFailed(ctx, id, []string{"file", "log"}, errors.New("sink down"), 0))
+	records, err = repos.outbox.ClaimPending(ctx, 10, time.Minute)
+	require.NoError(t, err)
+	require.Len(t, records, 1)
+	assert.Equal(t, []string{"file", "log"}, records[0].DeliveredSinks)
+
+	require.NoError(t, repos.outbox.MarkDelivered(ctx, id))
+	require.NoError(t, repos.outbox.MarkFailed(ctx, id, nil, errors.New("late"), 0))
+	records, err = repos.outbox.ClaimPending(ctx, 10, 0)
+	require.NoError(t, err)
+	assert.Empty(t, records)
+}
+
+func TestSubscriptionRepository_EventTypes(t *testing.T) {
+	repos := newTestRepos(t)
+	ctx := context.Background()
+
+	all, err := repos.subscriptions.CreateSubscription(ctx, &events.Subscription{URL: "http://all"})
+	require.NoError(t, err)
+	assert.NotZero(t, all.ID)
+	assert.Empty(t, all.EventTypes)
+	assert.True(t, all.IsActive)
+	merged, err := repos.subscriptions.CreateSubscription(ctx, &events.Subscription{URL: "http://merged", EventTypes: []events.Type{events.PRMerged}})
+	require.NoError(t, err)
+	assert.Equal(t, []events.Type{events.PRMerged}, merged.EventTypes)
+
+	subscriptions, err := repos.subscriptions.GetSubscriptionsForEvent(ctx, events.ReviewerAssigned)
+	require.NoError(t, err)
+	require.Len(t, subscriptions, 1)
+	assert.Equal(t, all.ID, subscriptions[0].ID)
+	subscriptions, err = repos.subscriptions.GetSubscriptionsForEvent(ctx, events.PRMerged)
+	require.NoError(t, err)
+	assert.Len(t, subscriptions, 2)
+
+	delivery := &events.Delivery{SubscriptionID: merged.ID, EventID: "e1", EventType: events.PRMerged, Attempt: 1, StatusCode: 200, Success: true, DeliveredAt: time.Now()}
+	require.NoError(t, repos.subscriptions.LogDelivery(ctx, delivery))
+	assert.NotZero(t, delivery.ID)
+	retry := &events.Delivery{SubscriptionID: all.ID, EventID: "e1", EventType: events.PRMerged, Attempt: 1, StatusCode: 503, Error: "unavailable", DeliveredAt: time.Now()}
+	require.NoError(t, repos.subscriptions.LogDelivery(ctx, retry))
+	eventDeliveries, err := repos.subscriptions.ListEventDeliveries(ctx, "e1")
+	require.NoError(t, err)
+	require.Len(t, eventDeliveries, 2)
+	assert.Equal(t, delivery.ID, eventDeliveries[0].ID)
+	assert.Equal(t, 503, eventDeliveries[1].StatusCode)
+
+	// Удаление подписки удаляет и журнал доставок
+	require.NoError(t, repos.subscriptions.DeleteSubscription(ctx, merged.ID))
+	assert.ErrorIs(t, repos.subscriptions.DeleteSubscription(ctx, merged.ID), storage.ErrNotFound)
+	deliveries, err := repos.subscriptions.ListDeliveries(ctx, merged.ID, 10)
+	require.NoError(t, err)
+	assert.Empty(t, deliveries)
+}
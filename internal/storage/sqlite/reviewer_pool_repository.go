@@ -0,0 +1,154 @@
+package sqlite
+
+import (
+	"context"
+	"time"
+
+	"pr-review-assigner/internal/api"
+	"pr-review-assigner/internal/storage"
+)
+
+// SaveReviewerPool создает пул ревьюверов или заменяет его участников
+func (r *TeamRepository) SaveReviewerPool(ctx context.Context, pool *api.ReviewerPool) error {
+	return r.inTx(ctx, func(tx dbtx) error {
+		query := `INSERT INTO reviewer_pools (pool_name) VALUES (?) ON CONFLICT (pool_name) DO NOTHING`
+		if _, err := tx.ExecContext(ctx, query, pool.PoolName); err != nil {
+			return HandleDBError(err)
+		}
+
+		if _, err := tx.ExecContext(ctx, `DELETE FROM reviewer_pool_members WHERE pool_name = ?`, pool.PoolName); err != nil {
+			return HandleDBError(err)
+		}
+		query = `INSERT INTO reviewer_pool_members (pool_name, user_id) VALUES (?, ?) ON CONFLICT DO NOTHING`
+		for _, userID := range pool.Members {
+			if _, err := tx.ExecContext(ctx, query, pool.PoolName, userID); err != nil {
+				return HandleDBError(err)
+			}
+		}
+		return nil
+	})
+}
+
+// GetReviewerPool получает пул ревьюверов с участниками, упорядоченными по user_id
+func (r *TeamRepository) GetReviewerPool(ctx context.Context, poolName string) (*api.ReviewerPool, error) {
+	pools, err := r.queryReviewerPools(ctx, `WHERE p.pool_name = ?`, poolName)
+	if err != nil {
+		return nil, err
+	}
+	if len(pools) == 0 {
+		return nil, storage.ErrNotFound
+	}
+	return &pools[0], nil
+}
+
+// ListReviewerPools получает все пулы, упорядоченные по имени
+func (r *TeamRepository) ListReviewerPools(ctx context.Context) ([]api.ReviewerPool, error) {
+	return r.queryReviewerPools(ctx, "")
+}
+
+// queryReviewerPools получает пулы с участниками; where ограничивает выборку пулов
+func (r *TeamRepository) queryReviewerPools(ctx context.Context, where string, args ...any) ([]api.ReviewerPool, error) {
+	query := `
+		SELECT p.pool_name, m.user_id
+		FROM reviewer_pools p
+		LEFT JOIN reviewer_pool_members m ON m.pool_name = p.pool_name
+		` + where + `
+		ORDER BY p.pool_name, m.user_id
+	`
+	rows, err := r.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, HandleDBError(err)
+	}
+	defer rows.Close()
+
+	pools := []api.ReviewerPool{}
+	for rows.Next() {
+		var poolName string
+		var userID *string
+		if err := rows.Scan(&poolName, &userID); err != nil {
+			return nil, HandleDBError(err)
+		}
+		if len(pools) == 0 || pools[len(pools)-1].PoolName != poolName {
+			pools = append(pools, api.ReviewerPool{PoolName: poolName, Members: []string{}})
+		}
+		if userID != nil {
+			pool := &pools[len(pools)-1]
+			pool.Members = append(pool.Members, *userID)
+		}
+	}
+	if err = rows.Err(); err != nil {
+		return nil, HandleDBError(err)
+	}
+	return pools, nil
+}
+
+// DeleteReviewerPool удаляет пул; участники и резервные пулы команд удаляются каскадно
+func (r *TeamRepository) DeleteReviewerPool(ctx context.Context, poolName string) error {
+	result, err := r.db.ExecContext(ctx, `DELETE FROM reviewer_pools WHERE pool_name = ?`, poolName)
+	if err != nil {
+		return HandleDBError(err)
+	}
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return HandleDBError(err)
+	}
+	if affected == 0 {
+		return storage.ErrNotFound
+	}
+	return nil
+}
+
+// GetActiveUsersByPool получает активных участников пула, у которых в момент now нет периода отсутствия
+func (r *UserRepository) GetActiveUsersByPool(ctx context.Context, poolName string, now time.Time) ([]api.User, error) {
+	query := `
+		SELECT u.user_id, u.username, u.team_name, u.is_active, u.timezone, u.working_hours_start, u.working_hours_end
+		FROM reviewer_pool_members m
+		JOIN users u ON u.user_id = m.user_id
+		WHERE m.pool_name = ? AND u.is_active = 1
+			AND NOT EXISTS (
+				SELECT 1 FROM user_unavailability ua
+				WHERE ua.user_id = u.user_id
+					AND unixepoch(ua.starts_at, 'subsec') <= unixepoch(?, 'subsec')
+					AND unixepoch(ua.ends_at, 'subsec') > unixepoch(?, 'subsec')
+			)
+		ORDER BY u.user_id
+	`
+	return r.queryUsers(ctx, r.db, query, poolName, now, now)
+}
+
+// getFallbackPools получает резервные пулы команды в порядке использования
+func getFallbackPools(ctx context.Context, db dbtx, teamName string) ([]string, error) {
+	query := `SELECT pool_name FROM team_fallback_pools WHERE team_name = ? ORDER BY position`
+	rows, err := db.QueryContext(ctx, query, teamName)
+	if err != nil {
+		return nil, HandleDBError(err)
+	}
+	defer rows.Close()
+
+	var pools []string
+	for rows.Next() {
+		var poolName string
+		if err := rows.Scan(&poolName); err != nil {
+			return nil, HandleDBError(err)
+		}
+		pools = append(pools, poolName)
+	}
+	if err = rows.Err(); err != nil {
+		return nil, HandleDBError(err)
+	}
+	return pools, nil
+}
+
+// replaceFallbackPools заменяет резервные пулы команды; порядок в pools - порядок использования
+func replaceFallbackPools(ctx context.Context, tx dbtx, teamName string, pools []string) error {
+	if _, err := tx.ExecContext(ctx, `DELETE FROM team_fallback_pools WHERE team_name = ?`, teamName); err != nil {
+		return HandleDBError(err)
+	}
+	query := `INSERT INTO team_fallback_pools (team_name, pool_name, position) VALUES (?, ?, ?)`
+	for i, poolName := range pools {
+		if _, err := tx.ExecContext(ctx, query, teamName, poolName, i); err != nil {
+			return HandleDBError(err)
+		}
+	}
+	return nil
+}
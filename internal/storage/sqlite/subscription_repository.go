@@ -0,0 +1,252 @@
+package sqlite
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"pr-review-assigner/internal/events"
+	"pr-review-assigner/internal/storage"
+)
+
+// SubscriptionRepository предоставляет методы для работы с подписками на события и журналом доставок
+type SubscriptionRepository struct {
+	*Repository
+}
+
+// NewSubscriptionRepository создает новый экземпляр репозитория подписок
+func NewSubscriptionRepository(repo *Repository) *SubscriptionRepository {
+	return &SubscriptionRepository{Repository: repo}
+}
+
+const subscriptionColumns = `subscription_id, url, secret, event_types, is_active, created_at`
+
+// scanSubscription читает подписку из строки результата
+// event_types хранится как JSON массив строк
+func scanSubscription(row rowScanner) (*events.Subscription, error) {
+	var subscription events.Subscription
+	var eventTypesJSON string
+	var eventTypes []string
+	err := row.Scan(
+		&subscription.ID,
+		&subscription.URL,
+		&subscription.Secret,
+		&eventTypesJSON,
+		&subscription.IsActive,
+		&subscription.CreatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+	if err = json.Unmarshal([]byte(eventTypesJSON), &eventTypes); err != nil {
+		return nil, fmt.Errorf("failed to decode event types of subscription %d: %w", subscription.ID, err)
+	}
+	subscription.EventTypes = make([]events.Type, 0, len(eventTypes))
+	for _, t := range eventTypes {
+		subscription.EventTypes = append(subscription.EventTypes, events.Type(t))
+	}
+	return &subscription, nil
+}
+
+// CreateSubscription создает подписку
+func (r *SubscriptionRepository) CreateSubscription(ctx context.Context, subscription *events.Subscription) (*events.Subscription, error) {
+	eventTypes := make([]string, 0, len(subscription.EventTypes))
+	for _, t := range subscription.EventTypes {
+		eventTypes = append(eventTypes, string(t))
+	}
+
+	eventTypesJSON, err := json.Marshal(eventTypes)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode event types: %w", err)
+	}
+
+	query := `
+		INSERT INTO webhook_subscriptions (url, secret, event_types, created_at)
+		VALUES (?, ?, ?, ?)
+		RETURNING ` + subscriptionColumns
+	created, err := scanSubscription(r.db.QueryRowContext(ctx, query, subscription.URL, subscription.Secret, string(eventTypesJSON), time.Now()))
+	if err != nil {
+		return nil, HandleDBError(err)
+	}
+	return created, nil
+}
+
+// ListSubscriptions получает все подписки
+func (r *SubscriptionRepository) ListSubscriptions(ctx context.Context) ([]events.Subscription, error) {
+	query := `SELECT ` + subscriptionColumns + ` FROM webhook_subscriptions ORDER BY subscription_id`
+	return r.querySubscriptions(ctx, query)
+}
+
+// GetSubscriptionsForEvent получает активные подписки на указанный тип события
+func (r *SubscriptionRepository) GetSubscriptionsForEvent(ctx context.Context, eventType events.Type) ([]events.Subscription, error) {
+	query := `
+		SELECT ` + subscriptionColumns + `
+		FROM webhook_subscriptions
+		WHERE is_active = 1
+		  AND (json_array_length(event_types) = 0 OR EXISTS(SELECT 1 FROM json_each(event_types) WHERE value = ?))
+		ORDER BY subscription_id
+	`
+	return r.querySubscriptions(ctx, query, string(eventType))
+}
+
+func (r *SubscriptionRepository) querySubscriptions(ctx context.Context, query string, args ...interface{}) ([]events.Subscription, error) {
+	rows, err := r.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, HandleDBError(err)
+	}
+	defer rows.Close()
+
+	subscriptions := []events.Subscription{}
+	for rows.Next() {
+		subscription, err := scanSubscription(rows)
+		if err != nil {
+			return nil, HandleDBError(err)
+		}
+		subscriptions = append(subscriptions, *subscription)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, HandleDBError(err)
+	}
+
+	return subscriptions, nil
+}
+
+// DeleteSubscription удаляет подписку вместе с журналом ее доставок
+func (r *SubscriptionRepository) DeleteSubscription(ctx context.Context, subscriptionID int64) error {
+	result, err := r.db.ExecContext(ctx, `DELETE FROM webhook_subscriptions WHERE subscription_id = ?`, subscriptionID)
+	if err != nil {
+		return HandleDBError(err)
+	}
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return HandleDBError(err)
+	}
+	if affected == 0 {
+		return storage.ErrNotFound
+	}
+	return nil
+}
+
+// SubscriptionExists проверяет существование подписки
+func (r *SubscriptionRepository) SubscriptionExists(ctx context.Context, subscriptionID int64) (bool, error) {
+	var exists bool
+	err := r.db.QueryRowContext(ctx, `SELECT EXISTS(SELECT 1 FROM webhook_subscriptions WHERE subscription_id = ?)`, subscriptionID).Scan(&exists)
+	if err != nil {
+		return false, HandleDBError(err)
+	}
+	return exists, nil
+}
+
+// LogDelivery записывает попытку доставки события в журнал
+func (r *SubscriptionRepository) LogDelivery(ctx context.Context, delivery *events.Delivery) error {
+	query := `
+		INSERT INTO webhook_deliveries (subscription_id, event_id, event_type, attempt, status_code, error, success, delivered_at)
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?)
+	`
+	result, err := r.db.ExecContext(ctx, query,
+		delivery.SubscriptionID,
+		delivery.EventID,
+		string(delivery.EventType),
+		delivery.Attempt,
+		delivery.StatusCode,
+		delivery.Error,
+		delivery.Success,
+		delivery.DeliveredAt,
+	)
+	if err != nil {
+		return HandleDBError(err)
+	}
+	if delivery.ID, err = result.LastInsertId(); err != nil {
+		return HandleDBError(err)
+	}
+	return nil
+}
+
+// ListDeliveries получает последние попытки доставки по подписке (новые первыми)
+func (r *SubscriptionRepository) ListDeliveries(ctx context.Context, subscriptionID int64, limit int) ([]events.Delivery, error) {
+	query := `
+		SELECT delivery_id, subscription_id, event_id, event_type, attempt, status_code, error, success, delivered_at
+		FROM webhook_deliveries
+		WHERE subscription_id = ?
+		ORDER BY delivery_id DESC
+		LIMIT ?
+	`
+	rows, err := r.db.QueryContext(ctx, query, subscriptionID, limit)
+	if err != nil {
+		return nil, HandleDBError(err)
+	}
+	defer rows.Close()
+
+	deliveries := []events.Delivery{}
+	for rows.Next() {
+		var delivery events.Delivery
+		var eventType string
+		err := rows.Scan(
+			&delivery.ID,
+			&delivery.SubscriptionID,
+			&delivery.EventID,
+			&eventType,
+			&delivery.Attempt,
+			&delivery.StatusCode,
+			&delivery.Error,
+			&delivery.Success,
+			&delivery.DeliveredAt,
+		)
+		if err != nil {
+			return nil, HandleDBError(err)
+		}
+		delivery.EventType = events.Type(eventType)
+		deliveries = append(deliveries, delivery)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, HandleDBError(err)
+	}
+
+	return deliveries, nil
+}
+
+// ListEventDeliveries получает все попытки доставки события в порядке записи
+func (r *SubscriptionRepository) ListEventDeliveries(ctx context.Context, eventID string) ([]events.Delivery, error) {
+	query := `
+		SELECT delivery_id, subscription_id, event_id, event_type, attempt, status_code, error, success, delivered_at
+		FROM webhook_deliveries
+		WHERE event_id = ?
+		ORDER BY delivery_id
+	`
+	rows, err := r.db.QueryContext(ctx, query, eventID)
+	if err != nil {
+		return nil, HandleDBError(err)
+	}
+	defer rows.Close()
+
+	deliveries := []events.Delivery{}
+	for rows.Next() {
+		var delivery events.Delivery
+		var eventType string
+		err := rows.Scan(
+			&delivery.ID,
+			&delivery.SubscriptionID,
+			&delivery.EventID,
+			&eventType,
+			&delivery.Attempt,
+			&delivery.StatusCode,
+			&delivery.Error,
+			&delivery.Success,
+			&delivery.DeliveredAt,
+		)
+		if err != nil {
+			return nil, HandleDBError(err)
+		}
+		delivery.EventType = events.Type(eventType)
+		deliveries = append(deliveries, delivery)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, HandleDBError(err)
+	}
+
+	return deliveries, nil
+}
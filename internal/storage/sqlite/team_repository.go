@@ -0,0 +1,149 @@
+package sqlite
+
+import (
+	"context"
+	"time"
+
+	"pr-review-assigner/internal/api"
+	"pr-review-assigner/internal/storage"
+)
+
+// TeamRepository предоставляет методы для работы с командами
+type TeamRepository struct {
+	*Repository
+}
+
+// NewTeamRepository создает новый экземпляр репозитория команд
+func NewTeamRepository(repo *Repository) *TeamRepository {
+	return &TeamRepository{Repository: repo}
+}
+
+// CreateTeam создает новую команду
+func (r *TeamRepository) CreateTeam(ctx context.Context, teamName string) error {
+	query := `INSERT INTO teams (team_name) VALUES (?)`
+	_, err := r.db.ExecContext(ctx, query, teamName)
+	if err != nil {
+		return HandleDBError(err)
+	}
+	return nil
+}
+
+// GetTeam получает команду с участниками по имени
+func (r *TeamRepository) GetTeam(ctx context.Context, teamName string) (*api.Team, error) {
+	// Сначала получаем настройки команды (заодно проверяем ее существование)
+	settings, err := r.GetTeamSettings(ctx, teamName)
+	if err != nil {
+		return nil, err
+	}
+
+	// Получаем участников команды
+	query := `
+		SELECT user_id, username, is_active
+		FROM users
+		WHERE team_name = ?
+		ORDER BY user_id
+	`
+	rows, err := r.db.QueryContext(ctx, query, teamName)
+	if err != nil {
+		return nil, HandleDBError(err)
+	}
+	defer rows.Close()
+
+	var members []api.TeamMember
+	for rows.Next() {
+		var member api.TeamMember
+		err := rows.Scan(&member.UserId, &member.Username, &member.IsActive)
+		if err != nil {
+			return nil, HandleDBError(err)
+		}
+		members = append(members, member)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, HandleDBError(err)
+	}
+
+	reviewSLASeconds := int(settings.ReviewSLA / time.Second)
+	autoReassignAfterSeconds := int(settings.AutoReassignAfter / time.Second)
+	fallbackPools := append([]string{}, settings.FallbackPools...)
+	return &api.Team{
+		TeamName:          teamName,
+		Members:           members,
+		MinReviewers:      &settings.MinReviewers,
+		MaxReviewers:      &settings.MaxReviewers,
+		RequiredApprovals: &settings.RequiredApprovals,
+		ReviewSlaSeconds:  &reviewSLASeconds,
+
+		AutoReassignAfterSeconds: &autoReassignAfterSeconds,
+		MaxAutoReassignments:     &settings.MaxAutoReassignments,
+		FallbackPools:            &fallbackPools,
+	}, nil
+}
+
+// TeamExists проверяет существование команды
+func (r *TeamRepository) TeamExists(ctx context.Context, teamName string) (bool, error) {
+	var exists bool
+	query := `SELECT EXISTS(SELECT 1 FROM teams WHERE team_name = ?)`
+	err := r.db.QueryRowContext(ctx, query, teamName).Scan(&exists)
+	if err != nil {
+		return false, HandleDBError(err)
+	}
+	return exists, nil
+}
+
+// GetTeamSettings получает настройки назначения ревьюверов команды
+func (r *TeamRepository) GetTeamSettings(ctx context.Context, teamName string) (*storage.TeamSettings, error) {
+	query := `
+		SELECT team_name, min_reviewers, max_reviewers, required_approvals, review_sla_seconds,
+			auto_reassign_after_seconds, max_auto_reassignments
+		FROM teams
+		WHERE team_name = ?
+	`
+	var settings storage.TeamSettings
+	var reviewSLASeconds, autoReassignAfterSeconds int64
+	err := r.db.QueryRowContext(ctx, query, teamName).Scan(
+		&settings.TeamName,
+		&settings.MinReviewers,
+		&settings.MaxReviewers,
+		&settings.RequiredApprovals,
+		&reviewSLASeconds,
+		&autoReassignAfterSeconds,
+		&settings.MaxAutoReassignments,
+	)
+	if err != nil {
+		return nil, HandleDBError(err)
+	}
+	settings.ReviewSLA = time.Duration(reviewSLASeconds) * time.Second
+	settings.AutoReassignAfter = time.Duration(autoReassignAfterSeconds) * time.Second
+
+	if settings.FallbackPools, err = getFallbackPools(ctx, r.db, teamName); err != nil {
+		return nil, err
+	}
+	return &settings, nil
+}
+
+// UpdateTeamSettings обновляет настройки назначения ревьюверов команды и заменяет ее резервные пулы
+func (r *TeamRepository) UpdateTeamSettings(ctx context.Context, settings *storage.TeamSettings) error {
+	query := `
+		UPDATE teams
+		SET min_reviewers = ?, max_reviewers = ?, required_approvals = ?, review_sla_seconds = ?,
+			auto_reassign_after_seconds = ?, max_auto_reassignments = ?
+		WHERE team_name = ?
+	`
+	return r.inTx(ctx, func(tx dbtx) error {
+		result, err := tx.ExecContext(ctx, query, settings.MinReviewers, settings.MaxReviewers, settings.RequiredApprovals,
+			int64(settings.ReviewSLA/time.Second), int64(settings.AutoReassignAfter/time.Second), settings.MaxAutoReassignments, settings.TeamName)
+		if err != nil {
+			return HandleDBError(err)
+		}
+
+		affected, err := result.RowsAffected()
+		if err != nil {
+			return HandleDBError(err)
+		}
+		if affected == 0 {
+			return storage.ErrNotFound
+		}
+		return replaceFallbackPools(ctx, tx, settings.TeamName, settings.FallbackPools)
+	})
+}
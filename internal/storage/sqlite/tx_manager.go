@@ -0,0 +1,44 @@
+package sqlite
+
+import (
+	"context"
+	"database/sql"
+
+	"pr-review-assigner/internal/storage"
+)
+
+// SQLTxManager реализует storage.TxManager поверх транзакций SQLite
+type SQLTxManager struct {
+	db *sql.DB
+}
+
+// NewTxManager создает новый экземпляр менеджера транзакций
+func NewTxManager(db *sql.DB) *SQLTxManager {
+	return &SQLTxManager{db: db}
+}
+
+// WithTx выполняет fn в транзакции БД
+// Репозитории внутри fn используют эту транзакцию, в том числе для собственных
+// многошаговых операций (например, BatchReassignReviewers)
+func (m *SQLTxManager) WithTx(ctx context.Context, fn func(repos storage.Repositories) error) error {
+	tx, err := m.db.BeginTx(ctx, nil)
+	if err != nil {
+		return HandleDBError(err)
+	}
+	defer tx.Rollback()
+
+	repo := &Repository{db: tx}
+	repos := storage.Repositories{
+		Teams: NewTeamRepository(repo),
+		Users: NewUserRepository(repo),
+		PRs:   NewPRRepository(repo),
+	}
+	if err = fn(repos); err != nil {
+		return err
+	}
+
+	if err = tx.Commit(); err != nil {
+		return HandleDBError(err)
+	}
+	return nil
+}
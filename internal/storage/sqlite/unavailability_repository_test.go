@@ -0,0 +1,97 @@
+package sqlite
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"pr-review-assigner/internal/api"
+	"pr-review-assigner/internal/storage"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestUserRepository_Unavailability(t *testing.T) {
+	repos := newTestRepos(t)
+	repos.seedTeam(t, "backend", "u1", "u2", "u3")
+	ctx := context.Background()
+	now := time.Now()
+
+	_, err := repos.users.CreateUnavailability(ctx, &api.UserUnavailability{UserId: "missing", StartsAt: now, EndsAt: now.Add(time.Hour)})
+	assert.ErrorIs(t, err, storage.ErrForeignKeyViolation)
+	_, err = repos.users.CreateUnavailability(ctx, &api.UserUnavailability{UserId: "u2", StartsAt: now, EndsAt: now})
+	assert.ErrorIs(t, err, storage.ErrCheckViolation)
+
+	current, err := repos.users.CreateUnavailability(ctx, &api.UserUnavailability{
+		UserId: "u2", StartsAt: now.Add(-time.Hour), EndsAt: now.Add(72 * time.Hour), Reason: "vacation", HandoffReviews: true,
+	})
+	require.NoError(t, err)
+	assert.Equal(t, "vacation", current.Reason)
+	assert.Nil(t, current.HandedOffAt)
+	past, err := repos.users.CreateUnavailability(ctx, &api.UserUnavailability{UserId: "u3", StartsAt: now.Add(-48 * time.Hour), EndsAt: now.Add(-24 * time.Hour)})
+	require.NoError(t, err)
+
+	// Пользователь в текущем периоде отсутствия не считается доступным
+	active, err := repos.users.GetActiveUsersByTeam(ctx, "backend", "u1", now)
+	require.NoError(t, err)
+	require.Len(t, active, 1)
+	assert.Equal(t, "u3", active[0].UserId)
+	// Доступность определяется на переданный момент, а не по часам хранилища
+	active, err = repos.users.GetActiveUsersByTeam(ctx, "backend", "u1", now.Add(-36*time.Hour))
+	require.NoError(t, err)
+	require.Len(t, active, 1)
+	assert.Equal(t, "u2", active[0].UserId)
+
+	periods, err := repos.users.ListUnavailability(ctx, &storage.UnavailabilityFilter{})
+	require.NoError(t, err)
+	require.Len(t, periods, 2)
+	assert.Equal(t, past.UnavailabilityId, periods[0].UnavailabilityId)
+	periods, err = repos.users.ListUnavailability(ctx, &storage.UnavailabilityFilter{EndsAfter: &now})
+	require.NoError(t, err)
+	require.Len(t, periods, 1)
+	assert.Equal(t, current.UnavailabilityId, periods[0].UnavailabilityId)
+	periods, err = repos.users.ListUnavailability(ctx, &storage.UnavailabilityFilter{UserID: "u3"})
+	require.NoError(t, err)
+	require.Len(t, periods, 1)
+
+	// Передача ревью отмечается один раз
+	pending, err := repos.users.GetPendingHandoffs(ctx, now)
+	require.NoError(t, err)
+	require.Len(t, pending, 1)
+	assert.Equal(t, current.UnavailabilityId, pending[0].UnavailabilityId)
+	marked, err := repos.users.MarkHandedOff(ctx, current.UnavailabilityId, now)
+	require.NoError(t, err)
+	assert.True(t, marked)
+	marked, err = repos.users.MarkHandedOff(ctx, current.UnavailabilityId, now)
+	require.NoError(t, err)
+	assert.False(t, marked)
+	pending, err = repos.users.GetPendingHandoffs(ctx, now)
+	require.NoError(t, err)
+	assert.Empty(t, pending)
+
+	// Изменение без переноса начала сохраняет отметку, перенос начала ее сбрасывает
+	current.Reason = "sick leave"
+	updated, err := repos.users.UpdateUnavailability(ctx, current)
+	require.NoError(t, err)
+	assert.Equal(t, "sick leave", updated.Reason)
+	assert.NotNil(t, updated.HandedOffAt)
+	current.StartsAt = now.Add(24 * time.Hour)
+	updated, err = repos.users.UpdateUnavailability(ctx, current)
+	require.NoError(t, err)
+	assert.Nil(t, updated.HandedOffAt)
+	fetched, err := repos.users.GetUnavailability(ctx, current.UnavailabilityId)
+	require.NoError(t, err)
+	assert.True(t, current.StartsAt.Equal(fetched.StartsAt))
+
+	active, err = repos.users.GetActiveUsersByTeam(ctx, "backend", "u1", now)
+	require.NoError(t, err)
+	assert.Len(t, active, 2)
+
+	_, err = repos.users.UpdateUnavailability(ctx, &api.UserUnavailability{UnavailabilityId: 999, StartsAt: now, EndsAt: now.Add(time.Hour)})
+	assert.ErrorIs(t, err, storage.ErrNotFound)
+	require.NoError(t, repos.users.DeleteUnavailability(ctx, past.UnavailabilityId))
+	assert.ErrorIs(t, repos.users.DeleteUnavailability(ctx, past.UnavailabilityId), storage.ErrNotFound)
+	_, err = repos.users.GetUnavailability(ctx, past.UnavailabilityId)
+	assert.ErrorIs(t, err, storage.ErrNotFound)
+}
@@ -0,0 +1,245 @@
+package sqlite
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	"pr-review-assigner/internal/api"
+	"pr-review-assigner/internal/storage"
+)
+
+// UserRepository предоставляет методы для работы с пользователями
+type UserRepository struct {
+	*Repository
+}
+
+// NewUserRepository создает новый экземпляр репозитория пользователей
+func NewUserRepository(repo *Repository) *UserRepository {
+	return &UserRepository{Repository: repo}
+}
+
+// CreateOrUpdateUser создает или обновляет пользователя
+func (r *UserRepository) CreateOrUpdateUser(ctx context.Context, user *api.User) error {
+	query := `
+		INSERT INTO users (user_id, username, team_name, is_active)
+		VALUES (?, ?, ?, ?)
+		ON CONFLICT (user_id)
+		DO UPDATE SET
+			username = excluded.username,
+			team_name = excluded.team_name,
+			is_active = excluded.is_active,
+			updated_at = CURRENT_TIMESTAMP
+	`
+	_, err := r.db.ExecContext(ctx, query, user.UserId, user.Username, user.TeamName, user.IsActive)
+	if err != nil {
+		return HandleDBError(err)
+	}
+	return nil
+}
+
+// GetUser получает пользователя по ID
+func (r *UserRepository) GetUser(ctx context.Context, userID string) (*api.User, error) {
+	query := `
+		SELECT user_id, username, team_name, is_active, timezone, working_hours_start, working_hours_end
+		FROM users
+		WHERE user_id = ?
+	`
+	var user api.User
+	err := r.db.QueryRowContext(ctx, query, userID).Scan(
+		&user.UserId,
+		&user.Username,
+		&user.TeamName,
+		&user.IsActive,
+		&user.Timezone,
+		&user.WorkingHoursStart,
+		&user.WorkingHoursEnd,
+	)
+	if err != nil {
+		return nil, HandleDBError(err)
+	}
+	return &user, nil
+}
+
+// UpdateUserIsActive обновляет флаг активности пользователя и возвращает обновленного пользователя
+func (r *UserRepository) UpdateUserIsActive(ctx context.Context, userID string, isActive bool) (*api.User, error) {
+	query := `
+		UPDATE users
+		SET is_active = ?, updated_at = CURRENT_TIMESTAMP
+		WHERE user_id = ?
+		RETURNING user_id, username, team_name, is_active, timezone, working_hours_start, working_hours_end
+	`
+	var user api.User
+	err := r.db.QueryRowContext(ctx, query, isActive, userID).Scan(
+		&user.UserId,
+		&user.Username,
+		&user.TeamName,
+		&user.IsActive,
+		&user.Timezone,
+		&user.WorkingHoursStart,
+		&user.WorkingHoursEnd,
+	)
+	if err != nil {
+		return nil, HandleDBError(err)
+	}
+
+	return &user, nil
+}
+
+// UpdateUserWorkingHours задает часовой пояс и рабочие часы пользователя; nil сбрасывает их
+func (r *UserRepository) UpdateUserWorkingHours(ctx context.Context, userID string, hours *storage.WorkingHours) (*api.User, error) {
+	query := `
+		UPDATE users
+		SET timezone = ?, working_hours_start = ?, working_hours_end = ?, updated_at = CURRENT_TIMESTAMP
+		WHERE user_id = ?
+		RETURNING user_id, username, team_name, is_active, timezone, working_hours_start, working_hours_end
+	`
+	var timezone, start, end sql.NullString
+	if hours != nil {
+		timezone = sql.NullString{String: hours.Timezone, Valid: true}
+		start = sql.NullString{String: hours.Start, Valid: true}
+		end = sql.NullString{String: hours.End, Valid: true}
+	}
+
+	var user api.User
+	err := r.db.QueryRowContext(ctx, query, timezone, start, end, userID).Scan(
+		&user.UserId,
+		&user.Username,
+		&user.TeamName,
+		&user.IsActive,
+		&user.Timezone,
+		&user.WorkingHoursStart,
+		&user.WorkingHoursEnd,
+	)
+	if err != nil {
+		return nil, HandleDBError(err)
+	}
+	return &user, nil
+}
+
+// GetActiveUsersByTeam получает список активных пользователей команды, исключая указанного пользователя
+// и пользователей, у которых в момент now идет период отсутствия
+func (r *UserRepository) GetActiveUsersByTeam(ctx context.Context, teamName string, excludeUserID string, now time.Time) ([]api.User, error) {
+	query := `
+		SELECT user_id, username, team_name, is_active, timezone, working_hours_start, working_hours_end
+		FROM users u
+		WHERE team_name = ? AND is_active = 1 AND user_id != ?
+			AND NOT EXISTS (
+				SELECT 1 FROM user_unavailability ua
+				WHERE ua.user_id = u.user_id
+					AND unixepoch(ua.starts_at, 'subsec') <= unixepoch(?, 'subsec')
+					AND unixepoch(ua.ends_at, 'subsec') > unixepoch(?, 'subsec')
+			)
+		ORDER BY user_id
+	`
+	return r.queryUsers(ctx, r.db, query, teamName, excludeUserID, now, now)
+}
+
+// BatchDeactivateUsers массово деактивирует указанных пользователей
+func (r *UserRepository) BatchDeactivateUsers(ctx context.Context, userIDs []string) ([]api.User, error) {
+	if len(userIDs) == 0 {
+		return []api.User{}, nil
+	}
+
+	placeholders, args := inClause(userIDs)
+	var users []api.User
+	err := r.inTx(ctx, func(tx dbtx) error {
+		query := `
+			UPDATE users
+			SET is_active = 0, updated_at = CURRENT_TIMESTAMP
+			WHERE user_id IN (` + placeholders + `)
+		`
+		if _, err := tx.ExecContext(ctx, query, args...); err != nil {
+			return HandleDBError(err)
+		}
+
+		// Порядок строк RETURNING в SQLite не определен, поэтому обновленных пользователей читаем отдельно
+		query = `
+			SELECT user_id, username, team_name, is_active, timezone, working_hours_start, working_hours_end
+			FROM users
+			WHERE user_id IN (` + placeholders + `)
+			ORDER BY user_id
+		`
+		var err error
+		users, err = r.queryUsers(ctx, tx, query, args...)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	return users, nil
+}
+
+// GetUsersByTeam получает всех пользователей команды (включая неактивных)
+func (r *UserRepository) GetUsersByTeam(ctx context.Context, teamName string) ([]api.User, error) {
+	query := `
+		SELECT user_id, username, team_name, is_active, timezone, working_hours_start, working_hours_end
+		FROM users
+		WHERE team_name = ?
+		ORDER BY user_id
+	`
+	return r.queryUsers(ctx, r.db, query, teamName)
+}
+
+// GetUserIDByLogin получает user_id по логину во внешней системе
+func (r *UserRepository) GetUserIDByLogin(ctx context.Context, provider string, login string) (string, error) {
+	query := `
+		SELECT user_id
+		FROM user_identities
+		WHERE provider = ? AND login = ?
+	`
+	var userID string
+	err := r.db.QueryRowContext(ctx, query, provider, login).Scan(&userID)
+	if err != nil {
+		return "", HandleDBError(err)
+	}
+	return userID, nil
+}
+
+// LinkLogin связывает логин во внешней системе с пользователем (перепривязывает, если связь уже есть)
+func (r *UserRepository) LinkLogin(ctx context.Context, provider string, login string, userID string) error {
+	query := `
+		INSERT INTO user_identities (provider, login, user_id)
+		VALUES (?, ?, ?)
+		ON CONFLICT (provider, login)
+		DO UPDATE SET user_id = excluded.user_id
+	`
+	_, err := r.db.ExecContext(ctx, query, provider, login, userID)
+	if err != nil {
+		return HandleDBError(err)
+	}
+	return nil
+}
+
+// queryUsers выполняет запрос, возвращающий user_id, username, team_name, is_active и рабочие часы
+func (r *UserRepository) queryUsers(ctx context.Context, db dbtx, query string, args ...any) ([]api.User, error) {
+	rows, err := db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, HandleDBError(err)
+	}
+	defer rows.Close()
+
+	var users []api.User
+	for rows.Next() {
+		var user api.User
+		err := rows.Scan(
+			&user.UserId,
+			&user.Username,
+			&user.TeamName,
+			&user.IsActive,
+			&user.Timezone,
+			&user.WorkingHoursStart,
+			&user.WorkingHoursEnd,
+		)
+		if err != nil {
+			return nil, HandleDBError(err)
+		}
+		users = append(users, user)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, HandleDBError(err)
+	}
+
+	return users, nil
+}
@@ -0,0 +1,109 @@
+package sqlite
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"pr-review-assigner/internal/api"
+	"pr-review-assigner/internal/storage"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestUserRepository_RequiresTeam(t *testing.T) {
+	repos := newTestRepos(t)
+
+	err := repos.users.CreateOrUpdateUser(context.Background(), &api.User{UserId: "u1", TeamName: "missing", IsActive: true})
+	assert.ErrorIs(t, err, storage.ErrForeignKeyViolation)
+}
+
+func TestUserRepository_UpsertMovesUser(t *testing.T) {
+	repos := newTestRepos(t)
+	repos.seedTeam(t, "backend", "u1")
+	repos.seedTeam(t, "frontend")
+	ctx := context.Background()
+
+	require.NoError(t, repos.users.CreateOrUpdateUser(ctx, &api.User{UserId: "u1", Username: "Alice", TeamName: "frontend", IsActive: true}))
+
+	backend, err := repos.users.GetUsersByTeam(ctx, "backend")
+	require.NoError(t, err)
+	assert.Empty(t, backend)
+	user, err := repos.users.GetUser(ctx, "u1")
+	require.NoError(t, err)
+	assert.Equal(t, "frontend", user.TeamName)
+	assert.Equal(t, "Alice", user.Username)
+}
+
+func TestUserRepository_ActiveUsersAndDeactivation(t *testing.T) {
+	repos := newTestRepos(t)
+	repos.seedTeam(t, "backend", "u1", "u2", "u3")
+	ctx := context.Background()
+
+	_, err := repos.users.UpdateUserIsActive(ctx, "u3", false)
+	require.NoError(t, err)
+	_, err = repos.users.UpdateUserIsActive(ctx, "missing", false)
+	assert.ErrorIs(t, err, storage.ErrNotFound)
+
+	active, err := repos.users.GetActiveUsersByTeam(ctx, "backend", "u1", time.Now())
+	require.NoError(t, err)
+	require.Len(t, active, 1)
+	assert.Equal(t, "u2", active[0].UserId)
+
+	deactivated, err := repos.users.BatchDeactivateUsers(ctx, []string{"u2", "missing", "u1"})
+	require.NoError(t, err)
+	require.Len(t, deactivated, 2)
+	assert.Equal(t, "u1", deactivated[0].UserId)
+	assert.Equal(t, "u2", deactivated[1].UserId)
+	assert.False(t, deactivated[0].IsActive)
+}
+
+func TestUserRepository_LinkLogin(t *testing.T) {
+	repos := newTestRepos(t)
+	repos.seedTeam(t, "backend", "u1", "u2")
+	ctx := context.Background()
+
+	assert.ErrorIs(t, repos.users.LinkLogin(ctx, "github", "alice", "missing"), storage.ErrForeignKeyViolation)
+
+	require.NoError(t, repos.users.LinkLogin(ctx, "github", "alice", "u1"))
+	require.NoError(t, repos.users.LinkLogin(ctx, "github", "alice", "u2"))
+	userID, err := repos.users.GetUserIDByLogin(ctx, "github", "alice")
+	require.NoError(t, err)
+	assert.Equal(t, "u2", userID)
+
+	_, err = repos.users.GetUserIDByLogin(ctx, "gitlab", "alice")
+	assert.ErrorIs(t, err, storage.ErrNotFound)
+}
+
+func TestUserRepository_WorkingHours(t *testing.T) {
+	repos := newTestRepos(t)
+	repos.seedTeam(t, "backend", "u1", "u2")
+	ctx := context.Background()
+
+	_, err := repos.users.UpdateUserWorkingHours(ctx, "missing", nil)
+	assert.ErrorIs(t, err, storage.ErrNotFound)
+
+	user, err := repos.users.UpdateUserWorkingHours(ctx, "u2", &storage.WorkingHours{Timezone: "America/Los_Angeles", Start: "09:00", End: "18:00"})
+	require.NoError(t, err)
+	require.NotNil(t, user.Timezone)
+	assert.Equal(t, "America/Los_Angeles", *user.Timezone)
+
+	// Обновление команды не сбрасывает рабочие часы
+	require.NoError(t, repos.users.CreateOrUpdateUser(ctx, &api.User{UserId: "u2", Username: "Bob", TeamName: "backend", IsActive: true}))
+	active, err := repos.users.GetActiveUsersByTeam(ctx, "backend", "u1", time.Now())
+	require.NoError(t, err)
+	require.Len(t, active, 1)
+	require.NotNil(t, active[0].WorkingHoursStart)
+	require.NotNil(t, active[0].WorkingHoursEnd)
+	assert.Equal(t, "09:00", *active[0].WorkingHoursStart)
+	assert.Equal(t, "18:00", *active[0].WorkingHoursEnd)
+
+	user, err = repos.users.UpdateUserWorkingHours(ctx, "u2", nil)
+	require.NoError(t, err)
+	assert.Nil(t, user.Timezone)
+	user, err = repos.users.GetUser(ctx, "u2")
+	require.NoError(t, err)
+	assert.Nil(t, user.WorkingHoursStart)
+	assert.Nil(t, user.WorkingHoursEnd)
+}
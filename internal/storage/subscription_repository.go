@@ -0,0 +1,238 @@
+package storage
+
+import (
+	"context"
+
+	"pr-review-assigner/internal/events"
+
+	"github.com/lib/pq"
+)
+
+// SubscriptionRepository предоставляет методы для работы с подписками на события и журналом доставок
+type SubscriptionRepository struct {
+	*Repository
+}
+
+// NewSubscriptionRepository создает новый экземпляр репозитория подписок
+func NewSubscriptionRepository(repo *Repository) *SubscriptionRepository {
+	return &SubscriptionRepository{Repository: repo}
+}
+
+const subscriptionColumns = `subscription_id, url, secret, event_types, is_active, created_at`
+
+// scanSubscription читает подписку из строки результата
+func scanSubscription(row rowScanner) (*events.Subscription, error) {
+	var subscription events.Subscription
+	var eventTypes pq.StringArray
+	err := row.Scan(
+		&subscription.ID,
+		&subscription.URL,
+		&subscription.Secret,
+		&eventTypes,
+		&subscription.IsActive,
+		&subscription.CreatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+	subscription.EventTypes = make([]events.Type, 0, len(eventTypes))
+	for _, t := range eventTypes {
+		subscription.EventTypes = append(subscription.EventTypes, events.Type(t))
+	}
+	return &subscription, nil
+}
+
+// CreateSubscription создает подписку
+func (r *SubscriptionRepository) CreateSubscription(ctx context.Context, subscription *events.Subscription) (*events.Subscription, error) {
+	eventTypes := make([]string, 0, len(subscription.EventTypes))
+	for _, t := range subscription.EventTypes {
+		eventTypes = append(eventTypes, string(t))
+	}
+
+	query := `
+		INSERT INTO webhook_subscriptions (url, secret, event_types)
+		VALUES ($1, $2, $3)
+		RETURNING ` + subscriptionColumns
+	created, err := scanSubscription(r.db.QueryRowContext(ctx, query, subscription.URL, subscription.Secret, pq.Array(eventTypes)))
+	if err != nil {
+		return nil, HandleDBError(err)
+	}
+	return created, nil
+}
+
+// ListSubscriptions получает все подписки
+func (r *SubscriptionRepository) ListSubscriptions(ctx context.Context) ([]events.Subscription, error) {
+	query := `SELECT ` + subscriptionColumns + ` FROM webhook_subscriptions ORDER BY subscription_id`
+	return r.querySubscriptions(ctx, query)
+}
+
+// GetSubscriptionsForEvent получает активные подписки на указанный тип события
+func (r *SubscriptionRepository) GetSubscriptionsForEvent(ctx context.Context, eventType events.Type) ([]events.Subscription, error) {
+	query := `
+		SELECT ` + subscriptionColumns + `
+		FROM webhook_subscriptions
+		WHERE is_active = TRUE
+		  AND (cardinality(event_types) = 0 OR $1 = ANY(event_types))
+		ORDER BY subscription_id
+	`
+	return r.querySubscriptions(ctx, query, string(eventType))
+}
+
+func (r *SubscriptionRepository) querySubscriptions(ctx context.Context, query string, args ...interface{}) ([]events.Subscription, error) {
+	rows, err := r.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, HandleDBError(err)
+	}
+	defer rows.Close()
+
+	subscriptions := []events.Subscription{}
+	for rows.Next() {
+		subscription, err := scanSubscription(rows)
+		if err != nil {
+			return nil, HandleDBError(err)
+		}
+		subscriptions = append(subscriptions, *subscription)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, HandleDBError(err)
+	}
+
+	return subscriptions, nil
+}
+
+// DeleteSubscription удаляет подписку вместе с журналом ее доставок
+func (r *SubscriptionRepository) DeleteSubscription(ctx context.Context, subscriptionID int64) error {
+	result, err := r.db.ExecContext(ctx, `DELETE FROM webhook_subscriptions WHERE subscription_id = $1`, subscriptionID)
+	if err != nil {
+		return HandleDBError(err)
+	}
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return HandleDBError(err)
+	}
+	if affected == 0 {
+		return ErrNotFound
+	}
+	return nil
+}
+
+// SubscriptionExists проверяет существование подписки
+func (r *SubscriptionRepository) SubscriptionExists(ctx context.Context, subscriptionID int64) (bool, error) {
+	var exists bool
+	err := r.db.QueryRowContext(ctx, `SELECT EXISTS(SELECT 1 FROM webhook_subscriptions WHERE subscription_id = $1)`, subscriptionID).Scan(&exists)
+	if err != nil {
+		return false, HandleDBError(err)
+	}
+	return exists, nil
+}
+
+// LogDelivery записывает попытку доставки события в журнал
+func (r *SubscriptionRepository) LogDelivery(ctx context.Context, delivery *events.Delivery) error {
+	query := `
+		INSERT INTO webhook_deliveries (subscription_id, event_id, event_type, attempt, status_code, error, success, delivered_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8)
+		RETURNING delivery_id
+	`
+	err := r.db.QueryRowContext(ctx, query,
+		delivery.SubscriptionID,
+		delivery.EventID,
+		string(delivery.EventType),
+		delivery.Attempt,
+		delivery.StatusCode,
+		delivery.Error,
+		delivery.Success,
+		delivery.DeliveredAt,
+	).Scan(&delivery.ID)
+	if err != nil {
+		return HandleDBError(err)
+	}
+	return nil
+}
+
+// ListDeliveries получает последние попытки доставки по подписке (новые первыми)
+func (r *SubscriptionRepository) ListDeliveries(ctx context.Context, subscriptionID int64, limit int) ([]events.Delivery, error) {
+	query := `
+		SELECT delivery_id, subscription_id, event_id, event_type, attempt, status_code, error, success, delivered_at
+		FROM webhook_deliveries
+		WHERE subscription_id = $1
+		ORDER BY delivery_id DESC
+		LIMIT $2
+	`
+	rows, err := r.db.QueryContext(ctx, query, subscriptionID, limit)
+	if err != nil {
+		return nil, HandleDBError(err)
+	}
+	defer rows.Close()
+
+	deliveries := []events.Delivery{}
+	for rows.Next() {
+		var delivery events.Delivery
+		var eventType string
+		err := rows.Scan(
+			&delivery.ID,
+			&delivery.SubscriptionID,
+			&delivery.EventID,
+			&eventType,
+			&delivery.Attempt,
+			&delivery.StatusCode,
+			&delivery.Error,
+			&delivery.Success,
+			&delivery.DeliveredAt,
+		)
+		if err != nil {
+			return nil, HandleDBError(err)
+		}
+		delivery.EventType = events.Type(eventType)
+		deliveries = append(deliveries, delivery)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, HandleDBError(err)
+	}
+
+	return deliveries, nil
+}
+
+// ListEventDeliveries получает все попытки доставки события в порядке записи
+func (r *SubscriptionRepository) ListEventDeliveries(ctx context.Context, eventID string) ([]events.Delivery, error) {
+	query := `
+		SELECT delivery_id, subscription_id, event_id, event_type, attempt, status_code, error, success, delivered_at
+		FROM webhook_deliveries
+		WHERE event_id = $1
+		ORDER BY delivery_id
+	`
+	rows, err := r.db.QueryContext(ctx, query, eventID)
+	if err != nil {
+		return nil, HandleDBError(err)
+	}
+	defer rows.Close()
+
+	deliveries := []events.Delivery{}
+	for rows.Next() {
+		var delivery events.Delivery
+		var eventType string
+		err := rows.Scan(
+			&delivery.ID,
+			&delivery.SubscriptionID,
+			&delivery.EventID,
+			&eventType,
+			&delivery.Attempt,
+			&delivery.StatusCode,
+			&delivery.Error,
+			&delivery.Success,
+			&delivery.DeliveredAt,
+		)
+		if err != nil {
+			return nil, HandleDBError(err)
+		}
+		delivery.EventType = events.Type(eventType)
+		deliveries = append(deliveries, delivery)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, HandleDBError(err)
+	}
+
+	return deliveries, nil
+}
@@ -1,6 +1,9 @@
 package storage
 
 import (
+	"context"
+	"time"
+
 	"pr-review-assigner/internal/api"
 )
 
@@ -15,9 +18,9 @@ func NewTeamRepository(repo *Repository) *TeamRepository {
 }
 
 // CreateTeam создает новую команду
-func (r *TeamRepository) CreateTeam(teamName string) error {
+func (r *TeamRepository) CreateTeam(ctx context.Context, teamName string) error {
 	query := `INSERT INTO teams (team_name) VALUES ($1)`
-	_, err := r.db.Exec(query, teamName)
+	_, err := r.db.ExecContext(ctx, query, teamName)
 	if err != nil {
 		return HandleDBError(err)
 	}
@@ -25,16 +28,11 @@ func (r *TeamRepository) CreateTeam(teamName string) error {
 }
 
 // GetTeam получает команду с участниками по имени
-func (r *TeamRepository) GetTeam(teamName string) (*api.Team, error) {
-	// Сначала проверяем существование команды
-	var exists bool
-	checkQuery := `SELECT EXISTS(SELECT 1 FROM teams WHERE team_name = $1)`
-	err := r.db.QueryRow(checkQuery, teamName).Scan(&exists)
+func (r *TeamRepository) GetTeam(ctx context.Context, teamName string) (*api.Team, error) {
+	// Сначала получаем настройки команды (заодно проверяем ее существование)
+	settings, err := r.GetTeamSettings(ctx, teamName)
 	if err != nil {
-		return nil, HandleDBError(err)
-	}
-	if !exists {
-		return nil, ErrNotFound
+		return nil, err
 	}
 
 	// Получаем участников команды
@@ -44,7 +42,7 @@ func (r *TeamRepository) GetTeam(teamName string) (*api.Team, error) {
 		WHERE team_name = $1
 		ORDER BY user_id
 	`
-	rows, err := r.db.Query(query, teamName)
+	rows, err := r.db.QueryContext(ctx, query, teamName)
 	if err != nil {
 		return nil, HandleDBError(err)
 	}
@@ -64,19 +62,87 @@ func (r *TeamRepository) GetTeam(teamName string) (*api.Team, error) {
 		return nil, HandleDBError(err)
 	}
 
+	reviewSLASeconds := int(settings.ReviewSLA / time.Second)
+	autoReassignAfterSeconds := int(settings.AutoReassignAfter / time.Second)
+	fallbackPools := append([]string{}, settings.FallbackPools...)
 	return &api.Team{
-		TeamName: teamName,
-		Members:  members,
+		TeamName:          teamName,
+		Members:           members,
+		MinReviewers:      &settings.MinReviewers,
+		MaxReviewers:      &settings.MaxReviewers,
+		RequiredApprovals: &settings.RequiredApprovals,
+		ReviewSlaSeconds:  &reviewSLASeconds,
+
+		AutoReassignAfterSeconds: &autoReassignAfterSeconds,
+		MaxAutoReassignments:     &settings.MaxAutoReassignments,
+		FallbackPools:            &fallbackPools,
 	}, nil
 }
 
 // TeamExists проверяет существование команды
-func (r *TeamRepository) TeamExists(teamName string) (bool, error) {
+func (r *TeamRepository) TeamExists(ctx context.Context, teamName string) (bool, error) {
 	var exists bool
 	query := `SELECT EXISTS(SELECT 1 FROM teams WHERE team_name = $1)`
-	err := r.db.QueryRow(query, teamName).Scan(&exists)
+	err := r.db.QueryRowContext(ctx, query, teamName).Scan(&exists)
 	if err != nil {
 		return false, HandleDBError(err)
 	}
 	return exists, nil
 }
+
+// GetTeamSettings получает настройки назначения ревьюверов команды
+func (r *TeamRepository) GetTeamSettings(ctx context.Context, teamName string) (*TeamSettings, error) {
+	query := `
+		SELECT team_name, min_reviewers, max_reviewers, required_approvals, review_sla_seconds,
+			auto_reassign_after_seconds, max_auto_reassignments
+		FROM teams
+		WHERE team_name = $1
+	`
+	var settings TeamSettings
+	var reviewSLASeconds, autoReassignAfterSeconds int64
+	err := r.db.QueryRowContext(ctx, query, teamName).Scan(
+		&settings.TeamName,
+		&settings.MinReviewers,
+		&settings.MaxReviewers,
+		&settings.RequiredApprovals,
+		&reviewSLASeconds,
+		&autoReassignAfterSeconds,
+		&settings.MaxAutoReassignments,
+	)
+	if err != nil {
+		return nil, HandleDBError(err)
+	}
+	settings.ReviewSLA = time.Duration(reviewSLASeconds) * time.Second
+	settings.AutoReassignAfter = time.Duration(autoReassignAfterSeconds) * time.Second
+
+	if settings.FallbackPools, err = getFallbackPools(ctx, r.db, teamName); err != nil {
+		return nil, err
+	}
+	return &settings, nil
+}
+
+// UpdateTeamSettings обновляет настройки назначения ревьюверов команды и заменяет ее резервные пулы
+func (r *TeamRepository) UpdateTeamSettings(ctx context.Context, settings *TeamSettings) error {
+	query := `
+		UPDATE teams
+		SET min_reviewers = $1, max_reviewers = $2, required_approvals = $3, review_sla_seconds = $4,
+			auto_reassign_after_seconds = $5, max_auto_reassignments = $6
+		WHERE team_name = $7
+	`
+	return r.inTx(ctx, func(tx dbtx) error {
+		result, err := tx.ExecContext(ctx, query, settings.MinReviewers, settings.MaxReviewers, settings.RequiredApprovals,
+			int64(settings.ReviewSLA/time.Second), int64(settings.AutoReassignAfter/time.Second), settings.MaxAutoReassignments, settings.TeamName)
+		if err != nil {
+			return HandleDBError(err)
+		}
+
+		affected, err := result.RowsAffected()
+		if err != nil {
+			return HandleDBError(err)
+		}
+		if affected == 0 {
+			return ErrNotFound
+		}
+		return replaceFallbackPools(ctx, tx, settings.TeamName, settings.FallbackPools)
+	})
+}
@@ -0,0 +1,55 @@
+package storage
+
+import (
+	"context"
+	"database/sql"
+)
+
+// Repositories набор репозиториев, работающих в одной транзакции
+type Repositories struct {
+	Teams TeamRepositoryInterface
+	Users UserRepositoryInterface
+	PRs   PRRepositoryInterface
+}
+
+// TxManager выполняет операции над несколькими репозиториями как единое целое
+type TxManager interface {
+	// WithTx выполняет fn в транзакции: если fn вернула ошибку, все изменения откатываются
+	WithTx(ctx context.Context, fn func(repos Repositories) error) error
+}
+
+// SQLTxManager реализует TxManager поверх транзакций БД
+type SQLTxManager struct {
+	db *sql.DB
+}
+
+// NewTxManager создает новый экземпляр менеджера транзакций
+func NewTxManager(db *sql.DB) *SQLTxManager {
+	return &SQLTxManager{db: db}
+}
+
+// WithTx выполняет fn в транзакции БД
+// Репозитории внутри fn используют эту транзакцию, в том числе для собственных
+// многошаговых операций (например, BatchReassignReviewers)
+func (m *SQLTxManager) WithTx(ctx context.Context, fn func(repos Repositories) error) error {
+	tx, err := m.db.BeginTx(ctx, nil)
+	if err != nil {
+		return HandleDBError(err)
+	}
+	defer tx.Rollback()
+
+	repo := &Repository{db: tx}
+	repos := Repositories{
+		Teams: NewTeamRepository(repo),
+		Users: NewUserRepository(repo),
+		PRs:   NewPRRepository(repo),
+	}
+	if err = fn(repos); err != nil {
+		return err
+	}
+
+	if err = tx.Commit(); err != nil {
+		return HandleDBError(err)
+	}
+	return nil
+}
@@ -0,0 +1,158 @@
+package storage
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"pr-review-assigner/internal/api"
+)
+
+const unavailabilityColumns = `unavailability_id, user_id, starts_at, ends_at, reason, handoff_reviews, handed_off_at`
+
+// scanUnavailability читает период отсутствия из строки результата
+func scanUnavailability(row rowScanner) (*api.UserUnavailability, error) {
+	var period api.UserUnavailability
+	err := row.Scan(
+		&period.UnavailabilityId,
+		&period.UserId,
+		&period.StartsAt,
+		&period.EndsAt,
+		&period.Reason,
+		&period.HandoffReviews,
+		&period.HandedOffAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+	return &period, nil
+}
+
+// CreateUnavailability создает период отсутствия пользователя
+func (r *UserRepository) CreateUnavailability(ctx context.Context, period *api.UserUnavailability) (*api.UserUnavailability, error) {
+	query := `
+		INSERT INTO user_unavailability (user_id, starts_at, ends_at, reason, handoff_reviews)
+		VALUES ($1, $2, $3, $4, $5)
+		RETURNING ` + unavailabilityColumns
+	created, err := scanUnavailability(r.db.QueryRowContext(ctx, query,
+		period.UserId, period.StartsAt, period.EndsAt, period.Reason, period.HandoffReviews))
+	if err != nil {
+		return nil, HandleDBError(err)
+	}
+	return created, nil
+}
+
+// GetUnavailability получает период отсутствия по ID
+func (r *UserRepository) GetUnavailability(ctx context.Context, unavailabilityID int64) (*api.UserUnavailability, error) {
+	query := `SELECT ` + unavailabilityColumns + ` FROM user_unavailability WHERE unavailability_id = $1`
+	period, err := scanUnavailability(r.db.QueryRowContext(ctx, query, unavailabilityID))
+	if err != nil {
+		return nil, HandleDBError(err)
+	}
+	return period, nil
+}
+
+// ListUnavailability получает периоды отсутствия, упорядоченные по началу
+func (r *UserRepository) ListUnavailability(ctx context.Context, filter *UnavailabilityFilter) ([]api.UserUnavailability, error) {
+	var args []any
+	arg := func(value any) string {
+		args = append(args, value)
+		return fmt.Sprintf("$%d", len(args))
+	}
+	conditions := []string{"TRUE"}
+	if filter.UserID != "" {
+		conditions = append(conditions, "user_id = "+arg(filter.UserID))
+	}
+	if filter.EndsAfter != nil {
+		conditions = append(conditions, "ends_at > "+arg(*filter.EndsAfter))
+	}
+
+	query := `
+		SELECT ` + unavailabilityColumns + `
+		FROM user_unavailability
+		WHERE ` + strings.Join(conditions, " AND ") + `
+		ORDER BY starts_at, unavailability_id`
+	return r.queryUnavailability(ctx, query, args...)
+}
+
+// UpdateUnavailability изменяет период отсутствия
+// При переносе начала отметка о передаче ревью сбрасывается, чтобы передать их в новое начало
+func (r *UserRepository) UpdateUnavailability(ctx context.Context, period *api.UserUnavailability) (*api.UserUnavailability, error) {
+	query := `
+		UPDATE user_unavailability
+		SET starts_at = $1, ends_at = $2, reason = $3, handoff_reviews = $4,
+			handed_off_at = CASE WHEN starts_at = $1 THEN handed_off_at END
+		WHERE unavailability_id = $5
+		RETURNING ` + unavailabilityColumns
+	updated, err := scanUnavailability(r.db.QueryRowContext(ctx, query,
+		period.StartsAt, period.EndsAt, period.Reason, period.HandoffReviews, period.UnavailabilityId))
+	if err != nil {
+		return nil, HandleDBError(err)
+	}
+	return updated, nil
+}
+
+// DeleteUnavailability удаляет период отсутствия
+func (r *UserRepository) DeleteUnavailability(ctx context.Context, unavailabilityID int64) error {
+	result, err := r.db.ExecContext(ctx, `DELETE FROM user_unavailability WHERE unavailability_id = $1`, unavailabilityID)
+	if err != nil {
+		return HandleDBError(err)
+	}
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return HandleDBError(err)
+	}
+	if affected == 0 {
+		return ErrNotFound
+	}
+	return nil
+}
+
+// GetPendingHandoffs получает текущие периоды с handoff_reviews, по которым ревью еще не переданы
+func (r *UserRepository) GetPendingHandoffs(ctx context.Context, now time.Time) ([]api.UserUnavailability, error) {
+	query := `
+		SELECT ` + unavailabilityColumns + `
+		FROM user_unavailability
+		WHERE handoff_reviews AND handed_off_at IS NULL AND starts_at <= $1 AND ends_at > $1
+		ORDER BY starts_at, unavailability_id`
+	return r.queryUnavailability(ctx, query, now)
+}
+
+// MarkHandedOff отмечает передачу ревью по периоду
+// Условие handed_off_at IS NULL не дает нескольким экземплярам сервиса отметить период дважды
+func (r *UserRepository) MarkHandedOff(ctx context.Context, unavailabilityID int64, at time.Time) (bool, error) {
+	query := `UPDATE user_unavailability SET handed_off_at = $1 WHERE unavailability_id = $2 AND handed_off_at IS NULL`
+	result, err := r.db.ExecContext(ctx, query, at, unavailabilityID)
+	if err != nil {
+		return false, HandleDBError(err)
+	}
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return false, HandleDBError(err)
+	}
+	return affected > 0, nil
+}
+
+func (r *UserRepository) queryUnavailability(ctx context.Context, query string, args ...any) ([]api.UserUnavailability, error) {
+	rows, err := r.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, HandleDBError(err)
+	}
+	defer rows.Close()
+
+	periods := []api.UserUnavailability{}
+	for rows.Next() {
+		period, err := scanUnavailability(rows)
+		if err != nil {
+			return nil, HandleDBError(err)
+		}
+		periods = append(periods, *period)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, HandleDBError(err)
+	}
+
+	return periods, nil
+}
@@ -1,6 +1,10 @@
 package storage
 
 import (
+	"context"
+	"database/sql"
+	"time"
+
 	"pr-review-assigner/internal/api"
 
 	"github.com/lib/pq"
@@ -17,7 +21,7 @@ func NewUserRepository(repo *Repository) *UserRepository {
 }
 
 // CreateOrUpdateUser создает или обновляет пользователя
-func (r *UserRepository) CreateOrUpdateUser(user *api.User) error {
+func (r *UserRepository) CreateOrUpdateUser(ctx context.Context, user *api.User) error {
 	query := `
 		INSERT INTO users (user_id, username, team_name, is_active)
 		VALUES ($1, $2, $3, $4)
@@ -28,7 +32,7 @@ func (r *UserRepository) CreateOrUpdateUser(user *api.User) error {
 			is_active = EXCLUDED.is_active,
 			updated_at = CURRENT_TIMESTAMP
 	`
-	_, err := r.db.Exec(query, user.UserId, user.Username, user.TeamName, user.IsActive)
+	_, err := r.db.ExecContext(ctx, query, user.UserId, user.Username, user.TeamName, user.IsActive)
 	if err != nil {
 		return HandleDBError(err)
 	}
@@ -36,18 +40,21 @@ func (r *UserRepository) CreateOrUpdateUser(user *api.User) error {
 }
 
 // GetUser получает пользователя по ID
-func (r *UserRepository) GetUser(userID string) (*api.User, error) {
+func (r *UserRepository) GetUser(ctx context.Context, userID string) (*api.User, error) {
 	query := `
-		SELECT user_id, username, team_name, is_active
+		SELECT user_id, username, team_name, is_active, timezone, working_hours_start, working_hours_end
 		FROM users
 		WHERE user_id = $1
 	`
 	var user api.User
-	err := r.db.QueryRow(query, userID).Scan(
+	err := r.db.QueryRowContext(ctx, query, userID).Scan(
 		&user.UserId,
 		&user.Username,
 		&user.TeamName,
 		&user.IsActive,
+		&user.Timezone,
+		&user.WorkingHoursStart,
+		&user.WorkingHoursEnd,
 	)
 	if err != nil {
 		return nil, HandleDBError(err)
@@ -56,19 +63,22 @@ func (r *UserRepository) GetUser(userID string) (*api.User, error) {
 }
 
 // UpdateUserIsActive обновляет флаг активности пользователя и возвращает обновленного пользователя
-func (r *UserRepository) UpdateUserIsActive(userID string, isActive bool) (*api.User, error) {
+func (r *UserRepository) UpdateUserIsActive(ctx context.Context, userID string, isActive bool) (*api.User, error) {
 	query := `
 		UPDATE users
 		SET is_active = $1, updated_at = CURRENT_TIMESTAMP
 		WHERE user_id = $2
-		RETURNING user_id, username, team_name, is_active
+		RETURNING user_id, username, team_name, is_active, timezone, working_hours_start, working_hours_end
 	`
 	var user api.User
-	err := r.db.QueryRow(query, isActive, userID).Scan(
+	err := r.db.QueryRowContext(ctx, query, isActive, userID).Scan(
 		&user.UserId,
 		&user.Username,
 		&user.TeamName,
 		&user.IsActive,
+		&user.Timezone,
+		&user.WorkingHoursStart,
+		&user.WorkingHoursEnd,
 	)
 	if err != nil {
 		return nil, HandleDBError(err)
@@ -77,15 +87,52 @@ func (r *UserRepository) UpdateUserIsActive(userID string, isActive bool) (*api.
 	return &user, nil
 }
 
+// UpdateUserWorkingHours задает часовой пояс и рабочие часы пользователя; nil сбрасывает их
+func (r *UserRepository) UpdateUserWorkingHours(ctx context.Context, userID string, hours *WorkingHours) (*api.User, error) {
+	query := `
+		UPDATE users
+		SET timezone = $1, working_hours_start = $2, working_hours_end = $3, updated_at = CURRENT_TIMESTAMP
+		WHERE user_id = $4
+		RETURNING user_id, username, team_name, is_active, timezone, working_hours_start, working_hours_end
+	`
+	var timezone, start, end sql.NullString
+	if hours != nil {
+		timezone = sql.NullString{String: hours.Timezone, Valid: true}
+		start = sql.NullString{String: hours.Start, Valid: true}
+		end = sql.NullString{String: hours.End, Valid: true}
+	}
+
+	var user api.User
+	err := r.db.QueryRowContext(ctx, query, timezone, start, end, userID).Scan(
+		&user.UserId,
+		&user.Username,
+		&user.TeamName,
+		&user.IsActive,
+		&user.Timezone,
+		&user.WorkingHoursStart,
+		&user.WorkingHoursEnd,
+	)
+	if err != nil {
+		return nil, HandleDBError(err)
+	}
+	return &user, nil
+}
+
 // GetActiveUsersByTeam получает список активных пользователей команды, исключая указанного пользователя
-func (r *UserRepository) GetActiveUsersByTeam(teamName string, excludeUserID string) ([]api.User, error) {
+// и пользователей, у которых в момент now идет период отсутствия
+// Периоды хранятся в UTC, поэтому now сравнивается с ними в UTC
+func (r *UserRepository) GetActiveUsersByTeam(ctx context.Context, teamName string, excludeUserID string, now time.Time) ([]api.User, error) {
 	query := `
-		SELECT user_id, username, team_name, is_active
-		FROM users
+		SELECT user_id, username, team_name, is_active, timezone, working_hours_start, working_hours_end
+		FROM users u
 		WHERE team_name = $1 AND is_active = true AND user_id != $2
+			AND NOT EXISTS (
+				SELECT 1 FROM user_unavailability ua
+				WHERE ua.user_id = u.user_id AND ua.starts_at <= $3 AND ua.ends_at > $3
+			)
 		ORDER BY user_id
 	`
-	rows, err := r.db.Query(query, teamName, excludeUserID)
+	rows, err := r.db.QueryContext(ctx, query, teamName, excludeUserID, now.UTC())
 	if err != nil {
 		return nil, HandleDBError(err)
 	}
@@ -99,6 +146,9 @@ func (r *UserRepository) GetActiveUsersByTeam(teamName string, excludeUserID str
 			&user.Username,
 			&user.TeamName,
 			&user.IsActive,
+			&user.Timezone,
+			&user.WorkingHoursStart,
+			&user.WorkingHoursEnd,
 		)
 		if err != nil {
 			return nil, HandleDBError(err)
@@ -114,7 +164,7 @@ func (r *UserRepository) GetActiveUsersByTeam(teamName string, excludeUserID str
 }
 
 // BatchDeactivateUsers массово деактивирует указанных пользователей
-func (r *UserRepository) BatchDeactivateUsers(userIDs []string) ([]api.User, error) {
+func (r *UserRepository) BatchDeactivateUsers(ctx context.Context, userIDs []string) ([]api.User, error) {
 	if len(userIDs) == 0 {
 		return []api.User{}, nil
 	}
@@ -123,9 +173,9 @@ func (r *UserRepository) BatchDeactivateUsers(userIDs []string) ([]api.User, err
 		UPDATE users
 		SET is_active = false, updated_at = CURRENT_TIMESTAMP
 		WHERE user_id = ANY($1)
-		RETURNING user_id, username, team_name, is_active
+		RETURNING user_id, username, team_name, is_active, timezone, working_hours_start, working_hours_end
 	`
-	rows, err := r.db.Query(query, pq.Array(userIDs))
+	rows, err := r.db.QueryContext(ctx, query, pq.Array(userIDs))
 	if err != nil {
 		return nil, HandleDBError(err)
 	}
@@ -139,6 +189,9 @@ func (r *UserRepository) BatchDeactivateUsers(userIDs []string) ([]api.User, err
 			&user.Username,
 			&user.TeamName,
 			&user.IsActive,
+			&user.Timezone,
+			&user.WorkingHoursStart,
+			&user.WorkingHoursEnd,
 		)
 		if err != nil {
 			return nil, HandleDBError(err)
@@ -154,14 +207,14 @@ func (r *UserRepository) BatchDeactivateUsers(userIDs []string) ([]api.User, err
 }
 
 // GetUsersByTeam получает всех пользователей команды (включая неактивных)
-func (r *UserRepository) GetUsersByTeam(teamName string) ([]api.User, error) {
+func (r *UserRepository) GetUsersByTeam(ctx context.Context, teamName string) ([]api.User, error) {
 	query := `
-		SELECT user_id, username, team_name, is_active
+		SELECT user_id, username, team_name, is_active, timezone, working_hours_start, working_hours_end
 		FROM users
 		WHERE team_name = $1
 		ORDER BY user_id
 	`
-	rows, err := r.db.Query(query, teamName)
+	rows, err := r.db.QueryContext(ctx, query, teamName)
 	if err != nil {
 		return nil, HandleDBError(err)
 	}
@@ -175,6 +228,9 @@ func (r *UserRepository) GetUsersByTeam(teamName string) ([]api.User, error) {
 			&user.Username,
 			&user.TeamName,
 			&user.IsActive,
+			&user.Timezone,
+			&user.WorkingHoursStart,
+			&user.WorkingHoursEnd,
 		)
 		if err != nil {
 			return nil, HandleDBError(err)
@@ -189,3 +245,32 @@ func (r *UserRepository) GetUsersByTeam(teamName string) ([]api.User, error) {
 	return users, nil
 }
 
+// GetUserIDByLogin получает user_id по логину во внешней системе
+func (r *UserRepository) GetUserIDByLogin(ctx context.Context, provider string, login string) (string, error) {
+	query := `
+		SELECT user_id
+		FROM user_identities
+		WHERE provider = $1 AND login = $2
+	`
+	var userID string
+	err := r.db.QueryRowContext(ctx, query, provider, login).Scan(&userID)
+	if err != nil {
+		return "", HandleDBError(err)
+	}
+	return userID, nil
+}
+
+// LinkLogin связывает логин во внешней системе с пользователем (перепривязывает, если связь уже есть)
+func (r *UserRepository) LinkLogin(ctx context.Context, provider string, login string, userID string) error {
+	query := `
+		INSERT INTO user_identities (provider, login, user_id)
+		VALUES ($1, $2, $3)
+		ON CONFLICT (provider, login)
+		DO UPDATE SET user_id = EXCLUDED.user_id
+	`
+	_, err := r.db.ExecContext(ctx, query, provider, login, userID)
+	if err != nil {
+		return HandleDBError(err)
+	}
+	return nil
+}
@@ -27,7 +27,7 @@ CREATE TABLE pull_requests (
 );
 
 -- Создание таблицы связей PR и ревьюверов (многие-ко-многим)
--- Ограничение на максимум 2 ревьювера будет проверяться на уровне приложения
+-- Ограничение на количество ревьюверов задается настройками команды (см. миграцию 000002)
 CREATE TABLE pr_reviewers (
     pull_request_id VARCHAR(255) NOT NULL,
     user_id VARCHAR(255) NOT NULL,
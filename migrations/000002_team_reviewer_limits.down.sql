@@ -0,0 +1,12 @@
+-- Откат миграции: удаление ограничений на количество ревьюверов
+DROP TRIGGER IF EXISTS trg_pr_reviewers_limit ON pr_reviewers;
+DROP FUNCTION IF EXISTS check_pr_reviewers_limit();
+
+ALTER TABLE pull_requests
+    DROP CONSTRAINT IF EXISTS chk_pr_reviewers_count,
+    DROP COLUMN IF EXISTS reviewers_count;
+
+ALTER TABLE teams
+    DROP CONSTRAINT IF EXISTS chk_team_reviewer_limits,
+    DROP COLUMN IF EXISTS max_reviewers,
+    DROP COLUMN IF EXISTS min_reviewers;
@@ -0,0 +1,41 @@
+-- Настройки количества ревьюверов на уровне команды
+ALTER TABLE teams
+    ADD COLUMN min_reviewers INT NOT NULL DEFAULT 1,
+    ADD COLUMN max_reviewers INT NOT NULL DEFAULT 2,
+    ADD CONSTRAINT chk_team_reviewer_limits CHECK (min_reviewers >= 0 AND max_reviewers >= 1 AND min_reviewers <= max_reviewers);
+
+-- Количество ревьюверов, запрошенное для конкретного PR (NULL - по настройке команды автора)
+ALTER TABLE pull_requests
+    ADD COLUMN reviewers_count INT,
+    ADD CONSTRAINT chk_pr_reviewers_count CHECK (reviewers_count IS NULL OR reviewers_count >= 0);
+
+-- Ограничение на количество ревьюверов PR: reviewers_count PR или max_reviewers команды автора
+CREATE OR REPLACE FUNCTION check_pr_reviewers_limit() RETURNS TRIGGER AS $$
+DECLARE
+    reviewers_limit INT;
+    assigned_count INT;
+BEGIN
+    -- Блокируем строку PR, чтобы параллельные назначения проверялись последовательно
+    SELECT COALESCE(pr.reviewers_count, t.max_reviewers) INTO reviewers_limit
+    FROM pull_requests pr
+    INNER JOIN users u ON u.user_id = pr.author_id
+    INNER JOIN teams t ON t.team_name = u.team_name
+    WHERE pr.pull_request_id = NEW.pull_request_id
+    FOR UPDATE OF pr;
+
+    SELECT COUNT(*) INTO assigned_count
+    FROM pr_reviewers
+    WHERE pull_request_id = NEW.pull_request_id AND user_id <> NEW.user_id;
+
+    IF reviewers_limit IS NOT NULL AND assigned_count >= reviewers_limit THEN
+        RAISE EXCEPTION 'pull request % already has % reviewers (limit %)', NEW.pull_request_id, assigned_count, reviewers_limit
+            USING ERRCODE = 'check_violation';
+    END IF;
+
+    RETURN NEW;
+END;
+$$ LANGUAGE plpgsql;
+
+CREATE TRIGGER trg_pr_reviewers_limit
+    BEFORE INSERT ON pr_reviewers
+    FOR EACH ROW EXECUTE FUNCTION check_pr_reviewers_limit();
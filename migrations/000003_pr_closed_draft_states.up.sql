@@ -0,0 +1,7 @@
+-- Дополнительные состояния PR: CLOSED (закрыт без слияния) и DRAFT (черновик)
+ALTER TABLE pull_requests DROP CONSTRAINT IF EXISTS pull_requests_status_check;
+ALTER TABLE pull_requests
+    ADD CONSTRAINT pull_requests_status_check CHECK (status IN ('OPEN', 'MERGED', 'CLOSED', 'DRAFT'));
+
+-- Время закрытия PR без слияния (сбрасывается при переоткрытии)
+ALTER TABLE pull_requests ADD COLUMN closed_at TIMESTAMP;
@@ -0,0 +1,6 @@
+-- Откат миграции: удаление вердиктов ревьюверов
+ALTER TABLE teams
+    DROP CONSTRAINT IF EXISTS chk_team_required_approvals,
+    DROP COLUMN IF EXISTS required_approvals;
+
+DROP TABLE IF EXISTS pr_reviews;
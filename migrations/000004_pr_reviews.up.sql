@@ -0,0 +1,18 @@
+-- Вердикты ревьюверов по PR (история: каждый отправленный вердикт - отдельная строка)
+CREATE TABLE pr_reviews (
+    review_id BIGSERIAL PRIMARY KEY,
+    pull_request_id VARCHAR(255) NOT NULL,
+    user_id VARCHAR(255) NOT NULL,
+    verdict VARCHAR(32) NOT NULL CHECK (verdict IN ('APPROVED', 'CHANGES_REQUESTED', 'COMMENTED')),
+    submitted_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    CONSTRAINT fk_pr_review_pr FOREIGN KEY (pull_request_id) REFERENCES pull_requests(pull_request_id) ON DELETE CASCADE,
+    CONSTRAINT fk_pr_review_user FOREIGN KEY (user_id) REFERENCES users(user_id) ON DELETE RESTRICT
+);
+
+-- Индекс для выборки последнего вердикта каждого ревьювера
+CREATE INDEX idx_pr_reviews_pr_user_submitted ON pr_reviews(pull_request_id, user_id, submitted_at DESC);
+
+-- Количество одобрений, необходимое для merge PR команды (0 - не требуется)
+ALTER TABLE teams
+    ADD COLUMN required_approvals INT NOT NULL DEFAULT 0,
+    ADD CONSTRAINT chk_team_required_approvals CHECK (required_approvals >= 0 AND required_approvals <= max_reviewers);
@@ -0,0 +1,2 @@
+-- Откат миграции: удаление связей с внешними системами
+DROP TABLE IF EXISTS user_identities;
@@ -0,0 +1,11 @@
+-- Связь логинов во внешних системах (GitHub и т.п.) с пользователями сервиса
+CREATE TABLE user_identities (
+    provider VARCHAR(32) NOT NULL,
+    login VARCHAR(255) NOT NULL,
+    user_id VARCHAR(255) NOT NULL,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    PRIMARY KEY (provider, login),
+    CONSTRAINT fk_user_identity_user FOREIGN KEY (user_id) REFERENCES users(user_id) ON DELETE CASCADE
+);
+
+CREATE INDEX idx_user_identities_user_id ON user_identities(user_id);
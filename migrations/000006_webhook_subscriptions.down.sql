@@ -0,0 +1,3 @@
+-- Откат миграции: удаление подписок и журнала доставок
+DROP TABLE IF EXISTS webhook_deliveries;
+DROP TABLE IF EXISTS webhook_subscriptions;
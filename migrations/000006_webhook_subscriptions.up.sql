@@ -0,0 +1,26 @@
+-- Подписки на события об изменении ревьюверов
+CREATE TABLE webhook_subscriptions (
+    subscription_id BIGSERIAL PRIMARY KEY,
+    url TEXT NOT NULL,
+    secret VARCHAR(255) NOT NULL DEFAULT '',
+    -- Пустой массив означает подписку на все события
+    event_types TEXT[] NOT NULL DEFAULT '{}',
+    is_active BOOLEAN NOT NULL DEFAULT TRUE,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+);
+
+-- Журнал доставок: одна запись на каждую попытку отправки события
+CREATE TABLE webhook_deliveries (
+    delivery_id BIGSERIAL PRIMARY KEY,
+    subscription_id BIGINT NOT NULL,
+    event_id VARCHAR(64) NOT NULL,
+    event_type VARCHAR(64) NOT NULL,
+    attempt INT NOT NULL,
+    status_code INT NOT NULL DEFAULT 0,
+    error TEXT NOT NULL DEFAULT '',
+    success BOOLEAN NOT NULL,
+    delivered_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    CONSTRAINT fk_webhook_delivery_subscription FOREIGN KEY (subscription_id) REFERENCES webhook_subscriptions(subscription_id) ON DELETE CASCADE
+);
+
+CREATE INDEX idx_webhook_deliveries_subscription ON webhook_deliveries(subscription_id, delivery_id DESC);
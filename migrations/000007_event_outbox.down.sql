@@ -0,0 +1,2 @@
+-- Откат миграции: удаление outbox событий
+DROP TABLE IF EXISTS event_outbox;
@@ -0,0 +1,16 @@
+-- Outbox событий: записывается в той же транзакции, что и изменение ревьюверов,
+-- и разбирается фоновым процессом доставки
+CREATE TABLE event_outbox (
+    outbox_id BIGSERIAL PRIMARY KEY,
+    event_id VARCHAR(64) NOT NULL UNIQUE,
+    event_type VARCHAR(64) NOT NULL,
+    payload JSONB NOT NULL,
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    -- Время, раньше которого запись не забирается: аренда экземпляром сервиса или пауза перед повтором
+    available_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    attempts INT NOT NULL DEFAULT 0,
+    last_error TEXT NOT NULL DEFAULT '',
+    delivered_at TIMESTAMP
+);
+
+CREATE INDEX idx_event_outbox_pending ON event_outbox(available_at, outbox_id) WHERE delivered_at IS NULL;
@@ -0,0 +1,5 @@
+-- Откат миграции: удаление индексов списка PR
+DROP INDEX IF EXISTS idx_pull_requests_merged;
+DROP INDEX IF EXISTS idx_pull_requests_status_created;
+DROP INDEX IF EXISTS idx_pull_requests_name;
+DROP INDEX IF EXISTS idx_pull_requests_created;
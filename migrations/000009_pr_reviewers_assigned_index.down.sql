@@ -0,0 +1,2 @@
+-- Откат миграции: удаление индекса списка PR ревьювера
+DROP INDEX IF EXISTS idx_pr_reviewers_user_assigned;
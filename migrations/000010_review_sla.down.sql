@@ -0,0 +1,7 @@
+-- Откат миграции: удаление SLA ревью
+ALTER TABLE pr_reviewers
+    DROP COLUMN IF EXISTS sla_breached_at;
+
+ALTER TABLE teams
+    DROP CONSTRAINT IF EXISTS chk_team_review_sla,
+    DROP COLUMN IF EXISTS review_sla_seconds;
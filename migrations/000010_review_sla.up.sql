@@ -0,0 +1,8 @@
+-- SLA первого ответа ревьювера на уровне команды (0 - не отслеживается)
+ALTER TABLE teams
+    ADD COLUMN review_sla_seconds INT NOT NULL DEFAULT 0,
+    ADD CONSTRAINT chk_team_review_sla CHECK (review_sla_seconds >= 0);
+
+-- Время, когда по назначению было записано событие review.sla_breached (NULL - еще не записано)
+ALTER TABLE pr_reviewers
+    ADD COLUMN sla_breached_at TIMESTAMP;
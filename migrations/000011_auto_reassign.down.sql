@@ -0,0 +1,7 @@
+-- Откат миграции: удаление автоматического переназначения и истории PR
+DROP TABLE IF EXISTS pr_history;
+
+ALTER TABLE teams
+    DROP CONSTRAINT IF EXISTS chk_team_auto_reassign,
+    DROP COLUMN IF EXISTS max_auto_reassignments,
+    DROP COLUMN IF EXISTS auto_reassign_after_seconds;
@@ -0,0 +1,19 @@
+-- Политика автоматического переназначения ревьюверов без вердикта (0 - отключена)
+ALTER TABLE teams
+    ADD COLUMN auto_reassign_after_seconds INT NOT NULL DEFAULT 0,
+    ADD COLUMN max_auto_reassignments INT NOT NULL DEFAULT 1,
+    ADD CONSTRAINT chk_team_auto_reassign CHECK (auto_reassign_after_seconds >= 0 AND max_auto_reassignments >= 0);
+
+-- История PR: автоматические переназначения ревьюверов
+-- Снятый ревьювер (old_reviewer_id) больше не назначается на этот PR автоматически
+CREATE TABLE pr_history (
+    history_id BIGSERIAL PRIMARY KEY,
+    pull_request_id VARCHAR(255) NOT NULL,
+    action VARCHAR(32) NOT NULL CHECK (action IN ('AUTO_REASSIGNED')),
+    old_reviewer_id VARCHAR(255) NOT NULL DEFAULT '',
+    new_reviewer_id VARCHAR(255) NOT NULL DEFAULT '',
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    CONSTRAINT fk_pr_history_pr FOREIGN KEY (pull_request_id) REFERENCES pull_requests(pull_request_id) ON DELETE CASCADE
+);
+
+CREATE INDEX idx_pr_history_pr ON pr_history(pull_request_id, history_id);
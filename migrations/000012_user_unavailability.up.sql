@@ -0,0 +1,16 @@
+-- Периоды отсутствия пользователей [starts_at, ends_at): в это время пользователь не назначается ревьювером
+-- handed_off_at отмечает, что открытые ревью пользователя уже переданы (при handoff_reviews)
+CREATE TABLE user_unavailability (
+    unavailability_id BIGSERIAL PRIMARY KEY,
+    user_id VARCHAR(255) NOT NULL,
+    starts_at TIMESTAMP NOT NULL,
+    ends_at TIMESTAMP NOT NULL,
+    reason VARCHAR(255) NOT NULL DEFAULT '',
+    handoff_reviews BOOLEAN NOT NULL DEFAULT FALSE,
+    handed_off_at TIMESTAMP,
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    CONSTRAINT fk_user_unavailability_user FOREIGN KEY (user_id) REFERENCES users(user_id) ON DELETE CASCADE,
+    CONSTRAINT chk_user_unavailability_period CHECK (ends_at > starts_at)
+);
+
+CREATE INDEX idx_user_unavailability_user ON user_unavailability(user_id, ends_at);
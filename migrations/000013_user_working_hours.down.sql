@@ -0,0 +1,6 @@
+-- Откат миграции: удаление рабочих часов пользователей
+ALTER TABLE users
+    DROP CONSTRAINT IF EXISTS chk_user_working_hours,
+    DROP COLUMN IF EXISTS working_hours_end,
+    DROP COLUMN IF EXISTS working_hours_start,
+    DROP COLUMN IF EXISTS timezone;
@@ -0,0 +1,9 @@
+-- Часовой пояс IANA и рабочие часы пользователя (HH:MM по местному времени)
+-- Задаются все вместе или не задаются совсем
+ALTER TABLE users
+    ADD COLUMN timezone VARCHAR(64),
+    ADD COLUMN working_hours_start VARCHAR(5),
+    ADD COLUMN working_hours_end VARCHAR(5),
+    ADD CONSTRAINT chk_user_working_hours CHECK (
+        (timezone IS NULL) = (working_hours_start IS NULL) AND (timezone IS NULL) = (working_hours_end IS NULL)
+    );
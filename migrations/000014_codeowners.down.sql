@@ -0,0 +1,2 @@
+-- Откат миграции: удаление правил CODEOWNERS
+DROP TABLE IF EXISTS codeowners;
@@ -0,0 +1,4 @@
+-- Откат миграции: удаление пулов ревьюверов
+DROP TABLE IF EXISTS team_fallback_pools;
+DROP TABLE IF EXISTS reviewer_pool_members;
+DROP TABLE IF EXISTS reviewer_pools;
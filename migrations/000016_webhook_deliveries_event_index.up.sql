@@ -0,0 +1,2 @@
+-- Индекс для выборки попыток доставки события: по журналу webhook решает, кому событие уже доставлено
+CREATE INDEX idx_webhook_deliveries_event ON webhook_deliveries(event_id, delivery_id);
@@ -0,0 +1,9 @@
+-- Измененные файлы черновиков PR: по ним при переводе в OPEN назначаются владельцы кода
+CREATE TABLE pr_changed_files (
+    pull_request_id VARCHAR(255) NOT NULL,
+    position INT NOT NULL,
+    repository VARCHAR(255) NOT NULL,
+    path TEXT NOT NULL,
+    PRIMARY KEY (pull_request_id, position),
+    CONSTRAINT fk_pr_changed_files_pr FOREIGN KEY (pull_request_id) REFERENCES pull_requests(pull_request_id) ON DELETE CASCADE
+);
@@ -0,0 +1,8 @@
+-- Получатели, уже принявшие событие outbox: при повторной доставке событие отправляется только остальным
+CREATE TABLE event_outbox_sinks (
+    outbox_id BIGINT NOT NULL,
+    sink VARCHAR(64) NOT NULL,
+    delivered_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    PRIMARY KEY (outbox_id, sink),
+    CONSTRAINT fk_event_outbox_sinks_outbox FOREIGN KEY (outbox_id) REFERENCES event_outbox(outbox_id) ON DELETE CASCADE
+);
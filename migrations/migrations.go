@@ -0,0 +1,10 @@
+// Package migrations встраивает миграции PostgreSQL в бинарный файл
+// Те же файлы использует контейнер migrate в docker-compose.yml
+package migrations
+
+import "embed"
+
+// FS файлы миграций в формате golang-migrate
+//
+//go:embed *.sql
+var FS embed.FS